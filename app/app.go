@@ -147,8 +147,9 @@ var (
 		stakingtypes.NotBondedPoolName: {authtypes.Burner, authtypes.Staking},
 		govtypes.ModuleName:            {authtypes.Burner},
 		// ibctransfertypes.ModuleName:    {authtypes.Minter, authtypes.Burner}, // temporarily disabled
+		// did escrows storage deposits and burns the part not refunded
+		didtypes.ModuleName: {authtypes.Burner},
 		// Custom module permissions - temporarily disabled
-		// didtypes.ModuleName:      nil,
 		// vctypes.ModuleName:       nil,
 		// zktypes.ModuleName:       nil,
 		// guardiantypes.ModuleName: nil,
@@ -359,8 +360,13 @@ func New(
 	app.DidKeeper = didkeeper.NewKeeper(
 		appCodec, 
 		runtime.NewKVStoreService(keys[didtypes.StoreKey]), 
+		app.GetSubspace(didtypes.ModuleName),
 		app.AccountKeeper,
 		app.BankKeeper,
+		app.MsgServiceRouter(),
+		nil,   // no CometBFT info service is wired into the app
+		false, // hsmEnabled
+		true,  // auditEnabled
 	)
 	
 	// TEMPORARILY DISABLED VC/ZK KEEPERS
@@ -563,8 +569,8 @@ func New(
 		upgradetypes.ModuleName,
 		// vestingtypes.ModuleName, // temporarily disabled
 		consensusparamtypes.ModuleName,
-		// Custom modules - temporarily disabled
-		// didtypes.ModuleName,
+		// Custom modules - VC/ZK/Guardian temporarily disabled
+		didtypes.ModuleName,
 		// vctypes.ModuleName,
 		// zktypes.ModuleName,
 		// guardiantypes.ModuleName,
@@ -648,6 +654,9 @@ func New(
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetEndBlocker(app.EndBlocker)
 
+	// Upgrade handlers must be registered before the latest version is loaded
+	app.RegisterUpgradeHandlers()
+
 	if loadLatest {
 		if err := app.LoadLatestVersion(); err != nil {
 			panic(fmt.Errorf("error loading last version: %w", err))
@@ -856,8 +865,8 @@ func initParamsKeeper(appCodec codec.BinaryCodec, legacyAmino *codec.LegacyAmino
 	paramsKeeper.Subspace(slashingtypes.ModuleName).WithKeyTable(slashingtypes.ParamKeyTable())
 	paramsKeeper.Subspace(govtypes.ModuleName).WithKeyTable(govv1.ParamKeyTable())
 	paramsKeeper.Subspace(crisistypes.ModuleName).WithKeyTable(crisistypes.ParamKeyTable())
+	paramsKeeper.Subspace(didtypes.ModuleName).WithKeyTable(didtypes.ParamKeyTable())
 	// Custom module subspaces - temporarily disabled
-	// paramsKeeper.Subspace(didtypes.ModuleName)
 	// paramsKeeper.Subspace(vctypes.ModuleName)
 	// paramsKeeper.Subspace(zktypes.ModuleName)
 	// paramsKeeper.Subspace(guardiantypes.ModuleName)
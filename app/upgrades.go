@@ -0,0 +1,26 @@
+package app
+
+import (
+	"context"
+
+	upgradetypes "cosmossdk.io/x/upgrade/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+)
+
+// DIDTypedDocumentsUpgradeName is the on-chain upgrade that moves the did
+// module from consensus version 2 to 3, converting legacy DidDocument
+// records into typed DID documents. No stores are added or removed.
+const DIDTypedDocumentsUpgradeName = "did-typed-documents"
+
+// RegisterUpgradeHandlers registers the handlers of the upgrades this binary
+// knows how to run
+func (app *App) RegisterUpgradeHandlers() {
+	app.UpgradeKeeper.SetUpgradeHandler(
+		DIDTypedDocumentsUpgradeName,
+		func(ctx context.Context, _ upgradetypes.Plan, fromVM module.VersionMap) (module.VersionMap, error) {
+			sdk.UnwrapSDKContext(ctx).Logger().Info("running module migrations", "upgrade", DIDTypedDocumentsUpgradeName)
+			return app.ModuleManager.RunMigrations(ctx, app.configurator, fromVM)
+		},
+	)
+}
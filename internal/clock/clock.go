@@ -0,0 +1,23 @@
+// Package clock provides the time source for identity module state.
+//
+// Anything a keeper stores or compares against stored state must be derived
+// from the block header rather than the validator's wall clock, otherwise
+// nodes executing the same block can disagree on the resulting state.
+package clock
+
+import (
+	"context"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Now returns the time of the block being executed, in UTC
+func Now(ctx context.Context) time.Time {
+	return sdk.UnwrapSDKContext(ctx).BlockTime().UTC()
+}
+
+// Unix returns the time of the block being executed as Unix seconds
+func Unix(ctx context.Context) int64 {
+	return Now(ctx).Unix()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	errorsmod "cosmossdk.io/errors"
 	"cosmossdk.io/log"
 	"cosmossdk.io/store"
 	"cosmossdk.io/store/metrics"
@@ -14,9 +15,12 @@ import (
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/runtime"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
 	"github.com/stretchr/testify/require"
 
 	"github.com/persona-chain/persona-chain/x/did/keeper"
@@ -24,6 +28,25 @@ import (
 )
 
 func DidKeeper(t testing.TB) (keeper.Keeper, sdk.Context) {
+	k, ctx, _ := DidKeeperWithStoreKey(t)
+	return k, ctx
+}
+
+// DidKeeperWithStoreKey is DidKeeper that also returns the module store key,
+// for tests that need to write raw records such as legacy documents
+func DidKeeperWithStoreKey(t testing.TB) (keeper.Keeper, sdk.Context, storetypes.StoreKey) {
+	k, ctx, storeKey, _ := didKeeper(t)
+	return k, ctx, storeKey
+}
+
+// DidKeeperWithBank is DidKeeper that also returns the bank keeper mock, for
+// tests of fees and deposits
+func DidKeeperWithBank(t testing.TB) (keeper.Keeper, sdk.Context, *MockBankKeeper) {
+	k, ctx, _, bankKeeper := didKeeper(t)
+	return k, ctx, bankKeeper
+}
+
+func didKeeper(t testing.TB) (keeper.Keeper, sdk.Context, storetypes.StoreKey, *MockBankKeeper) {
 	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
 	memStoreKey := storetypes.NewMemoryStoreKey(types.MemStoreKey)
 
@@ -34,8 +57,12 @@ func DidKeeper(t testing.TB) (keeper.Keeper, sdk.Context) {
 	require.NoError(t, stateStore.LoadLatestVersion())
 
 	registry := codectypes.NewInterfaceRegistry()
+	types.RegisterInterfaces(registry)
 	cdc := codec.NewProtoCodec(registry)
 
+	// Threshold updates dispatch the wrapped messages through the router
+	router := NewMsgRouter()
+
 	paramsSubspace := paramtypes.NewSubspace(cdc,
 		types.Amino,
 		storeKey,
@@ -47,7 +74,7 @@ func DidKeeper(t testing.TB) (keeper.Keeper, sdk.Context) {
 	accountKeeper := &MockAccountKeeper{}
 	
 	// Mock bank keeper
-	bankKeeper := &MockBankKeeper{}
+	bankKeeper := NewMockBankKeeper()
 
 	k := keeper.NewKeeper(
 		cdc,
@@ -55,8 +82,14 @@ func DidKeeper(t testing.TB) (keeper.Keeper, sdk.Context) {
 		paramsSubspace,
 		accountKeeper,
 		bankKeeper,
+		router,
+		nil,   // cometService
+		false, // hsmEnabled
+		true,  // auditEnabled
 	)
 
+	types.RegisterMsgServer(router, keeper.NewMsgServerImpl(k))
+
 	ctx := sdk.NewContext(stateStore, cmtproto.Header{}, false, log.NewNopLogger())
 
 	// Initialize the module's params
@@ -64,7 +97,7 @@ func DidKeeper(t testing.TB) (keeper.Keeper, sdk.Context) {
 		panic(err)
 	}
 
-	return k, ctx
+	return k, ctx, storeKey, bankKeeper
 }
 
 // MockAccountKeeper implements the expected account keeper interface for testing
@@ -96,17 +129,85 @@ func (m MockAccountKeeper) GetModuleAccount(ctx context.Context, name string) sd
 	return authtypes.NewEmptyModuleAccount(name)
 }
 
-// MockBankKeeper implements the expected bank keeper interface for testing
-type MockBankKeeper struct{}
+// MockBankKeeper implements the expected bank keeper interface for testing.
+// It keeps balances in memory, with module accounts keyed by module name.
+type MockBankKeeper struct {
+	Balances map[string]sdk.Coins
+	Burned   sdk.Coins
+}
+
+func NewMockBankKeeper() *MockBankKeeper {
+	return &MockBankKeeper{Balances: make(map[string]sdk.Coins), Burned: sdk.NewCoins()}
+}
+
+// Fund adds amt to the balance of addr
+func (m *MockBankKeeper) Fund(addr sdk.AccAddress, amt sdk.Coins) {
+	m.Balances[addr.String()] = m.Balances[addr.String()].Add(amt...)
+}
+
+func (m *MockBankKeeper) SpendableCoins(ctx context.Context, addr sdk.AccAddress) sdk.Coins {
+	return m.Balances[addr.String()]
+}
 
-func (m MockBankKeeper) SpendableCoins(ctx context.Context, addr sdk.AccAddress) sdk.Coins {
-	return sdk.NewCoins()
+func (m *MockBankKeeper) SendCoins(ctx context.Context, fromAddr, toAddr sdk.AccAddress, amt sdk.Coins) error {
+	return m.move(fromAddr.String(), toAddr.String(), amt)
 }
 
-func (m MockBankKeeper) SendCoins(ctx context.Context, fromAddr, toAddr sdk.AccAddress, amt sdk.Coins) error {
+func (m *MockBankKeeper) SendCoinsFromAccountToModule(ctx context.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error {
+	return m.move(senderAddr.String(), recipientModule, amt)
+}
+
+func (m *MockBankKeeper) SendCoinsFromModuleToAccount(ctx context.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error {
+	return m.move(senderModule, recipientAddr.String(), amt)
+}
+
+func (m *MockBankKeeper) BurnCoins(ctx context.Context, moduleName string, amt sdk.Coins) error {
+	if err := m.move(moduleName, "", amt); err != nil {
+		return err
+	}
+	m.Burned = m.Burned.Add(amt...)
+	return nil
+}
+
+func (m *MockBankKeeper) GetBalance(ctx context.Context, addr sdk.AccAddress, denom string) sdk.Coin {
+	return sdk.NewCoin(denom, m.Balances[addr.String()].AmountOf(denom))
+}
+
+// move transfers amt between balances, dropping it when to is empty
+func (m *MockBankKeeper) move(from, to string, amt sdk.Coins) error {
+	balance, negative := m.Balances[from].SafeSub(amt...)
+	if negative {
+		return errorsmod.Wrapf(sdkerrors.ErrInsufficientFunds, "%s is smaller than %s", m.Balances[from], amt)
+	}
+	m.Balances[from] = balance
+	if to != "" {
+		m.Balances[to] = m.Balances[to].Add(amt...)
+	}
 	return nil
 }
 
-func (m MockBankKeeper) GetBalance(ctx context.Context, addr sdk.AccAddress, denom string) sdk.Coin {
-	return sdk.NewCoin(denom, sdk.ZeroInt())
-}
\ No newline at end of file
+// MockICS4Wrapper implements the expected IBC channel interface for testing.
+// It records the packets sent and numbers them from 1.
+type MockICS4Wrapper struct {
+	Sent []channeltypes.Packet
+}
+
+func (m *MockICS4Wrapper) SendPacket(
+	ctx sdk.Context,
+	sourcePort string,
+	sourceChannel string,
+	timeoutHeight clienttypes.Height,
+	timeoutTimestamp uint64,
+	data []byte,
+) (uint64, error) {
+	packet := channeltypes.Packet{
+		Sequence:         uint64(len(m.Sent) + 1),
+		SourcePort:       sourcePort,
+		SourceChannel:    sourceChannel,
+		Data:             data,
+		TimeoutHeight:    timeoutHeight,
+		TimeoutTimestamp: timeoutTimestamp,
+	}
+	m.Sent = append(m.Sent, packet)
+	return packet.Sequence, nil
+}
@@ -0,0 +1,67 @@
+package keeper
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	"google.golang.org/grpc"
+)
+
+var errTypeProbe = errors.New("type probe")
+
+// MsgRouter is a baseapp.MessageRouter for keeper tests. Msg services
+// register on it like on the app's router, but it finds the request type of
+// each method by calling its handler rather than from file descriptors.
+type MsgRouter struct {
+	handlers map[string]baseapp.MsgServiceHandler
+}
+
+var _ baseapp.MessageRouter = &MsgRouter{}
+
+func NewMsgRouter() *MsgRouter {
+	return &MsgRouter{handlers: make(map[string]baseapp.MsgServiceHandler)}
+}
+
+// RegisterService implements grpc.ServiceRegistrar
+func (r *MsgRouter) RegisterService(sd *grpc.ServiceDesc, srv interface{}) {
+	for _, method := range sd.Methods {
+		method := method
+
+		var typeURL string
+		_, _ = method.Handler(srv, context.Background(), func(req interface{}) error {
+			typeURL = sdk.MsgTypeURL(req.(sdk.Msg))
+			return errTypeProbe
+		}, nil)
+
+		r.handlers[typeURL] = func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+			if m, ok := msg.(sdk.HasValidateBasic); ok {
+				if err := m.ValidateBasic(); err != nil {
+					return nil, err
+				}
+			}
+			ctx = ctx.WithEventManager(sdk.NewEventManager())
+			res, err := method.Handler(srv, ctx, func(req interface{}) error {
+				reflect.ValueOf(req).Elem().Set(reflect.ValueOf(msg).Elem())
+				return nil
+			}, nil)
+			if err != nil {
+				return nil, err
+			}
+			return sdk.WrapServiceResult(ctx, res.(proto.Message), nil)
+		}
+	}
+}
+
+// Handler implements baseapp.MessageRouter
+func (r *MsgRouter) Handler(msg sdk.Msg) baseapp.MsgServiceHandler {
+	return r.handlers[sdk.MsgTypeURL(msg)]
+}
+
+// HandlerByTypeURL implements baseapp.MessageRouter
+func (r *MsgRouter) HandlerByTypeURL(typeURL string) baseapp.MsgServiceHandler {
+	return r.handlers[typeURL]
+}
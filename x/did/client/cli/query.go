@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
@@ -26,6 +27,11 @@ func GetQueryCmd(queryRoute string) *cobra.Command {
 	cmd.AddCommand(CmdQueryParams())
 	cmd.AddCommand(CmdShowDidDocument())
 	cmd.AddCommand(CmdListDidDocument())
+	cmd.AddCommand(CmdResolveDID())
+	cmd.AddCommand(CmdDIDHistory())
+	cmd.AddCommand(CmdDIDDiff())
+	cmd.AddCommand(CmdAuditLog())
+	cmd.AddCommand(CmdRemoteResolutions())
 
 	return cmd
 }
@@ -98,6 +104,9 @@ func CmdShowDidDocument() *cobra.Command {
 			queryClient := types.NewQueryClient(clientCtx)
 
 			argId := args[0]
+			if _, err := types.ParseDID(argId); err != nil {
+				return err
+			}
 
 			params := &types.QueryGetDidDocumentRequest{
 				Id: argId,
@@ -115,4 +124,171 @@ func CmdShowDidDocument() *cobra.Command {
 	flags.AddQueryFlagsToCmd(cmd)
 
 	return cmd
-}
\ No newline at end of file
+}
+func CmdResolveDID() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resolve [did]",
+		Short: "Resolve a DID to its document and resolution metadata",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryResolveRequest{
+				Did: args[0],
+			}
+			params.VersionId, _ = cmd.Flags().GetString(FlagVersionID)
+			params.VersionTime, _ = cmd.Flags().GetString(FlagVersionTime)
+			params.Accept, _ = cmd.Flags().GetString(FlagAccept)
+
+			res, err := queryClient.Resolve(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	cmd.Flags().String(FlagVersionID, "", "Resolve a specific document version")
+	cmd.Flags().String(FlagVersionTime, "", "Resolve the version current at an RFC 3339 time")
+	cmd.Flags().String(FlagAccept, "", "Requested representation content type")
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdDIDHistory() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "history [did]",
+		Aliases: []string{"versions"},
+		Short:   "list the versions of a DID document",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryDIDHistoryRequest{
+				Id:         args[0],
+				Pagination: pageReq,
+			}
+
+			res, err := queryClient.DIDHistory(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddPaginationFlagsToCmd(cmd, cmd.Use)
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdDIDDiff() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff [did] [from-version] [to-version]",
+		Short: "shows the JSON Patch between two versions of a DID document",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			from, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid from-version: %w", err)
+			}
+			to, err := strconv.ParseUint(args[2], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid to-version: %w", err)
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryDIDDiffRequest{
+				Id:          args[0],
+				FromVersion: from,
+				ToVersion:   to,
+			}
+
+			res, err := queryClient.DIDDiff(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdAuditLog() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit-log [did]",
+		Short: "shows the audit entries recorded for a DID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			limit, _ := cmd.Flags().GetUint32(FlagLimit)
+			params := &types.QueryAuditLogRequest{
+				Did:   args[0],
+				Limit: limit,
+			}
+
+			res, err := queryClient.AuditLog(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	cmd.Flags().Uint32(FlagLimit, 0, "Maximum number of entries to return, 0 for all")
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdRemoteResolutions() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remote-resolutions [did]",
+		Short: "shows the cached results of resolving a DID on other chains",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryRemoteResolutionsRequest{
+				Did: args[0],
+			}
+
+			res, err := queryClient.RemoteResolutions(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
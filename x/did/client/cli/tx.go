@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -25,6 +26,16 @@ func GetTxCmd() *cobra.Command {
 	cmd.AddCommand(CmdCreateDid())
 	cmd.AddCommand(CmdUpdateDid())
 	cmd.AddCommand(CmdDeactivateDid())
+	cmd.AddCommand(CmdCreateDIDDocument())
+	cmd.AddCommand(CmdUpdateDIDDocument())
+	cmd.AddCommand(CmdDeactivateDIDDocument())
+	cmd.AddCommand(CmdAddVerificationMethod())
+	cmd.AddCommand(CmdRevokeVerificationMethod())
+	cmd.AddCommand(CmdAddService())
+	cmd.AddCommand(CmdRemoveService())
+	cmd.AddCommand(CmdUpdateDIDStatus())
+	cmd.AddCommand(CmdRecoverDID())
+	cmd.AddCommand(CmdRequestRemoteResolution())
 
 	return cmd
 }
@@ -37,6 +48,9 @@ func CmdCreateDid() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			argDidId := args[0]
 			argDidDocument := args[1]
+			if _, err := types.ParseDID(argDidId); err != nil {
+				return err
+			}
 
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
@@ -68,6 +82,9 @@ func CmdUpdateDid() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			argDidId := args[0]
 			argDidDocument := args[1]
+			if _, err := types.ParseDID(argDidId); err != nil {
+				return err
+			}
 
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
@@ -98,6 +115,9 @@ func CmdDeactivateDid() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			argDidId := args[0]
+			if _, err := types.ParseDID(argDidId); err != nil {
+				return err
+			}
 
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
@@ -118,4 +138,431 @@ func CmdDeactivateDid() *cobra.Command {
 	flags.AddTxFlagsToCmd(cmd)
 
 	return cmd
-}
\ No newline at end of file
+}
+func CmdCreateDIDDocument() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-document [document-file]",
+		Short: "Create a DID document from a JSON file",
+		Long: `Create a DID document from a JSON file. Every signing key in the document
+needs a proof of possession over its challenge for version 1, passed with
+--key-proofs. With --generate-key a new Ed25519 key is created in the
+keyring, added as an authentication method and its proof made locally.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var doc types.DIDDocument
+			if err := readJSONFile(args[0], &doc); err != nil {
+				return err
+			}
+			proofs, err := readKeyProofs(cmd)
+			if err != nil {
+				return err
+			}
+
+			keyName, _ := cmd.Flags().GetString(FlagGenerateKey)
+			if keyName != "" {
+				vm, proof, err := generateKey(clientCtx, doc.ID, keyName, 1)
+				if err != nil {
+					return err
+				}
+				doc.VerificationMethod = append(doc.VerificationMethod, vm)
+				doc.Authentication = append(doc.Authentication, vm.ID)
+				proofs = append(proofs, proof)
+			}
+
+			if err := doc.Validate(); err != nil {
+				return err
+			}
+
+			msg := &types.MsgCreateDIDDocument{
+				Creator:     clientCtx.GetFromAddress().String(),
+				DidDocument: doc,
+				KeyProofs:   proofs,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(FlagGenerateKey, "", "Name of a new Ed25519 keyring key to add as an authentication method")
+	cmd.Flags().String(FlagKeyProofs, "", "JSON file with the proofs of possession of the document's keys")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdUpdateDIDDocument() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-document [document-file]",
+		Short: "Replace a DID document with the contents of a JSON file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var doc types.DIDDocument
+			if err := readJSONFile(args[0], &doc); err != nil {
+				return err
+			}
+			if err := doc.Validate(); err != nil {
+				return err
+			}
+			proofs, err := readKeyProofs(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := &types.MsgUpdateDIDDocument{
+				Controller:  clientCtx.GetFromAddress().String(),
+				Id:          doc.ID,
+				DidDocument: doc,
+				KeyProofs:   proofs,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(FlagKeyProofs, "", "JSON file with the proofs of possession of the keys the update adds")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdDeactivateDIDDocument() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deactivate-document [did]",
+		Short: "Deactivate a DID document",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			reason, _ := cmd.Flags().GetString(FlagReason)
+			msg := &types.MsgDeactivateDIDDocument{
+				Controller: clientCtx.GetFromAddress().String(),
+				Id:         args[0],
+				Reason:     reason,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(FlagReason, "", "Reason for the deactivation")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdAddVerificationMethod() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-verification-method [did] [verification-method-file]",
+		Short: "Add a verification method to a DID document",
+		Long: `Add a verification method, read from a JSON file, to a DID document. A
+signing key needs a proof of possession for the next document version, passed
+with --key-proofs. With --generate-key the method file is omitted: a new
+Ed25519 key is created in the keyring and its proof made locally.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			did := args[0]
+			keyName, _ := cmd.Flags().GetString(FlagGenerateKey)
+			if (keyName == "") != (len(args) == 2) {
+				return fmt.Errorf("pass either a verification method file or --%s", FlagGenerateKey)
+			}
+
+			var (
+				vm    types.VerificationMethod
+				proof *types.KeyProof
+			)
+			if keyName != "" {
+				version, err := nextDocumentVersion(clientCtx, did)
+				if err != nil {
+					return err
+				}
+				generated, keyProof, err := generateKey(clientCtx, did, keyName, version)
+				if err != nil {
+					return err
+				}
+				vm, proof = generated, &keyProof
+			} else {
+				if err := readJSONFile(args[1], &vm); err != nil {
+					return err
+				}
+				proofs, err := readKeyProofs(cmd)
+				if err != nil {
+					return err
+				}
+				for i := range proofs {
+					if proofs[i].VerificationMethodId == vm.ID {
+						proof = &proofs[i]
+					}
+				}
+			}
+
+			msg := &types.MsgAddVerificationMethod{
+				Controller:         clientCtx.GetFromAddress().String(),
+				Id:                 did,
+				VerificationMethod: vm,
+				KeyProof:           proof,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(FlagGenerateKey, "", "Name of a new Ed25519 keyring key to add as the verification method")
+	cmd.Flags().String(FlagKeyProofs, "", "JSON file with the proof of possession of the method's key")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdRevokeVerificationMethod() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke-verification-method [did] [method-id]",
+		Short: "Revoke a verification method of a DID document",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			reason, _ := cmd.Flags().GetString(FlagReason)
+			msg := &types.MsgRevokeVerificationMethod{
+				Controller: clientCtx.GetFromAddress().String(),
+				Id:         args[0],
+				MethodId:   args[1],
+				Reason:     reason,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(FlagReason, "", "Reason for the revocation")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdAddService() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-service [did] [service-file]",
+		Short: "Add a service, read from a JSON file, to a DID document",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var service types.Service
+			if err := readJSONFile(args[1], &service); err != nil {
+				return err
+			}
+
+			msg := &types.MsgAddService{
+				Controller: clientCtx.GetFromAddress().String(),
+				Id:         args[0],
+				Service:    service,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdRemoveService() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove-service [did] [service-id]",
+		Short: "Remove a service from a DID document",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			reason, _ := cmd.Flags().GetString(FlagReason)
+			msg := &types.MsgRemoveService{
+				Controller: clientCtx.GetFromAddress().String(),
+				Id:         args[0],
+				ServiceId:  args[1],
+				Reason:     reason,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(FlagReason, "", "Reason for the removal")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdUpdateDIDStatus() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-status [did] [status]",
+		Short: "Change the lifecycle state of a DID document",
+		Long: `Change the lifecycle state of a DID document to active, inactive,
+suspended, revoked or migrating. A suspension can be given an end time with
+--until.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			reason, _ := cmd.Flags().GetString(FlagReason)
+			msg := &types.MsgUpdateDIDStatus{
+				Controller: clientCtx.GetFromAddress().String(),
+				Id:         args[0],
+				Status:     args[1],
+				Reason:     reason,
+			}
+			if until, _ := cmd.Flags().GetString(FlagUntil); until != "" {
+				t, err := time.Parse(time.RFC3339, until)
+				if err != nil {
+					return fmt.Errorf("invalid --%s: %w", FlagUntil, err)
+				}
+				msg.Until = &t
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(FlagReason, "", "Reason for the change")
+	cmd.Flags().String(FlagUntil, "", "RFC 3339 time at which a suspension ends")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdRecoverDID() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recover [did] [keys-file]",
+		Short: "Recover control of a DID by rotating to its pre-committed keys",
+		Long: `Replace the authentication keys of a DID with the keys its pre-rotation
+commitment was made to, read as a JSON array of verification methods. The
+rotation commits to the next key set, given directly with
+--next-key-commitment or computed from a JSON file with --next-keys.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var keys []types.VerificationMethod
+			if err := readJSONFile(args[1], &keys); err != nil {
+				return err
+			}
+			proofs, err := readKeyProofs(cmd)
+			if err != nil {
+				return err
+			}
+
+			commitment, _ := cmd.Flags().GetString(FlagNextKeyCommitment)
+			if nextKeysFile, _ := cmd.Flags().GetString(FlagNextKeys); nextKeysFile != "" {
+				if commitment != "" {
+					return fmt.Errorf("--%s and --%s are mutually exclusive", FlagNextKeyCommitment, FlagNextKeys)
+				}
+				var nextKeys []types.VerificationMethod
+				if err := readJSONFile(nextKeysFile, &nextKeys); err != nil {
+					return err
+				}
+				if commitment, err = types.NextKeyCommitment(nextKeys); err != nil {
+					return err
+				}
+			}
+
+			msg := &types.MsgRotateKeys{
+				Controller:        clientCtx.GetFromAddress().String(),
+				Id:                args[0],
+				NewKeys:           keys,
+				NextKeyCommitment: commitment,
+				KeyProofs:         proofs,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(FlagKeyProofs, "", "JSON file with the proofs of possession of the new keys")
+	cmd.Flags().String(FlagNextKeyCommitment, "", "Commitment to the next key set")
+	cmd.Flags().String(FlagNextKeys, "", "JSON file with the next key set to commit to")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdRequestRemoteResolution() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "request-remote-resolution [channel-id] [did]",
+		Short: "Resolve a DID on the chain at the other end of an IBC channel",
+		Long: `Sends a resolution packet over a DID channel. Once acknowledged, the
+document is cached and returned by the resolve query, marked as remote.
+Check the progress with the remote-resolutions query.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := &types.MsgRequestRemoteResolution{
+				Creator:   clientCtx.GetFromAddress().String(),
+				ChannelId: args[0],
+				Did:       args[1],
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+const (
+	FlagGenerateKey       = "generate-key"
+	FlagKeyProofs         = "key-proofs"
+	FlagReason            = "reason"
+	FlagUntil             = "until"
+	FlagNextKeyCommitment = "next-key-commitment"
+	FlagNextKeys          = "next-keys"
+	FlagLimit             = "limit"
+	FlagVersionID         = "version-id"
+	FlagVersionTime       = "version-time"
+	FlagAccept            = "accept"
+)
+
+// generatedKeyPassphrase encrypts a generated key only while it is handed
+// to the keyring, which stores it under its own protection
+const generatedKeyPassphrase = "persona-did-generated-key"
+
+// readJSONFile decodes a JSON file into v
+func readJSONFile(path string, v interface{}) error {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(bz, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return nil
+}
+
+// readKeyProofs returns the proofs of possession in the --key-proofs file,
+// if one is given
+func readKeyProofs(cmd *cobra.Command) ([]types.KeyProof, error) {
+	path, _ := cmd.Flags().GetString(FlagKeyProofs)
+	if path == "" {
+		return nil, nil
+	}
+	var proofs []types.KeyProof
+	if err := readJSONFile(path, &proofs); err != nil {
+		return nil, err
+	}
+	return proofs, nil
+}
+
+// nextDocumentVersion returns the version the next change to a DID document
+// will produce
+func nextDocumentVersion(clientCtx client.Context, did string) (uint64, error) {
+	queryClient := types.NewQueryClient(clientCtx)
+	res, err := queryClient.DidDocument(context.Background(), &types.QueryGetDidDocumentRequest{Id: did})
+	if err != nil {
+		return 0, err
+	}
+	return res.DidDocument.Version + 1, nil
+}
+
+// generateKey creates an Ed25519 key named name in the keyring and returns
+// it as a verification method of did, together with its proof of possession
+// for the given document version
+func generateKey(clientCtx client.Context, did, name string, version uint64) (types.VerificationMethod, types.KeyProof, error) {
+	if _, err := clientCtx.Keyring.Key(name); err == nil {
+		return types.VerificationMethod{}, types.KeyProof{}, fmt.Errorf("key %q already exists in the keyring", name)
+	}
+
+	privKey := ed25519.GenPrivKey()
+	armor := crypto.EncryptArmorPrivKey(privKey, generatedKeyPassphrase, string(hd.Ed25519Type))
+	if err := clientCtx.Keyring.ImportPrivKey(name, armor, generatedKeyPassphrase); err != nil {
+		return types.VerificationMethod{}, types.KeyProof{}, err
+	}
+
+	vm := types.VerificationMethod{
+		ID:                 did + "#" + name,
+		Type:               types.VerificationMethodTypeEd25519VerificationKey2020,
+		Controller:         did,
+		PublicKeyMultibase: types.EncodeMultibaseMultikey(types.MulticodecEd25519Pub, privKey.PubKey().Bytes()),
+	}
+	if err := vm.ValidateKeyMaterial(); err != nil {
+		return types.VerificationMethod{}, types.KeyProof{}, err
+	}
+
+	signature, err := privKey.Sign(types.PossessionChallenge(did, vm.ID, clientCtx.ChainID, version))
+	if err != nil {
+		return types.VerificationMethod{}, types.KeyProof{}, err
+	}
+	return vm, types.KeyProof{VerificationMethodId: vm.ID, Signature: signature}, nil
+}
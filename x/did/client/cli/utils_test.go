@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestGenerateKey(t *testing.T) {
+	const did = "did:persona:abc"
+	kr := keyring.NewInMemory(moduletestutil.MakeTestEncodingConfig().Codec)
+	clientCtx := client.Context{}.WithKeyring(kr).WithChainID("persona-test-1")
+
+	vm, proof, err := generateKey(clientCtx, did, "key-1", 3)
+	require.NoError(t, err)
+	require.Equal(t, did+"#key-1", vm.ID)
+	require.Equal(t, did, vm.Controller)
+	require.Equal(t, vm.ID, proof.VerificationMethodId)
+
+	// The keyring holds the key behind the verification method
+	record, err := kr.Key("key-1")
+	require.NoError(t, err)
+	pub, err := record.GetPubKey()
+	require.NoError(t, err)
+	_, key, err := vm.DecodePublicKey()
+	require.NoError(t, err)
+	require.Equal(t, pub.Bytes(), key)
+
+	tests := []struct {
+		name    string
+		chainID string
+		version uint64
+		valid   bool
+	}{
+		{name: "signed challenge", chainID: "persona-test-1", version: 3, valid: true},
+		{name: "other version", chainID: "persona-test-1", version: 4},
+		{name: "other chain", chainID: "persona-test-2", version: 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := vm.VerifySignature(types.PossessionChallenge(did, vm.ID, tc.chainID, tc.version), proof.Signature)
+			if tc.valid {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+		})
+	}
+
+	_, _, err = generateKey(clientCtx, did, "key-1", 3)
+	require.ErrorContains(t, err, "already exists")
+}
+
+func TestReadKeyProofs(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "proofs.json")
+	require.NoError(t, os.WriteFile(valid, []byte(`[{"verification_method_id":"did:persona:abc#key-1","signature":"AQID"}]`), 0o600))
+	malformed := filepath.Join(dir, "malformed.json")
+	require.NoError(t, os.WriteFile(malformed, []byte(`{`), 0o600))
+
+	tests := []struct {
+		name   string
+		path   string
+		proofs []types.KeyProof
+		err    bool
+	}{
+		{name: "no flag"},
+		{name: "proofs file", path: valid, proofs: []types.KeyProof{{VerificationMethodId: "did:persona:abc#key-1", Signature: []byte{1, 2, 3}}}},
+		{name: "malformed file", path: malformed, err: true},
+		{name: "missing file", path: filepath.Join(dir, "missing.json"), err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String(FlagKeyProofs, "", "")
+			if tc.path != "" {
+				require.NoError(t, cmd.Flags().Set(FlagKeyProofs, tc.path))
+			}
+
+			proofs, err := readKeyProofs(cmd)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.proofs, proofs)
+		})
+	}
+}
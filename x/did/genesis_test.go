@@ -0,0 +1,71 @@
+package did_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestGenesis(t *testing.T) {
+	source, sourceCtx := residencyKeeper(t)
+	exported := did.ExportGenesis(sourceCtx, source)
+	require.NoError(t, exported.Validate())
+	require.Len(t, exported.DidDocuments, 2)
+
+	k, ctx := keepertest.DidKeeper(t)
+	did.InitGenesis(ctx, k, *exported)
+
+	reexported := did.ExportGenesis(ctx, k)
+	require.Equal(t, exported.Params.ChannelJurisdictions, reexported.Params.ChannelJurisdictions)
+	require.Equal(t, exported.DidDocuments, reexported.DidDocuments)
+
+	doc, found := k.GetDidDocument(ctx, euOnlyDID)
+	require.True(t, found)
+	require.Equal(t, []string{"EU"}, doc.Compliance.DataResidency.AllowedRegions)
+	_, found = k.GetDocumentVersion(ctx, euOnlyDID, doc.Version)
+	require.True(t, found)
+}
+
+func TestGenesisStateValidate(t *testing.T) {
+	doc := types.DIDDocument{Context: []string{"https://www.w3.org/ns/did/v1"}, ID: openDID, Version: 1}
+
+	tests := []struct {
+		name  string
+		state types.GenesisState
+		err   error
+	}{
+		{
+			name:  "default",
+			state: *types.DefaultGenesis(),
+		},
+		{
+			name:  "documents",
+			state: types.GenesisState{Params: types.DefaultParams(), DidDocuments: []types.DIDDocument{doc}},
+		},
+		{
+			name:  "duplicate document",
+			state: types.GenesisState{Params: types.DefaultParams(), DidDocuments: []types.DIDDocument{doc, doc}},
+			err:   types.ErrDIDAlreadyExists,
+		},
+		{
+			name:  "invalid document",
+			state: types.GenesisState{Params: types.DefaultParams(), DidDocuments: []types.DIDDocument{{ID: "not-a-did"}}},
+			err:   types.ErrInvalidDID,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.state.Validate()
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
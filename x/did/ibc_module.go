@@ -2,13 +2,13 @@ package did
 
 import (
 	"fmt"
+	"strings"
 
+	errorsmod "cosmossdk.io/errors"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
-	// capabilitytypes removed - deprecated in IBC-Go v8
 	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
 	porttypes "github.com/cosmos/ibc-go/v8/modules/core/05-port/types"
-	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
 	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
 
 	"github.com/persona-chain/persona-chain/x/did/keeper"
@@ -16,10 +16,14 @@ import (
 )
 
 var (
-	_ porttypes.IBCModule = IBCModule{}
+	_ porttypes.IBCModule        = IBCModule{}
+	_ porttypes.UpgradableModule = IBCModule{}
 )
 
-// IBCModule implements the ICS26 interface for did module
+// IBCModule implements the ICS26 interface for the did module. Each channel
+// negotiates either did-1, which carries DidPacketData update and sync
+// packets, or did-2, which adds IBCPacketData resolution, VC verification,
+// proof and governance packets.
 type IBCModule struct {
 	keeper keeper.Keeper
 }
@@ -31,55 +35,74 @@ func NewIBCModule(k keeper.Keeper) IBCModule {
 	}
 }
 
-// OnChanOpenInit implements the IBCModule interface
+// OnChanOpenInit implements the IBCModule interface. An empty version
+// proposes the latest protocol version. The proposal is recorded so that
+// OnChanOpenAck can hold the counterparty to it.
 func (im IBCModule) OnChanOpenInit(
 	ctx sdk.Context,
 	order channeltypes.Order,
 	connectionHops []string,
 	portID string,
 	channelID string,
-	chanCap interface{} // Capability removed,
+	channelCap interface{}, // capability removed in IBC-Go v8
 	counterparty channeltypes.Counterparty,
 	version string,
 ) (string, error) {
-	// Require ordered channels for DID updates to ensure consistency
-	if order != channeltypes.ORDERED {
-		return "", sdkerrors.Wrapf(channeltypes.ErrInvalidChannelOrdering, "expected %s channel, got %s ", channeltypes.ORDERED, order)
+	if err := validateChannelParams(order, connectionHops, portID); err != nil {
+		return "", err
 	}
 
-	// Validate the version
-	if version != types.Version {
-		return "", sdkerrors.Wrapf(types.ErrInvalidVersion, "expected %s, got %s", types.Version, version)
+	version, err := types.NegotiateIBCVersion(version)
+	if err != nil {
+		return "", err
 	}
+	im.keeper.SetChannelVersion(ctx, channelID, version)
+
+	im.keeper.LogAuditEvent(ctx, "ibc_channel_open_init", map[string]interface{}{
+		"channel_id":   channelID,
+		"port_id":      portID,
+		"counterparty": counterparty.String(),
+		"version":      version,
+	})
 
 	return version, nil
 }
 
-// OnChanOpenTry implements the IBCModule interface
+// OnChanOpenTry implements the IBCModule interface. The version proposed by
+// the counterparty is accepted if this chain speaks it.
 func (im IBCModule) OnChanOpenTry(
 	ctx sdk.Context,
 	order channeltypes.Order,
 	connectionHops []string,
 	portID,
 	channelID string,
-	chanCap interface{} // Capability removed,
+	channelCap interface{}, // capability removed in IBC-Go v8
 	counterparty channeltypes.Counterparty,
 	counterpartyVersion string,
 ) (string, error) {
-	// Require ordered channels
-	if order != channeltypes.ORDERED {
-		return "", sdkerrors.Wrapf(channeltypes.ErrInvalidChannelOrdering, "expected %s channel, got %s ", channeltypes.ORDERED, order)
+	if err := validateChannelParams(order, connectionHops, portID); err != nil {
+		return "", err
 	}
 
-	// Validate the version
-	if counterpartyVersion != types.Version {
-		return "", sdkerrors.Wrapf(types.ErrInvalidVersion, "expected %s, got %s", types.Version, counterpartyVersion)
+	version, err := types.NegotiateIBCVersion(counterpartyVersion)
+	if err != nil {
+		return "", err
 	}
+	im.keeper.SetChannelVersion(ctx, channelID, version)
 
-	return types.Version, nil
+	im.keeper.LogAuditEvent(ctx, "ibc_channel_open_try", map[string]interface{}{
+		"channel_id":           channelID,
+		"port_id":              portID,
+		"counterparty":         counterparty.String(),
+		"counterparty_version": counterpartyVersion,
+		"negotiated_version":   version,
+	})
+
+	return version, nil
 }
 
-// OnChanOpenAck implements the IBCModule interface
+// OnChanOpenAck implements the IBCModule interface. The counterparty must
+// accept the version proposed in OnChanOpenInit.
 func (im IBCModule) OnChanOpenAck(
 	ctx sdk.Context,
 	portID,
@@ -87,9 +110,19 @@ func (im IBCModule) OnChanOpenAck(
 	counterpartyChannelID string,
 	counterpartyVersion string,
 ) error {
-	if counterpartyVersion != types.Version {
-		return sdkerrors.Wrapf(types.ErrInvalidVersion, "expected %s, got %s", types.Version, counterpartyVersion)
+	if err := types.ValidateIBCVersion(counterpartyVersion); err != nil {
+		return err
+	}
+	if proposed := im.keeper.GetChannelVersion(ctx, channelID); counterpartyVersion != proposed {
+		return errorsmod.Wrapf(types.ErrUnsupportedIBCVersion, "counterparty version %s does not match proposed version %s", counterpartyVersion, proposed)
 	}
+
+	im.keeper.LogAuditEvent(ctx, "ibc_channel_open_ack", map[string]interface{}{
+		"channel_id":              channelID,
+		"counterparty_channel_id": counterpartyChannelID,
+		"counterparty_version":    counterpartyVersion,
+	})
+
 	return nil
 }
 
@@ -121,23 +154,166 @@ func (im IBCModule) OnChanCloseConfirm(
 	return nil
 }
 
-// OnRecvPacket implements the IBCModule interface
+// OnChanUpgradeInit implements the UpgradableModule interface. Channels may
+// be upgraded from did-1 to did-2 but never downgraded.
+func (im IBCModule) OnChanUpgradeInit(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+	proposedOrder channeltypes.Order,
+	proposedConnectionHops []string,
+	proposedVersion string,
+) (string, error) {
+	return im.upgradeVersion(ctx, portID, channelID, proposedOrder, proposedConnectionHops, proposedVersion)
+}
+
+// OnChanUpgradeTry implements the UpgradableModule interface
+func (im IBCModule) OnChanUpgradeTry(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+	proposedOrder channeltypes.Order,
+	proposedConnectionHops []string,
+	counterpartyVersion string,
+) (string, error) {
+	return im.upgradeVersion(ctx, portID, channelID, proposedOrder, proposedConnectionHops, counterpartyVersion)
+}
+
+// OnChanUpgradeAck implements the UpgradableModule interface
+func (im IBCModule) OnChanUpgradeAck(
+	ctx sdk.Context,
+	portID,
+	channelID,
+	counterpartyVersion string,
+) error {
+	return types.ValidateIBCVersionUpgrade(im.keeper.GetChannelVersion(ctx, channelID), counterpartyVersion)
+}
+
+// OnChanUpgradeOpen implements the UpgradableModule interface. Packets of
+// the previous version still in flight keep being processed, since did-2
+// channels accept did-1 packets.
+func (im IBCModule) OnChanUpgradeOpen(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+	proposedOrder channeltypes.Order,
+	proposedConnectionHops []string,
+	proposedVersion string,
+) {
+	previous := im.keeper.GetChannelVersion(ctx, channelID)
+	im.keeper.SetChannelVersion(ctx, channelID, proposedVersion)
+
+	im.keeper.LogAuditEvent(ctx, "ibc_channel_upgrade_open", map[string]interface{}{
+		"channel_id":       channelID,
+		"port_id":          portID,
+		"previous_version": previous,
+		"version":          proposedVersion,
+	})
+}
+
+// upgradeVersion returns the version a channel is upgraded to, checking the
+// upgraded channel still meets the requirements of DID channels
+func (im IBCModule) upgradeVersion(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+	order channeltypes.Order,
+	connectionHops []string,
+	proposedVersion string,
+) (string, error) {
+	if err := validateChannelParams(order, connectionHops, portID); err != nil {
+		return "", err
+	}
+
+	version, err := types.NegotiateIBCVersion(proposedVersion)
+	if err != nil {
+		return "", err
+	}
+	if err := types.ValidateIBCVersionUpgrade(im.keeper.GetChannelVersion(ctx, channelID), version); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+// validateChannelParams validates the parameters of a DID channel
+func validateChannelParams(order channeltypes.Order, connectionHops []string, portID string) error {
+	if portID != types.PortID {
+		return errorsmod.Wrapf(types.ErrInvalidPort, "expected port %s, got %s", types.PortID, portID)
+	}
+
+	// Require ordered channels for DID updates to ensure consistency
+	if order != channeltypes.ORDERED {
+		return errorsmod.Wrapf(types.ErrInvalidChannelOrder, "expected %s channel, got %s", channeltypes.ORDERED, order)
+	}
+
+	if len(connectionHops) != 1 {
+		return errorsmod.Wrapf(types.ErrInvalidConnectionHops, "expected 1 connection hop, got %d", len(connectionHops))
+	}
+
+	return nil
+}
+
+// OnRecvPacket implements the IBCModule interface. did-1 packets are
+// accepted on every channel; did-2 packets only on channels that negotiated
+// did-2.
 func (im IBCModule) OnRecvPacket(
 	ctx sdk.Context,
 	packet channeltypes.Packet,
 	relayer sdk.AccAddress,
 ) ibcexported.Acknowledgement {
+	channelVersion := im.keeper.GetChannelVersion(ctx, packet.GetDestChannel())
+	packetVersion := types.PacketVersion(packet.GetData())
+	if !types.ChannelAccepts(channelVersion, packetVersion) {
+		return channeltypes.NewErrorAcknowledgement(errorsmod.Wrapf(types.ErrUnsupportedIBCVersion, "%s packet received on %s channel %s", packetVersion, channelVersion, packet.GetDestChannel()))
+	}
+
+	if packetVersion == types.IBCVersion2 {
+		return im.onRecvPacketV2(ctx, packet, relayer)
+	}
+	return im.onRecvPacketV1(ctx, packet)
+}
+
+// OnAcknowledgementPacket implements the IBCModule interface. Acknowledgements
+// are dispatched by the encoding of the packet sent, which outlives any
+// upgrade of the channel.
+func (im IBCModule) OnAcknowledgementPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	acknowledgement []byte,
+	relayer sdk.AccAddress,
+) error {
+	if types.PacketVersion(packet.GetData()) == types.IBCVersion2 {
+		return im.onAcknowledgementPacketV2(ctx, packet, acknowledgement, relayer)
+	}
+	return im.onAcknowledgementPacketV1(ctx, packet, acknowledgement)
+}
+
+// OnTimeoutPacket implements the IBCModule interface
+func (im IBCModule) OnTimeoutPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	relayer sdk.AccAddress,
+) error {
+	if types.PacketVersion(packet.GetData()) == types.IBCVersion2 {
+		return im.onTimeoutPacketV2(ctx, packet, relayer)
+	}
+	return im.onTimeoutPacketV1(ctx, packet)
+}
+
+// onRecvPacketV1 processes a did-1 DidPacketData packet
+func (im IBCModule) onRecvPacketV1(ctx sdk.Context, packet channeltypes.Packet) ibcexported.Acknowledgement {
 	var data types.DidPacketData
 	if err := types.ModuleCdc.UnmarshalJSON(packet.GetData(), &data); err != nil {
 		return channeltypes.NewErrorAcknowledgement(fmt.Errorf("cannot unmarshal IBC packet data: %w", err))
 	}
 
+	channelID := packet.GetDestChannel()
 	var ack ibcexported.Acknowledgement
 	switch packet := data.Packet.(type) {
 	case *types.DidPacketData_DidUpdatePacketData:
-		ack = im.onRecvDidUpdatePacket(ctx, packet.DidUpdatePacketData)
+		ack = im.onRecvDidUpdatePacket(ctx, channelID, packet.DidUpdatePacketData)
 	case *types.DidPacketData_DidSyncPacketData:
-		ack = im.onRecvDidSyncPacket(ctx, packet.DidSyncPacketData)
+		ack = im.onRecvDidSyncPacket(ctx, channelID, packet.DidSyncPacketData)
 	default:
 		ack = channeltypes.NewErrorAcknowledgement(fmt.Errorf("unknown packet type: %T", packet))
 	}
@@ -154,72 +330,53 @@ func (im IBCModule) OnRecvPacket(
 	return ack
 }
 
-func (im IBCModule) onRecvDidUpdatePacket(ctx sdk.Context, data *types.DidUpdatePacketData) ibcexported.Acknowledgement {
-	// Process DID update from another chain
-	ack := types.DidUpdatePacketAck{
-		Success: true,
+func (im IBCModule) onRecvDidUpdatePacket(ctx sdk.Context, channelID string, data *types.DidUpdatePacketData) ibcexported.Acknowledgement {
+	// DIDs received from another chain are kept in the channel's foreign
+	// namespace and never overwrite native DIDs
+	if err := im.keeper.ApplyForeignDidUpdate(ctx, channelID, *data); err != nil {
+		return channeltypes.NewErrorAcknowledgement(err)
 	}
 
-	// Check if DID already exists
-	existingDid, found := im.keeper.GetDidDocument(ctx, data.DidId)
-	if found {
-		// Update existing DID if the update is newer
-		if data.UpdatedAt > existingDid.UpdatedAt {
-			updatedDid := types.DidDocument{
-				Id:          data.DidId,
-				DidDocument: data.DidDocument,
-				Creator:     data.Controller,
-				Active:      data.Active,
-				CreatedAt:   existingDid.CreatedAt,
-				UpdatedAt:   data.UpdatedAt,
-			}
-			im.keeper.SetDidDocument(ctx, updatedDid)
-		}
-	} else {
-		// Create new DID document
-		newDid := types.DidDocument{
-			Id:          data.DidId,
-			DidDocument: data.DidDocument,
-			Creator:     data.Controller,
-			Active:      data.Active,
-			CreatedAt:   data.UpdatedAt,
-			UpdatedAt:   data.UpdatedAt,
-		}
-		im.keeper.SetDidDocument(ctx, newDid)
+	ack := types.DidUpdatePacketAck{
+		Success: true,
 	}
-
 	return channeltypes.NewResultAcknowledgement(types.ModuleCdc.MustMarshalJSON(&ack))
 }
 
-func (im IBCModule) onRecvDidSyncPacket(ctx sdk.Context, data *types.DidSyncPacketData) ibcexported.Acknowledgement {
+func (im IBCModule) onRecvDidSyncPacket(ctx sdk.Context, channelID string, data *types.DidSyncPacketData) ibcexported.Acknowledgement {
 	ack := types.DidSyncPacketAck{
 		DidDocuments: []types.DidUpdatePacketData{},
 	}
 
-	// Retrieve requested DIDs
+	// Retrieve requested DIDs, withholding those whose data residency
+	// policy forbids the jurisdiction of the requesting chain. DIDs that
+	// could not be exported are reported alongside the documents that were.
+	var withheld, failed []string
 	for _, didId := range data.DidIds {
-		if didDoc, found := im.keeper.GetDidDocument(ctx, didId); found {
-			updateData := types.DidUpdatePacketData{
-				DidId:       didDoc.Id,
-				DidDocument: didDoc.DidDocument,
-				Controller:  didDoc.Creator,
-				Active:      didDoc.Active,
-				UpdatedAt:   didDoc.UpdatedAt,
-			}
+		updateData, err := im.keeper.ExportDidUpdatePacket(ctx, didId, channelID)
+		switch {
+		case err == nil:
 			ack.DidDocuments = append(ack.DidDocuments, updateData)
+		case errorsmod.IsOf(err, types.ErrDataResidencyViolation):
+			withheld = append(withheld, didId)
+		default:
+			failed = append(failed, fmt.Sprintf("%s (%v)", didId, err))
 		}
 	}
+	var problems []string
+	if len(withheld) > 0 {
+		problems = append(problems, fmt.Sprintf("withheld by data residency policy: %s", strings.Join(withheld, ", ")))
+	}
+	if len(failed) > 0 {
+		problems = append(problems, fmt.Sprintf("failed to export: %s", strings.Join(failed, ", ")))
+	}
+	ack.Error = strings.Join(problems, "; ")
 
 	return channeltypes.NewResultAcknowledgement(types.ModuleCdc.MustMarshalJSON(&ack))
 }
 
-// OnAcknowledgementPacket implements the IBCModule interface
-func (im IBCModule) OnAcknowledgementPacket(
-	ctx sdk.Context,
-	packet channeltypes.Packet,
-	acknowledgement []byte,
-	relayer sdk.AccAddress,
-) error {
+// onAcknowledgementPacketV1 processes the acknowledgement of a did-1 packet
+func (im IBCModule) onAcknowledgementPacketV1(ctx sdk.Context, packet channeltypes.Packet, acknowledgement []byte) error {
 	var ack channeltypes.Acknowledgement
 	if err := types.ModuleCdc.UnmarshalJSON(acknowledgement, &ack); err != nil {
 		return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "cannot unmarshal packet acknowledgement: %v", err)
@@ -230,11 +387,12 @@ func (im IBCModule) OnAcknowledgementPacket(
 		return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "cannot unmarshal packet data: %v", err)
 	}
 
+	channelID := packet.GetSourceChannel()
 	switch packet := data.Packet.(type) {
 	case *types.DidPacketData_DidUpdatePacketData:
 		return im.onAckDidUpdatePacket(ctx, packet.DidUpdatePacketData, ack)
 	case *types.DidPacketData_DidSyncPacketData:
-		return im.onAckDidSyncPacket(ctx, packet.DidSyncPacketData, ack)
+		return im.onAckDidSyncPacket(ctx, channelID, packet.DidSyncPacketData, ack)
 	default:
 		return fmt.Errorf("unknown packet type: %T", packet)
 	}
@@ -269,7 +427,7 @@ func (im IBCModule) onAckDidUpdatePacket(ctx sdk.Context, data *types.DidUpdateP
 	return nil
 }
 
-func (im IBCModule) onAckDidSyncPacket(ctx sdk.Context, data *types.DidSyncPacketData, ack channeltypes.Acknowledgement) error {
+func (im IBCModule) onAckDidSyncPacket(ctx sdk.Context, channelID string, data *types.DidSyncPacketData, ack channeltypes.Acknowledgement) error {
 	switch resp := ack.Response.(type) {
 	case *channeltypes.Acknowledgement_Result:
 		var ackData types.DidSyncPacketAck
@@ -277,23 +435,12 @@ func (im IBCModule) onAckDidSyncPacket(ctx sdk.Context, data *types.DidSyncPacke
 			return err
 		}
 
-		// Process synchronized DID documents
+		// Store synchronized DID documents in the channel's foreign
+		// namespace, skipping any that fail authentication or are not newer
+		// than the version already received
 		for _, didDoc := range ackData.DidDocuments {
-			// Update local DID if newer
-			existingDid, found := im.keeper.GetDidDocument(ctx, didDoc.DidId)
-			if !found || didDoc.UpdatedAt > existingDid.UpdatedAt {
-				updatedDid := types.DidDocument{
-					Id:          didDoc.DidId,
-					DidDocument: didDoc.DidDocument,
-					Creator:     didDoc.Controller,
-					Active:      didDoc.Active,
-					CreatedAt:   existingDid.CreatedAt,
-					UpdatedAt:   didDoc.UpdatedAt,
-				}
-				if !found {
-					updatedDid.CreatedAt = didDoc.UpdatedAt
-				}
-				im.keeper.SetDidDocument(ctx, updatedDid)
+			if err := im.keeper.ApplyForeignDidUpdate(ctx, channelID, didDoc); err != nil {
+				im.keeper.Logger(ctx).Info("Rejected synchronized DID document", "did", didDoc.DidId, "channel", channelID, "error", err)
 			}
 		}
 
@@ -316,12 +463,8 @@ func (im IBCModule) onAckDidSyncPacket(ctx sdk.Context, data *types.DidSyncPacke
 	return nil
 }
 
-// OnTimeoutPacket implements the IBCModule interface
-func (im IBCModule) OnTimeoutPacket(
-	ctx sdk.Context,
-	packet channeltypes.Packet,
-	relayer sdk.AccAddress,
-) error {
+// onTimeoutPacketV1 processes the timeout of a did-1 packet
+func (im IBCModule) onTimeoutPacketV1(ctx sdk.Context, packet channeltypes.Packet) error {
 	var data types.DidPacketData
 	if err := types.ModuleCdc.UnmarshalJSON(packet.GetData(), &data); err != nil {
 		return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "cannot unmarshal packet data: %v", err)
@@ -355,4 +498,4 @@ func (im IBCModule) onTimeoutDidSyncPacket(ctx sdk.Context, data *types.DidSyncP
 		),
 	)
 	return nil
-}
\ No newline at end of file
+}
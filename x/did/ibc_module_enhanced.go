@@ -1,233 +1,19 @@
 package did
 
 import (
-	"context"
-	"fmt"
 	"encoding/json"
-	"time"
+	"fmt"
 
-	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
-	// capabilitytypes removed - deprecated in IBC-Go v8
-	
-	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
 	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
-	porttypes "github.com/cosmos/ibc-go/v8/modules/core/05-port/types"
-	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
 	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
-	
-	"github.com/persona-chain/persona-chain/x/did/keeper"
-	"github.com/persona-chain/persona-chain/x/did/types"
-)
 
-var (
-	_ porttypes.IBCModule = IBCModule{}
-	_ porttypes.Middleware = IBCModule{}
+	"github.com/persona-chain/persona-chain/x/did/types"
 )
 
-// IBCModule implements the ICS26 interface for interchain DID operations
-type IBCModule struct {
-	keeper        keeper.Keeper
-	app           porttypes.IBCModule
-	cdc           codec.Codec
-}
-
-// NewIBCModule creates a new IBCModule given the keeper and underlying application
-func NewIBCModule(k keeper.Keeper, app porttypes.IBCModule, cdc codec.Codec) IBCModule {
-	return IBCModule{
-		keeper:        k,
-		app:           app,
-		cdc:           cdc,
-	}
-}
-
-// OnChanOpenInit implements the IBCModule interface
-func (im IBCModule) OnChanOpenInit(
-	ctx sdk.Context,
-	order channeltypes.Order,
-	connectionHops []string,
-	portID string,
-	channelID string,
-	channelCap interface{}, // capability removed in IBC-Go v8
-	counterparty channeltypes.Counterparty,
-	version string,
-) (string, error) {
-	// Validate channel parameters for DID operations
-	if err := im.validateChannelParams(order, connectionHops, portID); err != nil {
-		return "", err
-	}
-
-	// Enhanced version negotiation for DID-specific features
-	negotiatedVersion, err := im.negotiateVersion(version)
-	if err != nil {
-		return "", err
-	}
-
-	// Capability claiming removed in IBC-Go v8
-	// if err := im.keeper.ClaimCapability(ctx, channelCap, host.ChannelCapabilityPath(portID, channelID)); err != nil {
-	//	return "", err
-	// }
-
-	// Log channel opening for audit
-	im.keeper.LogAuditEvent(ctx, "ibc_channel_open_init", map[string]interface{}{
-		"channel_id":   channelID,
-		"port_id":      portID,
-		"counterparty": counterparty.String(),
-		"version":      negotiatedVersion,
-	})
-
-	return negotiatedVersion, nil
-}
-
-// OnChanOpenTry implements the IBCModule interface
-func (im IBCModule) OnChanOpenTry(
-	ctx sdk.Context,
-	order channeltypes.Order,
-	connectionHops []string,
-	portID,
-	channelID string,
-	channelCap interface{}, // capability removed in IBC-Go v8
-	counterparty channeltypes.Counterparty,
-	counterpartyVersion string,
-) (string, error) {
-	// Validate channel parameters
-	if err := im.validateChannelParams(order, connectionHops, portID); err != nil {
-		return "", err
-	}
-
-	// Negotiate version with counterparty
-	negotiatedVersion, err := im.negotiateVersion(counterpartyVersion)
-	if err != nil {
-		return "", err
-	}
-
-	// Capability claiming removed in IBC-Go v8
-	// if err := im.keeper.ClaimCapability(ctx, channelCap, host.ChannelCapabilityPath(portID, channelID)); err != nil {
-	//	return "", err
-	// }
-
-	// Log channel opening attempt
-	im.keeper.LogAuditEvent(ctx, "ibc_channel_open_try", map[string]interface{}{
-		"channel_id":           channelID,
-		"port_id":              portID,
-		"counterparty":         counterparty.String(),
-		"counterparty_version": counterpartyVersion,
-		"negotiated_version":   negotiatedVersion,
-	})
-
-	return negotiatedVersion, nil
-}
-
-// OnChanOpenAck implements the IBCModule interface
-func (im IBCModule) OnChanOpenAck(
-	ctx sdk.Context,
-	portID,
-	channelID string,
-	counterpartyChannelID string,
-	counterpartyVersion string,
-) error {
-	// Validate counterparty version
-	if err := im.validateVersion(counterpartyVersion); err != nil {
-		return err
-	}
-
-	// Store channel information for future use
-	im.keeper.SetChannelInfo(ctx, channelID, types.ChannelInfo{
-		PortID:                portID,
-		ChannelID:             channelID,
-		CounterpartyChannelID: counterpartyChannelID,
-		Version:               counterpartyVersion,
-		State:                 types.ChannelStateOpen,
-		CreatedAt:             ctx.BlockTime(),
-	})
-
-	// Log successful channel opening
-	im.keeper.LogAuditEvent(ctx, "ibc_channel_open_ack", map[string]interface{}{
-		"channel_id":              channelID,
-		"counterparty_channel_id": counterpartyChannelID,
-		"counterparty_version":    counterpartyVersion,
-	})
-
-	return nil
-}
-
-// OnChanOpenConfirm implements the IBCModule interface
-func (im IBCModule) OnChanOpenConfirm(
-	ctx sdk.Context,
-	portID,
-	channelID string,
-) error {
-	// Update channel state to confirmed
-	channelInfo, found := im.keeper.GetChannelInfo(ctx, channelID)
-	if !found {
-		return sdkerrors.Wrapf(types.ErrChannelNotFound, "channel %s not found", channelID)
-	}
-
-	channelInfo.State = types.ChannelStateConfirmed
-	channelInfo.ConfirmedAt = &ctx.BlockTime()
-	im.keeper.SetChannelInfo(ctx, channelID, channelInfo)
-
-	// Log channel confirmation
-	im.keeper.LogAuditEvent(ctx, "ibc_channel_open_confirm", map[string]interface{}{
-		"channel_id": channelID,
-		"port_id":    portID,
-	})
-
-	return nil
-}
-
-// OnChanCloseInit implements the IBCModule interface
-func (im IBCModule) OnChanCloseInit(
-	ctx sdk.Context,
-	portID,
-	channelID string,
-) error {
-	// Update channel state to closing
-	channelInfo, found := im.keeper.GetChannelInfo(ctx, channelID)
-	if !found {
-		return sdkerrors.Wrapf(types.ErrChannelNotFound, "channel %s not found", channelID)
-	}
-
-	channelInfo.State = types.ChannelStateClosing
-	im.keeper.SetChannelInfo(ctx, channelID, channelInfo)
-
-	// Log channel closure initiation
-	im.keeper.LogAuditEvent(ctx, "ibc_channel_close_init", map[string]interface{}{
-		"channel_id": channelID,
-		"port_id":    portID,
-	})
-
-	return nil
-}
-
-// OnChanCloseConfirm implements the IBCModule interface
-func (im IBCModule) OnChanCloseConfirm(
-	ctx sdk.Context,
-	portID,
-	channelID string,
-) error {
-	// Update channel state to closed
-	channelInfo, found := im.keeper.GetChannelInfo(ctx, channelID)
-	if !found {
-		return sdkerrors.Wrapf(types.ErrChannelNotFound, "channel %s not found", channelID)
-	}
-
-	channelInfo.State = types.ChannelStateClosed
-	channelInfo.ClosedAt = &ctx.BlockTime()
-	im.keeper.SetChannelInfo(ctx, channelID, channelInfo)
-
-	// Log channel closure confirmation
-	im.keeper.LogAuditEvent(ctx, "ibc_channel_close_confirm", map[string]interface{}{
-		"channel_id": channelID,
-		"port_id":    portID,
-	})
-
-	return nil
-}
-
-// OnRecvPacket implements the IBCModule interface
-func (im IBCModule) OnRecvPacket(
+// onRecvPacketV2 processes a did-2 IBCPacketData packet
+func (im IBCModule) onRecvPacketV2(
 	ctx sdk.Context,
 	packet channeltypes.Packet,
 	relayer sdk.AccAddress,
@@ -267,16 +53,17 @@ func (im IBCModule) OnRecvPacket(
 	}
 }
 
-// OnAcknowledgementPacket implements the IBCModule interface
-func (im IBCModule) OnAcknowledgementPacket(
+// onAcknowledgementPacketV2 processes the acknowledgement of a did-2 packet
+func (im IBCModule) onAcknowledgementPacketV2(
 	ctx sdk.Context,
 	packet channeltypes.Packet,
 	acknowledgement []byte,
 	relayer sdk.AccAddress,
 ) error {
-	// Parse acknowledgement
+	// Parse acknowledgement. Its result or error is a oneof, which only the
+	// proto JSON codec decodes.
 	var ack channeltypes.Acknowledgement
-	if err := json.Unmarshal(acknowledgement, &ack); err != nil {
+	if err := types.ModuleCdc.UnmarshalJSON(acknowledgement, &ack); err != nil {
 		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "failed to unmarshal acknowledgement: %v", err)
 	}
 
@@ -297,8 +84,8 @@ func (im IBCModule) OnAcknowledgementPacket(
 	}
 }
 
-// OnTimeoutPacket implements the IBCModule interface
-func (im IBCModule) OnTimeoutPacket(
+// onTimeoutPacketV2 processes the timeout of a did-2 packet
+func (im IBCModule) onTimeoutPacketV2(
 	ctx sdk.Context,
 	packet channeltypes.Packet,
 	relayer sdk.AccAddress,
@@ -332,30 +119,40 @@ func (im IBCModule) handleDIDResolution(ctx sdk.Context, packet channeltypes.Pac
 	}
 
 	// Resolve DID
-	didDoc, found := im.keeper.GetDIDDocument(ctx, req.DID)
+	didDoc, found := im.keeper.GetDidDocument(ctx, req.DID)
 	if !found {
 		return channeltypes.NewErrorAcknowledgement(fmt.Errorf("DID not found: %s", req.DID))
 	}
 
-	// Create response
+	// Create response. A document withheld by its data residency policy is
+	// reported in the response so the audited refusal is not reverted with
+	// an error acknowledgement.
 	response := types.DIDResolutionResponse{
 		DID:       req.DID,
-		Document:  didDoc,
 		Timestamp: ctx.BlockTime(),
 		ChainID:   ctx.ChainID(),
 	}
+	if err := im.keeper.CheckDataResidency(ctx, didDoc, packet.DestinationChannel); err != nil {
+		response.Error = err.Error()
+	} else {
+		response.Document = didDoc
+	}
 
 	responseData, err := json.Marshal(response)
 	if err != nil {
 		return channeltypes.NewErrorAcknowledgement(fmt.Errorf("failed to marshal response: %w", err))
 	}
 
-	// Log successful resolution
-	im.keeper.LogAuditEvent(ctx, "cross_chain_did_resolution", map[string]interface{}{
-		"did":            req.DID,
-		"source_chain":   packet.SourceChannel,
-		"success":        true,
-	})
+	// Log the resolution, including the refusal if the document was withheld
+	auditData := map[string]interface{}{
+		"did":          req.DID,
+		"source_chain": packet.SourceChannel,
+		"success":      response.Error == "",
+	}
+	if response.Error != "" {
+		auditData["error"] = response.Error
+	}
+	im.keeper.LogAuditEvent(ctx, "cross_chain_did_resolution", auditData)
 
 	return channeltypes.NewResultAcknowledgement(responseData)
 }
@@ -476,54 +273,6 @@ func (im IBCModule) handleGovernanceProposal(ctx sdk.Context, packet channeltype
 
 // Utility functions
 
-// validateChannelParams validates channel opening parameters
-func (im IBCModule) validateChannelParams(order channeltypes.Order, connectionHops []string, portID string) error {
-	// Validate port ID
-	if portID != types.PortID {
-		return sdkerrors.Wrapf(types.ErrInvalidPort, "expected port %s, got %s", types.PortID, portID)
-	}
-
-	// Validate order (must be ORDERED for DID operations)
-	if order != channeltypes.ORDERED {
-		return sdkerrors.Wrapf(types.ErrInvalidChannelOrder, "expected ORDERED channel, got %s", order)
-	}
-
-	// Validate connection hops
-	if len(connectionHops) != 1 {
-		return sdkerrors.Wrapf(types.ErrInvalidConnectionHops, "expected 1 connection hop, got %d", len(connectionHops))
-	}
-
-	return nil
-}
-
-// negotiateVersion negotiates the IBC version for DID operations
-func (im IBCModule) negotiateVersion(version string) (string, error) {
-	supportedVersions := []string{
-		types.IBCVersion1,
-		types.IBCVersion2,
-	}
-
-	// If no version specified, use latest
-	if version == "" {
-		return types.IBCVersion2, nil
-	}
-
-	// Check if version is supported
-	for _, supported := range supportedVersions {
-		if version == supported {
-			return version, nil
-		}
-	}
-
-	return "", sdkerrors.Wrapf(types.ErrUnsupportedVersion, "unsupported version: %s", version)
-}
-
-// validateVersion validates IBC version
-func (im IBCModule) validateVersion(version string) error {
-	_, err := im.negotiateVersion(version)
-	return err
-}
-
 // validatePacketData validates incoming packet data
 func (im IBCModule) validatePacketData(data types.IBCPacketData) error {
 	// Validate packet type
@@ -638,8 +387,16 @@ func (im IBCModule) handlePacketTimeout(ctx sdk.Context, packet channeltypes.Pac
 
 // Specific acknowledgement handlers (simplified implementations)
 func (im IBCModule) handleDIDResolutionAck(ctx sdk.Context, packet channeltypes.Packet, result []byte) error {
-	// Handle DID resolution acknowledgement
-	return nil
+	req, err := resolutionRequest(packet)
+	if err != nil {
+		return err
+	}
+
+	var res types.DIDResolutionResponse
+	if err := json.Unmarshal(result, &res); err != nil {
+		return im.keeper.OnRemoteResolutionFailure(ctx, packet.SourceChannel, packet.Sequence, req.DID, fmt.Sprintf("invalid resolution response: %v", err))
+	}
+	return im.keeper.OnRemoteResolutionAck(ctx, packet.SourceChannel, packet.Sequence, req.DID, res)
 }
 
 func (im IBCModule) handleVCVerificationAck(ctx sdk.Context, packet channeltypes.Packet, result []byte) error {
@@ -659,8 +416,11 @@ func (im IBCModule) handleGovernanceProposalAck(ctx sdk.Context, packet channelt
 
 // Error handlers
 func (im IBCModule) handleDIDResolutionError(ctx sdk.Context, packet channeltypes.Packet, errorMsg string) error {
-	// Handle DID resolution error
-	return nil
+	req, err := resolutionRequest(packet)
+	if err != nil {
+		return err
+	}
+	return im.keeper.OnRemoteResolutionFailure(ctx, packet.SourceChannel, packet.Sequence, req.DID, errorMsg)
 }
 
 func (im IBCModule) handleVCVerificationError(ctx sdk.Context, packet channeltypes.Packet, errorMsg string) error {
@@ -680,8 +440,11 @@ func (im IBCModule) handleGovernanceProposalError(ctx sdk.Context, packet channe
 
 // Timeout handlers
 func (im IBCModule) handleDIDResolutionTimeout(ctx sdk.Context, packet channeltypes.Packet, data types.IBCPacketData) error {
-	// Handle DID resolution timeout
-	return nil
+	var req types.DIDResolutionRequest
+	if err := json.Unmarshal(data.Data, &req); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "failed to unmarshal DID resolution request: %v", err)
+	}
+	return im.keeper.OnRemoteResolutionFailure(ctx, packet.SourceChannel, packet.Sequence, req.DID, "resolution packet timed out")
 }
 
 func (im IBCModule) handleVCVerificationTimeout(ctx sdk.Context, packet channeltypes.Packet, data types.IBCPacketData) error {
@@ -697,4 +460,18 @@ func (im IBCModule) handleCrossChainProofTimeout(ctx sdk.Context, packet channel
 func (im IBCModule) handleGovernanceProposalTimeout(ctx sdk.Context, packet channeltypes.Packet, data types.IBCPacketData) error {
 	// Handle governance proposal timeout
 	return nil
-}
\ No newline at end of file
+}
+
+// resolutionRequest returns the DID resolution request a packet carries
+func resolutionRequest(packet channeltypes.Packet) (types.DIDResolutionRequest, error) {
+	var data types.IBCPacketData
+	if err := json.Unmarshal(packet.GetData(), &data); err != nil {
+		return types.DIDResolutionRequest{}, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "failed to unmarshal packet data: %v", err)
+	}
+
+	var req types.DIDResolutionRequest
+	if err := json.Unmarshal(data.Data, &req); err != nil {
+		return types.DIDResolutionRequest{}, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "failed to unmarshal DID resolution request: %v", err)
+	}
+	return req, nil
+}
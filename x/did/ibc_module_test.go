@@ -0,0 +1,475 @@
+package did_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did"
+	"github.com/persona-chain/persona-chain/x/did/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+const (
+	openDID   = "did:persona:open"
+	euOnlyDID = "did:persona:eu"
+	euChannel = "channel-0"
+	usChannel = "channel-1"
+)
+
+// residencyKeeper returns a keeper holding an unrestricted DID and an EU-only
+// one, with channel-0 registered as EU and channel-1 as US
+func residencyKeeper(t *testing.T) (keeper.Keeper, sdk.Context) {
+	k, ctx := keepertest.DidKeeper(t)
+	params := k.GetParams(ctx)
+	params.ChannelJurisdictions = []types.ChannelJurisdiction{
+		{ChannelId: euChannel, Jurisdiction: "EU"},
+		{ChannelId: usChannel, Jurisdiction: "US"},
+	}
+	require.NoError(t, k.SetParams(ctx, params))
+
+	creator := make([]byte, 20)
+	copy(creator, "creator")
+	for did, residency := range map[string]types.DataResidency{
+		openDID:   {},
+		euOnlyDID: {AllowedRegions: []string{"EU"}},
+	} {
+		doc := types.DIDDocument{
+			Context: []string{"https://www.w3.org/ns/did/v1"},
+			ID:      did,
+			Creator: sdk.AccAddress(creator).String(),
+			Version: 1,
+		}
+		doc.Compliance.DataResidency = residency
+		require.NoError(t, k.SetDidDocument(ctx, doc))
+	}
+	return k, ctx
+}
+
+// resultOf returns the result of a successful acknowledgement
+func resultOf(t *testing.T, ack ibcexported.Acknowledgement) []byte {
+	require.True(t, ack.Success())
+	result, ok := ack.(channeltypes.Acknowledgement)
+	require.True(t, ok)
+	return result.GetResult()
+}
+
+// auditedIBCEvents returns the data of the IBC audit entries recorded for
+// action
+func auditedIBCEvents(t *testing.T, ctx sdk.Context, k keeper.Keeper, action string) []map[string]interface{} {
+	entries, err := k.GetAuditLog(ctx, "", 0)
+	require.NoError(t, err)
+	var events []map[string]interface{}
+	for _, entry := range entries {
+		if entry.Action != action {
+			continue
+		}
+		var data map[string]interface{}
+		require.NoError(t, json.Unmarshal(entry.Data, &data))
+		events = append(events, data)
+	}
+	return events
+}
+
+func TestRecvDidSyncPacket(t *testing.T) {
+	k, ctx := residencyKeeper(t)
+	module := did.NewIBCModule(k)
+
+	tests := []struct {
+		name     string
+		channel  string
+		dids     []string
+		exported []string
+		error    string
+	}{
+		{name: "all exported", channel: euChannel, dids: []string{openDID, euOnlyDID}, exported: []string{openDID, euOnlyDID}},
+		{
+			name:     "withheld by residency",
+			channel:  usChannel,
+			dids:     []string{openDID, euOnlyDID},
+			exported: []string{openDID},
+			error:    "withheld by data residency policy: " + euOnlyDID,
+		},
+		{
+			name:     "unknown DID",
+			channel:  euChannel,
+			dids:     []string{"did:persona:xyz", openDID},
+			exported: []string{openDID},
+			error:    "failed to export: did:persona:xyz (DID did:persona:xyz: DID document not found)",
+		},
+		{
+			name:    "withheld and unknown",
+			channel: usChannel,
+			dids:    []string{euOnlyDID, "did:persona:xyz"},
+			error:   "withheld by data residency policy: " + euOnlyDID + "; failed to export: did:persona:xyz (DID did:persona:xyz: DID document not found)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			packet := channeltypes.Packet{
+				SourcePort:         types.PortID,
+				SourceChannel:      "channel-7",
+				DestinationPort:    types.PortID,
+				DestinationChannel: tc.channel,
+				Data: types.ModuleCdc.MustMarshalJSON(&types.DidPacketData{
+					Packet: &types.DidPacketData_DidSyncPacketData{DidSyncPacketData: &types.DidSyncPacketData{DidIds: tc.dids}},
+				}),
+			}
+
+			var ack types.DidSyncPacketAck
+			require.NoError(t, types.ModuleCdc.UnmarshalJSON(resultOf(t, module.OnRecvPacket(cacheCtx, packet, nil)), &ack))
+			var exported []string
+			for _, doc := range ack.DidDocuments {
+				exported = append(exported, doc.DidId)
+			}
+			require.Equal(t, tc.exported, exported)
+			require.Equal(t, tc.error, ack.Error)
+		})
+	}
+}
+
+func TestRecvDIDResolutionPacket(t *testing.T) {
+	k, ctx := residencyKeeper(t)
+	module := did.NewIBCModule(k)
+	k.SetChannelVersion(ctx, euChannel, types.IBCVersion2)
+	k.SetChannelVersion(ctx, usChannel, types.IBCVersion2)
+
+	tests := []struct {
+		name    string
+		channel string
+		did     string
+		refused bool
+	}{
+		{name: "unrestricted", channel: usChannel, did: openDID},
+		{name: "allowed jurisdiction", channel: euChannel, did: euOnlyDID},
+		{name: "withheld by residency", channel: usChannel, did: euOnlyDID, refused: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			request, err := json.Marshal(types.DIDResolutionRequest{DID: tc.did})
+			require.NoError(t, err)
+			data, err := json.Marshal(types.IBCPacketData{Type: types.PacketTypeDIDResolution, Data: request})
+			require.NoError(t, err)
+			packet := channeltypes.Packet{
+				SourcePort:         types.PortID,
+				SourceChannel:      "channel-7",
+				DestinationPort:    types.PortID,
+				DestinationChannel: tc.channel,
+				Data:               data,
+			}
+
+			var response types.DIDResolutionResponse
+			require.NoError(t, json.Unmarshal(resultOf(t, module.OnRecvPacket(cacheCtx, packet, nil)), &response))
+			require.Equal(t, tc.did, response.DID)
+
+			// The audit entry of the resolution records whether the document
+			// was returned
+			audited := auditedIBCEvents(t, cacheCtx, k, "cross_chain_did_resolution")
+			require.Len(t, audited, 1)
+			require.Equal(t, !tc.refused, audited[0]["success"])
+			if tc.refused {
+				require.Empty(t, response.Document.ID)
+				require.Contains(t, response.Error, types.ErrDataResidencyViolation.Error())
+				require.Equal(t, response.Error, audited[0]["error"])
+				return
+			}
+			require.Empty(t, response.Error)
+			require.Equal(t, tc.did, response.Document.ID)
+			require.NotContains(t, audited[0], "error")
+		})
+	}
+}
+
+// signedForeignUpdate returns the packet data of version 1 of did sent from
+// a partner chain, signed by the document's only authentication key
+func signedForeignUpdate(t *testing.T, did string) types.DidUpdatePacketData {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	vm := types.VerificationMethod{
+		ID:                 did + "#key-1",
+		Type:               types.VerificationMethodTypeEd25519VerificationKey2020,
+		Controller:         did,
+		PublicKeyMultibase: types.EncodeMultibaseMultikey(types.MulticodecEd25519Pub, pub),
+	}
+	doc := types.DIDDocument{
+		Context:            []string{"https://www.w3.org/ns/did/v1"},
+		ID:                 did,
+		VerificationMethod: []types.VerificationMethod{vm},
+		Authentication:     []string{vm.ID},
+		Version:            1,
+	}
+	bz, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	data := types.DidUpdatePacketData{DidId: did, DidDocument: string(bz), Version: 1, SourceChainId: "partner-1"}
+	challenge := types.ForeignUpdateChallenge(did, data.SourceChainId, data.Version, data.DidDocument)
+	data.Proof = &types.KeyProof{VerificationMethodId: vm.ID, Signature: ed25519.Sign(priv, challenge)}
+	return data
+}
+
+func TestRecvDidUpdatePacket(t *testing.T) {
+	k, ctx := residencyKeeper(t)
+	module := did.NewIBCModule(k)
+	const remoteDID = "did:persona:remote"
+
+	tests := []struct {
+		name     string
+		data     types.DidUpdatePacketData
+		accepted bool
+	}{
+		{name: "foreign DID", data: signedForeignUpdate(t, remoteDID), accepted: true},
+		{name: "native DID", data: signedForeignUpdate(t, openDID)},
+		{
+			name: "unsigned",
+			data: func() types.DidUpdatePacketData {
+				data := signedForeignUpdate(t, remoteDID)
+				data.Proof = nil
+				return data
+			}(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			packet := channeltypes.Packet{
+				SourcePort:         types.PortID,
+				SourceChannel:      "channel-7",
+				DestinationPort:    types.PortID,
+				DestinationChannel: euChannel,
+				Data: types.ModuleCdc.MustMarshalJSON(&types.DidPacketData{
+					Packet: &types.DidPacketData_DidUpdatePacketData{DidUpdatePacketData: &tc.data},
+				}),
+			}
+
+			ack := module.OnRecvPacket(cacheCtx, packet, nil)
+			require.Equal(t, tc.accepted, ack.Success())
+			_, stored := k.GetForeignDidDocument(cacheCtx, euChannel, tc.data.DidId)
+			require.Equal(t, tc.accepted, stored)
+
+			// Native documents are never replaced from IBC
+			native, found := k.GetDidDocument(cacheCtx, openDID)
+			require.True(t, found)
+			require.Empty(t, native.VerificationMethod)
+		})
+	}
+}
+
+func TestRemoteResolutionAcknowledgement(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ics4Wrapper := &keepertest.MockICS4Wrapper{}
+	k.SetICS4Wrapper(ics4Wrapper)
+	k.SetChannelVersion(ctx, euChannel, types.IBCVersion2)
+	module := did.NewIBCModule(k)
+	const remoteDID = "did:persona:remote"
+
+	response, err := json.Marshal(types.DIDResolutionResponse{
+		DID:      remoteDID,
+		Document: types.DIDDocument{Context: []string{"https://www.w3.org/ns/did/v1"}, ID: remoteDID, Version: 1},
+		ChainID:  "partner-1",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		deliver func(ctx sdk.Context, packet channeltypes.Packet) error
+		status  string
+		error   string
+	}{
+		{
+			name: "result",
+			deliver: func(ctx sdk.Context, packet channeltypes.Packet) error {
+				return module.OnAcknowledgementPacket(ctx, packet, channeltypes.NewResultAcknowledgement(response).Acknowledgement(), nil)
+			},
+			status: types.RemoteResolutionResolved,
+		},
+		{
+			name: "error",
+			deliver: func(ctx sdk.Context, packet channeltypes.Packet) error {
+				ack := channeltypes.NewErrorAcknowledgement(types.ErrDIDNotFound)
+				return module.OnAcknowledgementPacket(ctx, packet, ack.Acknowledgement(), nil)
+			},
+			status: types.RemoteResolutionFailed,
+			error:  "ABCI code: 1002: error handling packet: see events for details",
+		},
+		{
+			name: "timeout",
+			deliver: func(ctx sdk.Context, packet channeltypes.Packet) error {
+				return module.OnTimeoutPacket(ctx, packet, nil)
+			},
+			status: types.RemoteResolutionFailed,
+			error:  "resolution packet timed out",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			_, err := k.RequestRemoteResolution(cacheCtx, euChannel, remoteDID, "")
+			require.NoError(t, err)
+			require.NoError(t, tc.deliver(cacheCtx, ics4Wrapper.Sent[len(ics4Wrapper.Sent)-1]))
+
+			entry, found := k.GetRemoteDidDocument(cacheCtx, remoteDID, euChannel)
+			require.True(t, found)
+			require.Equal(t, tc.status, entry.Status)
+			require.Equal(t, tc.error, entry.Error)
+		})
+	}
+}
+
+func TestChannelHandshakeVersion(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	module := did.NewIBCModule(k)
+	counterparty := channeltypes.NewCounterparty(types.PortID, "channel-7")
+	hops := []string{"connection-0"}
+
+	tests := []struct {
+		name        string
+		proposed    string
+		ackVersion  string
+		initVersion string
+		err         error
+	}{
+		{name: "latest by default", ackVersion: types.IBCVersion2, initVersion: types.IBCVersion2},
+		{name: "did-1 proposed and accepted", proposed: types.IBCVersion1, ackVersion: types.IBCVersion1, initVersion: types.IBCVersion1},
+		{name: "did-2 proposed and accepted", proposed: types.IBCVersion2, ackVersion: types.IBCVersion2, initVersion: types.IBCVersion2},
+		{name: "counterparty downgrades", proposed: types.IBCVersion2, ackVersion: types.IBCVersion1, initVersion: types.IBCVersion2, err: types.ErrUnsupportedIBCVersion},
+		{name: "counterparty upgrades", proposed: types.IBCVersion1, ackVersion: types.IBCVersion2, initVersion: types.IBCVersion1, err: types.ErrUnsupportedIBCVersion},
+		{name: "unknown counterparty version", proposed: types.IBCVersion2, ackVersion: "did-3", initVersion: types.IBCVersion2, err: types.ErrUnsupportedIBCVersion},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			version, err := module.OnChanOpenInit(cacheCtx, channeltypes.ORDERED, hops, types.PortID, "channel-0", nil, counterparty, tc.proposed)
+			require.NoError(t, err)
+			require.Equal(t, tc.initVersion, version)
+
+			err = module.OnChanOpenAck(cacheCtx, types.PortID, "channel-0", "channel-7", tc.ackVersion)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.ackVersion, k.GetChannelVersion(cacheCtx, "channel-0"))
+		})
+	}
+
+	// The counterparty opens with the version proposed to it
+	for proposed, negotiated := range map[string]string{"": types.IBCVersion2, types.IBCVersion1: types.IBCVersion1, types.IBCVersion2: types.IBCVersion2} {
+		cacheCtx, _ := ctx.CacheContext()
+		version, err := module.OnChanOpenTry(cacheCtx, channeltypes.ORDERED, hops, types.PortID, "channel-1", nil, counterparty, proposed)
+		require.NoError(t, err)
+		require.Equal(t, negotiated, version)
+		require.Equal(t, negotiated, k.GetChannelVersion(cacheCtx, "channel-1"))
+	}
+	_, err := module.OnChanOpenTry(ctx, channeltypes.ORDERED, hops, types.PortID, "channel-1", nil, counterparty, "did-3")
+	require.ErrorIs(t, err, types.ErrUnsupportedIBCVersion)
+}
+
+func TestChannelUpgradeVersion(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	module := did.NewIBCModule(k)
+	hops := []string{"connection-0"}
+
+	tests := []struct {
+		name     string
+		current  string
+		proposed string
+		err      error
+	}{
+		{name: "did-1 to did-2", current: types.IBCVersion1, proposed: types.IBCVersion2},
+		{name: "latest by default", current: types.IBCVersion1},
+		{name: "did-2 to did-1", current: types.IBCVersion2, proposed: types.IBCVersion1, err: types.ErrUnsupportedIBCVersion},
+		{name: "unknown version", current: types.IBCVersion1, proposed: "did-3", err: types.ErrUnsupportedIBCVersion},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			k.SetChannelVersion(cacheCtx, "channel-0", tc.current)
+
+			version, err := module.OnChanUpgradeInit(cacheCtx, types.PortID, "channel-0", channeltypes.ORDERED, hops, tc.proposed)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, types.IBCVersion2, version)
+
+			// The channel only speaks the new version once the upgrade opens
+			require.Equal(t, tc.current, k.GetChannelVersion(cacheCtx, "channel-0"))
+			module.OnChanUpgradeOpen(cacheCtx, types.PortID, "channel-0", channeltypes.ORDERED, hops, version)
+			require.Equal(t, version, k.GetChannelVersion(cacheCtx, "channel-0"))
+		})
+	}
+}
+
+func TestRecvPacketVersionDispatch(t *testing.T) {
+	k, ctx := residencyKeeper(t)
+	k.SetChannelVersion(ctx, euChannel, types.IBCVersion1)
+	k.SetChannelVersion(ctx, usChannel, types.IBCVersion2)
+	module := did.NewIBCModule(k)
+
+	syncPacket := types.ModuleCdc.MustMarshalJSON(&types.DidPacketData{
+		Packet: &types.DidPacketData_DidSyncPacketData{DidSyncPacketData: &types.DidSyncPacketData{DidIds: []string{openDID}}},
+	})
+	request, err := json.Marshal(types.DIDResolutionRequest{DID: openDID})
+	require.NoError(t, err)
+	resolutionPacket, err := json.Marshal(types.IBCPacketData{Type: types.PacketTypeDIDResolution, Data: request})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		channel  string
+		data     []byte
+		accepted bool
+	}{
+		{name: "did-1 packet on did-1 channel", channel: euChannel, data: syncPacket, accepted: true},
+		{name: "did-1 packet on did-2 channel", channel: usChannel, data: syncPacket, accepted: true},
+		{name: "did-2 packet on did-2 channel", channel: usChannel, data: resolutionPacket, accepted: true},
+		{name: "did-2 packet on did-1 channel", channel: euChannel, data: resolutionPacket},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			packet := channeltypes.Packet{
+				SourcePort:         types.PortID,
+				SourceChannel:      "channel-7",
+				DestinationPort:    types.PortID,
+				DestinationChannel: tc.channel,
+				Data:               tc.data,
+			}
+
+			ack := module.OnRecvPacket(cacheCtx, packet, nil)
+			require.Equal(t, tc.accepted, ack.Success())
+			if !tc.accepted {
+				return
+			}
+
+			// Each version answers in its own acknowledgement format
+			result := resultOf(t, ack)
+			if types.PacketVersion(tc.data) == types.IBCVersion2 {
+				var response types.DIDResolutionResponse
+				require.NoError(t, json.Unmarshal(result, &response))
+				require.Equal(t, openDID, response.Document.ID)
+				return
+			}
+			var syncAck types.DidSyncPacketAck
+			require.NoError(t, types.ModuleCdc.UnmarshalJSON(result, &syncAck))
+			require.Len(t, syncAck.DidDocuments, 1)
+		})
+	}
+}
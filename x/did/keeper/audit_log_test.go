@@ -0,0 +1,108 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestAuditLogQuery(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	const did = "did:persona:abc"
+	start := time.Unix(1_700_000_000, 0).UTC()
+
+	// Three stored versions, each in its own block
+	doc := newTestDocument(did, testAddress("alice"))
+	for i := 0; i < 3; i++ {
+		doc.Version = uint64(i + 1)
+		require.NoError(t, k.SetDidDocument(ctx.WithBlockTime(start.Add(time.Duration(i)*time.Second)), doc))
+	}
+
+	tests := []struct {
+		name    string
+		req     *types.QueryAuditLogRequest
+		updates int
+		code    codes.Code
+	}{
+		{name: "all entries", req: &types.QueryAuditLogRequest{Did: did}, updates: 3},
+		{name: "limited", req: &types.QueryAuditLogRequest{Did: did, Limit: 2}},
+		{name: "DID sharing a prefix", req: &types.QueryAuditLogRequest{Did: "did:persona:ab"}},
+		{name: "unknown DID", req: &types.QueryAuditLogRequest{Did: "did:persona:xyz"}},
+		{name: "no DID", req: &types.QueryAuditLogRequest{}, code: codes.InvalidArgument},
+		{name: "nil request", code: codes.InvalidArgument},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := k.AuditLog(ctx, tc.req)
+			if tc.code != codes.OK {
+				require.Equal(t, tc.code, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+
+			updates := 0
+			for _, entry := range res.Entries {
+				require.Equal(t, did, entry.DID)
+				if entry.Action == "document_updated" {
+					updates++
+				}
+			}
+			if tc.req.Limit > 0 {
+				require.Len(t, res.Entries, int(tc.req.Limit))
+				return
+			}
+			require.Equal(t, tc.updates, updates)
+		})
+	}
+}
+
+func TestAuditLogSameBlock(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithBlockTime(time.Unix(1_700_000_000, 0).UTC())
+	const did = "did:persona:abc"
+
+	// Two versions stored in one block record the same action at the same time
+	doc := newTestDocument(did, testAddress("alice"))
+	for i := 0; i < 2; i++ {
+		doc.Version = uint64(i + 1)
+		require.NoError(t, k.SetDidDocument(ctx, doc))
+	}
+
+	// IBC events are recorded without a DID
+	for i := 0; i < 2; i++ {
+		k.LogAuditEvent(ctx, "ibc_packet_received", map[string]interface{}{"sequence": i})
+	}
+
+	tests := []struct {
+		name   string
+		did    string
+		action string
+	}{
+		{name: "DID entries", did: did, action: "document_updated"},
+		{name: "entries without a DID", action: "ibc_packet_received"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			entries, err := k.GetAuditLog(ctx, tc.did, 0)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, entry := range entries {
+				if entry.Action == tc.action {
+					ids = append(ids, entry.ID)
+				}
+			}
+			require.Len(t, ids, 2)
+			require.NotEqual(t, ids[0], ids[1])
+			require.Less(t, ids[0], ids[1])
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package keeper
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cosmossdk.io/errors"
+
+	"github.com/persona-chain/persona-chain/internal/clock"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// BatchUpdate applies operations to a DID document in order and stores the
+// result as a single new version. The controller is authorized once and
+// every key the batch adds must prove possession for that version. Either
+// all operations apply or none do. Returns the new version.
+func (k Keeper) BatchUpdate(ctx context.Context, didID string, ops []types.DIDOperation, proofs []types.KeyProof, controllerAddr string) (uint64, error) {
+	// Authorize the operation
+	if err := k.ValidateControllerAuthorization(ctx, didID, controllerAddr); err != nil {
+		return 0, err
+	}
+
+	// Operations mutate doc while previous keeps the stored version
+	previous, found := k.GetDidDocument(ctx, didID)
+	if !found {
+		return 0, types.ErrDIDNotFound
+	}
+	if previous.IsDeactivated() {
+		return 0, errors.Wrapf(types.ErrDIDDeactivated, "DID %s", didID)
+	}
+	doc, _ := k.GetDidDocument(ctx, didID)
+
+	now := clock.Now(ctx)
+	var addedKeys []types.VerificationMethod
+	for i, op := range ops {
+		added, err := k.applyOperation(ctx, &doc, op, now)
+		if err != nil {
+			return 0, errors.Wrapf(err, "operation %d (%s)", i, op.Type)
+		}
+		if added != nil {
+			addedKeys = append(addedKeys, *added)
+		}
+	}
+
+	newVersion := previous.Version + 1
+	if err := k.VerifyKeyPossessions(ctx, &doc, addedKeys, newVersion, proofs); err != nil {
+		return 0, err
+	}
+
+	// Authentication keys under a pre-rotation commitment change only by rotation
+	if err := checkPreRotation(ctx, previous, &doc); err != nil {
+		return 0, err
+	}
+
+	doc.UpdatedBy = controllerAddr
+	doc.Version = newVersion
+	doc.Metadata.VersionID = fmt.Sprintf("%d", newVersion)
+
+	if err := k.SetDidDocument(ctx, doc); err != nil {
+		return 0, err
+	}
+	return newVersion, nil
+}
+
+// applyOperation applies one batch operation to doc. It returns the
+// verification method it added, if any, so its proof can be checked.
+func (k Keeper) applyOperation(ctx context.Context, doc *types.DIDDocument, op types.DIDOperation, now time.Time) (*types.VerificationMethod, error) {
+	if err := op.ValidateBasic(doc.ID); err != nil {
+		return nil, err
+	}
+
+	switch op.Type {
+	case types.DIDOperationAddVerificationMethod:
+		vm := *op.VerificationMethod
+		if _, found := doc.FindVerificationMethod(vm.ID); found {
+			return nil, errors.Wrapf(types.ErrDuplicateVerificationMethod, "verification method %s", vm.ID)
+		}
+		if err := k.ValidateVerificationMethodKey(ctx, vm); err != nil {
+			return nil, errors.Wrapf(err, "verification method %s", vm.ID)
+		}
+		vm.CreatedAt = now
+		vm.Revoked = false
+		vm.RevokedAt = nil
+		if vm.SecurityLevel == "" {
+			vm.SecurityLevel = types.SecurityLevelStandard
+		}
+		doc.VerificationMethod = append(doc.VerificationMethod, vm)
+		return &vm, nil
+
+	case types.DIDOperationRevokeVerificationMethod:
+		for i := range doc.VerificationMethod {
+			vm := &doc.VerificationMethod[i]
+			if vm.ID != op.TargetId {
+				continue
+			}
+			if vm.Revoked {
+				return nil, errors.Wrapf(types.ErrKeyRevoked, "verification method %s", vm.ID)
+			}
+			vm.Revoked = true
+			vm.RevokedAt = &now
+			return nil, nil
+		}
+		return nil, errors.Wrapf(types.ErrVerificationMethodNotFound, "verification method %s", op.TargetId)
+
+	case types.DIDOperationAddService:
+		for _, existing := range doc.Service {
+			if existing.ID == op.Service.ID {
+				return nil, errors.Wrapf(types.ErrDuplicateService, "service %s", op.Service.ID)
+			}
+		}
+		doc.Service = append(doc.Service, *op.Service)
+		return nil, nil
+
+	case types.DIDOperationRemoveService:
+		for i, svc := range doc.Service {
+			if svc.ID == op.TargetId {
+				doc.Service = append(doc.Service[:i], doc.Service[i+1:]...)
+				return nil, nil
+			}
+		}
+		return nil, errors.Wrapf(types.ErrServiceNotFound, "service %s", op.TargetId)
+
+	case types.DIDOperationAddRelationship:
+		if _, found := doc.FindVerificationMethod(op.TargetId); !found {
+			return nil, errors.Wrapf(types.ErrVerificationMethodNotFound, "verification method %s", op.TargetId)
+		}
+		refs, _ := doc.RelationshipRefs(op.Relationship)
+		for _, ref := range *refs {
+			if ref == op.TargetId {
+				return nil, errors.Wrapf(types.ErrDuplicateVerificationMethod, "%s is already in %s", op.TargetId, op.Relationship)
+			}
+		}
+		*refs = append(*refs, op.TargetId)
+		return nil, nil
+
+	case types.DIDOperationRemoveRelationship:
+		refs, _ := doc.RelationshipRefs(op.Relationship)
+		for i, ref := range *refs {
+			if ref == op.TargetId {
+				*refs = append((*refs)[:i], (*refs)[i+1:]...)
+				return nil, nil
+			}
+		}
+		return nil, errors.Wrapf(types.ErrVerificationMethodNotFound, "%s is not in %s", op.TargetId, op.Relationship)
+
+	case types.DIDOperationUpdateMetadata:
+		op.Metadata.Apply(&doc.Metadata)
+		return nil, nil
+	}
+
+	return nil, errors.Wrapf(types.ErrInvalidDID, "unknown operation type %q", op.Type)
+}
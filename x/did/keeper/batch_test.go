@@ -0,0 +1,197 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestBatchUpdate(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID)
+	msgServer := keeper.NewMsgServerImpl(k)
+	creator := testAddress("creator")
+
+	const did, committed, gone = "did:persona:abc", "did:persona:committed", "did:persona:gone"
+	current := newEd25519Key(t, did, "key-1")
+	added := newEd25519Key(t, did, "key-2")
+	createTestDID(t, ctx, k, did, creator, current)
+
+	committedKey := newEd25519Key(t, committed, "key-1")
+	createCommittedDID(t, ctx, k, committed, creator, committedKey, newEd25519Key(t, committed, "key-2"))
+	committedAdded := newEd25519Key(t, committed, "key-3")
+
+	goneDoc := newTestDocument(gone, creator)
+	goneDoc.Metadata.Deactivated = true
+	require.NoError(t, k.SetDidDocument(ctx, goneDoc))
+
+	hub := &types.Service{ID: "#hub", Type: "Hub", ServiceEndpoint: "https://hub.example"}
+	addKey := func(key testKey) types.DIDOperation {
+		vm := key.vm
+		return types.DIDOperation{Type: types.DIDOperationAddVerificationMethod, VerificationMethod: &vm}
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		controller string
+		ops        []types.DIDOperation
+		proofs     []types.KeyProof
+		err        error
+		check      func(t *testing.T, doc types.DIDDocument)
+	}{
+		{
+			name: "keys, relationships, services and metadata in one version",
+			ops: []types.DIDOperation{
+				addKey(added),
+				{Type: types.DIDOperationAddRelationship, TargetId: added.vm.ID, Relationship: types.RelationshipAssertionMethod},
+				{Type: types.DIDOperationAddService, Service: hub},
+				{Type: types.DIDOperationUpdateMetadata, Metadata: &types.DIDMetadataUpdate{Category: "payments", Tags: []string{"kyc"}}},
+			},
+			proofs: []types.KeyProof{added.proof(did, 2)},
+			check: func(t *testing.T, doc types.DIDDocument) {
+				require.Len(t, doc.VerificationMethod, 2)
+				require.Equal(t, []string{added.vm.ID}, doc.AssertionMethod)
+				require.Equal(t, []types.Service{*hub}, doc.Service)
+				require.Equal(t, "payments", doc.Metadata.Category)
+				require.Equal(t, []string{"kyc"}, doc.Metadata.Tags)
+			},
+		},
+		{
+			name: "later operations see earlier ones",
+			ops: []types.DIDOperation{
+				{Type: types.DIDOperationAddService, Service: hub},
+				{Type: types.DIDOperationRemoveService, TargetId: hub.ID},
+				{Type: types.DIDOperationAddRelationship, TargetId: current.vm.ID, Relationship: types.RelationshipCapabilityInvocation},
+				{Type: types.DIDOperationRemoveRelationship, TargetId: current.vm.ID, Relationship: types.RelationshipCapabilityInvocation},
+			},
+			check: func(t *testing.T, doc types.DIDDocument) {
+				require.Empty(t, doc.Service)
+				require.Empty(t, doc.CapabilityInvocation)
+			},
+		},
+		{
+			name: "key replaced",
+			ops: []types.DIDOperation{
+				addKey(added),
+				{Type: types.DIDOperationAddRelationship, TargetId: added.vm.ID, Relationship: types.RelationshipAuthentication},
+				{Type: types.DIDOperationRevokeVerificationMethod, TargetId: current.vm.ID},
+			},
+			proofs: []types.KeyProof{added.proof(did, 2)},
+			check: func(t *testing.T, doc types.DIDDocument) {
+				old, found := doc.FindVerificationMethod(current.vm.ID)
+				require.True(t, found)
+				require.True(t, old.Revoked)
+				require.NotNil(t, old.RevokedAt)
+			},
+		},
+		{
+			name: "failing operation discards the batch",
+			ops: []types.DIDOperation{
+				{Type: types.DIDOperationAddService, Service: hub},
+				{Type: types.DIDOperationRemoveService, TargetId: "#missing"},
+			},
+			err: types.ErrServiceNotFound,
+		},
+		{
+			name: "added key without proof",
+			ops:  []types.DIDOperation{addKey(added)},
+			err:  types.ErrMissingKeyProof,
+		},
+		{
+			name:   "added key proved for the current version",
+			ops:    []types.DIDOperation{addKey(added)},
+			proofs: []types.KeyProof{added.proof(did, 1)},
+			err:    types.ErrInvalidKeyProof,
+		},
+		{
+			name: "existing verification method",
+			ops:  []types.DIDOperation{addKey(current)},
+			err:  types.ErrDuplicateVerificationMethod,
+		},
+		{
+			name: "revoked twice",
+			ops: []types.DIDOperation{
+				{Type: types.DIDOperationRevokeVerificationMethod, TargetId: current.vm.ID},
+				{Type: types.DIDOperationRevokeVerificationMethod, TargetId: current.vm.ID},
+			},
+			err: types.ErrKeyRevoked,
+		},
+		{
+			name: "relationship to an unknown key",
+			ops:  []types.DIDOperation{{Type: types.DIDOperationAddRelationship, TargetId: did + "#missing", Relationship: types.RelationshipKeyAgreement}},
+			err:  types.ErrVerificationMethodNotFound,
+		},
+		{
+			name: "unknown relationship",
+			ops:  []types.DIDOperation{{Type: types.DIDOperationAddRelationship, TargetId: current.vm.ID, Relationship: "owner"}},
+			err:  types.ErrInvalidDID,
+		},
+		{
+			name:       "not a controller",
+			controller: testAddress("mallory"),
+			ops:        []types.DIDOperation{{Type: types.DIDOperationAddService, Service: hub}},
+			err:        types.ErrUnauthorized,
+		},
+		{
+			name: "deactivated DID",
+			id:   gone,
+			ops:  []types.DIDOperation{{Type: types.DIDOperationAddService, Service: hub}},
+			err:  types.ErrDIDDeactivated,
+		},
+		{
+			name: "authentication change under a pre-rotation commitment",
+			id:   committed,
+			ops: []types.DIDOperation{
+				addKey(committedAdded),
+				{Type: types.DIDOperationAddRelationship, TargetId: committedAdded.vm.ID, Relationship: types.RelationshipAuthentication},
+			},
+			proofs: []types.KeyProof{committedAdded.proof(committed, 2)},
+			err:    types.ErrPreRotationRequired,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			id, controller := did, creator
+			if tc.id != "" {
+				id = tc.id
+			}
+			if tc.controller != "" {
+				controller = tc.controller
+			}
+
+			cacheCtx, _ := ctx.CacheContext()
+			res, err := msgServer.BatchUpdateDID(cacheCtx, &types.MsgBatchUpdateDID{
+				Controller: controller,
+				Id:         id,
+				Operations: tc.ops,
+				KeyProofs:  tc.proofs,
+			})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				doc, _ := k.GetDidDocument(cacheCtx, id)
+				require.Equal(t, uint64(1), doc.Version)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, uint64(2), res.Version)
+
+			doc, found := k.GetDidDocument(cacheCtx, id)
+			require.True(t, found)
+			require.Equal(t, uint64(2), doc.Version)
+			require.Equal(t, controller, doc.UpdatedBy)
+			tc.check(t, doc)
+
+			// The whole batch is one version on top of the original
+			archived, found := k.GetDocumentVersion(cacheCtx, id, 1)
+			require.True(t, found)
+			require.Len(t, archived.VerificationMethod, 1)
+			require.Empty(t, archived.Service)
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package keeper
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/persona-chain/persona-chain/internal/clock"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// GetCapability returns a delegated capability by ID
+func (k Keeper) GetCapability(ctx context.Context, id string) (types.Capability, bool) {
+	b := k.policyStore(ctx, types.CapabilityKeyPrefix).Get([]byte(id))
+	if b == nil {
+		return types.Capability{}, false
+	}
+
+	var capability types.Capability
+	if err := k.cdc.Unmarshal(b, &capability); err != nil {
+		return types.Capability{}, false
+	}
+	return capability, true
+}
+
+func (k Keeper) setCapability(ctx context.Context, capability types.Capability) {
+	k.policyStore(ctx, types.CapabilityKeyPrefix).Set([]byte(capability.Id), k.cdc.MustMarshal(&capability))
+}
+
+// DelegateCapability stores a capability signed by the delegator with one of
+// its capabilityDelegation keys. A root capability must be delegated by the
+// DID owning the target. A capability derived from a parent must be
+// delegated by the parent's invoker and may only narrow its target, actions,
+// expiry and uses. Returns the capability ID.
+func (k Keeper) DelegateCapability(ctx context.Context, capability types.Capability) (string, error) {
+	if err := capability.ValidateBasic(); err != nil {
+		return "", err
+	}
+
+	now := clock.Now(ctx)
+	if capability.IsExpiredAt(now) {
+		return "", errors.Wrap(types.ErrInvalidDelegation, "capability expires in the past")
+	}
+
+	if capability.IsRoot() {
+		targetDID, _ := types.CapabilityTargetDID(capability.InvocationTarget)
+		if capability.Delegator != targetDID {
+			return "", errors.Wrapf(types.ErrInvalidDelegation, "only %s can delegate a root capability for %s", targetDID, capability.InvocationTarget)
+		}
+	} else {
+		chain, err := k.resolveChain(ctx, capability.ParentCapability, now)
+		if err != nil {
+			return "", errors.Wrapf(err, "parent capability %s", capability.ParentCapability)
+		}
+		if len(chain) >= types.MaxDelegationDepth {
+			return "", errors.Wrapf(types.ErrInvalidDelegation, "delegation chains are limited to %d capabilities", types.MaxDelegationDepth)
+		}
+		if err := checkAttenuation(chain[0], capability); err != nil {
+			return "", err
+		}
+	}
+
+	if _, err := k.activeDocument(ctx, capability.Invoker); err != nil {
+		return "", errors.Wrap(err, "invoker")
+	}
+
+	chainID := sdk.UnwrapSDKContext(ctx).ChainID()
+	challenge := types.DelegationChallenge(capability, chainID)
+	if err := k.checkCapabilityProof(ctx, capability.Delegator, capability.Proof, types.RelationshipCapabilityDelegation, challenge, now, types.ErrInvalidDelegation); err != nil {
+		return "", err
+	}
+
+	capability.Id = types.CapabilityID(challenge)
+	if _, found := k.GetCapability(ctx, capability.Id); found {
+		return "", errors.Wrapf(types.ErrCapabilityExists, "capability %s", capability.Id)
+	}
+	capability.Uses = 0
+	capability.CreatedAt = now
+	capability.Revoked = false
+	capability.RevokedAt = nil
+	capability.RevokedBy = ""
+	k.setCapability(ctx, capability)
+
+	if err := sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventCapabilityDelegated{
+		CapabilityId:     capability.Id,
+		ParentCapability: capability.ParentCapability,
+		Delegator:        capability.Delegator,
+		Invoker:          capability.Invoker,
+		InvocationTarget: capability.InvocationTarget,
+	}); err != nil {
+		return "", err
+	}
+	return capability.Id, nil
+}
+
+// checkAttenuation checks that a capability derived from parent is
+// delegated by the parent's invoker and grants no more than the parent
+func checkAttenuation(parent, child types.Capability) error {
+	if child.Delegator != parent.Invoker {
+		return errors.Wrapf(types.ErrInvalidDelegation, "capability %s was delegated to %s, not %s", parent.Id, parent.Invoker, child.Delegator)
+	}
+	if !parent.CoversTarget(child.InvocationTarget) {
+		return errors.Wrapf(types.ErrInvalidDelegation, "target %s is outside %s", child.InvocationTarget, parent.InvocationTarget)
+	}
+	if len(parent.AllowedActions) > 0 {
+		if len(child.AllowedActions) == 0 {
+			return errors.Wrapf(types.ErrInvalidDelegation, "capability %s allows only %s", parent.Id, strings.Join(parent.AllowedActions, ","))
+		}
+		for _, action := range child.AllowedActions {
+			if !parent.AllowsAction(action) {
+				return errors.Wrapf(types.ErrInvalidDelegation, "action %q is not allowed by %s", action, parent.Id)
+			}
+		}
+	}
+	if parent.Expires != nil && (child.Expires == nil || child.Expires.After(*parent.Expires)) {
+		return errors.Wrapf(types.ErrInvalidDelegation, "capability cannot outlive %s", parent.Id)
+	}
+	if parent.MaxUses > 0 {
+		remaining := parent.MaxUses - parent.Uses
+		if child.MaxUses == 0 || child.MaxUses > remaining {
+			return errors.Wrapf(types.ErrInvalidDelegation, "capability %s has %d uses left", parent.Id, remaining)
+		}
+	}
+	return nil
+}
+
+// resolveChain loads a capability and its ancestors, leaf first, and checks
+// that every link can still be invoked: none is revoked, expired or used up,
+// and every delegator is active and still holds the capabilityDelegation key
+// it signed with
+func (k Keeper) resolveChain(ctx context.Context, id string, now time.Time) ([]types.Capability, error) {
+	var chain []types.Capability
+	for id != "" {
+		if len(chain) >= types.MaxDelegationDepth {
+			return nil, errors.Wrapf(types.ErrInvalidDelegation, "delegation chain exceeds %d capabilities", types.MaxDelegationDepth)
+		}
+		capability, found := k.GetCapability(ctx, id)
+		if !found {
+			return nil, errors.Wrapf(types.ErrCapabilityNotFound, "capability %s", id)
+		}
+		if capability.Revoked {
+			return nil, errors.Wrapf(types.ErrCapabilityRevoked, "capability %s was revoked by %s", id, capability.RevokedBy)
+		}
+		if capability.IsExpiredAt(now) {
+			return nil, errors.Wrapf(types.ErrCapabilityExpired, "capability %s", id)
+		}
+		if capability.IsExhausted() {
+			return nil, errors.Wrapf(types.ErrCapabilityExhausted, "capability %s", id)
+		}
+		if err := k.checkCapabilityProof(ctx, capability.Delegator, capability.Proof, types.RelationshipCapabilityDelegation, nil, now, types.ErrInvalidDelegation); err != nil {
+			return nil, errors.Wrapf(err, "capability %s", id)
+		}
+		chain = append(chain, capability)
+		id = capability.ParentCapability
+	}
+	return chain, nil
+}
+
+// checkCapabilityProof checks that the proof's verification method belongs
+// to an active DID, is active and is listed under relationship. The
+// signature is verified against challenge unless challenge is nil, which
+// rechecks the key behind an existing signature.
+func (k Keeper) checkCapabilityProof(ctx context.Context, did string, proof types.CapabilityProof, relationship string, challenge []byte, now time.Time, errType *errors.Error) error {
+	doc, err := k.activeDocument(ctx, did)
+	if err != nil {
+		return err
+	}
+	vm, found := doc.FindVerificationMethod(proof.VerificationMethodId)
+	if !found {
+		return errors.Wrapf(errType, "%s has no verification method %s", did, proof.VerificationMethodId)
+	}
+	if !vm.IsActiveAt(now) {
+		return errors.Wrapf(errType, "verification method %s is revoked or expired", vm.ID)
+	}
+	if !containsString(doc.VerificationRelationships(vm.ID), relationship) {
+		return errors.Wrapf(errType, "verification method %s is not a %s key", vm.ID, relationship)
+	}
+	if challenge == nil {
+		return nil
+	}
+	if err := vm.VerifySignature(challenge, proof.Signature); err != nil {
+		return errors.Wrapf(errType, "verification method %s: %v", vm.ID, err)
+	}
+	return nil
+}
+
+// activeDocument returns a DID document that is neither deactivated nor
+// suspended
+func (k Keeper) activeDocument(ctx context.Context, did string) (types.DIDDocument, error) {
+	doc, found := k.GetDidDocument(ctx, did)
+	if !found {
+		return types.DIDDocument{}, errors.Wrapf(types.ErrDIDNotFound, "DID %s", did)
+	}
+	if doc.IsDeactivated() || doc.Status.State == types.DIDStateSuspended {
+		return types.DIDDocument{}, errors.Wrapf(types.ErrDIDDeactivated, "DID %s", did)
+	}
+	return doc, nil
+}
+
+// VerifyInvocation checks an invocation against the full delegation chain of
+// its capability: every link covers the target and allows the action, and
+// the invoker of the leaf signed the invocation with one of its
+// capabilityInvocation keys. Returns the chain, leaf first.
+func (k Keeper) VerifyInvocation(ctx context.Context, inv types.CapabilityInvocation) ([]types.Capability, error) {
+	if inv.InvocationTarget == "" || inv.Action == "" {
+		return nil, errors.Wrap(types.ErrInvalidInvocation, "invocation target and action are required")
+	}
+
+	now := clock.Now(ctx)
+	chain, err := k.resolveChain(ctx, inv.Capability, now)
+	if err != nil {
+		return nil, err
+	}
+
+	root := chain[len(chain)-1]
+	if targetDID, _ := types.CapabilityTargetDID(root.InvocationTarget); root.Delegator != targetDID {
+		return nil, errors.Wrapf(types.ErrInvalidDelegation, "root capability %s was not delegated by %s", root.Id, targetDID)
+	}
+	for _, capability := range chain {
+		if !capability.CoversTarget(inv.InvocationTarget) {
+			return nil, errors.Wrapf(types.ErrInvalidInvocation, "target %s is outside capability %s", inv.InvocationTarget, capability.Id)
+		}
+		if !capability.AllowsAction(inv.Action) {
+			return nil, errors.Wrapf(types.ErrInvalidInvocation, "action %q is not allowed by capability %s", inv.Action, capability.Id)
+		}
+	}
+
+	leaf := chain[0]
+	challenge := types.InvocationChallenge(inv, sdk.UnwrapSDKContext(ctx).ChainID(), leaf.Uses)
+	if err := k.checkCapabilityProof(ctx, leaf.Invoker, inv.Proof, types.RelationshipCapabilityInvocation, challenge, now, types.ErrInvalidInvocation); err != nil {
+		return nil, err
+	}
+	return chain, nil
+}
+
+// InvokeCapability verifies an invocation and counts a use against every
+// capability in its chain, so a signed invocation is accepted once. Returns
+// the invoked capability.
+func (k Keeper) InvokeCapability(ctx context.Context, inv types.CapabilityInvocation) (types.Capability, error) {
+	chain, err := k.VerifyInvocation(ctx, inv)
+	if err != nil {
+		return types.Capability{}, err
+	}
+	for i := range chain {
+		chain[i].Uses++
+		k.setCapability(ctx, chain[i])
+	}
+
+	leaf := chain[0]
+	if err := sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventCapabilityInvoked{
+		CapabilityId:     leaf.Id,
+		Invoker:          leaf.Invoker,
+		InvocationTarget: inv.InvocationTarget,
+		Action:           inv.Action,
+	}); err != nil {
+		return types.Capability{}, err
+	}
+	return leaf, nil
+}
+
+// RevokeCapability revokes a capability on behalf of revoker, which must be
+// its delegator or the delegator of one of its ancestors. The controller
+// must be authorized for revoker. Revoking a capability also invalidates
+// every capability derived from it.
+func (k Keeper) RevokeCapability(ctx context.Context, id, revoker, controllerAddr string) error {
+	if err := k.ValidateControllerAuthorization(ctx, revoker, controllerAddr); err != nil {
+		return err
+	}
+
+	capability, found := k.GetCapability(ctx, id)
+	if !found {
+		return errors.Wrapf(types.ErrCapabilityNotFound, "capability %s", id)
+	}
+	if capability.Revoked {
+		return errors.Wrapf(types.ErrCapabilityRevoked, "capability %s", id)
+	}
+
+	authorized := false
+	for link, depth := capability, 0; depth < types.MaxDelegationDepth; depth++ {
+		if link.Delegator == revoker {
+			authorized = true
+			break
+		}
+		if link.IsRoot() {
+			break
+		}
+		if link, found = k.GetCapability(ctx, link.ParentCapability); !found {
+			break
+		}
+	}
+	if !authorized {
+		return errors.Wrapf(types.ErrUnauthorized, "%s did not delegate capability %s or any capability it derives from", revoker, id)
+	}
+
+	now := clock.Now(ctx)
+	capability.Revoked = true
+	capability.RevokedAt = &now
+	capability.RevokedBy = revoker
+	k.setCapability(ctx, capability)
+
+	return sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventCapabilityRevoked{
+		CapabilityId: id,
+		Revoker:      revoker,
+		Controller:   controllerAddr,
+	})
+}
@@ -0,0 +1,495 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+const (
+	issuerDID = "did:persona:issuer"
+	agentDID  = "did:persona:agent"
+	subDID    = "did:persona:sub"
+)
+
+// capabilityDID stores did with one key used for authentication, capability
+// delegation and capability invocation
+func capabilityDID(t testing.TB, ctx sdk.Context, k keeper.Keeper, did string) testKey {
+	key := newEd25519Key(t, did, "key-1")
+	doc := newTestDocument(did, testAddress(did), key)
+	doc.CapabilityDelegation = []string{key.vm.ID}
+	doc.CapabilityInvocation = []string{key.vm.ID}
+	require.NoError(t, k.SetDidDocument(ctx, doc))
+	return key
+}
+
+// delegate submits c signed by key
+func delegate(ctx sdk.Context, k keeper.Keeper, key testKey, c types.Capability) (string, error) {
+	c.Proof = types.CapabilityProof{
+		VerificationMethodId: key.vm.ID,
+		Signature:            key.sign(types.DelegationChallenge(c, testChainID)),
+	}
+	res, err := keeper.NewMsgServerImpl(k).DelegateCapability(ctx, &types.MsgDelegateCapability{
+		Submitter:        testAddress("relayer"),
+		Delegator:        c.Delegator,
+		Invoker:          c.Invoker,
+		InvocationTarget: c.InvocationTarget,
+		ParentCapability: c.ParentCapability,
+		AllowedActions:   c.AllowedActions,
+		Expires:          c.Expires,
+		MaxUses:          c.MaxUses,
+		Proof:            c.Proof,
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.CapabilityId, nil
+}
+
+// invoke submits an invocation of capability signed by key for the next use
+// of the capability
+func invoke(ctx sdk.Context, k keeper.Keeper, key testKey, capability, target, action string) error {
+	inv := types.CapabilityInvocation{Capability: capability, InvocationTarget: target, Action: action}
+	stored, _ := k.GetCapability(ctx, capability)
+	inv.Proof = types.CapabilityProof{
+		VerificationMethodId: key.vm.ID,
+		Signature:            key.sign(types.InvocationChallenge(inv, testChainID, stored.Uses)),
+	}
+	_, err := keeper.NewMsgServerImpl(k).InvokeCapability(ctx, &types.MsgInvokeCapability{
+		Submitter:  testAddress("relayer"),
+		Invocation: inv,
+	})
+	return err
+}
+
+// capabilityChain sets up issuer -> agent -> sub: a root capability for the
+// schemas of the issuer and a narrower one the agent delegated to sub
+type capabilityChain struct {
+	issuer, agent, sub testKey
+	root, child        string
+	expires            time.Time
+}
+
+func newCapabilityChain(t testing.TB, ctx sdk.Context, k keeper.Keeper) capabilityChain {
+	c := capabilityChain{
+		issuer:  capabilityDID(t, ctx, k, issuerDID),
+		agent:   capabilityDID(t, ctx, k, agentDID),
+		sub:     capabilityDID(t, ctx, k, subDID),
+		expires: ctx.BlockTime().Add(30 * 24 * time.Hour),
+	}
+
+	var err error
+	c.root, err = delegate(ctx, k, c.issuer, types.Capability{
+		Delegator:        issuerDID,
+		Invoker:          agentDID,
+		InvocationTarget: issuerDID + "/schemas",
+		AllowedActions:   []string{"issue", "revoke"},
+		Expires:          &c.expires,
+		MaxUses:          10,
+	})
+	require.NoError(t, err)
+
+	childExpires := c.expires.Add(-time.Hour)
+	c.child, err = delegate(ctx, k, c.agent, types.Capability{
+		ParentCapability: c.root,
+		Delegator:        agentDID,
+		Invoker:          subDID,
+		InvocationTarget: issuerDID + "/schemas/kyc",
+		AllowedActions:   []string{"issue"},
+		Expires:          &childExpires,
+		MaxUses:          2,
+	})
+	require.NoError(t, err)
+	return c
+}
+
+func TestDelegateCapability(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID).WithBlockTime(time.Unix(1_700_000_000, 0).UTC())
+	chain := newCapabilityChain(t, ctx, k)
+	now := ctx.BlockTime()
+	at := func(d time.Duration) *time.Time {
+		ts := now.Add(d)
+		return &ts
+	}
+
+	// child returns a valid capability the agent derives from the root
+	child := func(modify func(c *types.Capability)) types.Capability {
+		c := types.Capability{
+			ParentCapability: chain.root,
+			Delegator:        agentDID,
+			Invoker:          subDID,
+			InvocationTarget: issuerDID + "/schemas/employment",
+			AllowedActions:   []string{"revoke"},
+			Expires:          at(24 * time.Hour),
+			MaxUses:          5,
+		}
+		modify(&c)
+		return c
+	}
+
+	tests := []struct {
+		name       string
+		capability types.Capability
+		signer     testKey
+		err        error
+	}{
+		{
+			name:       "narrowed capability",
+			capability: child(func(c *types.Capability) {}),
+			signer:     chain.agent,
+		},
+		{
+			name: "same limits as the parent",
+			capability: child(func(c *types.Capability) {
+				c.InvocationTarget = issuerDID + "/schemas"
+				c.Expires = &chain.expires
+				c.MaxUses = 10
+			}),
+			signer: chain.agent,
+		},
+		{
+			name: "derived from a derived capability",
+			capability: types.Capability{
+				ParentCapability: chain.child,
+				Delegator:        subDID,
+				Invoker:          agentDID,
+				InvocationTarget: issuerDID + "/schemas/kyc/v2",
+				AllowedActions:   []string{"issue"},
+				Expires:          at(24 * time.Hour),
+				MaxUses:          1,
+			},
+			signer: chain.sub,
+		},
+		{
+			name:       "root capability from a DID that does not own the target",
+			capability: types.Capability{Delegator: agentDID, Invoker: subDID, InvocationTarget: issuerDID + "/schemas"},
+			signer:     chain.agent,
+			err:        types.ErrInvalidDelegation,
+		},
+		{
+			name:       "delegator is not the invoker of the parent",
+			capability: child(func(c *types.Capability) { c.Delegator = issuerDID }),
+			signer:     chain.issuer,
+			err:        types.ErrInvalidDelegation,
+		},
+		{
+			name:       "target outside the parent",
+			capability: child(func(c *types.Capability) { c.InvocationTarget = issuerDID + "/roles" }),
+			signer:     chain.agent,
+			err:        types.ErrInvalidDelegation,
+		},
+		{
+			name:       "action the parent does not allow",
+			capability: child(func(c *types.Capability) { c.AllowedActions = []string{"revoke", "delete"} }),
+			signer:     chain.agent,
+			err:        types.ErrInvalidDelegation,
+		},
+		{
+			name:       "every action under a restricted parent",
+			capability: child(func(c *types.Capability) { c.AllowedActions = nil }),
+			signer:     chain.agent,
+			err:        types.ErrInvalidDelegation,
+		},
+		{
+			name:       "outlives the parent",
+			capability: child(func(c *types.Capability) { c.Expires = at(60 * 24 * time.Hour) }),
+			signer:     chain.agent,
+			err:        types.ErrInvalidDelegation,
+		},
+		{
+			name:       "never expires under an expiring parent",
+			capability: child(func(c *types.Capability) { c.Expires = nil }),
+			signer:     chain.agent,
+			err:        types.ErrInvalidDelegation,
+		},
+		{
+			name:       "more uses than the parent has left",
+			capability: child(func(c *types.Capability) { c.MaxUses = 11 }),
+			signer:     chain.agent,
+			err:        types.ErrInvalidDelegation,
+		},
+		{
+			name:       "unlimited uses under a limited parent",
+			capability: child(func(c *types.Capability) { c.MaxUses = 0 }),
+			signer:     chain.agent,
+			err:        types.ErrInvalidDelegation,
+		},
+		{
+			name:       "already expired",
+			capability: child(func(c *types.Capability) { c.Expires = &now }),
+			signer:     chain.agent,
+			err:        types.ErrInvalidDelegation,
+		},
+		{
+			name:       "unknown parent",
+			capability: child(func(c *types.Capability) { c.ParentCapability = types.CapabilityIDPrefix + "00" }),
+			signer:     chain.agent,
+			err:        types.ErrCapabilityNotFound,
+		},
+		{
+			name:       "signed by another DID's key",
+			capability: child(func(c *types.Capability) {}),
+			signer:     testKey{vm: chain.agent.vm, sign: chain.sub.sign},
+			err:        types.ErrInvalidDelegation,
+		},
+		{
+			name:       "invoker does not exist",
+			capability: child(func(c *types.Capability) { c.Invoker = "did:persona:nobody" }),
+			signer:     chain.agent,
+			err:        types.ErrDIDNotFound,
+		},
+		{
+			name: "delegated twice",
+			capability: types.Capability{
+				ParentCapability: chain.root,
+				Delegator:        agentDID,
+				Invoker:          subDID,
+				InvocationTarget: issuerDID + "/schemas/kyc",
+				AllowedActions:   []string{"issue"},
+				Expires:          at(30*24*time.Hour - time.Hour),
+				MaxUses:          2,
+			},
+			signer: chain.agent,
+			err:    types.ErrCapabilityExists,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			id, err := delegate(cacheCtx, k, tc.signer, tc.capability)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, types.CapabilityID(types.DelegationChallenge(tc.capability, testChainID)), id)
+			require.Equal(t, 1, countEvents(cacheCtx, &types.EventCapabilityDelegated{}))
+
+			stored, found := k.GetCapability(cacheCtx, id)
+			require.True(t, found)
+			require.Equal(t, now, stored.CreatedAt)
+			require.Zero(t, stored.Uses)
+		})
+	}
+}
+
+func TestDelegationDepth(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID).WithBlockTime(time.Unix(1_700_000_000, 0).UTC())
+	key := capabilityDID(t, ctx, k, issuerDID)
+
+	// The issuer keeps delegating to itself
+	var parent string
+	for depth := 1; depth <= types.MaxDelegationDepth+1; depth++ {
+		id, err := delegate(ctx, k, key, types.Capability{
+			ParentCapability: parent,
+			Delegator:        issuerDID,
+			Invoker:          issuerDID,
+			InvocationTarget: issuerDID,
+		})
+		if depth > types.MaxDelegationDepth {
+			require.ErrorIs(t, err, types.ErrInvalidDelegation)
+			break
+		}
+		require.NoError(t, err, "depth %d", depth)
+		parent = id
+	}
+
+	res, err := k.Capability(ctx, &types.QueryCapabilityRequest{Id: parent})
+	require.NoError(t, err)
+	require.Len(t, res.Chain, types.MaxDelegationDepth-1)
+	require.True(t, res.Chain[len(res.Chain)-1].IsRoot())
+}
+
+func TestInvokeCapability(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID).WithBlockTime(time.Unix(1_700_000_000, 0).UTC())
+	chain := newCapabilityChain(t, ctx, k)
+
+	tests := []struct {
+		name       string
+		capability string
+		target     string
+		action     string
+		signer     testKey
+		err        error
+	}{
+		{name: "root", capability: chain.root, target: issuerDID + "/schemas/employment", action: "revoke", signer: chain.agent},
+		{name: "leaf through the chain", capability: chain.child, target: issuerDID + "/schemas/kyc/v2", action: "issue", signer: chain.sub},
+		{name: "target outside the leaf", capability: chain.child, target: issuerDID + "/schemas", action: "issue", signer: chain.sub, err: types.ErrInvalidInvocation},
+		{name: "action only an ancestor allows", capability: chain.child, target: issuerDID + "/schemas/kyc", action: "revoke", signer: chain.sub, err: types.ErrInvalidInvocation},
+		{name: "action no link allows", capability: chain.root, target: issuerDID + "/schemas", action: "delete", signer: chain.agent, err: types.ErrInvalidInvocation},
+		{name: "not the invoker", capability: chain.child, target: issuerDID + "/schemas/kyc", action: "issue", signer: chain.agent, err: types.ErrInvalidInvocation},
+		{name: "forged signature", capability: chain.child, target: issuerDID + "/schemas/kyc", action: "issue", signer: testKey{vm: chain.sub.vm, sign: chain.agent.sign}, err: types.ErrInvalidInvocation},
+		{name: "unknown capability", capability: types.CapabilityIDPrefix + "00", target: issuerDID, action: "issue", signer: chain.agent, err: types.ErrCapabilityNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			err := invoke(cacheCtx, k, tc.signer, tc.capability, tc.target, tc.action)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 1, countEvents(cacheCtx, &types.EventCapabilityInvoked{}))
+
+			// A use counts against every link of the chain
+			res, err := k.Capability(cacheCtx, &types.QueryCapabilityRequest{Id: tc.capability})
+			require.NoError(t, err)
+			require.Equal(t, uint64(1), res.Capability.Uses)
+			for _, link := range res.Chain {
+				require.Equal(t, uint64(1), link.Uses)
+			}
+		})
+	}
+}
+
+func TestCapabilityChainLimits(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0).UTC()
+	kyc := issuerDID + "/schemas/kyc"
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, ctx sdk.Context, k keeper.Keeper, chain capabilityChain) error
+		err  error
+	}{
+		{
+			name: "signature replayed",
+			run: func(t *testing.T, ctx sdk.Context, k keeper.Keeper, chain capabilityChain) error {
+				inv := types.CapabilityInvocation{Capability: chain.child, InvocationTarget: kyc, Action: "issue"}
+				inv.Proof = types.CapabilityProof{
+					VerificationMethodId: chain.sub.vm.ID,
+					Signature:            chain.sub.sign(types.InvocationChallenge(inv, testChainID, 0)),
+				}
+				_, err := k.InvokeCapability(ctx, inv)
+				require.NoError(t, err)
+				_, err = k.InvokeCapability(ctx, inv)
+				return err
+			},
+			err: types.ErrInvalidInvocation,
+		},
+		{
+			name: "leaf used up",
+			run: func(t *testing.T, ctx sdk.Context, k keeper.Keeper, chain capabilityChain) error {
+				require.NoError(t, invoke(ctx, k, chain.sub, chain.child, kyc, "issue"))
+				require.NoError(t, invoke(ctx, k, chain.sub, chain.child, kyc, "issue"))
+				return invoke(ctx, k, chain.sub, chain.child, kyc, "issue")
+			},
+			err: types.ErrCapabilityExhausted,
+		},
+		{
+			name: "root expired",
+			run: func(t *testing.T, ctx sdk.Context, k keeper.Keeper, chain capabilityChain) error {
+				return invoke(ctx.WithBlockTime(chain.expires), k, chain.agent, chain.root, kyc, "issue")
+			},
+			err: types.ErrCapabilityExpired,
+		},
+		{
+			name: "ancestor revoked",
+			run: func(t *testing.T, ctx sdk.Context, k keeper.Keeper, chain capabilityChain) error {
+				require.NoError(t, k.RevokeCapability(ctx, chain.root, issuerDID, testAddress(issuerDID)))
+				return invoke(ctx, k, chain.sub, chain.child, kyc, "issue")
+			},
+			err: types.ErrCapabilityRevoked,
+		},
+		{
+			name: "delegator lost its delegation key",
+			run: func(t *testing.T, ctx sdk.Context, k keeper.Keeper, chain capabilityChain) error {
+				doc, _ := k.GetDidDocument(ctx, agentDID)
+				doc.CapabilityDelegation = nil
+				doc.Version++
+				require.NoError(t, k.SetDidDocument(ctx, doc))
+				return invoke(ctx, k, chain.sub, chain.child, kyc, "issue")
+			},
+			err: types.ErrInvalidDelegation,
+		},
+		{
+			name: "delegator suspended",
+			run: func(t *testing.T, ctx sdk.Context, k keeper.Keeper, chain capabilityChain) error {
+				doc, _ := k.GetDidDocument(ctx, issuerDID)
+				doc.Status.State = types.DIDStateSuspended
+				doc.Version++
+				require.NoError(t, k.SetDidDocument(ctx, doc))
+				return invoke(ctx, k, chain.sub, chain.child, kyc, "issue")
+			},
+			err: types.ErrDIDDeactivated,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k, ctx := keepertest.DidKeeper(t)
+			ctx = ctx.WithChainID(testChainID).WithBlockTime(start)
+			chain := newCapabilityChain(t, ctx, k)
+			require.ErrorIs(t, tc.run(t, ctx, k, chain), tc.err)
+		})
+	}
+}
+
+func TestRevokeCapability(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID).WithBlockTime(time.Unix(1_700_000_000, 0).UTC())
+	chain := newCapabilityChain(t, ctx, k)
+
+	tests := []struct {
+		name       string
+		capability string
+		revoker    string
+		controller string
+		err        error
+	}{
+		{name: "by its delegator", capability: chain.child, revoker: agentDID},
+		{name: "by the delegator of an ancestor", capability: chain.child, revoker: issuerDID},
+		{name: "root by its delegator", capability: chain.root, revoker: issuerDID},
+		{name: "by its invoker", capability: chain.child, revoker: subDID, err: types.ErrUnauthorized},
+		{name: "ancestor by a descendant's delegator", capability: chain.root, revoker: agentDID, err: types.ErrUnauthorized},
+		{name: "controller not authorized for the revoker", capability: chain.child, revoker: issuerDID, controller: testAddress(agentDID), err: types.ErrUnauthorized},
+		{name: "unknown capability", capability: types.CapabilityIDPrefix + "00", revoker: issuerDID, err: types.ErrCapabilityNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := tc.controller
+			if controller == "" {
+				controller = testAddress(tc.revoker)
+			}
+
+			cacheCtx, _ := ctx.CacheContext()
+			_, err := keeper.NewMsgServerImpl(k).RevokeCapability(cacheCtx, &types.MsgRevokeCapability{
+				Controller:   controller,
+				Revoker:      tc.revoker,
+				CapabilityId: tc.capability,
+			})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 1, countEvents(cacheCtx, &types.EventCapabilityRevoked{}))
+
+			revoked, _ := k.GetCapability(cacheCtx, tc.capability)
+			require.True(t, revoked.Revoked)
+			require.Equal(t, tc.revoker, revoked.RevokedBy)
+			require.Equal(t, ctx.BlockTime(), *revoked.RevokedAt)
+
+			// Revoking twice fails, and the leaf can no longer be invoked
+			_, err = keeper.NewMsgServerImpl(k).RevokeCapability(cacheCtx, &types.MsgRevokeCapability{
+				Controller:   controller,
+				Revoker:      tc.revoker,
+				CapabilityId: tc.capability,
+			})
+			require.ErrorIs(t, err, types.ErrCapabilityRevoked)
+			require.ErrorIs(t, invoke(cacheCtx, k, chain.sub, chain.child, issuerDID+"/schemas/kyc", "issue"), types.ErrCapabilityRevoked)
+		})
+	}
+}
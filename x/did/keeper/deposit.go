@@ -0,0 +1,140 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/errors"
+	"cosmossdk.io/math"
+	"cosmossdk.io/store/prefix"
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func (k Keeper) storageDepositStore(ctx context.Context) prefix.Store {
+	storeAdapter := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	return prefix.NewStore(storeAdapter, types.KeyPrefix(types.DIDStorageDepositPrefix))
+}
+
+// GetStorageDeposit returns the storage deposit held for a DID
+func (k Keeper) GetStorageDeposit(ctx context.Context, did string) (types.StorageDeposit, bool) {
+	b := k.storageDepositStore(ctx).Get(types.DIDDocumentKey(did))
+	if b == nil {
+		return types.StorageDeposit{}, false
+	}
+
+	var deposit types.StorageDeposit
+	if err := k.cdc.Unmarshal(b, &deposit); err != nil {
+		return types.StorageDeposit{}, false
+	}
+	return deposit, true
+}
+
+func (k Keeper) setStorageDeposit(ctx context.Context, deposit types.StorageDeposit) {
+	k.storageDepositStore(ctx).Set(types.DIDDocumentKey(deposit.Did), k.cdc.MustMarshal(&deposit))
+}
+
+// settleStorageDeposit is called by SetDidDocument with the stored size of
+// doc. A document growing beyond the size its deposit covers is charged to
+// the account that produced the version, and deactivation, including a move
+// to the revoked or inactive state, releases the deposit.
+func (k Keeper) settleStorageDeposit(ctx context.Context, previous *types.DIDDocument, doc types.DIDDocument, size uint64) error {
+	if doc.IsDeactivated() {
+		if previous != nil && !previous.IsDeactivated() {
+			return k.refundStorageDeposit(ctx, doc)
+		}
+		return nil
+	}
+	return k.collectStorageDeposit(ctx, doc, size)
+}
+
+// collectStorageDeposit escrows the deposit for the bytes doc occupies beyond
+// those already paid for. Versions produced by governance or by the module
+// itself are not charged, so the next controller update pays for their
+// growth.
+func (k Keeper) collectStorageDeposit(ctx context.Context, doc types.DIDDocument, size uint64) error {
+	deposit, found := k.GetStorageDeposit(ctx, doc.ID)
+	if !found {
+		deposit = types.StorageDeposit{Did: doc.ID, Amount: sdk.NewCoins()}
+	}
+	if size <= deposit.Bytes {
+		return nil
+	}
+
+	payer, err := sdk.AccAddressFromBech32(doc.UpdatedBy)
+	if err != nil || doc.UpdatedBy == k.authority {
+		return nil
+	}
+
+	due := k.GetParams(ctx).StorageDepositFor(size - deposit.Bytes)
+	if !due.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, payer, types.ModuleName, due); err != nil {
+			return errors.Wrapf(types.ErrInsufficientDeposit, "%s owes %s for %d bytes: %v", doc.UpdatedBy, due, size-deposit.Bytes, err)
+		}
+		deposit.Amount = deposit.Amount.Add(due...)
+	}
+	deposit.Bytes = size
+	k.setStorageDeposit(ctx, deposit)
+
+	return sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventStorageDepositCollected{
+		Did:       doc.ID,
+		Depositor: doc.UpdatedBy,
+		Amount:    due,
+		Bytes:     size,
+	})
+}
+
+// refundStorageDeposit returns the refundable share of a deactivated DID's
+// deposit to its owner and burns the rest, which pays for the version archive
+// kept in state. The account submitting the deactivation may be a policy
+// signer, an admin or governance, so it is never the recipient.
+func (k Keeper) refundStorageDeposit(ctx context.Context, doc types.DIDDocument) error {
+	deposit, found := k.GetStorageDeposit(ctx, doc.ID)
+	if !found {
+		return nil
+	}
+
+	recipient, recipientAddr, found := depositOwner(doc)
+	if !found {
+		return errors.Wrapf(types.ErrInvalidDID, "no refund recipient for %s", doc.ID)
+	}
+
+	ratio := k.GetParams(ctx).DepositRefundRatio
+	refund := sdk.NewCoins()
+	for _, coin := range deposit.Amount {
+		amount := math.LegacyNewDecFromInt(coin.Amount).Mul(ratio).TruncateInt()
+		refund = refund.Add(sdk.NewCoin(coin.Denom, amount))
+	}
+	burned := deposit.Amount.Sub(refund...)
+
+	if !refund.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, recipientAddr, refund); err != nil {
+			return err
+		}
+	}
+	if !burned.IsZero() {
+		if err := k.bankKeeper.BurnCoins(ctx, types.ModuleName, burned); err != nil {
+			return err
+		}
+	}
+	k.storageDepositStore(ctx).Delete(types.DIDDocumentKey(doc.ID))
+
+	return sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventStorageDepositRefunded{
+		Did:       doc.ID,
+		Recipient: recipient,
+		Refund:    refund,
+		Burned:    burned,
+	})
+}
+
+// depositOwner returns the account a deposit is refunded to: the creator of
+// doc, or else its first controller that is an account address
+func depositOwner(doc types.DIDDocument) (string, sdk.AccAddress, bool) {
+	for _, candidate := range append([]string{doc.Creator}, doc.Controller...) {
+		if addr, err := sdk.AccAddressFromBech32(candidate); err == nil {
+			return candidate, addr, true
+		}
+	}
+	return "", nil, false
+}
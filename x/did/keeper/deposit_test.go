@@ -0,0 +1,237 @@
+package keeper_test
+
+import (
+	"strings"
+	"testing"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// depositKeeper returns a keeper charging one stake per byte and refunding
+// ratio of the deposit on deactivation
+func depositKeeper(t *testing.T, ratio math.LegacyDec) (keeper.Keeper, sdk.Context, *keepertest.MockBankKeeper) {
+	k, ctx, bank := keepertest.DidKeeperWithBank(t)
+	params := types.DefaultParams()
+	params.StorageDepositPerByte = sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)
+	params.DepositRefundRatio = ratio
+	require.NoError(t, k.SetParams(ctx, params))
+	return k, ctx, bank
+}
+
+func stake(amount int64) sdk.Coins {
+	return sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, amount))
+}
+
+func TestCollectStorageDeposit(t *testing.T) {
+	const did = "did:persona:abc"
+	alice := testAddress("alice")
+	aliceAddr := sdk.MustAccAddressFromBech32(alice)
+
+	tests := []struct {
+		name      string
+		updatedBy func(k keeper.Keeper) string
+		mutate    func(doc *types.DIDDocument)
+		// charged reports whether alice pays for the growth of the update
+		charged bool
+		err     error
+	}{
+		{
+			name:      "growth charged to the updater",
+			updatedBy: func(keeper.Keeper) string { return alice },
+			mutate: func(doc *types.DIDDocument) {
+				doc.Service = []types.Service{{ID: "#hub", Type: "Hub", ServiceEndpoint: "https://hub.example"}}
+			},
+			charged: true,
+		},
+		{
+			name:      "no growth is free",
+			updatedBy: func(keeper.Keeper) string { return alice },
+			mutate:    func(*types.DIDDocument) {},
+		},
+		{
+			name:      "governance growth is not charged",
+			updatedBy: func(k keeper.Keeper) string { return k.GetAuthority() },
+			mutate: func(doc *types.DIDDocument) {
+				doc.Service = []types.Service{{ID: "#hub", Type: "Hub", ServiceEndpoint: "https://hub.example"}}
+			},
+		},
+		{
+			name:      "updater cannot pay",
+			updatedBy: func(keeper.Keeper) string { return testAddress("bob") },
+			mutate: func(doc *types.DIDDocument) {
+				doc.Service = []types.Service{{ID: "#hub", Type: "Hub", ServiceEndpoint: "https://" + strings.Repeat("a", 64) + ".example"}}
+			},
+			err: types.ErrInsufficientDeposit,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k, ctx, bank := depositKeeper(t, math.LegacyNewDecWithPrec(8, 1))
+			bank.Fund(aliceAddr, stake(100_000))
+
+			doc := newTestDocument(did, alice)
+			doc.UpdatedBy = alice
+			require.NoError(t, k.SetDidDocument(ctx, doc))
+
+			created, found := k.GetStorageDeposit(ctx, did)
+			require.True(t, found)
+			require.NotZero(t, created.Bytes)
+			require.Equal(t, stake(int64(created.Bytes)), created.Amount)
+			require.Equal(t, stake(100_000-int64(created.Bytes)), bank.Balances[alice])
+			require.Equal(t, created.Amount, bank.Balances[types.ModuleName])
+
+			doc.Version = 2
+			doc.UpdatedBy = tc.updatedBy(k)
+			tc.mutate(&doc)
+			err := k.SetDidDocument(ctx, doc)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			updated, found := k.GetStorageDeposit(ctx, did)
+			require.True(t, found)
+			require.Equal(t, stake(int64(updated.Bytes)), updated.Amount)
+			require.Equal(t, tc.charged, updated.Bytes > created.Bytes)
+			require.Equal(t, stake(100_000-int64(updated.Bytes)), bank.Balances[alice])
+			require.Equal(t, updated.Amount, bank.Balances[types.ModuleName])
+		})
+	}
+}
+
+func TestRefundStorageDeposit(t *testing.T) {
+	const did = "did:persona:abc"
+	alice, bob := testAddress("alice"), testAddress("bob")
+
+	tests := []struct {
+		name        string
+		ratio       math.LegacyDec
+		creator     string
+		controller  []string
+		deactivator func(k keeper.Keeper) string
+		recipient   string
+	}{
+		{
+			name:        "refunded to the creator",
+			ratio:       math.LegacyNewDecWithPrec(8, 1),
+			creator:     alice,
+			deactivator: func(keeper.Keeper) string { return alice },
+			recipient:   alice,
+		},
+		{
+			name:        "deactivated by a controller",
+			ratio:       math.LegacyNewDecWithPrec(8, 1),
+			creator:     alice,
+			controller:  []string{bob},
+			deactivator: func(keeper.Keeper) string { return bob },
+			recipient:   alice,
+		},
+		{
+			name:        "deactivated by governance",
+			ratio:       math.LegacyNewDecWithPrec(5, 1),
+			creator:     alice,
+			deactivator: func(k keeper.Keeper) string { return k.GetAuthority() },
+			recipient:   alice,
+		},
+		{
+			name:        "creator is not an account",
+			ratio:       math.LegacyOneDec(),
+			creator:     "did:persona:org",
+			controller:  []string{"did:persona:parent", bob},
+			deactivator: func(keeper.Keeper) string { return bob },
+			recipient:   bob,
+		},
+		{
+			name:        "everything burned",
+			ratio:       math.LegacyZeroDec(),
+			creator:     alice,
+			deactivator: func(keeper.Keeper) string { return alice },
+			recipient:   alice,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k, ctx, bank := depositKeeper(t, tc.ratio)
+			payer := testAddress("payer")
+			bank.Fund(sdk.MustAccAddressFromBech32(payer), stake(100_000))
+
+			doc := newTestDocument(did, tc.creator)
+			doc.Controller = tc.controller
+			doc.UpdatedBy = payer
+			require.NoError(t, k.SetDidDocument(ctx, doc))
+			deposit, found := k.GetStorageDeposit(ctx, did)
+			require.True(t, found)
+
+			doc.Version = 2
+			doc.UpdatedBy = tc.deactivator(k)
+			doc.Metadata.Deactivated = true
+			require.NoError(t, k.SetDidDocument(ctx, doc))
+
+			refund := math.LegacyNewDecFromInt(deposit.Amount.AmountOf(sdk.DefaultBondDenom)).Mul(tc.ratio).TruncateInt()
+			burned := deposit.Amount.AmountOf(sdk.DefaultBondDenom).Sub(refund)
+			require.True(t, refund.Equal(bank.Balances[tc.recipient].AmountOf(sdk.DefaultBondDenom)), "refund")
+			require.True(t, burned.Equal(bank.Burned.AmountOf(sdk.DefaultBondDenom)), "burned")
+			require.True(t, bank.Balances[types.ModuleName].IsZero())
+			require.Equal(t, 1, countEvents(ctx, &types.EventStorageDepositRefunded{}))
+
+			_, found = k.GetStorageDeposit(ctx, did)
+			require.False(t, found)
+		})
+	}
+}
+
+func TestRefundStorageDepositOnStatus(t *testing.T) {
+	const did = "did:persona:abc"
+	alice := testAddress("alice")
+
+	tests := []struct {
+		name     string
+		state    types.DIDState
+		refunded bool
+	}{
+		{name: "revoked", state: types.DIDStateRevoked, refunded: true},
+		{name: "inactive", state: types.DIDStateInactive, refunded: true},
+		{name: "suspended", state: types.DIDStateSuspended},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k, ctx, bank := depositKeeper(t, math.LegacyOneDec())
+			payer := testAddress("payer")
+			bank.Fund(sdk.MustAccAddressFromBech32(payer), stake(100_000))
+
+			doc := newTestDocument(did, alice)
+			doc.UpdatedBy = payer
+			require.NoError(t, k.SetDidDocument(ctx, doc))
+			deposit, found := k.GetStorageDeposit(ctx, did)
+			require.True(t, found)
+
+			doc.Version = 2
+			doc.Status.State = tc.state
+			require.NoError(t, k.SetDidDocument(ctx, doc))
+
+			_, found = k.GetStorageDeposit(ctx, did)
+			require.Equal(t, !tc.refunded, found)
+			if !tc.refunded {
+				require.Zero(t, countEvents(ctx, &types.EventStorageDepositRefunded{}))
+				return
+			}
+			require.True(t, deposit.Amount.Equal(bank.Balances[alice]))
+
+			// Flagging the revoked document deactivated does not refund again
+			doc.Version = 3
+			doc.Metadata.Deactivated = true
+			require.NoError(t, k.SetDidDocument(ctx, doc))
+			require.Equal(t, 1, countEvents(ctx, &types.EventStorageDepositRefunded{}))
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package keeper
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"cosmossdk.io/errors"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// DereferenceDIDURL dereferences a DID URL such as did:persona:abc#key-1 to a
+// single verification method, service, document or DID-linked resource. The
+// versionId and versionTime query parameters select an archived version of
+// the document. Resources stay addressable after their DID is deactivated.
+func (k Keeper) DereferenceDIDURL(ctx context.Context, didURL string) (types.DereferencedResource, error) {
+	parsed, err := types.ParseDIDURL(didURL)
+	if err != nil {
+		return types.DereferencedResource{}, err
+	}
+
+	if resource, ok, err := k.dereferenceResource(ctx, parsed); ok {
+		return resource, err
+	}
+
+	doc, err := k.getDocumentForURL(ctx, parsed)
+	if err != nil {
+		return types.DereferencedResource{}, err
+	}
+
+	return doc.Dereference(didURL)
+}
+
+// getDocumentForURL returns the version of the document addressed by the
+// versionId/versionTime parameters of a parsed DID URL, or the current one
+func (k Keeper) getDocumentForURL(ctx context.Context, parsed types.DIDURL) (types.DIDDocument, error) {
+	current, found := k.GetDidDocument(ctx, parsed.DID)
+	if !found {
+		return types.DIDDocument{}, errors.Wrapf(types.ErrDIDNotFound, "DID %s not found", parsed.DID)
+	}
+
+	return k.selectDocumentVersion(ctx, current, parsed.VersionID(), parsed.VersionTime())
+}
+
+// selectDocumentVersion picks the archived version matching versionID or
+// versionTime. With neither set the current document is returned.
+func (k Keeper) selectDocumentVersion(ctx context.Context, current types.DIDDocument, versionID, versionTime string) (types.DIDDocument, error) {
+	if versionID != "" && versionTime != "" {
+		return types.DIDDocument{}, errors.Wrap(types.ErrInvalidVersion, "versionId and versionTime are mutually exclusive")
+	}
+
+	if versionID != "" {
+		version, err := strconv.ParseUint(versionID, 10, 64)
+		if err != nil {
+			return types.DIDDocument{}, errors.Wrapf(types.ErrInvalidVersion, "versionId %q: %v", versionID, err)
+		}
+		return k.documentAtVersion(ctx, current, version)
+	}
+
+	if versionTime != "" {
+		at, err := time.Parse(time.RFC3339, versionTime)
+		if err != nil {
+			return types.DIDDocument{}, errors.Wrapf(types.ErrInvalidVersion, "versionTime %q: %v", versionTime, err)
+		}
+		return k.getDocumentAtTime(ctx, current, at)
+	}
+
+	return current, nil
+}
+
+// documentAtVersion returns the given version of a document, which is either
+// current or archived
+func (k Keeper) documentAtVersion(ctx context.Context, current types.DIDDocument, version uint64) (types.DIDDocument, error) {
+	if version == current.Version {
+		return current, nil
+	}
+	doc, found := k.GetDocumentVersion(ctx, current.ID, version)
+	if !found {
+		return types.DIDDocument{}, errors.Wrapf(types.ErrVersionNotFound, "version %d of %s not found", version, current.ID)
+	}
+	return doc, nil
+}
+
+// getDocumentAtTime returns the latest version that was updated at or before
+// the given time
+func (k Keeper) getDocumentAtTime(ctx context.Context, current types.DIDDocument, at time.Time) (types.DIDDocument, error) {
+	if !current.UpdatedAt.After(at) {
+		return current, nil
+	}
+
+	versions, err := k.GetDocumentVersions(ctx, current.ID)
+	if err != nil {
+		return types.DIDDocument{}, err
+	}
+
+	// Versions are keyed by big-endian version number, so iterate backwards
+	for i := len(versions) - 1; i >= 0; i-- {
+		if !versions[i].UpdatedAt.After(at) {
+			return versions[i], nil
+		}
+	}
+
+	return types.DIDDocument{}, errors.Wrapf(types.ErrVersionNotFound, "%s did not exist at %s", current.ID, at.Format(time.RFC3339))
+}
@@ -0,0 +1,62 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestDereferenceDIDURL(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+
+	const did = "did:persona:abc"
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	doc := types.DIDDocument{
+		Context: []string{"https://www.w3.org/ns/did/v1"},
+		ID:      did,
+		Service: []types.Service{
+			{ID: "#hub", Type: "LinkedDomains", ServiceEndpoint: "https://v1.example.com/"},
+		},
+		Version: 1,
+	}
+	require.NoError(t, k.SetDidDocument(ctx.WithBlockTime(t1), doc))
+
+	doc.Service[0].ServiceEndpoint = "https://v2.example.com/"
+	doc.Version = 2
+	require.NoError(t, k.SetDidDocument(ctx.WithBlockTime(t2), doc))
+
+	tests := []struct {
+		name     string
+		url      string
+		endpoint string
+		err      error
+	}{
+		{name: "current version", url: did + "?service=hub", endpoint: "https://v2.example.com/"},
+		{name: "versionId", url: did + "?service=hub&versionId=1", endpoint: "https://v1.example.com/"},
+		{name: "versionTime before update", url: did + "?service=hub&versionTime=2024-01-01T00:30:00Z", endpoint: "https://v1.example.com/"},
+		{name: "versionTime after update", url: did + "?service=hub&versionTime=2024-01-01T01:00:00Z", endpoint: "https://v2.example.com/"},
+		{name: "unknown versionId", url: did + "?versionId=7", err: types.ErrVersionNotFound},
+		{name: "versionTime before creation", url: did + "?versionTime=2023-12-31T00:00:00Z", err: types.ErrVersionNotFound},
+		{name: "malformed versionId", url: did + "?versionId=latest", err: types.ErrInvalidVersion},
+		{name: "unknown DID", url: "did:persona:xyz", err: types.ErrDIDNotFound},
+		{name: "invalid DID URL", url: "did:persona:abc#a b", err: types.ErrInvalidDIDURL},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := k.DereferenceDIDURL(ctx.WithBlockTime(t2), tc.url)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.endpoint, res.ServiceEndpoint)
+		})
+	}
+}
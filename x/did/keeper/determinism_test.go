@@ -0,0 +1,135 @@
+package keeper_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// testBlock is a block header time and the transactions executed in it
+type testBlock struct {
+	offset time.Duration
+	txs    []func(ctx sdk.Context, k keeper.Keeper) error
+}
+
+// nodeState is everything a node commits or emits while replaying blocks
+type nodeState struct {
+	store  map[string][]byte
+	events []sdk.Events
+}
+
+// replayBlocks executes blocks on a fresh keeper after waiting skew, so the
+// node's wall clock differs from every other node's while the headers match
+func replayBlocks(t *testing.T, start time.Time, skew time.Duration, blocks []testBlock) (keeper.Keeper, sdk.Context, nodeState) {
+	k, ctx, storeKey := keepertest.DidKeeperWithStoreKey(t)
+	ctx = ctx.WithChainID(testChainID)
+	time.Sleep(skew)
+
+	var state nodeState
+	for height, block := range blocks {
+		blockCtx := ctx.WithBlockHeight(int64(height + 1)).
+			WithBlockTime(start.Add(block.offset)).
+			WithEventManager(sdk.NewEventManager())
+		for i, tx := range block.txs {
+			txCtx := blockCtx.WithTxBytes([]byte(fmt.Sprintf("tx-%d-%d", height, i)))
+			require.NoError(t, tx(txCtx, k), "block %d tx %d", height, i)
+		}
+		require.NoError(t, k.EndBlocker(blockCtx))
+		state.events = append(state.events, blockCtx.EventManager().Events())
+	}
+
+	state.store = make(map[string][]byte)
+	it := storetypes.KVStorePrefixIterator(ctx.KVStore(storeKey), nil)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		state.store[string(it.Key())] = it.Value()
+	}
+	return k, ctx, state
+}
+
+func TestReplayWithSkewedClocks(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0).UTC()
+	creator := testAddress("creator")
+
+	const did, org = "did:persona:abc", "did:persona:org"
+	key := newEd25519Key(t, did, "key-1")
+	orgKey := newEd25519Key(t, org, "key-1")
+	msgServer := func(k keeper.Keeper) types.MsgServer { return keeper.NewMsgServerImpl(k) }
+
+	blocks := []testBlock{
+		{
+			txs: []func(sdk.Context, keeper.Keeper) error{
+				func(ctx sdk.Context, k keeper.Keeper) error {
+					doc := newTestDocument(did, creator, key)
+					expiresAt := start.Add(2 * time.Hour)
+					doc.VerificationMethod[0].ExpiresAt = &expiresAt
+					_, err := msgServer(k).CreateDIDDocument(ctx, &types.MsgCreateDIDDocument{
+						Creator:     creator,
+						DidDocument: doc,
+						KeyProofs:   []types.KeyProof{key.proof(did, 1)},
+					})
+					return err
+				},
+			},
+		},
+		{
+			offset: time.Hour,
+			txs: []func(sdk.Context, keeper.Keeper) error{
+				func(ctx sdk.Context, k keeper.Keeper) error {
+					until := start.Add(90 * time.Minute)
+					_, err := msgServer(k).UpdateDIDStatus(ctx, &types.MsgUpdateDIDStatus{
+						Controller: creator, Id: did, Status: string(types.DIDStateSuspended), Reason: "audit", Until: &until,
+					})
+					return err
+				},
+				func(ctx sdk.Context, k keeper.Keeper) error {
+					doc := newTestDocument(org, creator, orgKey)
+					nextUpdate := start.Add(150 * time.Minute)
+					doc.Metadata.NextUpdate = &nextUpdate
+					_, err := msgServer(k).CreateDIDDocument(ctx, &types.MsgCreateDIDDocument{
+						Creator:     creator,
+						DidDocument: doc,
+						KeyProofs:   []types.KeyProof{orgKey.proof(org, 1)},
+					})
+					return err
+				},
+			},
+		},
+		// Lifts the suspension
+		{offset: 90 * time.Minute},
+		// Expires the only authentication key
+		{offset: 2 * time.Hour},
+		// Passes the organization's update deadline
+		{offset: 3 * time.Hour},
+	}
+
+	tests := []struct {
+		name string
+		skew time.Duration
+	}{
+		{name: "same wall clock"},
+		{name: "wall clock a second ahead", skew: 1100 * time.Millisecond},
+	}
+
+	k, ctx, reference := replayBlocks(t, start, 0, blocks)
+	stored, found := k.GetDidDocument(ctx, did)
+	require.True(t, found)
+	require.Equal(t, types.DIDStateSuspended, stored.Status.State)
+	require.Equal(t, types.StatusRoleModule, stored.Status.UpdatedByRole)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, replayed := replayBlocks(t, start, tc.skew, blocks)
+			require.Equal(t, reference.store, replayed.store)
+			require.Equal(t, reference.events, replayed.events)
+		})
+	}
+}
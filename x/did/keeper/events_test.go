@@ -0,0 +1,161 @@
+package keeper_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestTypedEvents(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID)
+	msgServer := keeper.NewMsgServerImpl(k)
+	creator := testAddress("creator")
+
+	const did, other = "did:persona:abc", "did:persona:new"
+	createTestDID(t, ctx, k, did, creator, newEd25519Key(t, did, "key-1"), newEd25519Key(t, did, "key-2"))
+	hub := types.Service{ID: "#hub", Type: "Hub", ServiceEndpoint: "https://hub.example"}
+	_, err := msgServer.AddService(ctx, &types.MsgAddService{Controller: creator, Id: did, Service: hub})
+	require.NoError(t, err)
+	current, _ := k.GetDidDocument(ctx, did)
+	added := newEd25519Key(t, did, "key-3")
+	otherKey := newEd25519Key(t, other, "key-1")
+
+	tests := []struct {
+		name  string
+		run   func(ctx sdk.Context) error
+		event proto.Message
+	}{
+		{
+			name: "DID created",
+			run: func(ctx sdk.Context) error {
+				_, err := msgServer.CreateDIDDocument(ctx, &types.MsgCreateDIDDocument{
+					Creator:     creator,
+					DidDocument: newTestDocument(other, creator, otherKey),
+					KeyProofs:   []types.KeyProof{otherKey.proof(other, 1)},
+				})
+				return err
+			},
+			event: &types.EventDIDCreated{Did: other, Creator: creator, Version: 1},
+		},
+		{
+			name: "DID updated",
+			run: func(ctx sdk.Context) error {
+				_, err := msgServer.UpdateDIDDocument(ctx, &types.MsgUpdateDIDDocument{Controller: creator, Id: did, DidDocument: current})
+				return err
+			},
+			event: &types.EventDIDUpdated{Did: did, Controller: creator, Version: current.Version + 1},
+		},
+		{
+			name: "verification method added",
+			run: func(ctx sdk.Context) error {
+				proof := added.proof(did, current.Version+1)
+				_, err := msgServer.AddVerificationMethod(ctx, &types.MsgAddVerificationMethod{Controller: creator, Id: did, VerificationMethod: added.vm, KeyProof: &proof})
+				return err
+			},
+			event: &types.EventVerificationMethodAdded{Did: did, Controller: creator, VerificationMethodId: added.vm.ID},
+		},
+		{
+			name: "verification method revoked",
+			run: func(ctx sdk.Context) error {
+				_, err := msgServer.RevokeVerificationMethod(ctx, &types.MsgRevokeVerificationMethod{Controller: creator, Id: did, MethodId: did + "#key-2", Reason: "compromised"})
+				return err
+			},
+			event: &types.EventVerificationMethodRevoked{Did: did, Controller: creator, VerificationMethodId: did + "#key-2", Reason: "compromised"},
+		},
+		{
+			name: "service added",
+			run: func(ctx sdk.Context) error {
+				inbox := types.Service{ID: "#inbox", Type: "Inbox", ServiceEndpoint: "https://inbox.example"}
+				_, err := msgServer.AddService(ctx, &types.MsgAddService{Controller: creator, Id: did, Service: inbox})
+				return err
+			},
+			event: &types.EventServiceAdded{Did: did, Controller: creator, ServiceId: "#inbox"},
+		},
+		{
+			name: "service removed",
+			run: func(ctx sdk.Context) error {
+				_, err := msgServer.RemoveService(ctx, &types.MsgRemoveService{Controller: creator, Id: did, ServiceId: hub.ID, Reason: "moved"})
+				return err
+			},
+			event: &types.EventServiceRemoved{Did: did, Controller: creator, ServiceId: hub.ID, Reason: "moved"},
+		},
+		{
+			name: "status changed",
+			run: func(ctx sdk.Context) error {
+				_, err := msgServer.UpdateDIDStatus(ctx, &types.MsgUpdateDIDStatus{Controller: creator, Id: did, Status: string(types.DIDStateSuspended), Reason: "audit"})
+				return err
+			},
+			event: &types.EventDIDStatusChanged{
+				Did:           did,
+				PreviousState: string(types.DIDStateActive),
+				NewState:      string(types.DIDStateSuspended),
+				Reason:        "audit",
+				Actor:         creator,
+				Role:          string(types.StatusRoleController),
+			},
+		},
+		{
+			name: "DID deactivated",
+			run: func(ctx sdk.Context) error {
+				_, err := msgServer.DeactivateDIDDocument(ctx, &types.MsgDeactivateDIDDocument{Controller: creator, Id: did, Reason: "retired"})
+				return err
+			},
+			event: &types.EventDIDDeactivated{Did: did, Controller: creator, Reason: "retired"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+			require.NoError(t, tc.run(cacheCtx))
+
+			// Every event is typed, and the one for the change carries its fields
+			var found []proto.Message
+			for _, event := range cacheCtx.EventManager().ABCIEvents() {
+				parsed, err := sdk.ParseTypedEvent(event)
+				require.NoError(t, err, event.Type)
+				if event.Type == proto.MessageName(tc.event) {
+					found = append(found, parsed)
+				}
+			}
+			require.Equal(t, []proto.Message{tc.event}, found)
+		})
+	}
+}
+
+func TestTypedEventAttributes(t *testing.T) {
+	ctx := sdk.Context{}.WithEventManager(sdk.NewEventManager())
+	require.NoError(t, ctx.EventManager().EmitTypedEvent(&types.EventVerificationMethodRevoked{
+		Did:                  "did:persona:abc",
+		Controller:           testAddress("creator"),
+		VerificationMethodId: "did:persona:abc#key-1",
+	}))
+
+	// Indexers decode attributes by their proto field names, including
+	// fields left empty
+	events := ctx.EventManager().ABCIEvents()
+	require.Len(t, events, 1)
+	require.Equal(t, "persona_chain.did.v1.EventVerificationMethodRevoked", events[0].Type)
+
+	attributes := make(map[string]string)
+	for _, attr := range events[0].Attributes {
+		var value string
+		require.NoError(t, json.Unmarshal([]byte(attr.Value), &value), attr.Key)
+		attributes[attr.Key] = value
+	}
+	require.Equal(t, map[string]string{
+		"did":                    "did:persona:abc",
+		"controller":             testAddress("creator"),
+		"verification_method_id": "did:persona:abc#key-1",
+		"reason":                 "",
+	}, attributes)
+}
@@ -0,0 +1,251 @@
+package keeper
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cosmossdk.io/store/prefix"
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/persona-chain/persona-chain/internal/clock"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// expiryEntry is a scheduled key expiry, or an update deadline when vmID is
+// empty
+type expiryEntry struct {
+	at   time.Time
+	vmID string
+}
+
+// documentExpiries returns the expiries of a document: one per verification
+// method with an expiry that is not revoked, and the next update deadline
+func documentExpiries(doc types.DIDDocument) []expiryEntry {
+	var entries []expiryEntry
+	for _, vm := range doc.VerificationMethod {
+		if vm.ExpiresAt != nil && !vm.Revoked {
+			entries = append(entries, expiryEntry{at: *vm.ExpiresAt, vmID: vm.ID})
+		}
+	}
+	if doc.Metadata.NextUpdate != nil {
+		entries = append(entries, expiryEntry{at: *doc.Metadata.NextUpdate})
+	}
+	return entries
+}
+
+func (k Keeper) expiryQueueStore(ctx context.Context) prefix.Store {
+	storeAdapter := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	return prefix.NewStore(storeAdapter, types.KeyPrefix(types.DIDExpiryQueuePrefix))
+}
+
+// setExpiryQueue schedules the expiries of a document that are still ahead
+// of the block time. Past expiries have already been processed or need no
+// sweep.
+func (k Keeper) setExpiryQueue(ctx context.Context, doc types.DIDDocument) {
+	now := clock.Now(ctx)
+	store := k.expiryQueueStore(ctx)
+	for _, e := range documentExpiries(doc) {
+		if e.at.After(now) {
+			store.Set(types.DIDExpiryQueueKey(e.at, doc.ID, e.vmID), []byte{})
+		}
+	}
+}
+
+// removeExpiryQueue unschedules the expiries of a document
+func (k Keeper) removeExpiryQueue(ctx context.Context, doc types.DIDDocument) {
+	store := k.expiryQueueStore(ctx)
+	for _, e := range documentExpiries(doc) {
+		store.Delete(types.DIDExpiryQueueKey(e.at, doc.ID, e.vmID))
+	}
+}
+
+// EndBlocker processes every expiry queue entry due by the block time. Keys
+// that expired emit an event, and a document whose last usable
+// authentication key expired is moved to the AuthenticationExpiredState
+// param. Passed update deadlines emit an event. Timed suspensions that ended
+// are lifted and pending updates that were not approved in time are dropped.
+func (k Keeper) EndBlocker(ctx context.Context) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	now := clock.Now(ctx)
+
+	store := k.expiryQueueStore(ctx)
+	for _, key := range dueQueueEntries(store, now) {
+		store.Delete(key)
+
+		did, vmID, err := types.ParseDIDExpiryQueueKey(key)
+		if err != nil {
+			k.Logger(ctx).Error("Dropping malformed expiry queue entry", "error", err)
+			continue
+		}
+		if vmID == "" {
+			if err := k.processUpdateDeadline(sdkCtx, did, now); err != nil {
+				k.Logger(ctx).Error("Failed to process update deadline", "did", did, "error", err)
+			}
+			continue
+		}
+		if err := k.processKeyExpiry(sdkCtx, did, vmID, now); err != nil {
+			k.Logger(ctx).Error("Failed to process key expiry", "did", did, "verification_method", vmID, "error", err)
+		}
+	}
+
+	store = k.suspensionQueueStore(ctx)
+	for _, key := range dueQueueEntries(store, now) {
+		store.Delete(key)
+
+		did, _, err := types.ParseDIDExpiryQueueKey(key)
+		if err != nil {
+			k.Logger(ctx).Error("Dropping malformed suspension queue entry", "error", err)
+			continue
+		}
+		if err := k.processSuspensionEnd(sdkCtx, did, now); err != nil {
+			k.Logger(ctx).Error("Failed to lift suspension", "did", did, "error", err)
+		}
+	}
+
+	store = k.policyStore(ctx, types.PendingUpdateQueuePrefix)
+	for _, key := range dueQueueEntries(store, now) {
+		store.Delete(key)
+
+		did, id, err := types.ParseDIDExpiryQueueKey(key)
+		if err != nil {
+			k.Logger(ctx).Error("Dropping malformed pending update queue entry", "error", err)
+			continue
+		}
+		if err := k.processPendingUpdateExpiry(sdkCtx, id, now); err != nil {
+			k.Logger(ctx).Error("Failed to expire pending update", "did", did, "update", id, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// dueQueueEntries returns the keys of all queue entries due by now. Keys are
+// collected first so the queue is not mutated while iterating.
+func dueQueueEntries(store prefix.Store, now time.Time) [][]byte {
+	iterator := store.Iterator(nil, types.DIDExpiryQueueTimePrefix(now.Add(time.Second)))
+	defer iterator.Close()
+
+	var due [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		due = append(due, iterator.Key())
+	}
+	return due
+}
+
+func (k Keeper) processKeyExpiry(ctx sdk.Context, did, vmID string, now time.Time) error {
+	doc, found := k.GetDidDocument(ctx, did)
+	if !found || doc.IsDeactivated() {
+		return nil
+	}
+
+	// The entry is stale if the key was revoked or its expiry moved
+	vm, found := doc.FindVerificationMethod(vmID)
+	if !found || vm.Revoked || vm.ExpiresAt == nil || now.Before(*vm.ExpiresAt) {
+		return nil
+	}
+
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventVerificationMethodExpired{
+		Did:                  did,
+		VerificationMethodId: vmID,
+		ExpiresAt:            *vm.ExpiresAt,
+	}); err != nil {
+		return err
+	}
+
+	state := types.DIDState(k.GetParams(ctx).AuthenticationExpiredState)
+	if state == "" || doc.Status.State != types.DIDStateActive || !isAuthenticationKey(&doc, vmID) || hasUsableAuthenticationKey(&doc, now) {
+		return nil
+	}
+
+	reason := fmt.Sprintf("last authentication key %s expired", vmID)
+	return k.setDocumentStatus(ctx, doc, state, reason, types.ModuleName, types.StatusRoleModule, nil)
+}
+
+func (k Keeper) processUpdateDeadline(ctx sdk.Context, did string, now time.Time) error {
+	doc, found := k.GetDidDocument(ctx, did)
+	if !found || doc.IsDeactivated() {
+		return nil
+	}
+	next := doc.Metadata.NextUpdate
+	if next == nil || now.Before(*next) {
+		return nil
+	}
+
+	return ctx.EventManager().EmitTypedEvent(&types.EventDIDUpdateDue{
+		Did:        did,
+		NextUpdate: *next,
+	})
+}
+
+func isAuthenticationKey(doc *types.DIDDocument, vmID string) bool {
+	for _, rel := range doc.VerificationRelationships(vmID) {
+		if rel == types.RelationshipAuthentication {
+			return true
+		}
+	}
+	return false
+}
+
+func hasUsableAuthenticationKey(doc *types.DIDDocument, now time.Time) bool {
+	for _, vm := range doc.VerificationMethod {
+		if vm.IsActiveAt(now) && isAuthenticationKey(doc, vm.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckHealth evaluates the health of a DID document at the block time
+// without modifying it
+func (k Keeper) CheckHealth(ctx context.Context, id string) (types.HealthCheck, error) {
+	doc, found := k.GetDidDocument(ctx, id)
+	if !found {
+		return types.HealthCheck{}, types.ErrDIDNotFound
+	}
+
+	now := clock.Now(ctx)
+	var errors []string
+
+	// Check document validity
+	if err := doc.Validate(); err != nil {
+		errors = append(errors, fmt.Sprintf("Validation failed: %v", err))
+	}
+
+	// Check verification methods
+	for _, vm := range doc.VerificationMethod {
+		if vm.Revoked {
+			errors = append(errors, fmt.Sprintf("Verification method %s is revoked", vm.ID))
+		}
+		if vm.ExpiresAt != nil && !now.Before(*vm.ExpiresAt) {
+			errors = append(errors, fmt.Sprintf("Verification method %s has expired", vm.ID))
+		}
+	}
+	if len(doc.Authentication) > 0 && !hasUsableAuthenticationKey(&doc, now) {
+		errors = append(errors, "No usable authentication key")
+	}
+
+	// Check update deadline
+	if next := doc.Metadata.NextUpdate; next != nil && !now.Before(*next) {
+		errors = append(errors, fmt.Sprintf("Update was due at %s", next.UTC().Format(time.RFC3339)))
+	}
+
+	// Check services
+	for _, svc := range doc.Service {
+		if svc.ServiceEndpoint == "" {
+			errors = append(errors, fmt.Sprintf("Service %s has empty endpoint", svc.ID))
+		}
+	}
+
+	status := "healthy"
+	if len(errors) > 0 {
+		status = "unhealthy"
+	}
+
+	return types.HealthCheck{
+		Status:      status,
+		LastChecked: now,
+		Errors:      errors,
+	}, nil
+}
@@ -0,0 +1,191 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestEndBlockerExpiryQueue(t *testing.T) {
+	const did = "did:persona:abc"
+	start := time.Unix(1_700_000_000, 0).UTC()
+	at := func(d time.Duration) *time.Time {
+		t := start.Add(d)
+		return &t
+	}
+
+	// step is one EndBlocker run at start+offset and what it should emit
+	type step struct {
+		offset  time.Duration
+		expired int
+		due     int
+		state   types.DIDState
+	}
+
+	tests := []struct {
+		name   string
+		doc    func(keys []testKey) types.DIDDocument
+		update func(doc *types.DIDDocument)
+		steps  []step
+	}{
+		{
+			name: "keys expire in order and the last one suspends the document",
+			doc: func(keys []testKey) types.DIDDocument {
+				doc := newTestDocument(did, testAddress("alice"), keys...)
+				doc.VerificationMethod[0].ExpiresAt = at(time.Hour)
+				doc.VerificationMethod[1].ExpiresAt = at(2 * time.Hour)
+				return doc
+			},
+			steps: []step{
+				{offset: 30 * time.Minute, state: types.DIDStateActive},
+				{offset: time.Hour, expired: 1, state: types.DIDStateActive},
+				{offset: 2 * time.Hour, expired: 1, state: types.DIDStateSuspended},
+				{offset: 3 * time.Hour, state: types.DIDStateSuspended},
+			},
+		},
+		{
+			name: "key without expiry keeps the document active",
+			doc: func(keys []testKey) types.DIDDocument {
+				doc := newTestDocument(did, testAddress("alice"), keys...)
+				doc.VerificationMethod[0].ExpiresAt = at(time.Hour)
+				return doc
+			},
+			steps: []step{
+				{offset: time.Hour, expired: 1, state: types.DIDStateActive},
+			},
+		},
+		{
+			name: "entries due between blocks are processed by the next block",
+			doc: func(keys []testKey) types.DIDDocument {
+				doc := newTestDocument(did, testAddress("alice"), keys...)
+				doc.VerificationMethod[0].ExpiresAt = at(time.Hour)
+				doc.VerificationMethod[1].ExpiresAt = at(time.Hour + time.Second)
+				doc.Metadata.NextUpdate = at(time.Hour + 2*time.Second)
+				return doc
+			},
+			steps: []step{
+				{offset: 5 * time.Hour, expired: 2, due: 1, state: types.DIDStateSuspended},
+			},
+		},
+		{
+			name: "update deadline",
+			doc: func(keys []testKey) types.DIDDocument {
+				doc := newTestDocument(did, testAddress("alice"), keys...)
+				doc.Metadata.NextUpdate = at(90 * time.Minute)
+				return doc
+			},
+			steps: []step{
+				{offset: 89 * time.Minute, state: types.DIDStateActive},
+				{offset: 90 * time.Minute, due: 1, state: types.DIDStateActive},
+				{offset: 91 * time.Minute, state: types.DIDStateActive},
+			},
+		},
+		{
+			name: "revoked key is unscheduled",
+			doc: func(keys []testKey) types.DIDDocument {
+				doc := newTestDocument(did, testAddress("alice"), keys...)
+				doc.VerificationMethod[0].ExpiresAt = at(time.Hour)
+				return doc
+			},
+			update: func(doc *types.DIDDocument) { doc.VerificationMethod[0].Revoked = true },
+			steps: []step{
+				{offset: time.Hour, state: types.DIDStateActive},
+			},
+		},
+		{
+			name: "extended expiry is rescheduled",
+			doc: func(keys []testKey) types.DIDDocument {
+				doc := newTestDocument(did, testAddress("alice"), keys...)
+				doc.VerificationMethod[0].ExpiresAt = at(time.Hour)
+				return doc
+			},
+			update: func(doc *types.DIDDocument) { doc.VerificationMethod[0].ExpiresAt = at(3 * time.Hour) },
+			steps: []step{
+				{offset: time.Hour, state: types.DIDStateActive},
+				{offset: 3 * time.Hour, expired: 1, state: types.DIDStateActive},
+			},
+		},
+		{
+			name: "deactivated document is skipped",
+			doc: func(keys []testKey) types.DIDDocument {
+				doc := newTestDocument(did, testAddress("alice"), keys...)
+				doc.VerificationMethod[0].ExpiresAt = at(time.Hour)
+				doc.Metadata.NextUpdate = at(time.Hour)
+				return doc
+			},
+			update: func(doc *types.DIDDocument) {
+				doc.Metadata.Deactivated = true
+				doc.Status.State = types.DIDStateRevoked
+			},
+			steps: []step{
+				{offset: time.Hour, state: types.DIDStateRevoked},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k, ctx := keepertest.DidKeeper(t)
+			ctx = ctx.WithBlockTime(start)
+
+			keys := []testKey{newEd25519Key(t, did, "key-1"), newEd25519Key(t, did, "key-2")}
+			doc := tc.doc(keys)
+			doc.Status.State = types.DIDStateActive
+			require.NoError(t, k.SetDidDocument(ctx, doc))
+			if tc.update != nil {
+				stored, found := k.GetDidDocument(ctx, did)
+				require.True(t, found)
+				stored.Version++
+				tc.update(&stored)
+				require.NoError(t, k.SetDidDocument(ctx, stored))
+			}
+
+			for _, s := range tc.steps {
+				blockCtx := ctx.WithBlockTime(start.Add(s.offset)).WithEventManager(sdk.NewEventManager())
+				require.NoError(t, k.EndBlocker(blockCtx))
+
+				require.Equal(t, s.expired, countEvents(blockCtx, &types.EventVerificationMethodExpired{}), "expired at %s", s.offset)
+				require.Equal(t, s.due, countEvents(blockCtx, &types.EventDIDUpdateDue{}), "due at %s", s.offset)
+				stored, found := k.GetDidDocument(blockCtx, did)
+				require.True(t, found)
+				require.Equal(t, s.state, stored.Status.State, "state at %s", s.offset)
+			}
+		})
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	start := time.Unix(1_700_000_000, 0).UTC()
+	ctx = ctx.WithBlockTime(start)
+
+	const did = "did:persona:abc"
+	doc := newTestDocument(did, testAddress("alice"), newEd25519Key(t, did, "key-1"))
+	expiry := start.Add(time.Hour)
+	doc.VerificationMethod[0].ExpiresAt = &expiry
+	require.NoError(t, k.SetDidDocument(ctx, doc))
+
+	health, err := k.CheckHealth(ctx, did)
+	require.NoError(t, err)
+	require.Equal(t, "healthy", health.Status)
+	require.Equal(t, start, health.LastChecked)
+
+	later := ctx.WithBlockTime(expiry)
+	health, err = k.CheckHealth(later, did)
+	require.NoError(t, err)
+	require.Equal(t, "unhealthy", health.Status)
+	require.Len(t, health.Errors, 2)
+
+	// CheckHealth does not write a new version
+	stored, found := k.GetDidDocument(later, did)
+	require.True(t, found)
+	require.Equal(t, uint64(1), stored.Version)
+
+	_, err = k.CheckHealth(ctx, "did:persona:missing")
+	require.ErrorIs(t, err, types.ErrDIDNotFound)
+}
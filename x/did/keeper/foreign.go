@@ -0,0 +1,96 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/persona-chain/persona-chain/internal/clock"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// GetForeignDidDocument returns a DID document received over an IBC channel
+func (k Keeper) GetForeignDidDocument(ctx context.Context, channelID, did string) (types.ForeignDIDDocument, bool) {
+	b := k.policyStore(ctx, types.ForeignDIDKeyPrefix).Get(types.ForeignDIDKey(channelID, did))
+	if b == nil {
+		return types.ForeignDIDDocument{}, false
+	}
+
+	var foreign types.ForeignDIDDocument
+	if err := k.cdc.Unmarshal(b, &foreign); err != nil {
+		return types.ForeignDIDDocument{}, false
+	}
+	return foreign, true
+}
+
+func (k Keeper) setForeignDidDocument(ctx context.Context, foreign types.ForeignDIDDocument) {
+	key := types.ForeignDIDKey(foreign.ChannelId, foreign.Document.ID)
+	k.policyStore(ctx, types.ForeignDIDKeyPrefix).Set(key, k.cdc.MustMarshal(&foreign))
+}
+
+// ApplyForeignDidUpdate stores a DID document received over an IBC channel in
+// the namespace of that channel. Native DIDs are never written. An update
+// must carry a higher version than the document already received for the
+// DID and be signed by one of that document's authentication keys. The
+// first version received must be signed by one of its own.
+func (k Keeper) ApplyForeignDidUpdate(ctx context.Context, channelID string, data types.DidUpdatePacketData) error {
+	if err := data.ValidateBasic(); err != nil {
+		return err
+	}
+	if k.DidDocumentExists(ctx, data.DidId) {
+		return errors.Wrapf(types.ErrUnauthorized, "DID %s is native to this chain and cannot be written over IBC", data.DidId)
+	}
+
+	var doc types.DIDDocument
+	if err := json.Unmarshal([]byte(data.DidDocument), &doc); err != nil {
+		return errors.Wrapf(types.ErrCrossChainVerificationFailed, "invalid DID document: %v", err)
+	}
+	if doc.ID != data.DidId || doc.Version != data.Version {
+		return errors.Wrapf(types.ErrCrossChainVerificationFailed, "document is %s version %d, packet claims %s version %d", doc.ID, doc.Version, data.DidId, data.Version)
+	}
+	if err := doc.Validate(); err != nil {
+		return err
+	}
+
+	now := clock.Now(ctx)
+	signer := doc
+	if previous, found := k.GetForeignDidDocument(ctx, channelID, data.DidId); found {
+		if data.Version <= previous.Document.Version {
+			return errors.Wrapf(types.ErrVersionConflict, "received version %d of %s, already have version %d", data.Version, data.DidId, previous.Document.Version)
+		}
+		signer = previous.Document
+	}
+	if err := verifyForeignUpdate(signer, data, now); err != nil {
+		return err
+	}
+
+	k.setForeignDidDocument(ctx, types.ForeignDIDDocument{
+		ChannelId:      channelID,
+		SourceChainId:  data.SourceChainId,
+		Document:       doc,
+		ReceivedAt:     now,
+		ReceivedHeight: sdk.UnwrapSDKContext(ctx).BlockHeight(),
+	})
+
+	return sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventForeignDIDUpdated{
+		Did:           doc.ID,
+		ChannelId:     channelID,
+		SourceChainId: data.SourceChainId,
+		Version:       doc.Version,
+	})
+}
+
+// verifyForeignUpdate checks an update's proof is a signature by an active
+// authentication key of signer
+func verifyForeignUpdate(signer types.DIDDocument, data types.DidUpdatePacketData, now time.Time) error {
+	challenge := types.ForeignUpdateChallenge(data.DidId, data.SourceChainId, data.Version, data.DidDocument)
+	for _, vm := range signer.ActiveAuthenticationKeys(now) {
+		if vm.ID == data.Proof.VerificationMethodId {
+			return vm.VerifySignature(challenge, data.Proof.Signature)
+		}
+	}
+	return errors.Wrapf(types.ErrCrossChainVerificationFailed, "%s is not an authentication key of %s version %d", data.Proof.VerificationMethodId, signer.ID, signer.Version)
+}
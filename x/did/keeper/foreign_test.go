@@ -0,0 +1,181 @@
+package keeper_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+const partnerChainID = "partner-1"
+
+// foreignUpdate returns the packet data carrying doc from the partner chain,
+// signed by signer
+func foreignUpdate(t *testing.T, doc types.DIDDocument, signer testKey) types.DidUpdatePacketData {
+	bz, err := json.Marshal(doc)
+	require.NoError(t, err)
+	data := types.DidUpdatePacketData{
+		DidId:         doc.ID,
+		DidDocument:   string(bz),
+		Controller:    doc.Creator,
+		Active:        true,
+		UpdatedAt:     doc.UpdatedAt.Unix(),
+		Version:       doc.Version,
+		SourceChainId: partnerChainID,
+	}
+	challenge := types.ForeignUpdateChallenge(data.DidId, data.SourceChainId, data.Version, data.DidDocument)
+	data.Proof = &types.KeyProof{VerificationMethodId: signer.vm.ID, Signature: signer.sign(challenge)}
+	return data
+}
+
+func TestApplyForeignDidUpdate(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithBlockTime(time.Unix(1_700_000_000, 0).UTC())
+	const (
+		native   = "did:persona:abc"
+		remote   = "did:persona:remote"
+		other    = "did:persona:other"
+		channel  = "channel-0"
+		channel2 = "channel-1"
+	)
+	creator := testAddress("creator")
+	require.NoError(t, k.SetDidDocument(ctx, newTestDocument(native, creator)))
+
+	// Version 1 of the remote DID has been received over channel-0
+	key1, key2 := newEd25519Key(t, remote, "key-1"), newEd25519Key(t, remote, "key-2")
+	v1 := newTestDocument(remote, creator, key1)
+	v1.UpdatedAt = ctx.BlockTime()
+	require.NoError(t, k.ApplyForeignDidUpdate(ctx, channel, foreignUpdate(t, v1, key1)))
+
+	// Version 2 rotates to key-2 and claims to be older than version 1
+	v2 := newTestDocument(remote, creator, key2)
+	v2.Version = 2
+	v2.UpdatedAt = ctx.BlockTime().Add(-time.Hour)
+
+	nativeKey := newEd25519Key(t, native, "key-1")
+	otherKey := newEd25519Key(t, other, "key-1")
+
+	tests := []struct {
+		name    string
+		channel string
+		data    func() types.DidUpdatePacketData
+		version uint64
+		err     error
+	}{
+		{
+			name:    "next version signed by known key",
+			channel: channel,
+			data:    func() types.DidUpdatePacketData { return foreignUpdate(t, v2, key1) },
+			version: 2,
+		},
+		{
+			name:    "signed by a key only in the new version",
+			channel: channel,
+			data:    func() types.DidUpdatePacketData { return foreignUpdate(t, v2, key2) },
+			err:     types.ErrCrossChainVerificationFailed,
+		},
+		{
+			name:    "version already received",
+			channel: channel,
+			data:    func() types.DidUpdatePacketData { return foreignUpdate(t, v1, key1) },
+			err:     types.ErrVersionConflict,
+		},
+		{
+			name:    "first version on another channel",
+			channel: channel2,
+			data:    func() types.DidUpdatePacketData { return foreignUpdate(t, v2, key2) },
+			version: 2,
+		},
+		{
+			name:    "first version not signed by its own key",
+			channel: channel,
+			data: func() types.DidUpdatePacketData {
+				return foreignUpdate(t, newTestDocument(other, creator, otherKey), newEd25519Key(t, other, "key-1"))
+			},
+			err: types.ErrInvalidSignature,
+		},
+		{
+			name:    "native DID",
+			channel: channel,
+			data: func() types.DidUpdatePacketData {
+				return foreignUpdate(t, newTestDocument(native, creator, nativeKey), nativeKey)
+			},
+			err: types.ErrUnauthorized,
+		},
+		{
+			name:    "document changed after signing",
+			channel: channel,
+			data: func() types.DidUpdatePacketData {
+				data := foreignUpdate(t, v2, key1)
+				tampered := v2
+				tampered.Creator = testAddress("mallory")
+				bz, err := json.Marshal(tampered)
+				require.NoError(t, err)
+				data.DidDocument = string(bz)
+				return data
+			},
+			err: types.ErrInvalidSignature,
+		},
+		{
+			name:    "packet version differs from document",
+			channel: channel,
+			data: func() types.DidUpdatePacketData {
+				data := foreignUpdate(t, v2, key1)
+				data.Version = 3
+				return data
+			},
+			err: types.ErrCrossChainVerificationFailed,
+		},
+		{
+			name:    "unsigned",
+			channel: channel,
+			data: func() types.DidUpdatePacketData {
+				data := foreignUpdate(t, v2, key1)
+				data.Proof = nil
+				return data
+			},
+			err: types.ErrMissingKeyProof,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+			data := tc.data()
+			err := k.ApplyForeignDidUpdate(cacheCtx, tc.channel, data)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				require.Zero(t, countEvents(cacheCtx, &types.EventForeignDIDUpdated{}))
+
+				// Native documents are untouched by refused updates
+				stored, found := k.GetDidDocument(cacheCtx, native)
+				require.True(t, found)
+				require.Empty(t, stored.VerificationMethod)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 1, countEvents(cacheCtx, &types.EventForeignDIDUpdated{}))
+
+			foreign, found := k.GetForeignDidDocument(cacheCtx, tc.channel, data.DidId)
+			require.True(t, found)
+			require.Equal(t, tc.version, foreign.Document.Version)
+			require.Equal(t, partnerChainID, foreign.SourceChainId)
+			require.Equal(t, ctx.BlockTime(), foreign.ReceivedAt)
+
+			// Foreign DIDs stay out of the native namespace, and each
+			// channel keeps its own copy
+			require.False(t, k.DidDocumentExists(cacheCtx, data.DidId))
+			previous, found := k.GetForeignDidDocument(cacheCtx, channel, remote)
+			require.True(t, found)
+			if tc.channel != channel {
+				require.Equal(t, uint64(1), previous.Document.Version)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+	"github.com/cosmos/cosmos-sdk/runtime"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// ImportDidDocument stores a document taken from genesis as-is. Unlike
+// SetDidDocument it neither charges deposits nor rewrites chain metadata, so
+// an exported state imports unchanged.
+func (k Keeper) ImportDidDocument(ctx context.Context, doc types.DIDDocument) error {
+	if err := doc.Validate(); err != nil {
+		return errors.Wrapf(types.ErrInvalidDID, "validation failed: %v", err)
+	}
+
+	storeAdapter := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	store := prefix.NewStore(storeAdapter, types.KeyPrefix(types.DIDDocumentKeyPrefix))
+	if store.Has(types.DIDDocumentKey(doc.ID)) {
+		return errors.Wrapf(types.ErrDIDAlreadyExists, "%s", doc.ID)
+	}
+	store.Set(types.DIDDocumentKey(doc.ID), k.cdc.MustMarshal(&doc))
+
+	if err := k.setDocumentVersion(ctx, doc); err != nil {
+		return err
+	}
+	if err := k.setDocumentMetadata(ctx, doc); err != nil {
+		return err
+	}
+	k.updateDocumentIndexes(ctx, nil, doc)
+	return nil
+}
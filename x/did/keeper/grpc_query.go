@@ -0,0 +1,418 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/persona-chain/persona-chain/internal/clock"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// Params returns the module parameters
+func (k Keeper) Params(goCtx context.Context, req *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	return &types.QueryParamsResponse{Params: k.GetParams(ctx)}, nil
+}
+
+// DidDocument returns the stored DID document for an ID
+func (k Keeper) DidDocument(goCtx context.Context, req *types.QueryGetDidDocumentRequest) (*types.QueryGetDidDocumentResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if _, err := types.ParseDID(req.Id); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	doc, found := k.GetDidDocument(ctx, req.Id)
+	if !found {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+
+	return &types.QueryGetDidDocumentResponse{DidDocument: doc}, nil
+}
+
+// DidDocumentAll returns a page of stored DID documents
+func (k Keeper) DidDocumentAll(goCtx context.Context, req *types.QueryAllDidDocumentRequest) (*types.QueryAllDidDocumentResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	storeAdapter := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	store := prefix.NewStore(storeAdapter, types.KeyPrefix(types.DIDDocumentKeyPrefix))
+
+	var docs []types.DIDDocument
+	pageRes, err := query.Paginate(store, req.Pagination, func(key []byte, value []byte) error {
+		var doc types.DIDDocument
+		if err := k.cdc.Unmarshal(value, &doc); err != nil {
+			return err
+		}
+		docs = append(docs, doc)
+		return nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryAllDidDocumentResponse{DidDocument: docs, Pagination: pageRes}, nil
+}
+
+// DidDocumentByController returns the first DID document created or
+// controlled by an address
+func (k Keeper) DidDocumentByController(goCtx context.Context, req *types.QueryGetDidDocumentByControllerRequest) (*types.QueryGetDidDocumentByControllerResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	docs, _, err := k.PaginateIndexedDocuments(ctx, types.DIDControllerIndexPrefix, req.Controller, &query.PageRequest{Limit: 1})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if len(docs) == 0 {
+		return &types.QueryGetDidDocumentByControllerResponse{Found: false}, nil
+	}
+
+	return &types.QueryGetDidDocumentByControllerResponse{DidDocument: docs[0], Found: true}, nil
+}
+
+// DidDocumentsByController returns a page of DID documents created or
+// controlled by an address or DID
+func (k Keeper) DidDocumentsByController(goCtx context.Context, req *types.QueryDidDocumentsByControllerRequest) (*types.QueryDidDocumentsByControllerResponse, error) {
+	if req == nil || req.Controller == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	docs, pageRes, err := k.PaginateIndexedDocuments(ctx, types.DIDControllerIndexPrefix, req.Controller, req.Pagination)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryDidDocumentsByControllerResponse{DidDocument: docs, Pagination: pageRes}, nil
+}
+
+// DidDocumentsByOrganization returns a page of DID documents belonging to an
+// organization
+func (k Keeper) DidDocumentsByOrganization(goCtx context.Context, req *types.QueryDidDocumentsByOrganizationRequest) (*types.QueryDidDocumentsByOrganizationResponse, error) {
+	if req == nil || req.OrganizationId == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	docs, pageRes, err := k.PaginateIndexedDocuments(ctx, types.DIDOrganizationIndexPrefix, req.OrganizationId, req.Pagination)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryDidDocumentsByOrganizationResponse{DidDocument: docs, Pagination: pageRes}, nil
+}
+
+// DidDocumentsByState returns a page of DID documents in a lifecycle state
+func (k Keeper) DidDocumentsByState(goCtx context.Context, req *types.QueryDidDocumentsByStateRequest) (*types.QueryDidDocumentsByStateResponse, error) {
+	if req == nil || req.State == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	docs, pageRes, err := k.PaginateIndexedDocuments(ctx, types.DIDStateIndexPrefix, req.State, req.Pagination)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryDidDocumentsByStateResponse{DidDocument: docs, Pagination: pageRes}, nil
+}
+
+// DidDocumentsByTag returns a page of DID documents carrying a metadata tag
+func (k Keeper) DidDocumentsByTag(goCtx context.Context, req *types.QueryDidDocumentsByTagRequest) (*types.QueryDidDocumentsByTagResponse, error) {
+	if req == nil || req.Tag == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	docs, pageRes, err := k.PaginateIndexedDocuments(ctx, types.DIDTagIndexPrefix, req.Tag, req.Pagination)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryDidDocumentsByTagResponse{DidDocument: docs, Pagination: pageRes}, nil
+}
+
+// DidDocumentsByEnvironment returns a page of DID documents deployed to an
+// environment
+func (k Keeper) DidDocumentsByEnvironment(goCtx context.Context, req *types.QueryDidDocumentsByEnvironmentRequest) (*types.QueryDidDocumentsByEnvironmentResponse, error) {
+	if req == nil || req.Environment == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	docs, pageRes, err := k.PaginateIndexedDocuments(ctx, types.DIDEnvironmentIndexPrefix, req.Environment, req.Pagination)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryDidDocumentsByEnvironmentResponse{DidDocument: docs, Pagination: pageRes}, nil
+}
+
+// DidsByKey returns the DIDs and verification methods registered with a
+// public key, with the revocation and expiry status of each
+func (k Keeper) DidsByKey(goCtx context.Context, req *types.QueryDidsByKeyRequest) (*types.QueryDidsByKeyResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	fingerprint := req.Fingerprint
+	var err error
+	switch {
+	case fingerprint != "" && req.PublicKeyMultibase == "" && len(req.PublicKeyJwk) == 0:
+	case fingerprint == "" && req.PublicKeyMultibase != "" && len(req.PublicKeyJwk) == 0:
+		fingerprint, err = types.KeyFingerprintFromMultibase(req.PublicKeyMultibase)
+	case fingerprint == "" && req.PublicKeyMultibase == "" && len(req.PublicKeyJwk) > 0:
+		fingerprint, err = types.KeyFingerprintFromJwk(req.PublicKeyJwk)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "exactly one of fingerprint, public_key_multibase or public_key_jwk is required")
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	now := clock.Now(ctx)
+	var registrations []types.KeyRegistration
+	for _, ref := range k.GetKeyReferences(ctx, fingerprint) {
+		doc, found := k.GetDidDocument(ctx, ref.Did)
+		if !found {
+			continue
+		}
+		vm, found := doc.FindVerificationMethod(ref.VerificationMethodId)
+		if !found {
+			continue
+		}
+		registrations = append(registrations, types.KeyRegistration{
+			Did:                  ref.Did,
+			VerificationMethodId: ref.VerificationMethodId,
+			Relationships:        ref.Relationships,
+			Revoked:              vm.Revoked,
+			Expired:              vm.ExpiresAt != nil && !now.Before(*vm.ExpiresAt),
+			DidDeactivated:       doc.IsDeactivated(),
+		})
+	}
+
+	return &types.QueryDidsByKeyResponse{Fingerprint: fingerprint, Registrations: registrations}, nil
+}
+
+// DidHealth evaluates the health of a DID document without modifying it
+func (k Keeper) DidHealth(goCtx context.Context, req *types.QueryDidHealthRequest) (*types.QueryDidHealthResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	health, err := k.CheckHealth(ctx, req.Id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return &types.QueryDidHealthResponse{HealthCheck: health}, nil
+}
+
+// DIDHistory returns a page of the versions of a DID document in order
+func (k Keeper) DIDHistory(goCtx context.Context, req *types.QueryDIDHistoryRequest) (*types.QueryDIDHistoryResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	versions, pageRes, err := k.GetDocumentHistory(ctx, req.Id, req.Pagination)
+	if err != nil {
+		if errors.IsOf(err, types.ErrDIDNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryDIDHistoryResponse{Versions: versions, Pagination: pageRes}, nil
+}
+
+// DIDDiff returns the RFC 6902 JSON Patch between two versions of a DID
+// document
+func (k Keeper) DIDDiff(goCtx context.Context, req *types.QueryDIDDiffRequest) (*types.QueryDIDDiffResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	operations, err := k.GetDocumentDiff(ctx, req.Id, req.FromVersion, req.ToVersion)
+	if err != nil {
+		if errors.IsOf(err, types.ErrDIDNotFound, types.ErrVersionNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryDIDDiffResponse{Operations: operations}, nil
+}
+
+// StorageDeposit returns the storage deposit held for a DID. DIDs created
+// while the deposit was disabled, or already deactivated, hold none.
+func (k Keeper) StorageDeposit(goCtx context.Context, req *types.QueryStorageDepositRequest) (*types.QueryStorageDepositResponse, error) {
+	if req == nil || req.Did == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if !k.DidDocumentExists(ctx, req.Did) {
+		return nil, status.Error(codes.NotFound, "DID not found")
+	}
+
+	deposit, found := k.GetStorageDeposit(ctx, req.Did)
+	if !found {
+		deposit = types.StorageDeposit{Did: req.Did, Amount: sdk.NewCoins()}
+	}
+	return &types.QueryStorageDepositResponse{Deposit: deposit}, nil
+}
+
+// ResolveHandle returns the DID a handle is registered to. Expired handles
+// and handles of deactivated DIDs do not resolve.
+func (k Keeper) ResolveHandle(goCtx context.Context, req *types.QueryResolveHandleRequest) (*types.QueryResolveHandleResponse, error) {
+	if req == nil || req.Handle == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	handle, err := k.LookupHandle(ctx, req.Handle)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return &types.QueryResolveHandleResponse{Handle: handle}, nil
+}
+
+// PrimaryHandle returns the primary handle of a DID
+func (k Keeper) PrimaryHandle(goCtx context.Context, req *types.QueryPrimaryHandleRequest) (*types.QueryPrimaryHandleResponse, error) {
+	if req == nil || req.Did == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	handle, err := k.GetPrimaryHandle(ctx, req.Did)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return &types.QueryPrimaryHandleResponse{Handle: handle}, nil
+}
+
+// UpdatePolicy returns the threshold update policy of a DID
+func (k Keeper) UpdatePolicy(goCtx context.Context, req *types.QueryUpdatePolicyRequest) (*types.QueryUpdatePolicyResponse, error) {
+	if req == nil || req.Did == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	policy, found := k.GetUpdatePolicy(ctx, req.Did)
+	if !found {
+		return nil, status.Error(codes.NotFound, "update policy not found")
+	}
+
+	return &types.QueryUpdatePolicyResponse{Policy: policy}, nil
+}
+
+// PendingUpdates returns the pending updates of a DID
+func (k Keeper) PendingUpdates(goCtx context.Context, req *types.QueryPendingUpdatesRequest) (*types.QueryPendingUpdatesResponse, error) {
+	if req == nil || req.Did == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	return &types.QueryPendingUpdatesResponse{Updates: k.GetPendingUpdatesByDID(ctx, req.Did)}, nil
+}
+
+// Capability returns a delegated capability and its delegation chain
+func (k Keeper) Capability(goCtx context.Context, req *types.QueryCapabilityRequest) (*types.QueryCapabilityResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	capability, found := k.GetCapability(ctx, req.Id)
+	if !found {
+		return nil, status.Error(codes.NotFound, "capability not found")
+	}
+
+	var chain []types.Capability
+	for parent := capability.ParentCapability; parent != "" && len(chain) < types.MaxDelegationDepth; {
+		link, found := k.GetCapability(ctx, parent)
+		if !found {
+			break
+		}
+		chain = append(chain, link)
+		parent = link.ParentCapability
+	}
+
+	return &types.QueryCapabilityResponse{Capability: capability, Chain: chain}, nil
+}
+
+// Resource returns a DID-linked resource and its content
+func (k Keeper) Resource(goCtx context.Context, req *types.QueryResourceRequest) (*types.QueryResourceResponse, error) {
+	if req == nil || req.Did == "" || req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	resource, found := k.GetResource(ctx, req.Did, req.Id)
+	if !found {
+		return nil, status.Error(codes.NotFound, "resource not found")
+	}
+
+	return &types.QueryResourceResponse{Resource: resource}, nil
+}
+
+// Resources returns the metadata of the resources linked to a DID
+func (k Keeper) Resources(goCtx context.Context, req *types.QueryResourcesRequest) (*types.QueryResourcesResponse, error) {
+	if req == nil || req.Did == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	return &types.QueryResourcesResponse{Resources: k.GetResourcesByDID(ctx, req.Did)}, nil
+}
+
+// AuditLog returns the audit entries recorded for a DID
+func (k Keeper) AuditLog(goCtx context.Context, req *types.QueryAuditLogRequest) (*types.QueryAuditLogResponse, error) {
+	if req == nil || req.Did == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	entries, err := k.GetAuditLog(ctx, req.Did, int(req.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryAuditLogResponse{Entries: entries}, nil
+}
+
+// RemoteResolutions returns the cached results of resolving a DID on other
+// chains over IBC
+func (k Keeper) RemoteResolutions(goCtx context.Context, req *types.QueryRemoteResolutionsRequest) (*types.QueryRemoteResolutionsResponse, error) {
+	if req == nil || req.Did == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	return &types.QueryRemoteResolutionsResponse{Entries: k.GetRemoteDidDocuments(ctx, req.Did)}, nil
+}
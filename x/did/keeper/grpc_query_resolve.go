@@ -0,0 +1,149 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/persona-chain/persona-chain/internal/clock"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// Resolve implements W3C DID Resolution. Resolution failures are reported in
+// the didResolutionMetadata error field rather than as gRPC errors, as
+// required by the specification.
+func (k Keeper) Resolve(goCtx context.Context, req *types.QueryResolveRequest) (*types.QueryResolveResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	res := k.ResolveDID(ctx, req.Did, req.VersionId, req.VersionTime, req.Accept)
+	return &res, nil
+}
+
+// ResolveDID resolves a DID, optionally at a given versionId or versionTime,
+// into a document plus resolution and document metadata
+func (k Keeper) ResolveDID(ctx context.Context, did, versionID, versionTime, accept string) types.QueryResolveResponse {
+	res := types.QueryResolveResponse{
+		DidResolutionMetadata: types.DIDResolutionMetadata{
+			Retrieved: clock.Now(ctx).Format(time.RFC3339),
+		},
+	}
+
+	fail := func(code string, err error) types.QueryResolveResponse {
+		res.DidResolutionMetadata.Error = code
+		if err != nil {
+			res.DidResolutionMetadata.ErrorMessage = err.Error()
+		}
+		return res
+	}
+
+	switch accept {
+	case "", types.ContentTypeDIDLDJSON:
+		res.DidResolutionMetadata.ContentType = types.ContentTypeDIDLDJSON
+	case types.ContentTypeDIDJSON:
+		res.DidResolutionMetadata.ContentType = types.ContentTypeDIDJSON
+	default:
+		return fail(types.ResolutionErrorRepresentationNotSupported, nil)
+	}
+
+	if _, err := types.ParseDID(did); err != nil {
+		return fail(types.ResolutionErrorInvalidDID, err)
+	}
+
+	now := clock.Now(ctx)
+	current, found := k.GetDidDocument(ctx, did)
+	if !found {
+		// Fall back to documents resolved from other chains over IBC
+		remote, cached := k.cachedRemoteDocument(ctx, did, now)
+		if !cached {
+			return fail(types.ResolutionErrorNotFound, k.remoteResolutionStatus(ctx, did))
+		}
+		current = *remote.Document
+		res.DidResolutionMetadata.Remote = true
+		res.DidResolutionMetadata.SourceChannel = remote.ChannelId
+		res.DidResolutionMetadata.SourceChainId = remote.SourceChainId
+		res.DidResolutionMetadata.ProofHeight = remote.ProofHeight
+		res.DidResolutionMetadata.CacheExpires = formatResolutionTime(*remote.ExpiresAt)
+	}
+
+	doc, err := k.selectDocumentVersion(ctx, current, versionID, versionTime)
+	switch {
+	case errors.IsOf(err, types.ErrInvalidVersion):
+		return fail(types.ResolutionErrorInvalidVersion, err)
+	case errors.IsOf(err, types.ErrVersionNotFound):
+		return fail(types.ResolutionErrorVersionNotFound, err)
+	case err != nil:
+		return fail(types.ResolutionErrorInternal, err)
+	}
+
+	res.DidDocumentMetadata = types.DIDDocumentMetadata{
+		Created:     formatResolutionTime(current.CreatedAt),
+		Updated:     formatResolutionTime(doc.UpdatedAt),
+		Deactivated: current.IsDeactivated(),
+		VersionId:   strconv.FormatUint(doc.Version, 10),
+	}
+	if doc.Version < current.Version {
+		res.DidDocumentMetadata.NextVersionId = strconv.FormatUint(k.nextArchivedVersion(ctx, current, doc.Version), 10)
+	}
+	if doc.Metadata.NextUpdate != nil {
+		res.DidDocumentMetadata.NextUpdate = formatResolutionTime(*doc.Metadata.NextUpdate)
+	}
+
+	// A deactivated DID resolves to its metadata only
+	if res.DidDocumentMetadata.Deactivated && versionID == "" && versionTime == "" {
+		return fail(types.ResolutionErrorDeactivated, nil)
+	}
+
+	bz, err := json.Marshal(doc)
+	if err != nil {
+		return fail(types.ResolutionErrorInternal, err)
+	}
+	res.DidDocument = bz
+
+	return res
+}
+
+// nextArchivedVersion returns the version that superseded the given one.
+// Versions are not guaranteed to be contiguous, so the archive is consulted.
+func (k Keeper) nextArchivedVersion(ctx context.Context, current types.DIDDocument, version uint64) uint64 {
+	versions, err := k.GetDocumentVersions(ctx, current.ID)
+	if err != nil {
+		return current.Version
+	}
+	for _, v := range versions {
+		if v.Version > version {
+			return v.Version
+		}
+	}
+	return current.Version
+}
+
+func formatResolutionTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// remoteResolutionStatus describes why a DID that is not cached could not be
+// resolved from another chain, or returns nil if it was never requested
+func (k Keeper) remoteResolutionStatus(ctx context.Context, did string) error {
+	for _, remote := range k.GetRemoteDidDocuments(ctx, did) {
+		switch remote.Status {
+		case types.RemoteResolutionPending:
+			return fmt.Errorf("remote resolution over %s is pending", remote.ChannelId)
+		case types.RemoteResolutionFailed:
+			return fmt.Errorf("remote resolution over %s failed: %s", remote.ChannelId, remote.Error)
+		}
+	}
+	return nil
+}
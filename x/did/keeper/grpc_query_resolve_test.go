@@ -0,0 +1,140 @@
+package keeper_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestResolve(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	doc := types.DIDDocument{
+		Context:   []string{"https://www.w3.org/ns/did/v1"},
+		ID:        "did:persona:abc",
+		CreatedAt: t1,
+		Version:   1,
+	}
+	require.NoError(t, k.SetDidDocument(ctx.WithBlockTime(t1), doc))
+	doc.Version = 2
+	require.NoError(t, k.SetDidDocument(ctx.WithBlockTime(t2), doc))
+
+	deactivated := types.DIDDocument{
+		Context:   []string{"https://www.w3.org/ns/did/v1"},
+		ID:        "did:persona:gone",
+		CreatedAt: t1,
+		Version:   1,
+	}
+	require.NoError(t, k.SetDidDocument(ctx.WithBlockTime(t1), deactivated))
+	deactivated.Metadata.Deactivated = true
+	deactivated.Version = 2
+	require.NoError(t, k.SetDidDocument(ctx.WithBlockTime(t2), deactivated))
+
+	ctx = ctx.WithBlockTime(t2)
+
+	tests := []struct {
+		name          string
+		req           types.QueryResolveRequest
+		err           string
+		contentType   string
+		versionID     string
+		nextVersionID string
+	}{
+		{
+			name:        "current version",
+			req:         types.QueryResolveRequest{Did: "did:persona:abc"},
+			contentType: types.ContentTypeDIDLDJSON,
+			versionID:   "2",
+		},
+		{
+			name:        "did+json representation",
+			req:         types.QueryResolveRequest{Did: "did:persona:abc", Accept: types.ContentTypeDIDJSON},
+			contentType: types.ContentTypeDIDJSON,
+			versionID:   "2",
+		},
+		{
+			name:          "archived version",
+			req:           types.QueryResolveRequest{Did: "did:persona:abc", VersionId: "1"},
+			contentType:   types.ContentTypeDIDLDJSON,
+			versionID:     "1",
+			nextVersionID: "2",
+		},
+		{
+			name:          "version at time",
+			req:           types.QueryResolveRequest{Did: "did:persona:abc", VersionTime: "2024-01-01T00:30:00Z"},
+			contentType:   types.ContentTypeDIDLDJSON,
+			versionID:     "1",
+			nextVersionID: "2",
+		},
+		{
+			name:          "deactivated version requested explicitly",
+			req:           types.QueryResolveRequest{Did: "did:persona:gone", VersionId: "1"},
+			contentType:   types.ContentTypeDIDLDJSON,
+			versionID:     "1",
+			nextVersionID: "2",
+		},
+		{
+			name: "unsupported representation",
+			req:  types.QueryResolveRequest{Did: "did:persona:abc", Accept: "text/plain"},
+			err:  types.ResolutionErrorRepresentationNotSupported,
+		},
+		{
+			name: "invalid DID",
+			req:  types.QueryResolveRequest{Did: "persona:abc"},
+			err:  types.ResolutionErrorInvalidDID,
+		},
+		{
+			name: "unknown DID",
+			req:  types.QueryResolveRequest{Did: "did:persona:xyz"},
+			err:  types.ResolutionErrorNotFound,
+		},
+		{
+			name: "malformed versionId",
+			req:  types.QueryResolveRequest{Did: "did:persona:abc", VersionId: "latest"},
+			err:  types.ResolutionErrorInvalidVersion,
+		},
+		{
+			name: "unknown versionId",
+			req:  types.QueryResolveRequest{Did: "did:persona:abc", VersionId: "9"},
+			err:  types.ResolutionErrorVersionNotFound,
+		},
+		{
+			name: "deactivated",
+			req:  types.QueryResolveRequest{Did: "did:persona:gone"},
+			err:  types.ResolutionErrorDeactivated,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := tc.req
+			res, err := k.Resolve(ctx, &req)
+			require.NoError(t, err)
+			require.Equal(t, tc.err, res.DidResolutionMetadata.Error)
+			if tc.err != "" {
+				require.Empty(t, res.DidDocument)
+				return
+			}
+
+			require.Equal(t, tc.contentType, res.DidResolutionMetadata.ContentType)
+			require.Equal(t, tc.versionID, res.DidDocumentMetadata.VersionId)
+			require.Equal(t, tc.nextVersionID, res.DidDocumentMetadata.NextVersionId)
+			require.Equal(t, "2024-01-01T00:00:00Z", res.DidDocumentMetadata.Created)
+
+			var resolved types.DIDDocument
+			require.NoError(t, json.Unmarshal(res.DidDocument, &resolved))
+			require.Equal(t, tc.req.Did, resolved.ID)
+		})
+	}
+
+	_, err := k.Resolve(ctx, nil)
+	require.Error(t, err)
+}
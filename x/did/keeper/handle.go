@@ -0,0 +1,292 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/persona-chain/persona-chain/internal/clock"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func (k Keeper) handleStore(ctx context.Context, storePrefix string) prefix.Store {
+	storeAdapter := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	return prefix.NewStore(storeAdapter, types.KeyPrefix(storePrefix))
+}
+
+// GetHandle returns the registration of a handle, whether or not it is
+// still valid
+func (k Keeper) GetHandle(ctx context.Context, name string) (types.Handle, bool) {
+	b := k.handleStore(ctx, types.HandleKeyPrefix).Get([]byte(name))
+	if b == nil {
+		return types.Handle{}, false
+	}
+
+	var handle types.Handle
+	if err := k.cdc.Unmarshal(b, &handle); err != nil {
+		return types.Handle{}, false
+	}
+	return handle, true
+}
+
+func (k Keeper) setHandle(ctx context.Context, handle types.Handle) {
+	k.handleStore(ctx, types.HandleKeyPrefix).Set([]byte(handle.Name), k.cdc.MustMarshal(&handle))
+	k.handleStore(ctx, types.HandleDIDIndexPrefix).Set(types.DIDIndexKey(handle.Did, handle.Name), []byte{})
+}
+
+// removeHandle deletes a registration and, if it was the primary handle of
+// its DID, the reverse mapping
+func (k Keeper) removeHandle(ctx context.Context, handle types.Handle) {
+	k.handleStore(ctx, types.HandleKeyPrefix).Delete([]byte(handle.Name))
+	k.handleStore(ctx, types.HandleDIDIndexPrefix).Delete(types.DIDIndexKey(handle.Did, handle.Name))
+	if primary, found := k.getPrimaryHandleName(ctx, handle.Did); found && primary == handle.Name {
+		k.handleStore(ctx, types.PrimaryHandleKeyPrefix).Delete([]byte(handle.Did))
+	}
+}
+
+// GetHandleNames returns the names of every handle registered to a DID
+func (k Keeper) GetHandleNames(ctx context.Context, did string) []string {
+	iterator := storetypes.KVStorePrefixIterator(k.indexStore(ctx, types.HandleDIDIndexPrefix, did), []byte{})
+	defer iterator.Close()
+
+	var names []string
+	for ; iterator.Valid(); iterator.Next() {
+		names = append(names, string(iterator.Key()))
+	}
+	return names
+}
+
+func (k Keeper) getPrimaryHandleName(ctx context.Context, did string) (string, bool) {
+	b := k.handleStore(ctx, types.PrimaryHandleKeyPrefix).Get([]byte(did))
+	if b == nil {
+		return "", false
+	}
+	return string(b), true
+}
+
+func (k Keeper) setPrimaryHandleName(ctx context.Context, did, name string) {
+	k.handleStore(ctx, types.PrimaryHandleKeyPrefix).Set([]byte(did), []byte(name))
+}
+
+// isHandleValid reports whether a handle resolves: it has not expired and
+// its DID has not been deactivated
+func (k Keeper) isHandleValid(ctx context.Context, handle types.Handle) bool {
+	if handle.IsExpiredAt(clock.Now(ctx)) {
+		return false
+	}
+	doc, found := k.GetDidDocument(ctx, handle.Did)
+	return found && !doc.IsDeactivated()
+}
+
+// LookupHandle returns the valid registration of a handle
+func (k Keeper) LookupHandle(ctx context.Context, name string) (types.Handle, error) {
+	handle, found := k.GetHandle(ctx, name)
+	if !found {
+		return types.Handle{}, errors.Wrapf(types.ErrHandleNotFound, "%s", name)
+	}
+	if !k.isHandleValid(ctx, handle) {
+		return types.Handle{}, errors.Wrapf(types.ErrHandleExpired, "%s", name)
+	}
+	return handle, nil
+}
+
+// GetPrimaryHandle returns the valid primary handle of a DID
+func (k Keeper) GetPrimaryHandle(ctx context.Context, did string) (types.Handle, error) {
+	name, found := k.getPrimaryHandleName(ctx, did)
+	if !found {
+		return types.Handle{}, errors.Wrapf(types.ErrHandleNotFound, "DID %s has no primary handle", did)
+	}
+	return k.LookupHandle(ctx, name)
+}
+
+// authorizeHandleHolder checks that controllerAddr controls an active DID
+func (k Keeper) authorizeHandleHolder(ctx context.Context, did, controllerAddr string) error {
+	if err := k.ValidateControllerAuthorization(ctx, did, controllerAddr); err != nil {
+		return err
+	}
+	doc, _ := k.GetDidDocument(ctx, did)
+	if doc.IsDeactivated() {
+		return errors.Wrapf(types.ErrDIDDeactivated, "DID %s", did)
+	}
+	return nil
+}
+
+// chargeHandleFee burns the handle fee from the payer
+func (k Keeper) chargeHandleFee(ctx context.Context, payer string) error {
+	fee := k.GetParams(ctx).HandleFee
+	if fee.IsZero() {
+		return nil
+	}
+
+	payerAddr, err := sdk.AccAddressFromBech32(payer)
+	if err != nil {
+		return errors.Wrapf(types.ErrInvalidController, "invalid payer address (%s)", err)
+	}
+	fees := sdk.NewCoins(fee)
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, payerAddr, types.ModuleName, fees); err != nil {
+		return errors.Wrapf(err, "handle fee %s", fee)
+	}
+	return k.bankKeeper.BurnCoins(ctx, types.ModuleName, fees)
+}
+
+// RegisterHandle registers a handle to a DID for one handle period. A handle
+// that expired or whose DID was deactivated can be registered again. The
+// first handle of a DID becomes its primary handle.
+func (k Keeper) RegisterHandle(ctx context.Context, did, name, controllerAddr string) (types.Handle, error) {
+	if err := types.ValidateHandle(name); err != nil {
+		return types.Handle{}, err
+	}
+	params := k.GetParams(ctx)
+	if params.IsHandleReserved(name) {
+		return types.Handle{}, errors.Wrapf(types.ErrHandleReserved, "%s", name)
+	}
+	if err := k.authorizeHandleHolder(ctx, did, controllerAddr); err != nil {
+		return types.Handle{}, err
+	}
+
+	if existing, found := k.GetHandle(ctx, name); found {
+		if k.isHandleValid(ctx, existing) {
+			return types.Handle{}, errors.Wrapf(types.ErrHandleTaken, "%s is registered to %s", name, existing.Did)
+		}
+		k.removeHandle(ctx, existing)
+	}
+
+	if err := k.chargeHandleFee(ctx, controllerAddr); err != nil {
+		return types.Handle{}, err
+	}
+
+	now := clock.Now(ctx)
+	handle := types.Handle{
+		Name:         name,
+		Did:          did,
+		RegisteredAt: now,
+		ExpiresAt:    now.Add(params.HandlePeriod),
+	}
+	k.setHandle(ctx, handle)
+	if _, found := k.getPrimaryHandleName(ctx, did); !found {
+		k.setPrimaryHandleName(ctx, did, name)
+	}
+
+	if err := sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventHandleRegistered{
+		Handle:     name,
+		Did:        did,
+		Controller: controllerAddr,
+		ExpiresAt:  handle.ExpiresAt,
+	}); err != nil {
+		return types.Handle{}, err
+	}
+	return handle, nil
+}
+
+// RenewHandle extends a registration by one handle period. An expired
+// handle can be renewed until someone else registers it.
+func (k Keeper) RenewHandle(ctx context.Context, name, controllerAddr string) (types.Handle, error) {
+	handle, found := k.GetHandle(ctx, name)
+	if !found {
+		return types.Handle{}, errors.Wrapf(types.ErrHandleNotFound, "%s", name)
+	}
+	if err := k.authorizeHandleHolder(ctx, handle.Did, controllerAddr); err != nil {
+		return types.Handle{}, err
+	}
+	if err := k.chargeHandleFee(ctx, controllerAddr); err != nil {
+		return types.Handle{}, err
+	}
+
+	start := clock.Now(ctx)
+	if handle.ExpiresAt.After(start) {
+		start = handle.ExpiresAt
+	}
+	handle.ExpiresAt = start.Add(k.GetParams(ctx).HandlePeriod)
+	k.setHandle(ctx, handle)
+
+	if err := sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventHandleRenewed{
+		Handle:    name,
+		Did:       handle.Did,
+		ExpiresAt: handle.ExpiresAt,
+	}); err != nil {
+		return types.Handle{}, err
+	}
+	return handle, nil
+}
+
+// TransferHandle moves a valid handle to another active DID. The handle
+// keeps its expiry and becomes the primary handle of the new DID if it has
+// none.
+func (k Keeper) TransferHandle(ctx context.Context, name, newDid, controllerAddr string) error {
+	handle, err := k.LookupHandle(ctx, name)
+	if err != nil {
+		return err
+	}
+	if err := k.ValidateControllerAuthorization(ctx, handle.Did, controllerAddr); err != nil {
+		return err
+	}
+	if newDid == handle.Did {
+		return errors.Wrapf(types.ErrInvalidHandle, "%s is already registered to %s", name, newDid)
+	}
+	target, found := k.GetDidDocument(ctx, newDid)
+	if !found {
+		return errors.Wrapf(types.ErrDIDNotFound, "DID %s", newDid)
+	}
+	if target.IsDeactivated() {
+		return errors.Wrapf(types.ErrDIDDeactivated, "DID %s", newDid)
+	}
+
+	previous := handle.Did
+	k.removeHandle(ctx, handle)
+	handle.Did = newDid
+	k.setHandle(ctx, handle)
+	if _, found := k.getPrimaryHandleName(ctx, newDid); !found {
+		k.setPrimaryHandleName(ctx, newDid, name)
+	}
+
+	return sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventHandleTransferred{
+		Handle:      name,
+		PreviousDid: previous,
+		Did:         newDid,
+		Controller:  controllerAddr,
+	})
+}
+
+// SetPrimaryHandle makes one of a DID's valid handles its primary handle
+func (k Keeper) SetPrimaryHandle(ctx context.Context, did, name, controllerAddr string) error {
+	if err := k.authorizeHandleHolder(ctx, did, controllerAddr); err != nil {
+		return err
+	}
+	handle, err := k.LookupHandle(ctx, name)
+	if err != nil {
+		return err
+	}
+	if handle.Did != did {
+		return errors.Wrapf(types.ErrUnauthorized, "%s is not registered to %s", name, did)
+	}
+	k.setPrimaryHandleName(ctx, did, name)
+
+	return sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventPrimaryHandleSet{
+		Handle: name,
+		Did:    did,
+	})
+}
+
+// releaseHandles deletes every handle of a DID. SetDidDocument calls it when
+// the DID is deactivated so its names can be registered again.
+func (k Keeper) releaseHandles(ctx context.Context, did string) error {
+	for _, name := range k.GetHandleNames(ctx, did) {
+		handle, found := k.GetHandle(ctx, name)
+		if !found {
+			continue
+		}
+		k.removeHandle(ctx, handle)
+
+		if err := sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventHandleReleased{
+			Handle: name,
+			Did:    did,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
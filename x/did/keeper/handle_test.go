@@ -0,0 +1,312 @@
+package keeper_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// handleKeeper returns a keeper with a one-day handle period, a fee of ten
+// stake and admin.persona reserved
+func handleKeeper(t *testing.T, start time.Time) (keeper.Keeper, sdk.Context, *keepertest.MockBankKeeper) {
+	k, ctx, bank := keepertest.DidKeeperWithBank(t)
+	ctx = ctx.WithBlockTime(start)
+	params := types.DefaultParams()
+	params.HandlePeriod = 24 * time.Hour
+	params.HandleFee = sdk.NewInt64Coin(sdk.DefaultBondDenom, 10)
+	params.ReservedHandles = []string{"admin.persona"}
+	require.NoError(t, k.SetParams(ctx, params))
+	return k, ctx, bank
+}
+
+func TestRegisterHandle(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0).UTC()
+	alice, bob, carol := testAddress("alice"), testAddress("bob"), testAddress("carol")
+	const aliceDID, bobDID, carolDID, goneDID = "did:persona:alice", "did:persona:bob", "did:persona:carol", "did:persona:gone"
+
+	k, ctx, bank := handleKeeper(t, start)
+	for _, addr := range []string{alice, bob} {
+		bank.Fund(sdk.MustAccAddressFromBech32(addr), stake(1_000))
+	}
+	require.NoError(t, k.SetDidDocument(ctx, newTestDocument(aliceDID, alice)))
+	require.NoError(t, k.SetDidDocument(ctx, newTestDocument(bobDID, bob)))
+	require.NoError(t, k.SetDidDocument(ctx, newTestDocument(carolDID, carol)))
+	gone := newTestDocument(goneDID, alice)
+	gone.Metadata.Deactivated = true
+	require.NoError(t, k.SetDidDocument(ctx, gone))
+
+	_, err := k.RegisterHandle(ctx.WithBlockTime(start.Add(-2*24*time.Hour)), bobDID, "lapsed.persona", bob)
+	require.NoError(t, err)
+	_, err = k.RegisterHandle(ctx, bobDID, "taken.persona", bob)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		did        string
+		handle     string
+		controller string
+		err        error
+	}{
+		{name: "first handle", did: aliceDID, handle: "alice.persona", controller: alice},
+		{name: "lapsed registration reclaimed", did: aliceDID, handle: "lapsed.persona", controller: alice},
+		{name: "invalid name", did: aliceDID, handle: "Alice.persona", controller: alice, err: types.ErrInvalidHandle},
+		{name: "reserved", did: aliceDID, handle: "admin.persona", controller: alice, err: types.ErrHandleReserved},
+		{name: "taken", did: aliceDID, handle: "taken.persona", controller: alice, err: types.ErrHandleTaken},
+		{name: "not a controller", did: aliceDID, handle: "alice.persona", controller: bob, err: types.ErrUnauthorized},
+		{name: "unknown DID", did: "did:persona:missing", handle: "alice.persona", controller: alice, err: types.ErrDIDNotFound},
+		{name: "deactivated DID", did: goneDID, handle: "gone.persona", controller: alice, err: types.ErrDIDDeactivated},
+		{name: "fee not paid", did: carolDID, handle: "carol.persona", controller: carol, err: sdkerrors.ErrInsufficientFunds},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			burned := bank.Burned
+
+			handle, err := k.RegisterHandle(cacheCtx, tc.did, tc.handle, tc.controller)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, start.Add(24*time.Hour), handle.ExpiresAt)
+			require.Equal(t, stake(10), bank.Burned.Sub(burned...))
+
+			resolved, err := k.LookupHandle(cacheCtx, tc.handle)
+			require.NoError(t, err)
+			require.Equal(t, tc.did, resolved.Did)
+			primary, err := k.GetPrimaryHandle(cacheCtx, tc.did)
+			require.NoError(t, err)
+			require.Equal(t, tc.handle, primary.Name)
+			require.NotContains(t, k.GetHandleNames(cacheCtx, bobDID), tc.handle)
+		})
+	}
+}
+
+func TestHandleLifecycle(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0).UTC()
+	day := 24 * time.Hour
+	alice, bob := testAddress("alice"), testAddress("bob")
+	const aliceDID, bobDID = "did:persona:alice", "did:persona:bob"
+
+	// step is one handle operation at start+offset. primary is the primary
+	// handle of each DID afterwards, "" when it has none.
+	type step struct {
+		offset  time.Duration
+		do      func(ctx sdk.Context, k keeper.Keeper) error
+		err     error
+		primary map[string]string
+	}
+
+	register := func(did, name, controller string) func(sdk.Context, keeper.Keeper) error {
+		return func(ctx sdk.Context, k keeper.Keeper) error {
+			_, err := k.RegisterHandle(ctx, did, name, controller)
+			return err
+		}
+	}
+	renew := func(name, controller string, expiresAt time.Time) func(sdk.Context, keeper.Keeper) error {
+		return func(ctx sdk.Context, k keeper.Keeper) error {
+			handle, err := k.RenewHandle(ctx, name, controller)
+			if err == nil && !handle.ExpiresAt.Equal(expiresAt) {
+				return fmt.Errorf("%s renewed until %s, want %s", name, handle.ExpiresAt, expiresAt)
+			}
+			return err
+		}
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "primary handle follows the first registration until changed",
+			steps: []step{
+				{do: register(aliceDID, "alice.persona", alice), primary: map[string]string{aliceDID: "alice.persona"}},
+				{do: register(aliceDID, "alice-work.persona", alice), primary: map[string]string{aliceDID: "alice.persona"}},
+				{
+					do: func(ctx sdk.Context, k keeper.Keeper) error {
+						return k.SetPrimaryHandle(ctx, aliceDID, "alice-work.persona", alice)
+					},
+					primary: map[string]string{aliceDID: "alice-work.persona"},
+				},
+				{
+					do: func(ctx sdk.Context, k keeper.Keeper) error {
+						return k.SetPrimaryHandle(ctx, bobDID, "alice.persona", bob)
+					},
+					err: types.ErrUnauthorized,
+				},
+			},
+		},
+		{
+			name: "renewal extends from the expiry while valid and from now once lapsed",
+			steps: []step{
+				{do: register(aliceDID, "alice.persona", alice)},
+				{offset: day / 2, do: renew("alice.persona", alice, start.Add(2*day))},
+				{offset: 3 * day, do: renew("alice.persona", alice, start.Add(4*day)), primary: map[string]string{aliceDID: "alice.persona"}},
+				{offset: 3 * day, do: renew("alice.persona", bob, time.Time{}), err: types.ErrUnauthorized},
+				{offset: 3 * day, do: renew("bob.persona", bob, time.Time{}), err: types.ErrHandleNotFound},
+			},
+		},
+		{
+			name: "lapsed handle stops resolving",
+			steps: []step{
+				{do: register(aliceDID, "alice.persona", alice)},
+				{
+					offset: day,
+					do: func(ctx sdk.Context, k keeper.Keeper) error {
+						_, err := k.GetPrimaryHandle(ctx, aliceDID)
+						return err
+					},
+					err: types.ErrHandleExpired,
+				},
+				{
+					offset: day,
+					do: func(ctx sdk.Context, k keeper.Keeper) error {
+						return k.SetPrimaryHandle(ctx, aliceDID, "alice.persona", alice)
+					},
+					err: types.ErrHandleExpired,
+				},
+			},
+		},
+		{
+			name: "transfer moves the handle and its primary status",
+			steps: []step{
+				{do: register(aliceDID, "alice.persona", alice)},
+				{do: register(aliceDID, "shared.persona", alice)},
+				{
+					do: func(ctx sdk.Context, k keeper.Keeper) error {
+						return k.TransferHandle(ctx, "shared.persona", bobDID, alice)
+					},
+					primary: map[string]string{aliceDID: "alice.persona", bobDID: "shared.persona"},
+				},
+				{
+					do: func(ctx sdk.Context, k keeper.Keeper) error {
+						return k.TransferHandle(ctx, "alice.persona", bobDID, alice)
+					},
+					primary: map[string]string{aliceDID: "", bobDID: "shared.persona"},
+				},
+				{
+					do: func(ctx sdk.Context, k keeper.Keeper) error {
+						return k.TransferHandle(ctx, "alice.persona", "did:persona:missing", bob)
+					},
+					err: types.ErrDIDNotFound,
+				},
+				{
+					do: func(ctx sdk.Context, k keeper.Keeper) error {
+						return k.TransferHandle(ctx, "alice.persona", bobDID, bob)
+					},
+					err: types.ErrInvalidHandle,
+				},
+			},
+		},
+		{
+			name: "deactivation releases every handle",
+			steps: []step{
+				{do: register(aliceDID, "alice.persona", alice)},
+				{do: register(aliceDID, "alice-work.persona", alice)},
+				{
+					do: func(ctx sdk.Context, k keeper.Keeper) error {
+						doc, _ := k.GetDidDocument(ctx, aliceDID)
+						doc.Version++
+						doc.Metadata.Deactivated = true
+						return k.SetDidDocument(ctx, doc)
+					},
+					primary: map[string]string{aliceDID: ""},
+				},
+				{do: register(bobDID, "alice.persona", bob), primary: map[string]string{bobDID: "alice.persona"}},
+			},
+		},
+		{
+			name: "revocation releases every handle",
+			steps: []step{
+				{do: register(aliceDID, "alice.persona", alice)},
+				{
+					do: func(ctx sdk.Context, k keeper.Keeper) error {
+						doc, _ := k.GetDidDocument(ctx, aliceDID)
+						doc.Version++
+						doc.Status.State = types.DIDStateRevoked
+						return k.SetDidDocument(ctx, doc)
+					},
+					primary: map[string]string{aliceDID: ""},
+				},
+				{do: register(bobDID, "alice.persona", bob), primary: map[string]string{bobDID: "alice.persona"}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k, ctx, bank := handleKeeper(t, start)
+			for _, addr := range []string{alice, bob} {
+				bank.Fund(sdk.MustAccAddressFromBech32(addr), stake(1_000))
+			}
+			require.NoError(t, k.SetDidDocument(ctx, newTestDocument(aliceDID, alice)))
+			require.NoError(t, k.SetDidDocument(ctx, newTestDocument(bobDID, bob)))
+
+			for i, s := range tc.steps {
+				stepCtx := ctx.WithBlockTime(start.Add(s.offset))
+				err := s.do(stepCtx, k)
+				if s.err != nil {
+					require.ErrorIs(t, err, s.err, "step %d", i)
+					continue
+				}
+				require.NoError(t, err, "step %d", i)
+
+				for did, want := range s.primary {
+					primary, err := k.GetPrimaryHandle(stepCtx, did)
+					if want == "" {
+						require.ErrorIs(t, err, types.ErrHandleNotFound, "step %d", i)
+						continue
+					}
+					require.NoError(t, err, "step %d", i)
+					require.Equal(t, want, primary.Name, "step %d", i)
+					require.Contains(t, k.GetHandleNames(stepCtx, did), want)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveHandleQuery(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0).UTC()
+	alice := testAddress("alice")
+	k, ctx, bank := handleKeeper(t, start)
+	bank.Fund(sdk.MustAccAddressFromBech32(alice), stake(1_000))
+	require.NoError(t, k.SetDidDocument(ctx, newTestDocument("did:persona:alice", alice)))
+	_, err := k.RegisterHandle(ctx, "did:persona:alice", "alice.persona", alice)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		req    *types.QueryResolveHandleRequest
+		offset time.Duration
+		code   codes.Code
+	}{
+		{name: "registered", req: &types.QueryResolveHandleRequest{Handle: "alice.persona"}},
+		{name: "lapsed", req: &types.QueryResolveHandleRequest{Handle: "alice.persona"}, offset: 24 * time.Hour, code: codes.NotFound},
+		{name: "unknown", req: &types.QueryResolveHandleRequest{Handle: "bob.persona"}, code: codes.NotFound},
+		{name: "empty request", req: &types.QueryResolveHandleRequest{}, code: codes.InvalidArgument},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := k.ResolveHandle(ctx.WithBlockTime(start.Add(tc.offset)), tc.req)
+			if tc.code != codes.OK {
+				require.Equal(t, tc.code, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "did:persona:alice", res.Handle.Did)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package keeper_test
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secp256k1ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/require"
+
+	"github.com/persona-chain/persona-chain/x/did/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+const testChainID = "persona-test-1"
+
+// testKey is a verification method together with a function producing
+// signatures with its private key. sign is nil for keys that cannot sign.
+type testKey struct {
+	vm   types.VerificationMethod
+	sign func(msg []byte) []byte
+}
+
+// proof returns the proof of possession of the key for the given version of did
+func (k testKey) proof(did string, version uint64) types.KeyProof {
+	challenge := types.PossessionChallenge(did, k.vm.ID, testChainID, version)
+	return types.KeyProof{VerificationMethodId: k.vm.ID, Signature: k.sign(challenge)}
+}
+
+func newEd25519Key(t testing.TB, did, fragment string) testKey {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return testKey{
+		vm: types.VerificationMethod{
+			ID:                 did + "#" + fragment,
+			Type:               types.VerificationMethodTypeEd25519VerificationKey2020,
+			Controller:         did,
+			PublicKeyMultibase: types.EncodeMultibaseMultikey(types.MulticodecEd25519Pub, pub),
+		},
+		sign: func(msg []byte) []byte { return ed25519.Sign(priv, msg) },
+	}
+}
+
+func newSecp256k1Key(t testing.TB, did, fragment string) testKey {
+	priv, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	return testKey{
+		vm: types.VerificationMethod{
+			ID:                 did + "#" + fragment,
+			Type:               types.VerificationMethodTypeMultikey,
+			Controller:         did,
+			PublicKeyMultibase: types.EncodeMultibaseMultikey(types.MulticodecSecp256k1Pub, priv.PubKey().SerializeCompressed()),
+		},
+		sign: func(msg []byte) []byte {
+			hash := sha256.Sum256(msg)
+			// Compact signatures are recovery byte || r || s in low-S form
+			return secp256k1ecdsa.SignCompact(priv, hash[:], true)[1:]
+		},
+	}
+}
+
+func newP256Key(t testing.TB, did, fragment string) testKey {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return testKey{
+		vm: types.VerificationMethod{
+			ID:                 did + "#" + fragment,
+			Type:               types.VerificationMethodTypeMultikey,
+			Controller:         did,
+			PublicKeyMultibase: types.EncodeMultibaseMultikey(types.MulticodecP256Pub, elliptic.MarshalCompressed(elliptic.P256(), priv.X, priv.Y)),
+		},
+		sign: func(msg []byte) []byte {
+			hash := sha256.Sum256(msg)
+			r, s, err := ecdsa.Sign(rand.Reader, priv, hash[:])
+			require.NoError(t, err)
+			return append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+		},
+	}
+}
+
+func newX25519Key(t testing.TB, did, fragment string) testKey {
+	priv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return testKey{
+		vm: types.VerificationMethod{
+			ID:                 did + "#" + fragment,
+			Type:               types.VerificationMethodTypeMultikey,
+			Controller:         did,
+			PublicKeyMultibase: types.EncodeMultibaseMultikey(types.MulticodecX25519Pub, priv.PublicKey().Bytes()),
+		},
+	}
+}
+
+// testAddress returns a deterministic account address for name
+func testAddress(name string) string {
+	addr := make([]byte, 20)
+	copy(addr, name)
+	return sdk.AccAddress(addr).String()
+}
+
+// newTestDocument returns a valid document for did authenticated by keys
+func newTestDocument(did, creator string, keys ...testKey) types.DIDDocument {
+	doc := types.DIDDocument{
+		Context: []string{"https://www.w3.org/ns/did/v1"},
+		ID:      did,
+		Creator: creator,
+		Version: 1,
+	}
+	for _, key := range keys {
+		doc.VerificationMethod = append(doc.VerificationMethod, key.vm)
+		doc.Authentication = append(doc.Authentication, key.vm.ID)
+	}
+	return doc
+}
+
+// createTestDID creates did through MsgCreateDIDDocument with keys as its
+// authentication keys
+func createTestDID(t testing.TB, ctx sdk.Context, k keeper.Keeper, did, creator string, keys ...testKey) types.DIDDocument {
+	doc := newTestDocument(did, creator, keys...)
+	var proofs []types.KeyProof
+	for _, key := range keys {
+		proofs = append(proofs, key.proof(did, 1))
+	}
+	_, err := keeper.NewMsgServerImpl(k).CreateDIDDocument(ctx, &types.MsgCreateDIDDocument{
+		Creator:     creator,
+		DidDocument: doc,
+		KeyProofs:   proofs,
+	})
+	require.NoError(t, err)
+
+	stored, found := k.GetDidDocument(ctx, did)
+	require.True(t, found)
+	return stored
+}
+
+// countEvents returns how many typed events of the same kind as event were
+// emitted on ctx
+func countEvents(ctx sdk.Context, event proto.Message) int {
+	name := proto.MessageName(event)
+	count := 0
+	for _, e := range ctx.EventManager().Events() {
+		if e.Type == name {
+			count++
+		}
+	}
+	return count
+}
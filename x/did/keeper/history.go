@@ -0,0 +1,78 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+	"github.com/cosmos/cosmos-sdk/runtime"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// GetDocumentHistory returns a page of the versions of a DID document in
+// ascending order. The current version is not archived and is added after
+// the last archived version, or before the first one in reverse order.
+func (k Keeper) GetDocumentHistory(ctx context.Context, id string, pageReq *query.PageRequest) ([]types.DIDVersionInfo, *query.PageResponse, error) {
+	current, found := k.GetDidDocument(ctx, id)
+	if !found {
+		return nil, nil, errors.Wrapf(types.ErrDIDNotFound, "DID %s not found", id)
+	}
+
+	storeAdapter := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	store := prefix.NewStore(storeAdapter, append(types.KeyPrefix(types.DIDVersionKeyPrefix), types.DIDVersionPrefix(id)...))
+
+	var versions []types.DIDVersionInfo
+	pageRes, err := query.FilteredPaginate(store, pageReq, func(key []byte, value []byte, accumulate bool) (bool, error) {
+		// Skip versions of other DIDs that extend this one's prefix
+		if len(key) != 8 {
+			return false, nil
+		}
+		if accumulate {
+			var doc types.DIDDocument
+			if err := k.cdc.Unmarshal(value, &doc); err != nil {
+				return false, err
+			}
+			versions = append(versions, types.NewDIDVersionInfo(doc))
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	currentInfo := types.NewDIDVersionInfo(current)
+	if pageReq != nil && pageReq.Reverse {
+		if len(pageReq.Key) == 0 && pageReq.Offset == 0 {
+			versions = append([]types.DIDVersionInfo{currentInfo}, versions...)
+		}
+	} else if len(pageRes.NextKey) == 0 {
+		versions = append(versions, currentInfo)
+	}
+	if pageRes.Total > 0 {
+		pageRes.Total++
+	}
+
+	return versions, pageRes, nil
+}
+
+// GetDocumentDiff returns the JSON Patch between two versions of a DID
+// document
+func (k Keeper) GetDocumentDiff(ctx context.Context, id string, fromVersion, toVersion uint64) ([]types.JSONPatchOperation, error) {
+	current, found := k.GetDidDocument(ctx, id)
+	if !found {
+		return nil, errors.Wrapf(types.ErrDIDNotFound, "DID %s not found", id)
+	}
+
+	from, err := k.documentAtVersion(ctx, current, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+	to, err := k.documentAtVersion(ctx, current, toVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.DiffDocuments(from, to)
+}
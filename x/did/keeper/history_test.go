@@ -0,0 +1,131 @@
+package keeper_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func versionNumbers(versions []types.DIDVersionInfo) []uint64 {
+	numbers := make([]uint64, 0, len(versions))
+	for _, v := range versions {
+		numbers = append(numbers, v.Version)
+	}
+	return numbers
+}
+
+func TestDIDHistory(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	start := time.Unix(1_700_000_000, 0).UTC()
+	alice, bob := testAddress("alice"), testAddress("bob")
+
+	// Three versions of abc, and of a DID whose version keys extend abc's prefix
+	for _, did := range []string{"did:persona:abc", "did:persona:abc-x"} {
+		doc := newTestDocument(did, alice)
+		for version := uint64(1); version <= 3; version++ {
+			doc.Version = version
+			doc.UpdatedBy = alice
+			if version == 2 {
+				doc.UpdatedBy = bob
+			}
+			txCtx := ctx.WithBlockTime(start.Add(time.Duration(version) * time.Hour)).WithTxBytes([]byte(fmt.Sprintf("tx-%d", version)))
+			require.NoError(t, k.SetDidDocument(txCtx, doc))
+		}
+	}
+
+	tests := []struct {
+		name     string
+		page     *query.PageRequest
+		versions []uint64
+		next     bool
+		total    uint64
+	}{
+		{name: "all", versions: []uint64{1, 2, 3}, total: 3},
+		{name: "first page", page: &query.PageRequest{Limit: 1, CountTotal: true}, versions: []uint64{1}, next: true, total: 3},
+		{name: "offset past the archive", page: &query.PageRequest{Offset: 2, Limit: 2}, versions: []uint64{3}},
+		{name: "reverse", page: &query.PageRequest{Reverse: true}, versions: []uint64{3, 2, 1}, total: 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := k.DIDHistory(ctx, &types.QueryDIDHistoryRequest{Id: "did:persona:abc", Pagination: tc.page})
+			require.NoError(t, err)
+			require.Equal(t, tc.versions, versionNumbers(res.Versions))
+			require.Equal(t, tc.next, len(res.Pagination.NextKey) > 0)
+			require.Equal(t, tc.total, res.Pagination.Total)
+
+			if tc.next {
+				rest, err := k.DIDHistory(ctx, &types.QueryDIDHistoryRequest{
+					Id:         "did:persona:abc",
+					Pagination: &query.PageRequest{Key: res.Pagination.NextKey, Limit: tc.page.Limit},
+				})
+				require.NoError(t, err)
+				require.Equal(t, []uint64{2, 3}, versionNumbers(rest.Versions))
+			}
+		})
+	}
+
+	res, err := k.DIDHistory(ctx, &types.QueryDIDHistoryRequest{Id: "did:persona:abc"})
+	require.NoError(t, err)
+	second := res.Versions[1]
+	require.Equal(t, bob, second.Actor)
+	require.Equal(t, start.Add(2*time.Hour), second.UpdatedAt)
+	require.Equal(t, fmt.Sprintf("%X", sha256.Sum256([]byte("tx-2"))), second.TxHash)
+
+	_, err = k.DIDHistory(ctx, &types.QueryDIDHistoryRequest{Id: "did:persona:missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = k.DIDHistory(ctx, nil)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestDIDDiff(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	const did = "did:persona:abc"
+
+	doc := newTestDocument(did, testAddress("alice"))
+	doc.Service = []types.Service{{ID: "#hub", Type: "Hub", ServiceEndpoint: "https://a.example"}}
+	require.NoError(t, k.SetDidDocument(ctx, doc))
+	doc.Version = 2
+	doc.Service[0].ServiceEndpoint = "https://b.example"
+	require.NoError(t, k.SetDidDocument(ctx, doc))
+
+	tests := []struct {
+		name     string
+		from, to uint64
+		ops      []types.JSONPatchOperation
+		code     codes.Code
+	}{
+		{
+			name: "archived to current",
+			from: 1,
+			to:   2,
+			ops: []types.JSONPatchOperation{
+				{Op: types.JSONPatchReplace, Path: "/service/0/serviceEndpoint", Value: `"https://b.example"`},
+				{Op: types.JSONPatchReplace, Path: "/version", Value: "2"},
+			},
+		},
+		{name: "same version", from: 2, to: 2, ops: []types.JSONPatchOperation{}},
+		{name: "unknown version", from: 1, to: 5, code: codes.NotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := k.DIDDiff(ctx, &types.QueryDIDDiffRequest{Id: did, FromVersion: tc.from, ToVersion: tc.to})
+			if tc.code != codes.OK {
+				require.Equal(t, tc.code, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.ops, res.Operations)
+		})
+	}
+}
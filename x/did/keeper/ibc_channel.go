@@ -0,0 +1,24 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// GetChannelVersion returns the DID protocol version negotiated for an IBC
+// channel. Channels without a recorded version were opened before versions
+// were negotiated and speak did-1.
+func (k Keeper) GetChannelVersion(ctx context.Context, channelID string) string {
+	b := k.policyStore(ctx, types.ChannelVersionKeyPrefix).Get([]byte(channelID))
+	if b == nil {
+		return types.IBCVersion1
+	}
+	return string(b)
+}
+
+// SetChannelVersion records the DID protocol version negotiated for an IBC
+// channel
+func (k Keeper) SetChannelVersion(ctx context.Context, channelID, version string) {
+	k.policyStore(ctx, types.ChannelVersionKeyPrefix).Set([]byte(channelID), []byte(version))
+}
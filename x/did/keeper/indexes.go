@@ -0,0 +1,121 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/runtime"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// indexEntry is one secondary index entry of a document
+type indexEntry struct {
+	prefix string
+	value  string
+}
+
+// documentIndexEntries returns every index entry a document should have.
+// The creator is indexed as a controller since it may always act on the
+// document.
+func documentIndexEntries(doc types.DIDDocument) []indexEntry {
+	var entries []indexEntry
+	seen := make(map[indexEntry]bool)
+	add := func(prefix, value string) {
+		e := indexEntry{prefix: prefix, value: value}
+		if value == "" || seen[e] {
+			return
+		}
+		seen[e] = true
+		entries = append(entries, e)
+	}
+
+	add(types.DIDControllerIndexPrefix, doc.Creator)
+	for _, controller := range doc.Controller {
+		add(types.DIDControllerIndexPrefix, controller)
+	}
+	add(types.DIDOrganizationIndexPrefix, doc.Metadata.OrganizationID)
+	add(types.DIDStateIndexPrefix, string(doc.Status.State))
+	for _, tag := range doc.Metadata.Tags {
+		add(types.DIDTagIndexPrefix, tag)
+	}
+	add(types.DIDEnvironmentIndexPrefix, doc.Metadata.Environment)
+
+	return entries
+}
+
+// updateDocumentIndexes replaces the index entries of previous, if any, with
+// those of doc, including the key index and the expiry and suspension queues
+func (k Keeper) updateDocumentIndexes(ctx context.Context, previous *types.DIDDocument, doc types.DIDDocument) {
+	if previous != nil {
+		k.removeDocumentIndexes(ctx, *previous)
+	}
+	k.setKeyIndex(ctx, doc)
+	k.setExpiryQueue(ctx, doc)
+	k.setSuspensionQueue(ctx, doc)
+
+	storeAdapter := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	for _, e := range documentIndexEntries(doc) {
+		store := prefix.NewStore(storeAdapter, types.KeyPrefix(e.prefix))
+		store.Set(types.DIDIndexKey(e.value, doc.ID), []byte{})
+	}
+}
+
+// removeDocumentIndexes deletes every index entry of a document
+func (k Keeper) removeDocumentIndexes(ctx context.Context, doc types.DIDDocument) {
+	k.removeKeyIndex(ctx, doc)
+	k.removeExpiryQueue(ctx, doc)
+	k.removeSuspensionQueue(ctx, doc)
+
+	storeAdapter := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	for _, e := range documentIndexEntries(doc) {
+		store := prefix.NewStore(storeAdapter, types.KeyPrefix(e.prefix))
+		store.Delete(types.DIDIndexKey(e.value, doc.ID))
+	}
+}
+
+// indexStore returns the store of all index entries for a value
+func (k Keeper) indexStore(ctx context.Context, indexPrefix, value string) prefix.Store {
+	storeAdapter := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	return prefix.NewStore(storeAdapter, append(types.KeyPrefix(indexPrefix), types.DIDIndexValuePrefix(value)...))
+}
+
+// PaginateIndexedDocuments returns a page of the documents indexed under a
+// value
+func (k Keeper) PaginateIndexedDocuments(ctx context.Context, indexPrefix, value string, pageReq *query.PageRequest) ([]types.DIDDocument, *query.PageResponse, error) {
+	storeAdapter := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	docStore := prefix.NewStore(storeAdapter, types.KeyPrefix(types.DIDDocumentKeyPrefix))
+
+	var docs []types.DIDDocument
+	pageRes, err := query.Paginate(k.indexStore(ctx, indexPrefix, value), pageReq, func(key []byte, _ []byte) error {
+		bz := docStore.Get(types.DIDDocumentKey(string(key)))
+		if bz == nil {
+			return nil
+		}
+		var doc types.DIDDocument
+		if err := k.cdc.Unmarshal(bz, &doc); err != nil {
+			return err
+		}
+		docs = append(docs, doc)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return docs, pageRes, nil
+}
+
+// GetIndexedDIDs returns the IDs of all documents indexed under a value
+func (k Keeper) GetIndexedDIDs(ctx context.Context, indexPrefix, value string) []string {
+	iterator := storetypes.KVStorePrefixIterator(k.indexStore(ctx, indexPrefix, value), []byte{})
+	defer iterator.Close()
+
+	var ids []string
+	for ; iterator.Valid(); iterator.Next() {
+		ids = append(ids, string(iterator.Key()))
+	}
+	return ids
+}
@@ -0,0 +1,183 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func documentIDs(docs []types.DIDDocument) []string {
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids
+}
+
+func TestDocumentIndexes(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	alice, bob := testAddress("alice"), testAddress("bob")
+
+	indexed := func(did, creator, org, env string, state types.DIDState, tags ...string) types.DIDDocument {
+		doc := newTestDocument(did, creator)
+		doc.Metadata.OrganizationID = org
+		doc.Metadata.Environment = env
+		doc.Metadata.Tags = tags
+		doc.Status.State = state
+		return doc
+	}
+	docs := []types.DIDDocument{
+		indexed("did:persona:a", alice, "acme", "prod", types.DIDStateActive, "payments", "eu"),
+		indexed("did:persona:b", alice, "acme", "dev", types.DIDStateSuspended, "payments"),
+		indexed("did:persona:c", bob, "globex", "prod", types.DIDStateActive, "eu"),
+	}
+	docs[2].Controller = []string{alice}
+	for _, doc := range docs {
+		require.NoError(t, k.SetDidDocument(ctx, doc))
+	}
+
+	tests := []struct {
+		name   string
+		prefix string
+		value  string
+		ids    []string
+	}{
+		{name: "creator", prefix: types.DIDControllerIndexPrefix, value: alice, ids: []string{"did:persona:a", "did:persona:b", "did:persona:c"}},
+		{name: "controller", prefix: types.DIDControllerIndexPrefix, value: bob, ids: []string{"did:persona:c"}},
+		{name: "organization", prefix: types.DIDOrganizationIndexPrefix, value: "acme", ids: []string{"did:persona:a", "did:persona:b"}},
+		{name: "state", prefix: types.DIDStateIndexPrefix, value: string(types.DIDStateActive), ids: []string{"did:persona:a", "did:persona:c"}},
+		{name: "tag", prefix: types.DIDTagIndexPrefix, value: "eu", ids: []string{"did:persona:a", "did:persona:c"}},
+		{name: "environment", prefix: types.DIDEnvironmentIndexPrefix, value: "dev", ids: []string{"did:persona:b"}},
+		{name: "value that is a prefix of another", prefix: types.DIDOrganizationIndexPrefix, value: "acm"},
+		{name: "unknown value", prefix: types.DIDTagIndexPrefix, value: "us"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.ids, k.GetIndexedDIDs(ctx, tc.prefix, tc.value))
+
+			got, _, err := k.PaginateIndexedDocuments(ctx, tc.prefix, tc.value, nil)
+			require.NoError(t, err)
+			require.Equal(t, len(tc.ids), len(got))
+			if len(tc.ids) > 0 {
+				require.Equal(t, tc.ids, documentIDs(got))
+			}
+		})
+	}
+
+	t.Run("update replaces stale entries", func(t *testing.T) {
+		cacheCtx, _ := ctx.CacheContext()
+		doc, found := k.GetDidDocument(cacheCtx, "did:persona:b")
+		require.True(t, found)
+		doc.Version++
+		doc.Metadata.OrganizationID = "globex"
+		doc.Metadata.Tags = nil
+		doc.Status.State = types.DIDStateActive
+		require.NoError(t, k.SetDidDocument(cacheCtx, doc))
+
+		require.Equal(t, []string{"did:persona:a"}, k.GetIndexedDIDs(cacheCtx, types.DIDOrganizationIndexPrefix, "acme"))
+		require.Equal(t, []string{"did:persona:b", "did:persona:c"}, k.GetIndexedDIDs(cacheCtx, types.DIDOrganizationIndexPrefix, "globex"))
+		require.Equal(t, []string{"did:persona:a"}, k.GetIndexedDIDs(cacheCtx, types.DIDTagIndexPrefix, "payments"))
+		require.Empty(t, k.GetIndexedDIDs(cacheCtx, types.DIDStateIndexPrefix, string(types.DIDStateSuspended)))
+	})
+
+	t.Run("remove clears entries", func(t *testing.T) {
+		cacheCtx, _ := ctx.CacheContext()
+		k.RemoveDidDocument(cacheCtx, "did:persona:a")
+		require.Equal(t, []string{"did:persona:b", "did:persona:c"}, k.GetIndexedDIDs(cacheCtx, types.DIDControllerIndexPrefix, alice))
+		require.Equal(t, []string{"did:persona:b"}, k.GetIndexedDIDs(cacheCtx, types.DIDTagIndexPrefix, "payments"))
+	})
+}
+
+func TestDidDocumentsByQueries(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	alice := testAddress("alice")
+	for _, did := range []string{"did:persona:a", "did:persona:b", "did:persona:c"} {
+		doc := newTestDocument(did, alice)
+		doc.Metadata.OrganizationID = "acme"
+		doc.Metadata.Tags = []string{"eu"}
+		doc.Metadata.Environment = "prod"
+		doc.Status.State = types.DIDStateActive
+		require.NoError(t, k.SetDidDocument(ctx, doc))
+	}
+
+	tests := []struct {
+		name  string
+		query func(page *query.PageRequest) ([]types.DIDDocument, *query.PageResponse, error)
+	}{
+		{
+			name: "controller",
+			query: func(page *query.PageRequest) ([]types.DIDDocument, *query.PageResponse, error) {
+				res, err := k.DidDocumentsByController(ctx, &types.QueryDidDocumentsByControllerRequest{Controller: alice, Pagination: page})
+				if err != nil {
+					return nil, nil, err
+				}
+				return res.DidDocument, res.Pagination, nil
+			},
+		},
+		{
+			name: "organization",
+			query: func(page *query.PageRequest) ([]types.DIDDocument, *query.PageResponse, error) {
+				res, err := k.DidDocumentsByOrganization(ctx, &types.QueryDidDocumentsByOrganizationRequest{OrganizationId: "acme", Pagination: page})
+				if err != nil {
+					return nil, nil, err
+				}
+				return res.DidDocument, res.Pagination, nil
+			},
+		},
+		{
+			name: "state",
+			query: func(page *query.PageRequest) ([]types.DIDDocument, *query.PageResponse, error) {
+				res, err := k.DidDocumentsByState(ctx, &types.QueryDidDocumentsByStateRequest{State: string(types.DIDStateActive), Pagination: page})
+				if err != nil {
+					return nil, nil, err
+				}
+				return res.DidDocument, res.Pagination, nil
+			},
+		},
+		{
+			name: "tag",
+			query: func(page *query.PageRequest) ([]types.DIDDocument, *query.PageResponse, error) {
+				res, err := k.DidDocumentsByTag(ctx, &types.QueryDidDocumentsByTagRequest{Tag: "eu", Pagination: page})
+				if err != nil {
+					return nil, nil, err
+				}
+				return res.DidDocument, res.Pagination, nil
+			},
+		},
+		{
+			name: "environment",
+			query: func(page *query.PageRequest) ([]types.DIDDocument, *query.PageResponse, error) {
+				res, err := k.DidDocumentsByEnvironment(ctx, &types.QueryDidDocumentsByEnvironmentRequest{Environment: "prod", Pagination: page})
+				if err != nil {
+					return nil, nil, err
+				}
+				return res.DidDocument, res.Pagination, nil
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			first, pageRes, err := tc.query(&query.PageRequest{Limit: 2, CountTotal: true})
+			require.NoError(t, err)
+			require.Equal(t, []string{"did:persona:a", "did:persona:b"}, documentIDs(first))
+			require.Equal(t, uint64(3), pageRes.Total)
+			require.NotNil(t, pageRes.NextKey)
+
+			rest, pageRes, err := tc.query(&query.PageRequest{Key: pageRes.NextKey, Limit: 2})
+			require.NoError(t, err)
+			require.Equal(t, []string{"did:persona:c"}, documentIDs(rest))
+			require.Nil(t, pageRes.NextKey)
+		})
+	}
+
+	_, err := k.DidDocumentsByTag(ctx, &types.QueryDidDocumentsByTagRequest{})
+	require.Error(t, err)
+	_, err = k.DidDocumentsByController(ctx, nil)
+	require.Error(t, err)
+}
@@ -2,8 +2,9 @@ package keeper
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
-	"time"
 	"strconv"
 	"strings"
 
@@ -12,6 +13,7 @@ import (
 	"cosmossdk.io/store/prefix"
 	storetypes "cosmossdk.io/store/types"
 	"cosmossdk.io/core/comet"
+	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/runtime"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -20,6 +22,7 @@ import (
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 
+	"github.com/persona-chain/persona-chain/internal/clock"
 	"github.com/persona-chain/persona-chain/x/did/types"
 )
 
@@ -37,6 +40,13 @@ type (
 
 		accountKeeper types.AccountKeeper
 		bankKeeper    types.BankKeeper
+
+		// router executes the DID messages of approved multi-controller updates
+		router baseapp.MessageRouter
+
+		// ics4Wrapper sends remote resolution packets. It is nil until IBC is
+		// wired with SetICS4Wrapper.
+		ics4Wrapper types.ICS4Wrapper
 		
 		// Enterprise features
 		cometService  comet.Service
@@ -51,6 +61,7 @@ func NewKeeper(
 	ps paramtypes.Subspace,
 	accountKeeper types.AccountKeeper,
 	bankKeeper types.BankKeeper,
+	router baseapp.MessageRouter,
 	cometService comet.Service,
 	hsmEnabled bool,
 	auditEnabled bool,
@@ -67,6 +78,7 @@ func NewKeeper(
 		paramstore:    ps,
 		accountKeeper: accountKeeper,
 		bankKeeper:    bankKeeper,
+		router:        router,
 		cometService:  cometService,
 		hsmEnabled:    hsmEnabled,
 		auditEnabled:  auditEnabled,
@@ -104,6 +116,15 @@ func (k Keeper) SetDidDocument(ctx context.Context, didDocument types.DIDDocumen
 		}
 	}
 	
+	// A key may only be active on one DID unless the DIDs are related
+	var previous *types.DIDDocument
+	if found {
+		previous = &existing
+	}
+	if err := k.checkKeyRegistrations(ctx, previous, didDocument); err != nil {
+		return err
+	}
+	
 	// Set blockchain metadata
 	sdkCtx := sdk.UnwrapSDKContext(ctx)
 	if k.cometService != nil {
@@ -111,9 +132,13 @@ func (k Keeper) SetDidDocument(ctx context.Context, didDocument types.DIDDocumen
 		didDocument.BlockHeight = cometInfo.LastCommit.Height
 		didDocument.ChainID = sdkCtx.ChainID()
 	}
+	didDocument.TxHash = ""
+	if txBytes := sdkCtx.TxBytes(); len(txBytes) > 0 {
+		didDocument.TxHash = fmt.Sprintf("%X", sha256.Sum256(txBytes))
+	}
 	
 	// Update timestamps
-	now := time.Now()
+	now := clock.Now(ctx)
 	didDocument.UpdatedAt = now
 	didDocument.Metadata.Updated = now
 	
@@ -123,6 +148,21 @@ func (k Keeper) SetDidDocument(ctx context.Context, didDocument types.DIDDocumen
 	b := k.cdc.MustMarshal(&didDocument)
 	store.Set(types.DIDDocumentKey(didDocument.ID), b)
 	
+	// Escrow the deposit for new state, or release it on deactivation
+	if err := k.settleStorageDeposit(ctx, previous, didDocument, uint64(len(b))); err != nil {
+		return err
+	}
+	
+	// A deactivated, revoked or inactive DID gives up its handles
+	if didDocument.IsDeactivated() && (previous == nil || !previous.IsDeactivated()) {
+		if err := k.releaseHandles(ctx, didDocument.ID); err != nil {
+			return err
+		}
+	}
+	
+	// Keep secondary indexes in sync
+	k.updateDocumentIndexes(ctx, previous, didDocument)
+	
 	// Store metadata for efficient queries
 	if err := k.setDocumentMetadata(ctx, didDocument); err != nil {
 		k.Logger(ctx).Error("Failed to store document metadata", "did", didDocument.ID, "error", err)
@@ -150,9 +190,9 @@ func (k Keeper) GetDidDocument(
 	ctx context.Context,
 	id string,
 ) (val types.DIDDocument, found bool) {
-	// Validate DID format
-	if !types.IsValidDIDFormat(id) {
-		k.Logger(ctx).Debug("Invalid DID format requested", "did", id)
+	// Validate DID syntax
+	if _, err := types.ParseDID(id); err != nil {
+		k.Logger(ctx).Debug("Invalid DID format requested", "did", id, "error", err)
 		return val, false
 	}
 	
@@ -173,7 +213,7 @@ func (k Keeper) GetDidDocument(
 	// Record access audit
 	if k.auditEnabled {
 		if err := k.recordAuditLog(ctx, "document_accessed", id, "", map[string]interface{}{
-			"timestamp": time.Now(),
+			"timestamp": clock.Now(ctx),
 			"version": val.Version,
 		}); err != nil {
 			k.Logger(ctx).Debug("Failed to record access audit", "did", id, "error", err)
@@ -189,9 +229,15 @@ func (k Keeper) RemoveDidDocument(
 	id string,
 
 ) {
+	doc, found := k.GetDidDocument(ctx, id)
+	if !found {
+		return
+	}
+	k.removeDocumentIndexes(ctx, doc)
+
 	storeAdapter := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
-	store := prefix.NewStore(storeAdapter, types.KeyPrefix(types.DidDocumentKeyPrefix))
-	store.Delete(types.DidDocumentKey(
+	store := prefix.NewStore(storeAdapter, types.KeyPrefix(types.DIDDocumentKeyPrefix))
+	store.Delete(types.DIDDocumentKey(
 		id,
 	))
 }
@@ -270,6 +316,10 @@ func (k Keeper) GetDocumentVersions(ctx context.Context, id string) ([]types.DID
 	
 	var versions []types.DIDDocument
 	for ; iterator.Valid(); iterator.Next() {
+		// Skip versions of other DIDs that extend this one's prefix
+		if len(iterator.Key()) != len(prefix)+8 {
+			continue
+		}
 		var doc types.DIDDocument
 		err := k.cdc.Unmarshal(iterator.Value(), &doc)
 		if err != nil {
@@ -308,52 +358,32 @@ func (k Keeper) setDocumentMetadata(ctx context.Context, doc types.DIDDocument)
 
 // GetDocumentsByOrganization returns all DID documents for an organization
 func (k Keeper) GetDocumentsByOrganization(ctx context.Context, orgID string) ([]types.DIDDocument, error) {
-	storeAdapter := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
-	store := prefix.NewStore(storeAdapter, types.KeyPrefix(types.DIDMetadataKeyPrefix))
-	iterator := storetypes.KVStorePrefixIterator(store, []byte{})
-	defer iterator.Close()
-	
-	var results []types.DIDDocument
-	for ; iterator.Valid(); iterator.Next() {
-		var metadata types.DocumentMetadata
-		err := k.cdc.Unmarshal(iterator.Value(), &metadata)
-		if err != nil {
-			continue
-		}
-		
-		if metadata.OrganizationID == orgID {
-			if doc, found := k.GetDidDocument(ctx, metadata.ID); found {
-				results = append(results, doc)
-			}
-		}
-	}
-	
-	return results, nil
+	return k.getIndexedDocuments(ctx, types.DIDOrganizationIndexPrefix, orgID), nil
 }
 
 // GetDocumentsByState returns all DID documents in a specific state
 func (k Keeper) GetDocumentsByState(ctx context.Context, state types.DIDState) ([]types.DIDDocument, error) {
-	storeAdapter := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
-	store := prefix.NewStore(storeAdapter, types.KeyPrefix(types.DIDMetadataKeyPrefix))
-	iterator := storetypes.KVStorePrefixIterator(store, []byte{})
-	defer iterator.Close()
-	
+	return k.getIndexedDocuments(ctx, types.DIDStateIndexPrefix, string(state)), nil
+}
+
+func (k Keeper) getIndexedDocuments(ctx context.Context, indexPrefix, value string) []types.DIDDocument {
 	var results []types.DIDDocument
-	for ; iterator.Valid(); iterator.Next() {
-		var metadata types.DocumentMetadata
-		err := k.cdc.Unmarshal(iterator.Value(), &metadata)
-		if err != nil {
-			continue
-		}
-		
-		if metadata.State == state {
-			if doc, found := k.GetDidDocument(ctx, metadata.ID); found {
-				results = append(results, doc)
-			}
+	for _, id := range k.GetIndexedDIDs(ctx, indexPrefix, value) {
+		if doc, found := k.GetDidDocument(ctx, id); found {
+			results = append(results, doc)
 		}
 	}
-	
-	return results, nil
+	return results
+}
+
+// nextAuditSequence returns the number of the next audit entry, which keeps
+// entries recorded at the same block time apart
+func (k Keeper) nextAuditSequence(ctx context.Context) uint64 {
+	store := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	key := types.KeyPrefix(types.DIDAuditSequenceKey)
+	seq := sdk.BigEndianToUint64(store.Get(key)) + 1
+	store.Set(key, sdk.Uint64ToBigEndian(seq))
+	return seq
 }
 
 // recordAuditLog records an audit event
@@ -361,18 +391,23 @@ func (k Keeper) recordAuditLog(ctx context.Context, action, didID, actor string,
 	storeAdapter := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
 	store := prefix.NewStore(storeAdapter, types.KeyPrefix(types.DIDAuditKeyPrefix))
 	
-	now := time.Now()
+	now := clock.Now(ctx)
 	sdkCtx := sdk.UnwrapSDKContext(ctx)
 	
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	
 	auditEntry := types.AuditEntry{
-		ID:          fmt.Sprintf(\"%s-%d-%s\", didID, now.UnixNano(), action),
+		ID:          fmt.Sprintf("%s-%d-%020d-%s", didID, now.UnixNano(), k.nextAuditSequence(ctx), action),
 		DID:         didID,
 		Action:      action,
 		Actor:       actor,
 		Timestamp:   now,
 		BlockHeight: sdkCtx.BlockHeight(),
-		TxHash:      fmt.Sprintf(\"%X\", sdkCtx.TxBytes()),
-		Data:        data,
+		TxHash:      fmt.Sprintf("%X", sdkCtx.TxBytes()),
+		Data:        payload,
 	}
 	
 	b := k.cdc.MustMarshal(&auditEntry)
@@ -381,12 +416,23 @@ func (k Keeper) recordAuditLog(ctx context.Context, action, didID, actor string,
 	return nil
 }
 
+// LogAuditEvent records an audit entry for IBC activity that is not tied to
+// a single DID, if auditing is enabled
+func (k Keeper) LogAuditEvent(ctx context.Context, action string, data map[string]interface{}) {
+	if !k.auditEnabled {
+		return
+	}
+	if err := k.recordAuditLog(ctx, action, "", types.PortID, data); err != nil {
+		k.Logger(ctx).Error("Failed to record audit log", "action", action, "error", err)
+	}
+}
+
 // GetAuditLog returns audit logs for a DID
 func (k Keeper) GetAuditLog(ctx context.Context, didID string, limit int) ([]types.AuditEntry, error) {
 	storeAdapter := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
 	store := prefix.NewStore(storeAdapter, types.KeyPrefix(types.DIDAuditKeyPrefix))
 	
-	prefix := []byte(didID + \"-\")
+	prefix := []byte(didID + "-")
 	iterator := storetypes.KVStorePrefixIterator(store, prefix)
 	defer iterator.Close()
 	
@@ -396,7 +442,7 @@ func (k Keeper) GetAuditLog(ctx context.Context, didID string, limit int) ([]typ
 		var entry types.AuditEntry
 		err := k.cdc.Unmarshal(iterator.Value(), &entry)
 		if err != nil {
-			k.Logger(ctx).Error(\"Failed to unmarshal audit entry\", \"error\", err)
+			k.Logger(ctx).Error("Failed to unmarshal audit entry", "error", err)
 			continue
 		}
 		entries = append(entries, entry)
@@ -406,34 +452,14 @@ func (k Keeper) GetAuditLog(ctx context.Context, didID string, limit int) ([]typ
 	return entries, nil
 }
 
-// UpdateDocumentStatus updates the status of a DID document
+// UpdateDocumentStatus moves a DID document to a new state on behalf of the
+// chain. Transitions requested by accounts go through TransitionStatus.
 func (k Keeper) UpdateDocumentStatus(ctx context.Context, id string, newState types.DIDState, reason, updatedBy string) error {
 	doc, found := k.GetDidDocument(ctx, id)
 	if !found {
 		return types.ErrDIDNotFound
 	}
-	
-	// Validate state transition
-	if !k.isValidStateTransition(doc.Status.State, newState) {
-		return errors.Wrapf(types.ErrInvalidDIDState, \"invalid transition from %s to %s\", doc.Status.State, newState)
-	}
-	
-	now := time.Now()
-	doc.Status.State = newState
-	doc.Status.Reason = reason
-	doc.Status.UpdatedAt = now
-	doc.Status.UpdatedBy = updatedBy
-	doc.UpdatedAt = now
-	doc.Version++
-	
-	// Update health check if deactivating
-	if newState == types.DIDStateInactive || newState == types.DIDStateRevoked {
-		doc.Status.HealthCheck.Status = \"unhealthy\"
-		doc.Status.HealthCheck.LastChecked = now
-		doc.Status.HealthCheck.Errors = append(doc.Status.HealthCheck.Errors, fmt.Sprintf(\"Document %s: %s\", newState, reason))
-	}
-	
-	return k.SetDidDocument(ctx, doc)
+	return k.setDocumentStatus(ctx, doc, newState, reason, updatedBy, types.StatusRoleModule, nil)
 }
 
 // isValidStateTransition checks if a state transition is valid
@@ -478,63 +504,19 @@ func (k Keeper) isValidStateTransition(from, to types.DIDState) bool {
 	return false
 }
 
-// PerformHealthCheck performs a health check on a DID document
-func (k Keeper) PerformHealthCheck(ctx context.Context, id string) error {
-	doc, found := k.GetDidDocument(ctx, id)
-	if !found {
-		return types.ErrDIDNotFound
-	}
-	
-	now := time.Now()
-	var errors []string
-	
-	// Check document validity
-	if err := doc.Validate(); err != nil {
-		errors = append(errors, fmt.Sprintf(\"Validation failed: %v\", err))
-	}
-	
-	// Check verification methods
-	for _, vm := range doc.VerificationMethod {
-		if vm.Revoked {
-			errors = append(errors, fmt.Sprintf(\"Verification method %s is revoked\", vm.ID))
-		}
-		if vm.ExpiresAt != nil && vm.ExpiresAt.Before(now) {
-			errors = append(errors, fmt.Sprintf(\"Verification method %s has expired\", vm.ID))
-		}
-	}
-	
-	// Check services
-	for _, svc := range doc.Service {
-		if svc.ServiceEndpoint == \"\" {
-			errors = append(errors, fmt.Sprintf(\"Service %s has empty endpoint\", svc.ID))
-		}
-	}
-	
-	// Update health check
-	status := \"healthy\"
-	if len(errors) > 0 {
-		status = \"unhealthy\"
-	}
-	
-	doc.Status.HealthCheck = types.HealthCheck{
-		Status:      status,
-		LastChecked: now,
-		Errors:      errors,
-	}
-	
-	doc.UpdatedAt = now
-	doc.Version++
-	
-	return k.SetDidDocument(ctx, doc)
-}
-
-// ValidateControllerAuthorization checks if an address can control a DID
+// ValidateControllerAuthorization checks if an address can control a DID.
+// A DID with an update policy is controlled only by policy controllers whose
+// approvals meet its threshold.
 func (k Keeper) ValidateControllerAuthorization(ctx context.Context, didID, controllerAddr string) error {
 	doc, found := k.GetDidDocument(ctx, didID)
 	if !found {
 		return types.ErrDIDNotFound
 	}
 	
+	if policy, found := k.GetUpdatePolicy(ctx, didID); found {
+		return authorizeByPolicy(ctx, policy, controllerAddr)
+	}
+	
 	// Check if the address is the creator
 	if doc.Creator == controllerAddr {
 		return nil
@@ -557,13 +539,19 @@ func (k Keeper) ValidateControllerAuthorization(ctx context.Context, didID, cont
 	return types.ErrUnauthorized
 }
 
-// AddVerificationMethod adds a new verification method to a DID document
-func (k Keeper) AddVerificationMethod(ctx context.Context, didID string, vm types.VerificationMethod, controllerAddr string) error {
+// AddVerificationMethod adds a verification method after checking the proof
+// that the submitter holds its private key
+func (k Keeper) AddVerificationMethod(ctx context.Context, didID string, vm types.VerificationMethod, proof *types.KeyProof, controllerAddr string) error {
 	// Authorize the operation
 	if err := k.ValidateControllerAuthorization(ctx, didID, controllerAddr); err != nil {
 		return err
 	}
 	
+	// Reject malformed or unsupported key material
+	if err := k.ValidateVerificationMethodKey(ctx, vm); err != nil {
+		return err
+	}
+	
 	doc, found := k.GetDidDocument(ctx, didID)
 	if !found {
 		return types.ErrDIDNotFound
@@ -576,16 +564,22 @@ func (k Keeper) AddVerificationMethod(ctx context.Context, didID string, vm type
 		}
 	}
 	
+	// Require a signature from the new key over the next document version
+	if err := k.VerifyKeyPossession(ctx, &doc, vm, doc.Version+1, proof); err != nil {
+		return err
+	}
+	
 	// Set creation timestamp and security level
-	now := time.Now()
+	now := clock.Now(ctx)
 	vm.CreatedAt = now
-	if vm.SecurityLevel == \"\" {
+	if vm.SecurityLevel == "" {
 		vm.SecurityLevel = types.SecurityLevelStandard
 	}
 	
 	// Add to document
 	doc.VerificationMethod = append(doc.VerificationMethod, vm)
 	doc.UpdatedAt = now
+	doc.UpdatedBy = controllerAddr
 	doc.Version++
 	
 	return k.SetDidDocument(ctx, doc)
@@ -602,10 +596,12 @@ func (k Keeper) RevokeVerificationMethod(ctx context.Context, didID, vmID, contr
 	if !found {
 		return types.ErrDIDNotFound
 	}
+	previous := doc
+	doc.VerificationMethod = append([]types.VerificationMethod{}, previous.VerificationMethod...)
 	
 	// Find and revoke the verification method
 	found = false
-	now := time.Now()
+	now := clock.Now(ctx)
 	for i, vm := range doc.VerificationMethod {
 		if vm.ID == vmID {
 			doc.VerificationMethod[i].Revoked = true
@@ -619,7 +615,13 @@ func (k Keeper) RevokeVerificationMethod(ctx context.Context, didID, vmID, contr
 		return types.ErrVerificationMethodNotFound
 	}
 	
+	// Authentication keys under a pre-rotation commitment change only by rotation
+	if err := checkPreRotation(ctx, previous, &doc); err != nil {
+		return err
+	}
+	
 	doc.UpdatedAt = now
+	doc.UpdatedBy = controllerAddr
 	doc.Version++
 	
 	return k.SetDidDocument(ctx, doc)
@@ -646,12 +648,13 @@ func (k Keeper) AddService(ctx context.Context, didID string, service types.Serv
 	
 	// Validate service
 	if err := service.Validate(); err != nil {
-		return errors.Wrapf(types.ErrInvalidService, \"service validation failed: %v\", err)
+		return errors.Wrapf(types.ErrInvalidService, "service validation failed: %v", err)
 	}
 	
 	// Add to document
 	doc.Service = append(doc.Service, service)
-	doc.UpdatedAt = time.Now()
+	doc.UpdatedAt = clock.Now(ctx)
+	doc.UpdatedBy = controllerAddr
 	doc.Version++
 	
 	return k.SetDidDocument(ctx, doc)
@@ -683,7 +686,8 @@ func (k Keeper) RemoveService(ctx context.Context, didID, serviceID, controllerA
 		return types.ErrServiceNotFound
 	}
 	
-	doc.UpdatedAt = time.Now()
+	doc.UpdatedAt = clock.Now(ctx)
+	doc.UpdatedBy = controllerAddr
 	doc.Version++
 	
 	return k.SetDidDocument(ctx, doc)
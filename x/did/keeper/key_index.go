@@ -0,0 +1,121 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/runtime"
+
+	"github.com/persona-chain/persona-chain/internal/clock"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// keyIndexEntry is one key index entry of a document
+type keyIndexEntry struct {
+	fingerprint string
+	ref         types.KeyReference
+}
+
+// documentKeyIndexEntries returns the key index entries of a document.
+// Revoked and expired keys stay indexed so lookups can report their status;
+// keys of types without a fingerprint are not indexed.
+func documentKeyIndexEntries(doc types.DIDDocument) []keyIndexEntry {
+	var entries []keyIndexEntry
+	for _, vm := range doc.VerificationMethod {
+		fingerprint, err := vm.KeyFingerprint()
+		if err != nil {
+			continue
+		}
+		entries = append(entries, keyIndexEntry{
+			fingerprint: fingerprint,
+			ref: types.KeyReference{
+				Did:                  doc.ID,
+				VerificationMethodId: vm.ID,
+				Relationships:        doc.VerificationRelationships(vm.ID),
+			},
+		})
+	}
+	return entries
+}
+
+// setKeyIndex writes the key index entries of a document
+func (k Keeper) setKeyIndex(ctx context.Context, doc types.DIDDocument) {
+	store := k.keyIndexStore(ctx)
+	for _, e := range documentKeyIndexEntries(doc) {
+		store.Set(types.DIDIndexKey(e.fingerprint, e.ref.VerificationMethodId), k.cdc.MustMarshal(&e.ref))
+	}
+}
+
+// removeKeyIndex deletes every key index entry of a document
+func (k Keeper) removeKeyIndex(ctx context.Context, doc types.DIDDocument) {
+	store := k.keyIndexStore(ctx)
+	for _, e := range documentKeyIndexEntries(doc) {
+		store.Delete(types.DIDIndexKey(e.fingerprint, e.ref.VerificationMethodId))
+	}
+}
+
+func (k Keeper) keyIndexStore(ctx context.Context) prefix.Store {
+	storeAdapter := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	return prefix.NewStore(storeAdapter, types.KeyPrefix(types.DIDKeyIndexPrefix))
+}
+
+// GetKeyReferences returns every verification method registered with a key
+func (k Keeper) GetKeyReferences(ctx context.Context, fingerprint string) []types.KeyReference {
+	store := prefix.NewStore(k.keyIndexStore(ctx), types.DIDIndexValuePrefix(fingerprint))
+	iterator := storetypes.KVStorePrefixIterator(store, []byte{})
+	defer iterator.Close()
+
+	var refs []types.KeyReference
+	for ; iterator.Valid(); iterator.Next() {
+		var ref types.KeyReference
+		if err := k.cdc.Unmarshal(iterator.Value(), &ref); err != nil {
+			k.Logger(ctx).Error("Failed to unmarshal key reference", "fingerprint", fingerprint, "error", err)
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// checkKeyRegistrations rejects keys that doc activates while they are
+// active on an unrelated DID, unless shared keys are allowed by params.
+// Keys the previous version already held are not rechecked.
+func (k Keeper) checkKeyRegistrations(ctx context.Context, previous *types.DIDDocument, doc types.DIDDocument) error {
+	if k.GetParams(ctx).AllowSharedKeys {
+		return nil
+	}
+
+	held := make(map[string]bool)
+	if previous != nil {
+		for _, e := range documentKeyIndexEntries(*previous) {
+			held[e.fingerprint] = true
+		}
+	}
+
+	now := clock.Now(ctx)
+	for _, vm := range doc.VerificationMethod {
+		if !vm.IsActiveAt(now) {
+			continue
+		}
+		fingerprint, err := vm.KeyFingerprint()
+		if err != nil || held[fingerprint] {
+			continue
+		}
+
+		for _, ref := range k.GetKeyReferences(ctx, fingerprint) {
+			if ref.Did == doc.ID {
+				continue
+			}
+			other, found := k.GetDidDocument(ctx, ref.Did)
+			if !found || other.IsDeactivated() || doc.IsRelatedTo(&other) {
+				continue
+			}
+			if otherVM, found := other.FindVerificationMethod(ref.VerificationMethodId); found && otherVM.IsActiveAt(now) {
+				return errors.Wrapf(types.ErrKeyAlreadyRegistered, "key of %s is active as %s", vm.ID, ref.VerificationMethodId)
+			}
+		}
+	}
+	return nil
+}
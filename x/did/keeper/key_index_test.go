@@ -0,0 +1,186 @@
+package keeper_test
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// sharedKeyDocument returns a document for did authenticated by the public
+// key of key under a verification method of did
+func sharedKeyDocument(did, creator string, key testKey) types.DIDDocument {
+	vm := key.vm
+	vm.ID = did + "#shared"
+	vm.Controller = did
+	doc := newTestDocument(did, creator)
+	doc.VerificationMethod = []types.VerificationMethod{vm}
+	doc.Authentication = []string{vm.ID}
+	return doc
+}
+
+func TestCheckKeyRegistrations(t *testing.T) {
+	alice, bob := testAddress("alice"), testAddress("bob")
+	now := time.Unix(1_700_000_000, 0).UTC()
+
+	tests := []struct {
+		name  string
+		setup func(doc *types.DIDDocument, params *types.Params)
+		doc   func(key testKey) types.DIDDocument
+		err   error
+	}{
+		{
+			name: "unrelated DID",
+			doc:  func(key testKey) types.DIDDocument { return sharedKeyDocument("did:persona:b", bob, key) },
+			err:  types.ErrKeyAlreadyRegistered,
+		},
+		{
+			name: "same creator",
+			doc:  func(key testKey) types.DIDDocument { return sharedKeyDocument("did:persona:b", alice, key) },
+		},
+		{
+			name: "controlled by the holder",
+			doc: func(key testKey) types.DIDDocument {
+				doc := sharedKeyDocument("did:persona:b", bob, key)
+				doc.Controller = []string{"did:persona:a"}
+				return doc
+			},
+		},
+		{
+			name:  "shared keys allowed",
+			setup: func(_ *types.DIDDocument, params *types.Params) { params.AllowSharedKeys = true },
+			doc:   func(key testKey) types.DIDDocument { return sharedKeyDocument("did:persona:b", bob, key) },
+		},
+		{
+			name:  "key revoked on the holder",
+			setup: func(doc *types.DIDDocument, _ *types.Params) { doc.VerificationMethod[0].Revoked = true },
+			doc:   func(key testKey) types.DIDDocument { return sharedKeyDocument("did:persona:b", bob, key) },
+		},
+		{
+			name: "key expired on the holder",
+			setup: func(doc *types.DIDDocument, _ *types.Params) {
+				expired := now.Add(-time.Hour)
+				doc.VerificationMethod[0].ExpiresAt = &expired
+			},
+			doc: func(key testKey) types.DIDDocument { return sharedKeyDocument("did:persona:b", bob, key) },
+		},
+		{
+			name: "holder deactivated",
+			setup: func(doc *types.DIDDocument, _ *types.Params) {
+				doc.Metadata.Deactivated = true
+			},
+			doc: func(key testKey) types.DIDDocument { return sharedKeyDocument("did:persona:b", bob, key) },
+		},
+		{
+			name: "revoked key on the new DID",
+			doc: func(key testKey) types.DIDDocument {
+				doc := sharedKeyDocument("did:persona:b", bob, key)
+				doc.VerificationMethod[0].Revoked = true
+				return doc
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k, ctx := keepertest.DidKeeper(t)
+			ctx = ctx.WithBlockTime(now)
+			key := newEd25519Key(t, "did:persona:a", "key-1")
+
+			holder := newTestDocument("did:persona:a", alice, key)
+			params := types.DefaultParams()
+			if tc.setup != nil {
+				tc.setup(&holder, &params)
+			}
+			require.NoError(t, k.SetParams(ctx, params))
+			require.NoError(t, k.SetDidDocument(ctx, holder))
+
+			err := k.SetDidDocument(ctx, tc.doc(key))
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDidsByKey(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	now := time.Unix(1_700_000_000, 0).UTC()
+	ctx = ctx.WithBlockTime(now)
+	alice := testAddress("alice")
+
+	key := newEd25519Key(t, "did:persona:a", "key-1")
+	holder := newTestDocument("did:persona:a", alice, key)
+	holder.AssertionMethod = []string{"#key-1"}
+	require.NoError(t, k.SetDidDocument(ctx, holder))
+
+	related := sharedKeyDocument("did:persona:b", alice, key)
+	expired := now.Add(-time.Minute)
+	related.VerificationMethod[0].ExpiresAt = &expired
+	require.NoError(t, k.SetDidDocument(ctx, related))
+
+	fingerprint, err := key.vm.KeyFingerprint()
+	require.NoError(t, err)
+	_, pub, err := key.vm.DecodePublicKey()
+	require.NoError(t, err)
+	jwk := map[string]string{"kty": "OKP", "crv": types.JWKCurveEd25519, "x": base64.RawURLEncoding.EncodeToString(pub)}
+
+	tests := []struct {
+		name string
+		req  *types.QueryDidsByKeyRequest
+		err  bool
+	}{
+		{name: "fingerprint", req: &types.QueryDidsByKeyRequest{Fingerprint: fingerprint}},
+		{name: "multikey", req: &types.QueryDidsByKeyRequest{PublicKeyMultibase: key.vm.PublicKeyMultibase}},
+		{name: "JWK", req: &types.QueryDidsByKeyRequest{PublicKeyJwk: jwk}},
+		{name: "nil request", err: true},
+		{name: "no key", req: &types.QueryDidsByKeyRequest{}, err: true},
+		{name: "two keys", req: &types.QueryDidsByKeyRequest{Fingerprint: fingerprint, PublicKeyMultibase: key.vm.PublicKeyMultibase}, err: true},
+		{name: "invalid multikey", req: &types.QueryDidsByKeyRequest{PublicKeyMultibase: "zAAAA"}, err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := k.DidsByKey(ctx, tc.req)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, fingerprint, res.Fingerprint)
+			require.ElementsMatch(t, []types.KeyRegistration{
+				{
+					Did:                  "did:persona:a",
+					VerificationMethodId: "did:persona:a#key-1",
+					Relationships:        []string{types.RelationshipAuthentication, types.RelationshipAssertionMethod},
+				},
+				{
+					Did:                  "did:persona:b",
+					VerificationMethodId: "did:persona:b#shared",
+					Relationships:        []string{types.RelationshipAuthentication},
+					Expired:              true,
+				},
+			}, res.Registrations)
+		})
+	}
+
+	// Revocation is reported rather than dropping the registration
+	holder, found := k.GetDidDocument(ctx, "did:persona:a")
+	require.True(t, found)
+	holder.Version++
+	holder.VerificationMethod[0].Revoked = true
+	require.NoError(t, k.SetDidDocument(ctx, holder))
+
+	res, err := k.DidsByKey(ctx, &types.QueryDidsByKeyRequest{Fingerprint: fingerprint})
+	require.NoError(t, err)
+	require.Len(t, res.Registrations, 2)
+	for _, reg := range res.Registrations {
+		require.Equal(t, reg.Did == "did:persona:a", reg.Revoked)
+	}
+}
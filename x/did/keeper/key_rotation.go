@@ -0,0 +1,117 @@
+package keeper
+
+import (
+	"context"
+	"fmt"
+
+	"cosmossdk.io/errors"
+
+	"github.com/persona-chain/persona-chain/internal/clock"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// RotateKeys replaces the authentication keys of a DID with newKeys. The new
+// keys must match the pre-rotation commitment of the current version and
+// prove possession for the next version, so the current keys alone cannot
+// rotate. The old authentication keys are revoked and nextCommitment becomes
+// the commitment for the following rotation. Returns the new version.
+func (k Keeper) RotateKeys(ctx context.Context, didID string, newKeys []types.VerificationMethod, nextCommitment string, proofs []types.KeyProof, controllerAddr string) (uint64, error) {
+	// Authorize the operation
+	if err := k.ValidateControllerAuthorization(ctx, didID, controllerAddr); err != nil {
+		return 0, err
+	}
+
+	doc, found := k.GetDidDocument(ctx, didID)
+	if !found {
+		return 0, types.ErrDIDNotFound
+	}
+	if doc.NextKeyCommitment == "" {
+		return 0, errors.Wrapf(types.ErrNoKeyCommitment, "DID %s", didID)
+	}
+
+	// The revealed keys must be exactly the committed set
+	commitment, err := types.NextKeyCommitment(newKeys)
+	if err != nil {
+		return 0, err
+	}
+	if commitment != doc.NextKeyCommitment {
+		return 0, errors.Wrapf(types.ErrKeyCommitmentMismatch, "DID %s", didID)
+	}
+	if nextCommitment == commitment {
+		return 0, errors.Wrap(types.ErrKeyCommitmentMismatch, "next commitment must not reuse the revealed keys")
+	}
+
+	for _, vm := range newKeys {
+		if _, found := doc.FindVerificationMethod(vm.ID); found {
+			return 0, errors.Wrapf(types.ErrDuplicateVerificationMethod, "verification method %s", vm.ID)
+		}
+		if err := k.ValidateVerificationMethodKey(ctx, vm); err != nil {
+			return 0, errors.Wrapf(err, "verification method %s", vm.ID)
+		}
+		if !vm.CanSign() {
+			return 0, errors.Wrapf(types.ErrInvalidKeyType, "authentication key %s cannot sign", vm.ID)
+		}
+	}
+
+	// Every new key signs the next version, which the current keys cannot do
+	newVersion := doc.Version + 1
+	if err := k.VerifyKeyPossessions(ctx, &doc, newKeys, newVersion, proofs); err != nil {
+		return 0, err
+	}
+
+	// Retire the current authentication keys
+	now := clock.Now(ctx)
+	for i := range doc.VerificationMethod {
+		vm := &doc.VerificationMethod[i]
+		if vm.Revoked || !isAuthenticationKey(&doc, vm.ID) {
+			continue
+		}
+		vm.Revoked = true
+		vm.RevokedAt = &now
+	}
+
+	doc.Authentication = make([]string, 0, len(newKeys))
+	for _, vm := range newKeys {
+		vm.CreatedAt = now
+		vm.Revoked = false
+		vm.RevokedAt = nil
+		if vm.SecurityLevel == "" {
+			vm.SecurityLevel = types.SecurityLevelStandard
+		}
+		doc.VerificationMethod = append(doc.VerificationMethod, vm)
+		doc.Authentication = append(doc.Authentication, vm.ID)
+	}
+
+	doc.NextKeyCommitment = nextCommitment
+	doc.UpdatedBy = controllerAddr
+	doc.Version = newVersion
+	doc.Metadata.VersionID = fmt.Sprintf("%d", newVersion)
+
+	// The previous version, with its commitment, is archived by SetDidDocument
+	if err := k.SetDidDocument(ctx, doc); err != nil {
+		return 0, err
+	}
+	return newVersion, nil
+}
+
+// checkPreRotation keeps document updates from bypassing rotation. While the
+// previous version holds a commitment, doc inherits it if it sets none and
+// may neither change it nor change the active authentication key set.
+func checkPreRotation(ctx context.Context, previous types.DIDDocument, doc *types.DIDDocument) error {
+	if previous.NextKeyCommitment == "" {
+		return nil
+	}
+
+	if doc.NextKeyCommitment == "" {
+		doc.NextKeyCommitment = previous.NextKeyCommitment
+	}
+	if doc.NextKeyCommitment != previous.NextKeyCommitment {
+		return errors.Wrap(types.ErrPreRotationRequired, "the key commitment can only change by rotation")
+	}
+
+	now := clock.Now(ctx)
+	if !types.SameKeySet(previous.ActiveAuthenticationKeys(now), doc.ActiveAuthenticationKeys(now)) {
+		return errors.Wrapf(types.ErrPreRotationRequired, "DID %s", doc.ID)
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// keyCommitment returns the pre-rotation commitment to keys
+func keyCommitment(t testing.TB, keys ...testKey) string {
+	vms := make([]types.VerificationMethod, 0, len(keys))
+	for _, key := range keys {
+		vms = append(vms, key.vm)
+	}
+	commitment, err := types.NextKeyCommitment(vms)
+	require.NoError(t, err)
+	return commitment
+}
+
+// createCommittedDID creates did authenticated by current with a
+// pre-rotation commitment to next
+func createCommittedDID(t testing.TB, ctx sdk.Context, k keeper.Keeper, did, creator string, current testKey, next ...testKey) {
+	doc := newTestDocument(did, creator, current)
+	doc.NextKeyCommitment = keyCommitment(t, next...)
+	_, err := keeper.NewMsgServerImpl(k).CreateDIDDocument(ctx, &types.MsgCreateDIDDocument{
+		Creator:     creator,
+		DidDocument: doc,
+		KeyProofs:   []types.KeyProof{current.proof(did, 1)},
+	})
+	require.NoError(t, err)
+}
+
+func TestRotateKeys(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID)
+	msgServer := keeper.NewMsgServerImpl(k)
+	creator := testAddress("creator")
+
+	const did = "did:persona:abc"
+	current := newEd25519Key(t, did, "key-1")
+	next := newEd25519Key(t, did, "key-2")
+	after := newEd25519Key(t, did, "key-3")
+	createCommittedDID(t, ctx, k, did, creator, current, next)
+
+	const uncommitted = "did:persona:uncommitted"
+	createTestDID(t, ctx, k, uncommitted, creator, newEd25519Key(t, uncommitted, "key-1"))
+
+	// The committed key published under the ID of the current key
+	clash := next
+	clash.vm.ID = current.vm.ID
+
+	tests := []struct {
+		name       string
+		id         string
+		controller string
+		newKeys    []testKey
+		proofs     []types.KeyProof
+		commitment string
+		err        error
+	}{
+		{
+			name:       "committed keys",
+			newKeys:    []testKey{next},
+			proofs:     []types.KeyProof{next.proof(did, 2)},
+			commitment: keyCommitment(t, after),
+		},
+		{
+			name:       "uncommitted keys",
+			newKeys:    []testKey{after},
+			proofs:     []types.KeyProof{after.proof(did, 2)},
+			commitment: keyCommitment(t, next),
+			err:        types.ErrKeyCommitmentMismatch,
+		},
+		{
+			name:       "superset of the committed keys",
+			newKeys:    []testKey{next, after},
+			proofs:     []types.KeyProof{next.proof(did, 2), after.proof(did, 2)},
+			commitment: keyCommitment(t, current),
+			err:        types.ErrKeyCommitmentMismatch,
+		},
+		{
+			name:       "next commitment reuses the revealed keys",
+			newKeys:    []testKey{next},
+			proofs:     []types.KeyProof{next.proof(did, 2)},
+			commitment: keyCommitment(t, next),
+			err:        types.ErrKeyCommitmentMismatch,
+		},
+		{
+			name:       "missing proof",
+			newKeys:    []testKey{next},
+			commitment: keyCommitment(t, after),
+			err:        types.ErrMissingKeyProof,
+		},
+		{
+			name:       "proof for the current version",
+			newKeys:    []testKey{next},
+			proofs:     []types.KeyProof{next.proof(did, 1)},
+			commitment: keyCommitment(t, after),
+			err:        types.ErrInvalidKeyProof,
+		},
+		{
+			name:       "proof signed by the current key",
+			newKeys:    []testKey{next},
+			proofs:     []types.KeyProof{{VerificationMethodId: next.vm.ID, Signature: current.proof(did, 2).Signature}},
+			commitment: keyCommitment(t, after),
+			err:        types.ErrInvalidKeyProof,
+		},
+		{
+			name:       "verification method ID in use",
+			newKeys:    []testKey{clash},
+			proofs:     []types.KeyProof{clash.proof(did, 2)},
+			commitment: keyCommitment(t, after),
+			err:        types.ErrDuplicateVerificationMethod,
+		},
+		{
+			name:       "unauthorized controller",
+			controller: testAddress("mallory"),
+			newKeys:    []testKey{next},
+			proofs:     []types.KeyProof{next.proof(did, 2)},
+			commitment: keyCommitment(t, after),
+			err:        types.ErrUnauthorized,
+		},
+		{
+			name:       "no commitment",
+			id:         uncommitted,
+			newKeys:    []testKey{newEd25519Key(t, uncommitted, "key-2")},
+			commitment: keyCommitment(t, after),
+			err:        types.ErrNoKeyCommitment,
+		},
+		{
+			name:    "unknown DID",
+			id:      "did:persona:missing",
+			newKeys: []testKey{next},
+			err:     types.ErrDIDNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			id, controller := did, creator
+			if tc.id != "" {
+				id = tc.id
+			}
+			if tc.controller != "" {
+				controller = tc.controller
+			}
+			var vms []types.VerificationMethod
+			for _, key := range tc.newKeys {
+				vms = append(vms, key.vm)
+			}
+
+			cacheCtx, _ := ctx.CacheContext()
+			res, err := msgServer.RotateKeys(cacheCtx, &types.MsgRotateKeys{
+				Controller:        controller,
+				Id:                id,
+				NewKeys:           vms,
+				NextKeyCommitment: tc.commitment,
+				KeyProofs:         tc.proofs,
+			})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, uint64(2), res.Version)
+
+			doc, found := k.GetDidDocument(cacheCtx, did)
+			require.True(t, found)
+			require.Equal(t, tc.commitment, doc.NextKeyCommitment)
+			require.Equal(t, []string{next.vm.ID}, doc.Authentication)
+			old, found := doc.FindVerificationMethod(current.vm.ID)
+			require.True(t, found)
+			require.True(t, old.Revoked)
+
+			archived, found := k.GetDocumentVersion(cacheCtx, did, 1)
+			require.True(t, found)
+			require.Equal(t, keyCommitment(t, next), archived.NextKeyCommitment)
+		})
+	}
+}
+
+func TestUpdateRespectsPreRotation(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID)
+	msgServer := keeper.NewMsgServerImpl(k)
+	creator := testAddress("creator")
+
+	const did = "did:persona:abc"
+	current := newEd25519Key(t, did, "key-1")
+	next := newEd25519Key(t, did, "key-2")
+	assertion := newEd25519Key(t, did, "assertion")
+	createCommittedDID(t, ctx, k, did, creator, current, next)
+	stored, found := k.GetDidDocument(ctx, did)
+	require.True(t, found)
+
+	tests := []struct {
+		name   string
+		mutate func(doc *types.DIDDocument) []types.KeyProof
+		err    error
+	}{
+		{
+			name: "commitment omitted",
+			mutate: func(doc *types.DIDDocument) []types.KeyProof {
+				doc.NextKeyCommitment = ""
+				return nil
+			},
+		},
+		{
+			name: "non-authentication key added",
+			mutate: func(doc *types.DIDDocument) []types.KeyProof {
+				doc.VerificationMethod = append(doc.VerificationMethod, assertion.vm)
+				doc.AssertionMethod = []string{assertion.vm.ID}
+				return []types.KeyProof{assertion.proof(did, 2)}
+			},
+		},
+		{
+			name: "commitment replaced",
+			mutate: func(doc *types.DIDDocument) []types.KeyProof {
+				doc.NextKeyCommitment = keyCommitment(t, assertion)
+				return nil
+			},
+			err: types.ErrPreRotationRequired,
+		},
+		{
+			name: "committed key added to authentication",
+			mutate: func(doc *types.DIDDocument) []types.KeyProof {
+				doc.VerificationMethod = append(doc.VerificationMethod, next.vm)
+				doc.Authentication = append(doc.Authentication, next.vm.ID)
+				return []types.KeyProof{next.proof(did, 2)}
+			},
+			err: types.ErrPreRotationRequired,
+		},
+		{
+			name: "current key removed from authentication",
+			mutate: func(doc *types.DIDDocument) []types.KeyProof {
+				doc.VerificationMethod = append(doc.VerificationMethod, assertion.vm)
+				doc.Authentication = []string{assertion.vm.ID}
+				return []types.KeyProof{assertion.proof(did, 2)}
+			},
+			err: types.ErrPreRotationRequired,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := stored
+			doc.VerificationMethod = append([]types.VerificationMethod{}, stored.VerificationMethod...)
+			doc.Authentication = append([]string{}, stored.Authentication...)
+			proofs := tc.mutate(&doc)
+
+			cacheCtx, _ := ctx.CacheContext()
+			_, err := msgServer.UpdateDIDDocument(cacheCtx, &types.MsgUpdateDIDDocument{
+				Controller:  creator,
+				Id:          did,
+				DidDocument: doc,
+				KeyProofs:   proofs,
+			})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			updated, found := k.GetDidDocument(cacheCtx, did)
+			require.True(t, found)
+			require.Equal(t, stored.NextKeyCommitment, updated.NextKeyCommitment)
+		})
+	}
+}
+
+func TestRevokeRespectsPreRotation(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID)
+	msgServer := keeper.NewMsgServerImpl(k)
+	creator := testAddress("creator")
+
+	const did = "did:persona:abc"
+	current := newEd25519Key(t, did, "key-1")
+	next := newEd25519Key(t, did, "key-2")
+	assertion := newEd25519Key(t, did, "assertion")
+	createCommittedDID(t, ctx, k, did, creator, current, next)
+
+	stored, found := k.GetDidDocument(ctx, did)
+	require.True(t, found)
+	stored.VerificationMethod = append(stored.VerificationMethod, assertion.vm)
+	stored.AssertionMethod = []string{assertion.vm.ID}
+	_, err := msgServer.UpdateDIDDocument(ctx, &types.MsgUpdateDIDDocument{
+		Controller:  creator,
+		Id:          did,
+		DidDocument: stored,
+		KeyProofs:   []types.KeyProof{assertion.proof(did, 2)},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		methodID string
+		err      error
+	}{
+		{name: "non-authentication key", methodID: assertion.vm.ID},
+		{name: "committed authentication key", methodID: current.vm.ID, err: types.ErrPreRotationRequired},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			_, err := msgServer.RevokeVerificationMethod(cacheCtx, &types.MsgRevokeVerificationMethod{
+				Controller: creator,
+				Id:         did,
+				MethodId:   tc.methodID,
+			})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			updated, found := k.GetDidDocument(cacheCtx, did)
+			require.True(t, found)
+			require.Equal(t, uint64(3), updated.Version)
+			require.True(t, updated.VerificationMethod[1].Revoked)
+		})
+	}
+}
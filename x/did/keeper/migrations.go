@@ -0,0 +1,101 @@
+package keeper
+
+import (
+	"fmt"
+
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// Migrator is a struct for handling in-place store migrations.
+type Migrator struct {
+	keeper Keeper
+}
+
+// NewMigrator returns a new Migrator.
+func NewMigrator(keeper Keeper) Migrator {
+	return Migrator{keeper: keeper}
+}
+
+// Migrate2to3 converts legacy DidDocument records into typed DID documents.
+// Converted records are moved to the DIDDocument store with version 1
+// seeded in their history, and all typed documents are indexed. Records that cannot be converted are left under
+// the legacy prefix, logged and reported as events so operators can repair
+// them; they do not halt the upgrade. Params missing from the subspace are
+// seeded with their defaults.
+func (m Migrator) Migrate2to3(ctx sdk.Context) error {
+	if err := m.keeper.SetParams(ctx, m.keeper.GetParams(ctx)); err != nil {
+		return err
+	}
+
+	storeAdapter := runtime.KVStoreAdapter(m.keeper.storeService.OpenKVStore(ctx))
+	legacyStore := prefix.NewStore(storeAdapter, types.KeyPrefix(types.DidDocumentKeyPrefix))
+
+	migrated, failed := 0, 0
+
+	// Collect first so the legacy store is not mutated while iterating
+	var records []types.DidDocument
+	var keys [][]byte
+	iterator := storetypes.KVStorePrefixIterator(legacyStore, []byte{})
+	for ; iterator.Valid(); iterator.Next() {
+		var record types.DidDocument
+		if err := m.keeper.cdc.Unmarshal(iterator.Value(), &record); err != nil {
+			m.reportFailure(ctx, string(iterator.Key()), fmt.Errorf("undecodable record: %w", err))
+			failed++
+			continue
+		}
+		records = append(records, record)
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	docStore := prefix.NewStore(storeAdapter, types.KeyPrefix(types.DIDDocumentKeyPrefix))
+	for i, record := range records {
+		doc, err := record.ToDIDDocument()
+		if err != nil {
+			m.reportFailure(ctx, record.Id, err)
+			failed++
+			continue
+		}
+		if docStore.Has(types.DIDDocumentKey(doc.ID)) {
+			m.reportFailure(ctx, record.Id, types.ErrDIDAlreadyExists)
+			failed++
+			continue
+		}
+
+		doc.ChainID = ctx.ChainID()
+		doc.BlockHeight = ctx.BlockHeight()
+		docStore.Set(types.DIDDocumentKey(doc.ID), m.keeper.cdc.MustMarshal(&doc))
+		if err := m.keeper.setDocumentVersion(ctx, doc); err != nil {
+			return err
+		}
+		if err := m.keeper.setDocumentMetadata(ctx, doc); err != nil {
+			return err
+		}
+		legacyStore.Delete(keys[i])
+		migrated++
+	}
+
+	// Build the secondary indexes for every typed document, including those
+	// written before the indexes existed
+	for _, doc := range m.keeper.GetAllDidDocument(ctx) {
+		m.keeper.updateDocumentIndexes(ctx, nil, doc)
+	}
+
+	m.keeper.Logger(ctx).Info("migrated legacy DID documents", "migrated", migrated, "failed", failed)
+	return nil
+}
+
+func (m Migrator) reportFailure(ctx sdk.Context, id string, err error) {
+	m.keeper.Logger(ctx).Error("failed to migrate legacy DID document", "did", id, "error", err)
+	if emitErr := ctx.EventManager().EmitTypedEvent(&types.EventLegacyMigrationFailed{
+		Did:    id,
+		Reason: err.Error(),
+	}); emitErr != nil {
+		m.keeper.Logger(ctx).Error("failed to emit migration failure event", "did", id, "error", emitErr)
+	}
+}
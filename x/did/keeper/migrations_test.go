@@ -0,0 +1,169 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"cosmossdk.io/store/prefix"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestMigrate2to3(t *testing.T) {
+	k, ctx, storeKey := keepertest.DidKeeperWithStoreKey(t)
+	ctx = ctx.WithChainID(testChainID).WithBlockHeight(10).WithBlockTime(time.Unix(2_000_000, 0))
+	cdc := codec.NewProtoCodec(codectypes.NewInterfaceRegistry())
+	creator := testAddress("creator")
+
+	legacyJSON := func(did string, key testKey) string {
+		return `{"@context":"https://www.w3.org/ns/did/v1","id":"` + did + `","verificationMethod":[{"id":"` + key.vm.ID +
+			`","type":"` + key.vm.Type + `","controller":"` + did + `","publicKeyMultibase":"` + key.vm.PublicKeyMultibase +
+			`"}],"authentication":["` + key.vm.ID + `"],"service":[{"id":"` + did + `#hub","type":"Hub","serviceEndpoint":{"origins":["https://hub.example"]}}]}`
+	}
+
+	const existing = "did:persona:existing"
+	createTestDID(t, ctx, k, existing, creator, newEd25519Key(t, existing, "key-1"))
+
+	tests := []struct {
+		name        string
+		record      types.DidDocument
+		migrated    bool
+		deactivated bool
+	}{
+		{
+			name: "active document",
+			record: types.DidDocument{
+				Id:          "did:persona:active",
+				DidDocument: legacyJSON("did:persona:active", newEd25519Key(t, "did:persona:active", "key-1")),
+				Creator:     creator,
+				Active:      true,
+				CreatedAt:   1_000_000,
+				UpdatedAt:   1_500_000,
+			},
+			migrated: true,
+		},
+		{
+			name: "inactive document",
+			record: types.DidDocument{
+				Id:          "did:persona:inactive",
+				DidDocument: legacyJSON("did:persona:inactive", newEd25519Key(t, "did:persona:inactive", "key-1")),
+				Creator:     creator,
+				CreatedAt:   1_000_000,
+				UpdatedAt:   1_500_000,
+			},
+			migrated:    true,
+			deactivated: true,
+		},
+		{
+			name: "malformed JSON",
+			record: types.DidDocument{
+				Id:          "did:persona:malformed",
+				DidDocument: `{"id":`,
+				Creator:     creator,
+				Active:      true,
+			},
+		},
+		{
+			name: "embedded id mismatch",
+			record: types.DidDocument{
+				Id:          "did:persona:mismatch",
+				DidDocument: legacyJSON("did:persona:other", newEd25519Key(t, "did:persona:other", "key-1")),
+				Creator:     creator,
+				Active:      true,
+			},
+		},
+		{
+			name: "already migrated",
+			record: types.DidDocument{
+				Id:          existing,
+				DidDocument: legacyJSON(existing, newEd25519Key(t, existing, "key-2")),
+				Creator:     testAddress("mallory"),
+				Active:      true,
+			},
+		},
+	}
+
+	legacyStore := prefix.NewStore(ctx.KVStore(storeKey), types.KeyPrefix(types.DidDocumentKeyPrefix))
+	for _, tc := range tests {
+		legacyStore.Set(types.DidDocumentKey(tc.record.Id), cdc.MustMarshal(&tc.record))
+	}
+
+	require.NoError(t, keeper.NewMigrator(k).Migrate2to3(ctx))
+
+	failures := map[string]bool{}
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type != "persona_chain.did.v1.EventLegacyMigrationFailed" {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key == "did" {
+				failures[attr.Value[1:len(attr.Value)-1]] = true
+			}
+		}
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			id := tc.record.Id
+			require.Equal(t, !tc.migrated, legacyStore.Has(types.DidDocumentKey(id)))
+			require.Equal(t, !tc.migrated, failures[id])
+			if !tc.migrated {
+				if id != existing {
+					_, found := k.GetDidDocument(ctx, id)
+					require.False(t, found)
+				}
+				return
+			}
+
+			doc, found := k.GetDidDocument(ctx, id)
+			require.True(t, found)
+			require.Equal(t, uint64(1), doc.Version)
+			require.Equal(t, creator, doc.Creator)
+			require.Equal(t, testChainID, doc.ChainID)
+			require.Equal(t, int64(10), doc.BlockHeight)
+			require.Equal(t, time.Unix(1_000_000, 0).UTC(), doc.CreatedAt)
+			require.Equal(t, time.Unix(1_500_000, 0).UTC(), doc.UpdatedAt)
+			require.Equal(t, tc.deactivated, doc.IsDeactivated())
+			require.Len(t, doc.VerificationMethod, 1)
+			require.Equal(t, []string{doc.VerificationMethod[0].ID}, doc.Authentication)
+			require.Len(t, doc.Service, 1)
+			require.Equal(t, `{"origins":["https://hub.example"]}`, doc.Service[0].ServiceEndpoint)
+
+			archived, found := k.GetDocumentVersion(ctx, id, 1)
+			require.True(t, found)
+			require.Equal(t, doc.ID, archived.ID)
+		})
+	}
+
+	// The document that already existed is left untouched
+	doc, found := k.GetDidDocument(ctx, existing)
+	require.True(t, found)
+	require.Equal(t, creator, doc.Creator)
+
+	// Running the migration again only retries the failed records
+	require.NoError(t, keeper.NewMigrator(k).Migrate2to3(ctx))
+	for _, tc := range tests {
+		require.Equal(t, !tc.migrated, legacyStore.Has(types.DidDocumentKey(tc.record.Id)), tc.name)
+	}
+}
+
+func TestMigrate2to3SeedsParams(t *testing.T) {
+	k, ctx, storeKey := keepertest.DidKeeperWithStoreKey(t)
+
+	keys := [][]byte{types.KeyPendingUpdatePeriod, types.KeyMaxResourceSize, types.KeyChannelJurisdictions, types.KeyRemoteResolutionTTL}
+	for _, key := range keys {
+		paramStore(ctx, storeKey).Delete(key)
+	}
+
+	require.NoError(t, keeper.NewMigrator(k).Migrate2to3(ctx))
+
+	for _, key := range keys {
+		require.True(t, paramStore(ctx, storeKey).Has(key), string(key))
+	}
+	require.Equal(t, types.DefaultParams().RemoteResolutionTTL, k.GetParams(ctx).RemoteResolutionTTL)
+}
@@ -2,14 +2,13 @@ package keeper
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"time"
 
 	errorsmod "cosmossdk.io/errors"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
+	"github.com/persona-chain/persona-chain/internal/clock"
 	"github.com/persona-chain/persona-chain/x/did/types"
 )
 
@@ -25,40 +24,62 @@ func NewMsgServerImpl(keeper Keeper) types.MsgServer {
 
 var _ types.MsgServer = msgServer{}
 
-// Legacy handler maintained for backward compatibility
+// Legacy handler maintained for backward compatibility. The JSON document is
+// converted and stored in the typed format, so keys it introduces are held to
+// the same possession requirement as the enterprise path. The message has no
+// room for key proofs, so only keys that cannot sign can be added here.
 func (k msgServer) CreateDid(goCtx context.Context, msg *types.MsgCreateDid) (*types.MsgCreateDidResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
 	// Check if the value already exists
-	_, isFound := k.GetDidDocument(ctx, msg.Id)
-	if isFound {
+	if k.DidDocumentExists(ctx, msg.Id) {
 		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "DID already exists")
 	}
 
-	var didDocument = types.DidDocument{
+	record := types.DidDocument{
 		Creator:     msg.Creator,
 		Id:          msg.Id,
 		DidDocument: msg.DidDocument,
 		Active:      true,
-		CreatedAt:   time.Now().Unix(),
-		UpdatedAt:   time.Now().Unix(),
+		CreatedAt:   clock.Unix(ctx),
+		UpdatedAt:   clock.Unix(ctx),
+	}
+	didDoc, err := record.ToDIDDocument()
+	if err != nil {
+		return nil, err
 	}
 
-	k.SetDidDocument(ctx, didDocument)
+	for _, vm := range didDoc.VerificationMethod {
+		if err := k.Keeper.ValidateVerificationMethodKey(ctx, vm); err != nil {
+			return nil, errorsmod.Wrapf(err, "verification method %s", vm.ID)
+		}
+	}
+	if err := k.Keeper.VerifyKeyPossessions(ctx, &didDoc, didDoc.VerificationMethod, didDoc.Version, nil); err != nil {
+		if errorsmod.IsOf(err, types.ErrMissingKeyProof) {
+			return nil, errorsmod.Wrap(err, "MsgCreateDid is deprecated and cannot carry key proofs, use MsgCreateDIDDocument to add signing keys")
+		}
+		return nil, err
+	}
+	didDoc.UpdatedBy = msg.Creator
+
+	if err := k.SetDidDocument(ctx, didDoc); err != nil {
+		return nil, err
+	}
 
 	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.TypeMsgCreateDid,
-			sdk.NewAttribute("creator", msg.Creator),
-			sdk.NewAttribute("id", msg.Id),
-		),
-	)
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventDIDCreated{
+		Did:     msg.Id,
+		Creator: msg.Creator,
+		Version: 1,
+	}); err != nil {
+		return nil, err
+	}
 
 	return &types.MsgCreateDidResponse{}, nil
 }
 
-// Legacy handler maintained for backward compatibility
+// Legacy handler maintained for backward compatibility. Like CreateDid it
+// cannot add signing keys.
 func (k msgServer) UpdateDid(goCtx context.Context, msg *types.MsgUpdateDid) (*types.MsgUpdateDidResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
@@ -74,29 +95,69 @@ func (k msgServer) UpdateDid(goCtx context.Context, msg *types.MsgUpdateDid) (*t
 	}
 
 	// Check if DID is active
-	if !valFound.Active {
+	if valFound.IsDeactivated() {
 		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "DID is deactivated")
 	}
 
-	var didDocument = types.DidDocument{
+	// The owner must also meet the update policy, if the DID has one
+	if err := k.Keeper.ValidateControllerAuthorization(ctx, msg.Id, msg.Creator); err != nil {
+		return nil, err
+	}
+
+	record := types.DidDocument{
 		Creator:     msg.Creator,
 		Id:          msg.Id,
 		DidDocument: msg.DidDocument,
-		Active:      valFound.Active,
-		CreatedAt:   valFound.CreatedAt,
-		UpdatedAt:   time.Now().Unix(),
+		Active:      true,
+		CreatedAt:   valFound.CreatedAt.Unix(),
+		UpdatedAt:   clock.Unix(ctx),
+	}
+	didDoc, err := record.ToDIDDocument()
+	if err != nil {
+		return nil, err
 	}
 
-	k.SetDidDocument(ctx, didDocument)
+	addedKeys, err := k.Keeper.addedVerificationMethods(ctx, valFound, &didDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	newVersion := valFound.Version + 1
+	if err := k.Keeper.VerifyKeyPossessions(ctx, &didDoc, addedKeys, newVersion, nil); err != nil {
+		if errorsmod.IsOf(err, types.ErrMissingKeyProof) {
+			return nil, errorsmod.Wrap(err, "MsgUpdateDid is deprecated and cannot carry key proofs, use MsgUpdateDIDDocument to add signing keys")
+		}
+		return nil, err
+	}
+
+	// The legacy JSON cannot carry a commitment, so the stored one is kept
+	if err := checkPreRotation(ctx, valFound, &didDoc); err != nil {
+		return nil, err
+	}
+
+	// Preserve chain-managed fields
+	didDoc.CreatedAt = valFound.CreatedAt
+	didDoc.Status = valFound.Status
+	didDoc.Metadata = valFound.Metadata
+	didDoc.Compliance = valFound.Compliance
+	didDoc.Recovery = valFound.Recovery
+	didDoc.Guardian = valFound.Guardian
+	didDoc.Metadata.VersionID = fmt.Sprintf("%d", newVersion)
+	didDoc.Version = newVersion
+	didDoc.UpdatedBy = msg.Creator
+
+	if err := k.SetDidDocument(ctx, didDoc); err != nil {
+		return nil, err
+	}
 
 	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.TypeMsgUpdateDid,
-			sdk.NewAttribute("creator", msg.Creator),
-			sdk.NewAttribute("id", msg.Id),
-		),
-	)
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventDIDUpdated{
+		Did:        msg.Id,
+		Controller: msg.Creator,
+		Version:    newVersion,
+	}); err != nil {
+		return nil, err
+	}
 
 	return &types.MsgUpdateDidResponse{}, nil
 }
@@ -117,29 +178,38 @@ func (k msgServer) DeactivateDid(goCtx context.Context, msg *types.MsgDeactivate
 	}
 
 	// Check if DID is already inactive
-	if !valFound.Active {
+	if valFound.IsDeactivated() {
 		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "DID is already deactivated")
 	}
 
-	var didDocument = types.DidDocument{
-		Creator:     valFound.Creator,
-		Id:          valFound.Id,
-		DidDocument: valFound.DidDocument,
-		Active:      false,
-		CreatedAt:   valFound.CreatedAt,
-		UpdatedAt:   time.Now().Unix(),
+	// The owner must also meet the update policy, if the DID has one
+	if err := k.Keeper.ValidateControllerAuthorization(ctx, msg.Id, msg.Creator); err != nil {
+		return nil, err
 	}
 
-	k.SetDidDocument(ctx, didDocument)
+	now := clock.Now(ctx)
+	valFound.Version++
+	valFound.Metadata.VersionID = fmt.Sprintf("%d", valFound.Version)
+	valFound.Metadata.Deactivated = true
+	valFound.Metadata.DeactivatedAt = &now
+	valFound.Status.State = types.DIDStateRevoked
+	valFound.Status.UpdatedAt = now
+	valFound.Status.UpdatedBy = msg.Creator
+	valFound.UpdatedBy = msg.Creator
+	valFound.Prune()
+
+	// Storing the deactivated version refunds the storage deposit
+	if err := k.SetDidDocument(ctx, valFound); err != nil {
+		return nil, err
+	}
 
 	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.TypeMsgDeactivateDid,
-			sdk.NewAttribute("creator", msg.Creator),
-			sdk.NewAttribute("id", msg.Id),
-		),
-	)
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventDIDDeactivated{
+		Did:        msg.Id,
+		Controller: msg.Creator,
+	}); err != nil {
+		return nil, err
+	}
 
 	return &types.MsgDeactivateDidResponse{}, nil
 }
@@ -149,61 +219,67 @@ func (k msgServer) DeactivateDid(goCtx context.Context, msg *types.MsgDeactivate
 // CreateDIDDocument creates a new DID document with enterprise features
 func (k msgServer) CreateDIDDocument(goCtx context.Context, msg *types.MsgCreateDIDDocument) (*types.MsgCreateDIDDocumentResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
+	didDoc := msg.DidDocument
 
 	// Validate the DID document
-	if err := msg.DidDocument.Validate(); err != nil {
-		return nil, errorsmod.Wrapf(types.ErrInvalidDIDDocument, "validation failed: %v", err)
+	if err := didDoc.Validate(); err != nil {
+		return nil, errorsmod.Wrapf(types.ErrInvalidDID, "validation failed: %v", err)
+	}
+
+	// Reject malformed or unsupported key material
+	for _, vm := range didDoc.VerificationMethod {
+		if err := k.Keeper.ValidateVerificationMethodKey(ctx, vm); err != nil {
+			return nil, errorsmod.Wrapf(err, "verification method %s", vm.ID)
+		}
+	}
+
+	// Every signing key must prove possession for the initial version
+	if err := k.Keeper.VerifyKeyPossessions(ctx, &didDoc, didDoc.VerificationMethod, 1, msg.KeyProofs); err != nil {
+		return nil, err
 	}
 
 	// Check if DID already exists
-	if k.Keeper.HasDIDDocument(ctx, msg.DidDocument.Id) {
-		return nil, errorsmod.Wrapf(types.ErrDIDAlreadyExists, "DID %s already exists", msg.DidDocument.Id)
+	if k.Keeper.DidDocumentExists(ctx, didDoc.ID) {
+		return nil, errorsmod.Wrapf(types.ErrDIDAlreadyExists, "DID %s already exists", didDoc.ID)
 	}
 
 	// Set creation metadata
-	now := time.Now()
-	msg.DidDocument.Created = now.Format(time.RFC3339)
-	msg.DidDocument.Updated = now.Format(time.RFC3339)
-	msg.DidDocument.Version = 1
-
-	// Set initial enterprise metadata
-	if msg.DidDocument.Metadata == nil {
-		msg.DidDocument.Metadata = &types.DocumentMetadata{}
-	}
-	msg.DidDocument.Metadata.CreatedAt = now.Unix()
-	msg.DidDocument.Metadata.UpdatedAt = now.Unix()
-	msg.DidDocument.Metadata.Version = 1
-	msg.DidDocument.Metadata.Status = "active"
-	msg.DidDocument.Metadata.CreatedBy = msg.Creator
-	msg.DidDocument.Metadata.ComplianceStatus = "compliant"
+	now := clock.Now(ctx)
+	didDoc.Creator = msg.Creator
+	didDoc.UpdatedBy = msg.Creator
+	didDoc.CreatedAt = now
+	didDoc.Version = 1
+	didDoc.Metadata.Created = now
+	didDoc.Metadata.VersionID = "1"
+	didDoc.Metadata.Deactivated = false
+	didDoc.Metadata.DeactivatedAt = nil
+	didDoc.Status = types.DIDStatus{
+		State:     types.DIDStateActive,
+		UpdatedAt: now,
+		UpdatedBy: msg.Creator,
+	}
+	for i := range didDoc.VerificationMethod {
+		didDoc.VerificationMethod[i].CreatedAt = now
+		didDoc.VerificationMethod[i].Revoked = false
+		didDoc.VerificationMethod[i].RevokedAt = nil
+	}
 
 	// Store the DID document
-	k.Keeper.SetDIDDocument(ctx, *msg.DidDocument)
-
-	// Create audit entry
-	auditEntry := types.AuditEntry{
-		Timestamp: now.Unix(),
-		Action:    "create",
-		Actor:     msg.Creator,
-		DID:       msg.DidDocument.Id,
-		Changes:   "DID document created",
-		IPAddress: msg.Metadata.GetIPAddress(),
-		UserAgent: msg.Metadata.GetUserAgent(),
+	if err := k.Keeper.SetDidDocument(ctx, didDoc); err != nil {
+		return nil, err
 	}
-	k.Keeper.AddAuditEntry(ctx, msg.DidDocument.Id, auditEntry)
 
 	// Emit creation event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.EventTypeCreateDID,
-			sdk.NewAttribute(types.AttributeKeyDID, msg.DidDocument.Id),
-			sdk.NewAttribute(types.AttributeKeyCreator, msg.Creator),
-			sdk.NewAttribute(types.AttributeKeyVersion, "1"),
-		),
-	)
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventDIDCreated{
+		Did:     didDoc.ID,
+		Creator: msg.Creator,
+		Version: 1,
+	}); err != nil {
+		return nil, err
+	}
 
 	return &types.MsgCreateDIDDocumentResponse{
-		Id:      msg.DidDocument.Id,
+		Id:      didDoc.ID,
 		Version: 1,
 	}, nil
 }
@@ -213,71 +289,77 @@ func (k msgServer) UpdateDIDDocument(goCtx context.Context, msg *types.MsgUpdate
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
 	// Get existing DID document
-	existingDoc, found := k.Keeper.GetDIDDocument(ctx, msg.Id)
+	existingDoc, found := k.Keeper.GetDidDocument(ctx, msg.Id)
 	if !found {
 		return nil, errorsmod.Wrapf(types.ErrDIDNotFound, "DID %s not found", msg.Id)
 	}
 
 	// Check if the document is deactivated
-	if existingDoc.Metadata != nil && existingDoc.Metadata.Status == "deactivated" {
+	if existingDoc.IsDeactivated() {
 		return nil, errorsmod.Wrapf(types.ErrDIDDeactivated, "DID %s is deactivated", msg.Id)
 	}
 
 	// Validate controller authorization
-	if !k.Keeper.IsAuthorizedController(ctx, msg.Id, msg.Controller) {
-		return nil, errorsmod.Wrapf(types.ErrUnauthorized, "controller %s not authorized for DID %s", msg.Controller, msg.Id)
+	if err := k.Keeper.ValidateControllerAuthorization(ctx, msg.Id, msg.Controller); err != nil {
+		return nil, errorsmod.Wrapf(err, "controller %s not authorized for DID %s", msg.Controller, msg.Id)
 	}
 
 	// Validate the updated document
-	if err := msg.DidDocument.Validate(); err != nil {
-		return nil, errorsmod.Wrapf(types.ErrInvalidDIDDocument, "validation failed: %v", err)
+	didDoc := msg.DidDocument
+	if err := didDoc.Validate(); err != nil {
+		return nil, errorsmod.Wrapf(types.ErrInvalidDID, "validation failed: %v", err)
 	}
 
-	// Update version and timestamps
-	now := time.Now()
-	msg.DidDocument.Updated = now.Format(time.RFC3339)
-	newVersion := existingDoc.Version + 1
-	msg.DidDocument.Version = newVersion
+	// Keys that are new in this version must be well formed and prove possession
+	addedKeys, err := k.Keeper.addedVerificationMethods(ctx, existingDoc, &didDoc)
+	if err != nil {
+		return nil, err
+	}
 
-	// Update enterprise metadata
-	if msg.DidDocument.Metadata == nil {
-		msg.DidDocument.Metadata = existingDoc.Metadata
+	newVersion := existingDoc.Version + 1
+	if err := k.Keeper.VerifyKeyPossessions(ctx, &didDoc, addedKeys, newVersion, msg.KeyProofs); err != nil {
+		return nil, err
 	}
-	if msg.DidDocument.Metadata != nil {
-		msg.DidDocument.Metadata.UpdatedAt = now.Unix()
-		msg.DidDocument.Metadata.Version = newVersion
-		msg.DidDocument.Metadata.UpdatedBy = msg.Controller
+
+	// Authentication keys under a pre-rotation commitment change only by rotation
+	if err := checkPreRotation(ctx, existingDoc, &didDoc); err != nil {
+		return nil, err
 	}
 
+	// Preserve chain-managed fields
+	didDoc.Creator = existingDoc.Creator
+	didDoc.CreatedAt = existingDoc.CreatedAt
+	didDoc.Status = existingDoc.Status
+	didDoc.Metadata.Created = existingDoc.Metadata.Created
+	didDoc.Metadata.Deactivated = existingDoc.Metadata.Deactivated
+	didDoc.Metadata.DeactivatedAt = existingDoc.Metadata.DeactivatedAt
+	didDoc.Metadata.VersionID = fmt.Sprintf("%d", newVersion)
+	didDoc.Version = newVersion
+	didDoc.UpdatedBy = msg.Controller
+
 	// Store the updated document
-	k.Keeper.SetDIDDocument(ctx, *msg.DidDocument)
+	if err := k.Keeper.SetDidDocument(ctx, didDoc); err != nil {
+		return nil, err
+	}
 
 	// Create audit entry
-	changes, _ := json.Marshal(map[string]interface{}{
-		"previous_version": existingDoc.Version,
-		"new_version":      newVersion,
-		"updated_fields":   msg.UpdatedFields,
-	})
-	auditEntry := types.AuditEntry{
-		Timestamp: now.Unix(),
-		Action:    "update",
-		Actor:     msg.Controller,
-		DID:       msg.Id,
-		Changes:   string(changes),
-		IPAddress: msg.Metadata.GetIPAddress(),
-		UserAgent: msg.Metadata.GetUserAgent(),
-	}
-	k.Keeper.AddAuditEntry(ctx, msg.Id, auditEntry)
+	if k.auditEnabled {
+		if err := k.recordAuditLog(ctx, "update", msg.Id, msg.Controller, map[string]interface{}{
+			"previous_version": existingDoc.Version,
+			"new_version":      newVersion,
+		}); err != nil {
+			k.Logger(ctx).Error("Failed to record audit log", "did", msg.Id, "error", err)
+		}
+	}
 
 	// Emit update event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.EventTypeUpdateDID,
-			sdk.NewAttribute(types.AttributeKeyDID, msg.Id),
-			sdk.NewAttribute(types.AttributeKeyController, msg.Controller),
-			sdk.NewAttribute(types.AttributeKeyVersion, fmt.Sprintf("%d", newVersion)),
-		),
-	)
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventDIDUpdated{
+		Did:        msg.Id,
+		Controller: msg.Controller,
+		Version:    newVersion,
+	}); err != nil {
+		return nil, err
+	}
 
 	return &types.MsgUpdateDIDDocumentResponse{
 		Version: newVersion,
@@ -289,62 +371,48 @@ func (k msgServer) DeactivateDIDDocument(goCtx context.Context, msg *types.MsgDe
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
 	// Get existing DID document
-	didDoc, found := k.Keeper.GetDIDDocument(ctx, msg.Id)
+	didDoc, found := k.Keeper.GetDidDocument(ctx, msg.Id)
 	if !found {
 		return nil, errorsmod.Wrapf(types.ErrDIDNotFound, "DID %s not found", msg.Id)
 	}
 
 	// Check if already deactivated
-	if didDoc.Metadata != nil && didDoc.Metadata.Status == "deactivated" {
-		return nil, errorsmod.Wrapf(types.ErrDIDAlreadyDeactivated, "DID %s already deactivated", msg.Id)
+	if didDoc.IsDeactivated() {
+		return nil, errorsmod.Wrapf(types.ErrDIDDeactivated, "DID %s already deactivated", msg.Id)
 	}
 
 	// Validate controller authorization
-	if !k.Keeper.IsAuthorizedController(ctx, msg.Id, msg.Controller) {
-		return nil, errorsmod.Wrapf(types.ErrUnauthorized, "controller %s not authorized for DID %s", msg.Controller, msg.Id)
+	if err := k.Keeper.ValidateControllerAuthorization(ctx, msg.Id, msg.Controller); err != nil {
+		return nil, errorsmod.Wrapf(err, "controller %s not authorized for DID %s", msg.Controller, msg.Id)
 	}
 
 	// Update document status
-	now := time.Now()
-	didDoc.Updated = now.Format(time.RFC3339)
+	now := clock.Now(ctx)
 	didDoc.Version++
+	didDoc.Metadata.Deactivated = true
+	didDoc.Metadata.DeactivatedAt = &now
+	didDoc.Status.State = types.DIDStateRevoked
+	didDoc.Status.Reason = msg.Reason
+	didDoc.Status.UpdatedAt = now
+	didDoc.Status.UpdatedBy = msg.Controller
+	didDoc.UpdatedBy = msg.Controller
+	didDoc.Prune()
 
-	if didDoc.Metadata != nil {
-		didDoc.Metadata.Status = "deactivated"
-		didDoc.Metadata.UpdatedAt = now.Unix()
-		didDoc.Metadata.UpdatedBy = msg.Controller
-		didDoc.Metadata.DeactivatedAt = &now.Unix()
-		didDoc.Metadata.DeactivationReason = msg.Reason
+	// Store the deactivated document, which refunds the storage deposit
+	if err := k.Keeper.SetDidDocument(ctx, didDoc); err != nil {
+		return nil, err
 	}
 
-	// Store the deactivated document
-	k.Keeper.SetDIDDocument(ctx, didDoc)
-
-	// Create audit entry
-	auditEntry := types.AuditEntry{
-		Timestamp: now.Unix(),
-		Action:    "deactivate",
-		Actor:     msg.Controller,
-		DID:       msg.Id,
-		Changes:   fmt.Sprintf("DID deactivated. Reason: %s", msg.Reason),
-		IPAddress: msg.Metadata.GetIPAddress(),
-		UserAgent: msg.Metadata.GetUserAgent(),
+	// Emit deactivation event
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventDIDDeactivated{
+		Did:        msg.Id,
+		Controller: msg.Controller,
+		Reason:     msg.Reason,
+	}); err != nil {
+		return nil, err
 	}
-	k.Keeper.AddAuditEntry(ctx, msg.Id, auditEntry)
 
-	// Emit deactivation event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.EventTypeDeactivateDID,
-			sdk.NewAttribute(types.AttributeKeyDID, msg.Id),
-			sdk.NewAttribute(types.AttributeKeyController, msg.Controller),
-			sdk.NewAttribute(types.AttributeKeyReason, msg.Reason),
-		),
-	)
-
-	return &types.MsgDeactivateDIDDocumentResponse{
-		Deactivated: true,
-	}, nil
+	return &types.MsgDeactivateDIDDocumentResponse{}, nil
 }
 
 // AddVerificationMethod adds a new verification method to a DID document
@@ -352,508 +420,437 @@ func (k msgServer) AddVerificationMethod(goCtx context.Context, msg *types.MsgAd
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
 	// Get existing DID document
-	didDoc, found := k.Keeper.GetDIDDocument(ctx, msg.Id)
+	didDoc, found := k.Keeper.GetDidDocument(ctx, msg.Id)
 	if !found {
 		return nil, errorsmod.Wrapf(types.ErrDIDNotFound, "DID %s not found", msg.Id)
 	}
 
 	// Check if the document is deactivated
-	if didDoc.Metadata != nil && didDoc.Metadata.Status == "deactivated" {
+	if didDoc.IsDeactivated() {
 		return nil, errorsmod.Wrapf(types.ErrDIDDeactivated, "DID %s is deactivated", msg.Id)
 	}
 
-	// Validate controller authorization
-	if !k.Keeper.IsAuthorizedController(ctx, msg.Id, msg.Controller) {
-		return nil, errorsmod.Wrapf(types.ErrUnauthorized, "controller %s not authorized for DID %s", msg.Controller, msg.Id)
+	// Authorization, key validation and proof of possession are enforced by the keeper
+	if err := k.Keeper.AddVerificationMethod(ctx, msg.Id, msg.VerificationMethod, msg.KeyProof, msg.Controller); err != nil {
+		return nil, err
 	}
 
-	// Check if verification method already exists
-	for _, vm := range didDoc.VerificationMethod {
-		if vm.Id == msg.VerificationMethod.Id {
-			return nil, errorsmod.Wrapf(types.ErrVerificationMethodExists, "verification method %s already exists", msg.VerificationMethod.Id)
-		}
+	// Emit event
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventVerificationMethodAdded{
+		Did:                  msg.Id,
+		Controller:           msg.Controller,
+		VerificationMethodId: msg.VerificationMethod.ID,
+	}); err != nil {
+		return nil, err
 	}
 
-	// Add the verification method
-	didDoc.VerificationMethod = append(didDoc.VerificationMethod, msg.VerificationMethod)
+	return &types.MsgAddVerificationMethodResponse{}, nil
+}
 
-	// Update document metadata
-	now := time.Now()
-	didDoc.Updated = now.Format(time.RFC3339)
-	didDoc.Version++
+// RevokeVerificationMethod revokes a verification method from a DID document
+func (k msgServer) RevokeVerificationMethod(goCtx context.Context, msg *types.MsgRevokeVerificationMethod) (*types.MsgRevokeVerificationMethodResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	if didDoc.Metadata != nil {
-		didDoc.Metadata.UpdatedAt = now.Unix()
-		didDoc.Metadata.Version = didDoc.Version
-		didDoc.Metadata.UpdatedBy = msg.Controller
+	// Get existing DID document
+	didDoc, found := k.Keeper.GetDidDocument(ctx, msg.Id)
+	if !found {
+		return nil, errorsmod.Wrapf(types.ErrDIDNotFound, "DID %s not found", msg.Id)
 	}
 
-	// Store the updated document
-	k.Keeper.SetDIDDocument(ctx, didDoc)
+	// Check if the document is deactivated
+	if didDoc.IsDeactivated() {
+		return nil, errorsmod.Wrapf(types.ErrDIDDeactivated, "DID %s is deactivated", msg.Id)
+	}
 
-	// Create audit entry
-	changes, _ := json.Marshal(map[string]interface{}{
-		"action":               "add_verification_method",
-		"verification_method":  msg.VerificationMethod.Id,
-		"type":                msg.VerificationMethod.Type,
-	})
-	auditEntry := types.AuditEntry{
-		Timestamp: now.Unix(),
-		Action:    "add_verification_method",
-		Actor:     msg.Controller,
-		DID:       msg.Id,
-		Changes:   string(changes),
-		IPAddress: msg.Metadata.GetIPAddress(),
-		UserAgent: msg.Metadata.GetUserAgent(),
-	}
-	k.Keeper.AddAuditEntry(ctx, msg.Id, auditEntry)
+	if err := k.Keeper.RevokeVerificationMethod(ctx, msg.Id, msg.MethodId, msg.Controller); err != nil {
+		return nil, err
+	}
 
 	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.EventTypeAddVerificationMethod,
-			sdk.NewAttribute(types.AttributeKeyDID, msg.Id),
-			sdk.NewAttribute(types.AttributeKeyController, msg.Controller),
-			sdk.NewAttribute(types.AttributeKeyVerificationMethod, msg.VerificationMethod.Id),
-		),
-	)
-
-	return &types.MsgAddVerificationMethodResponse{
-		Success: true,
-	}, nil
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventVerificationMethodRevoked{
+		Did:                  msg.Id,
+		Controller:           msg.Controller,
+		VerificationMethodId: msg.MethodId,
+		Reason:               msg.Reason,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgRevokeVerificationMethodResponse{}, nil
 }
 
-// RevokeVerificationMethod revokes a verification method from a DID document
-func (k msgServer) RevokeVerificationMethod(goCtx context.Context, msg *types.MsgRevokeVerificationMethod) (*types.MsgRevokeVerificationMethodResponse, error) {
+// AddService adds a new service to a DID document
+func (k msgServer) AddService(goCtx context.Context, msg *types.MsgAddService) (*types.MsgAddServiceResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
 	// Get existing DID document
-	didDoc, found := k.Keeper.GetDIDDocument(ctx, msg.Id)
+	didDoc, found := k.Keeper.GetDidDocument(ctx, msg.Id)
 	if !found {
 		return nil, errorsmod.Wrapf(types.ErrDIDNotFound, "DID %s not found", msg.Id)
 	}
 
 	// Check if the document is deactivated
-	if didDoc.Metadata != nil && didDoc.Metadata.Status == "deactivated" {
+	if didDoc.IsDeactivated() {
 		return nil, errorsmod.Wrapf(types.ErrDIDDeactivated, "DID %s is deactivated", msg.Id)
 	}
 
-	// Validate controller authorization
-	if !k.Keeper.IsAuthorizedController(ctx, msg.Id, msg.Controller) {
-		return nil, errorsmod.Wrapf(types.ErrUnauthorized, "controller %s not authorized for DID %s", msg.Controller, msg.Id)
+	if err := k.Keeper.AddService(ctx, msg.Id, msg.Service, msg.Controller); err != nil {
+		return nil, err
 	}
 
-	// Find and remove the verification method
-	var newVerificationMethods []*types.VerificationMethod
-	found = false
-	for _, vm := range didDoc.VerificationMethod {
-		if vm.Id == msg.MethodId {
-			found = true
-			// Don't add to the new slice (effectively removing it)
-		} else {
-			newVerificationMethods = append(newVerificationMethods, vm)
-		}
+	// Emit event
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventServiceAdded{
+		Did:        msg.Id,
+		Controller: msg.Controller,
+		ServiceId:  msg.Service.ID,
+	}); err != nil {
+		return nil, err
 	}
 
+	return &types.MsgAddServiceResponse{}, nil
+}
+
+// RemoveService removes a service from a DID document
+func (k msgServer) RemoveService(goCtx context.Context, msg *types.MsgRemoveService) (*types.MsgRemoveServiceResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	// Get existing DID document
+	didDoc, found := k.Keeper.GetDidDocument(ctx, msg.Id)
 	if !found {
-		return nil, errorsmod.Wrapf(types.ErrVerificationMethodNotFound, "verification method %s not found", msg.MethodId)
+		return nil, errorsmod.Wrapf(types.ErrDIDNotFound, "DID %s not found", msg.Id)
 	}
 
-	// Update the verification methods
-	didDoc.VerificationMethod = newVerificationMethods
+	// Check if the document is deactivated
+	if didDoc.IsDeactivated() {
+		return nil, errorsmod.Wrapf(types.ErrDIDDeactivated, "DID %s is deactivated", msg.Id)
+	}
 
-	// Update document metadata
-	now := time.Now()
-	didDoc.Updated = now.Format(time.RFC3339)
-	didDoc.Version++
+	if err := k.Keeper.RemoveService(ctx, msg.Id, msg.ServiceId, msg.Controller); err != nil {
+		return nil, err
+	}
 
-	if didDoc.Metadata != nil {
-		didDoc.Metadata.UpdatedAt = now.Unix()
-		didDoc.Metadata.Version = didDoc.Version
-		didDoc.Metadata.UpdatedBy = msg.Controller
+	// Emit event
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventServiceRemoved{
+		Did:        msg.Id,
+		Controller: msg.Controller,
+		ServiceId:  msg.ServiceId,
+		Reason:     msg.Reason,
+	}); err != nil {
+		return nil, err
 	}
 
-	// Store the updated document
-	k.Keeper.SetDIDDocument(ctx, didDoc)
+	return &types.MsgRemoveServiceResponse{}, nil
+}
 
-	// Create audit entry
-	changes, _ := json.Marshal(map[string]interface{}{
-		"action":               "revoke_verification_method",
-		"verification_method":  msg.MethodId,
-		"reason":              msg.Reason,
-	})
-	auditEntry := types.AuditEntry{
-		Timestamp: now.Unix(),
-		Action:    "revoke_verification_method",
-		Actor:     msg.Controller,
-		DID:       msg.Id,
-		Changes:   string(changes),
-		IPAddress: msg.Metadata.GetIPAddress(),
-		UserAgent: msg.Metadata.GetUserAgent(),
-	}
-	k.Keeper.AddAuditEntry(ctx, msg.Id, auditEntry)
+// UpdateDIDStatus updates the status of a DID document
+func (k msgServer) UpdateDIDStatus(goCtx context.Context, msg *types.MsgUpdateDIDStatus) (*types.MsgUpdateDIDStatusResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.EventTypeRevokeVerificationMethod,
-			sdk.NewAttribute(types.AttributeKeyDID, msg.Id),
-			sdk.NewAttribute(types.AttributeKeyController, msg.Controller),
-			sdk.NewAttribute(types.AttributeKeyVerificationMethod, msg.MethodId),
-			sdk.NewAttribute(types.AttributeKeyReason, msg.Reason),
-		),
-	)
-
-	return &types.MsgRevokeVerificationMethodResponse{
-		Success: true,
-	}, nil
+	// The signer's role and the state transition are validated by the keeper,
+	// which emits EventDIDStatusChanged
+	if _, err := k.Keeper.TransitionStatus(ctx, msg.Id, types.DIDState(msg.Status), msg.Reason, msg.Until, msg.Controller); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUpdateDIDStatusResponse{}, nil
 }
 
-// AddService adds a new service to a DID document
-func (k msgServer) AddService(goCtx context.Context, msg *types.MsgAddService) (*types.MsgAddServiceResponse, error) {
+// RotateKeys replaces the authentication keys of a DID with its pre-committed
+// next keys
+func (k msgServer) RotateKeys(goCtx context.Context, msg *types.MsgRotateKeys) (*types.MsgRotateKeysResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
 	// Get existing DID document
-	didDoc, found := k.Keeper.GetDIDDocument(ctx, msg.Id)
+	didDoc, found := k.Keeper.GetDidDocument(ctx, msg.Id)
 	if !found {
 		return nil, errorsmod.Wrapf(types.ErrDIDNotFound, "DID %s not found", msg.Id)
 	}
 
 	// Check if the document is deactivated
-	if didDoc.Metadata != nil && didDoc.Metadata.Status == "deactivated" {
+	if didDoc.IsDeactivated() {
 		return nil, errorsmod.Wrapf(types.ErrDIDDeactivated, "DID %s is deactivated", msg.Id)
 	}
 
-	// Validate controller authorization
-	if !k.Keeper.IsAuthorizedController(ctx, msg.Id, msg.Controller) {
-		return nil, errorsmod.Wrapf(types.ErrUnauthorized, "controller %s not authorized for DID %s", msg.Controller, msg.Id)
+	// Authorization, the commitment and proofs of possession are enforced by the keeper
+	newVersion, err := k.Keeper.RotateKeys(ctx, msg.Id, msg.NewKeys, msg.NextKeyCommitment, msg.KeyProofs, msg.Controller)
+	if err != nil {
+		return nil, err
 	}
 
-	// Check if service already exists
-	for _, service := range didDoc.Service {
-		if service.Id == msg.Service.Id {
-			return nil, errorsmod.Wrapf(types.ErrServiceExists, "service %s already exists", msg.Service.Id)
+	// Create audit entry
+	if k.auditEnabled {
+		if err := k.recordAuditLog(ctx, "rotate_keys", msg.Id, msg.Controller, map[string]interface{}{
+			"previous_version": didDoc.Version,
+			"new_version":      newVersion,
+		}); err != nil {
+			k.Logger(ctx).Error("Failed to record audit log", "did", msg.Id, "error", err)
 		}
 	}
 
-	// Add the service
-	didDoc.Service = append(didDoc.Service, msg.Service)
+	// Emit event
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventKeysRotated{
+		Did:               msg.Id,
+		Controller:        msg.Controller,
+		Version:           newVersion,
+		NextKeyCommitment: msg.NextKeyCommitment,
+	}); err != nil {
+		return nil, err
+	}
 
-	// Update document metadata
-	now := time.Now()
-	didDoc.Updated = now.Format(time.RFC3339)
-	didDoc.Version++
+	return &types.MsgRotateKeysResponse{
+		Version: newVersion,
+	}, nil
+}
+
+// RegisterHandle registers a human-readable handle to a DID
+func (k msgServer) RegisterHandle(goCtx context.Context, msg *types.MsgRegisterHandle) (*types.MsgRegisterHandleResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	if didDoc.Metadata != nil {
-		didDoc.Metadata.UpdatedAt = now.Unix()
-		didDoc.Metadata.Version = didDoc.Version
-		didDoc.Metadata.UpdatedBy = msg.Controller
+	// Authorization, reservations and fees are enforced by the keeper
+	handle, err := k.Keeper.RegisterHandle(ctx, msg.Did, msg.Handle, msg.Controller)
+	if err != nil {
+		return nil, err
 	}
 
-	// Store the updated document
-	k.Keeper.SetDIDDocument(ctx, didDoc)
+	return &types.MsgRegisterHandleResponse{
+		ExpiresAt: handle.ExpiresAt,
+	}, nil
+}
 
-	// Create audit entry
-	changes, _ := json.Marshal(map[string]interface{}{
-		"action":      "add_service",
-		"service_id":  msg.Service.Id,
-		"service_type": msg.Service.Type,
-		"endpoint":    msg.Service.ServiceEndpoint,
-	})
-	auditEntry := types.AuditEntry{
-		Timestamp: now.Unix(),
-		Action:    "add_service",
-		Actor:     msg.Controller,
-		DID:       msg.Id,
-		Changes:   string(changes),
-		IPAddress: msg.Metadata.GetIPAddress(),
-		UserAgent: msg.Metadata.GetUserAgent(),
-	}
-	k.Keeper.AddAuditEntry(ctx, msg.Id, auditEntry)
+// RenewHandle extends a handle registration
+func (k msgServer) RenewHandle(goCtx context.Context, msg *types.MsgRenewHandle) (*types.MsgRenewHandleResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.EventTypeAddService,
-			sdk.NewAttribute(types.AttributeKeyDID, msg.Id),
-			sdk.NewAttribute(types.AttributeKeyController, msg.Controller),
-			sdk.NewAttribute(types.AttributeKeyService, msg.Service.Id),
-		),
-	)
-
-	return &types.MsgAddServiceResponse{
-		Success: true,
+	handle, err := k.Keeper.RenewHandle(ctx, msg.Handle, msg.Controller)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgRenewHandleResponse{
+		ExpiresAt: handle.ExpiresAt,
 	}, nil
 }
 
-// RemoveService removes a service from a DID document
-func (k msgServer) RemoveService(goCtx context.Context, msg *types.MsgRemoveService) (*types.MsgRemoveServiceResponse, error) {
+// TransferHandle moves a handle to another DID
+func (k msgServer) TransferHandle(goCtx context.Context, msg *types.MsgTransferHandle) (*types.MsgTransferHandleResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	// Get existing DID document
-	didDoc, found := k.Keeper.GetDIDDocument(ctx, msg.Id)
-	if !found {
-		return nil, errorsmod.Wrapf(types.ErrDIDNotFound, "DID %s not found", msg.Id)
+	if err := k.Keeper.TransferHandle(ctx, msg.Handle, msg.NewDid, msg.Controller); err != nil {
+		return nil, err
 	}
 
-	// Check if the document is deactivated
-	if didDoc.Metadata != nil && didDoc.Metadata.Status == "deactivated" {
-		return nil, errorsmod.Wrapf(types.ErrDIDDeactivated, "DID %s is deactivated", msg.Id)
-	}
+	return &types.MsgTransferHandleResponse{}, nil
+}
 
-	// Validate controller authorization
-	if !k.Keeper.IsAuthorizedController(ctx, msg.Id, msg.Controller) {
-		return nil, errorsmod.Wrapf(types.ErrUnauthorized, "controller %s not authorized for DID %s", msg.Controller, msg.Id)
-	}
-
-	// Find and remove the service
-	var newServices []*types.Service
-	found = false
-	for _, service := range didDoc.Service {
-		if service.Id == msg.ServiceId {
-			found = true
-			// Don't add to the new slice (effectively removing it)
-		} else {
-			newServices = append(newServices, service)
-		}
-	}
+// SetPrimaryHandle chooses the primary handle of a DID
+func (k msgServer) SetPrimaryHandle(goCtx context.Context, msg *types.MsgSetPrimaryHandle) (*types.MsgSetPrimaryHandleResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	if !found {
-		return nil, errorsmod.Wrapf(types.ErrServiceNotFound, "service %s not found", msg.ServiceId)
+	if err := k.Keeper.SetPrimaryHandle(ctx, msg.Did, msg.Handle, msg.Controller); err != nil {
+		return nil, err
 	}
 
-	// Update the services
-	didDoc.Service = newServices
+	return &types.MsgSetPrimaryHandleResponse{}, nil
+}
 
-	// Update document metadata
-	now := time.Now()
-	didDoc.Updated = now.Format(time.RFC3339)
-	didDoc.Version++
+// BatchUpdateDID applies several document changes as a single new version
+func (k msgServer) BatchUpdateDID(goCtx context.Context, msg *types.MsgBatchUpdateDID) (*types.MsgBatchUpdateDIDResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	if didDoc.Metadata != nil {
-		didDoc.Metadata.UpdatedAt = now.Unix()
-		didDoc.Metadata.Version = didDoc.Version
-		didDoc.Metadata.UpdatedBy = msg.Controller
+	// Authorization, atomicity and proofs of possession are enforced by the keeper
+	newVersion, err := k.Keeper.BatchUpdate(ctx, msg.Id, msg.Operations, msg.KeyProofs, msg.Controller)
+	if err != nil {
+		return nil, err
 	}
 
-	// Store the updated document
-	k.Keeper.SetDIDDocument(ctx, didDoc)
+	opTypes := make([]string, len(msg.Operations))
+	for i, op := range msg.Operations {
+		opTypes[i] = op.Type
+	}
 
 	// Create audit entry
-	changes, _ := json.Marshal(map[string]interface{}{
-		"action":     "remove_service",
-		"service_id": msg.ServiceId,
-		"reason":     msg.Reason,
-	})
-	auditEntry := types.AuditEntry{
-		Timestamp: now.Unix(),
-		Action:    "remove_service",
-		Actor:     msg.Controller,
-		DID:       msg.Id,
-		Changes:   string(changes),
-		IPAddress: msg.Metadata.GetIPAddress(),
-		UserAgent: msg.Metadata.GetUserAgent(),
-	}
-	k.Keeper.AddAuditEntry(ctx, msg.Id, auditEntry)
+	if k.auditEnabled {
+		if err := k.recordAuditLog(ctx, "batch_update_did", msg.Id, msg.Controller, map[string]interface{}{
+			"operations":  opTypes,
+			"new_version": newVersion,
+		}); err != nil {
+			k.Logger(ctx).Error("Failed to record audit log", "did", msg.Id, "error", err)
+		}
+	}
 
 	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.EventTypeRemoveService,
-			sdk.NewAttribute(types.AttributeKeyDID, msg.Id),
-			sdk.NewAttribute(types.AttributeKeyController, msg.Controller),
-			sdk.NewAttribute(types.AttributeKeyService, msg.ServiceId),
-			sdk.NewAttribute(types.AttributeKeyReason, msg.Reason),
-		),
-	)
-
-	return &types.MsgRemoveServiceResponse{
-		Success: true,
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventDIDBatchUpdated{
+		Did:        msg.Id,
+		Controller: msg.Controller,
+		Version:    newVersion,
+		Operations: opTypes,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgBatchUpdateDIDResponse{
+		Version: newVersion,
 	}, nil
 }
 
-// UpdateDIDStatus updates the status of a DID document
-func (k msgServer) UpdateDIDStatus(goCtx context.Context, msg *types.MsgUpdateDIDStatus) (*types.MsgUpdateDIDStatusResponse, error) {
+// SetUpdatePolicy sets or removes the threshold policy of a DID
+func (k msgServer) SetUpdatePolicy(goCtx context.Context, msg *types.MsgSetUpdatePolicy) (*types.MsgSetUpdatePolicyResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	// Get existing DID document
-	didDoc, found := k.Keeper.GetDIDDocument(ctx, msg.Id)
-	if !found {
-		return nil, errorsmod.Wrapf(types.ErrDIDNotFound, "DID %s not found", msg.Id)
+	if err := k.Keeper.SetUpdatePolicy(ctx, msg.Id, msg.Threshold, msg.Controllers, msg.Controller); err != nil {
+		return nil, err
 	}
 
-	// Validate controller authorization
-	if !k.Keeper.IsAuthorizedController(ctx, msg.Id, msg.Controller) {
-		return nil, errorsmod.Wrapf(types.ErrUnauthorized, "controller %s not authorized for DID %s", msg.Controller, msg.Id)
+	// Create audit entry
+	if k.auditEnabled {
+		if err := k.recordAuditLog(ctx, "set_update_policy", msg.Id, msg.Controller, map[string]interface{}{
+			"threshold":   msg.Threshold,
+			"controllers": msg.Controllers,
+		}); err != nil {
+			k.Logger(ctx).Error("Failed to record audit log", "did", msg.Id, "error", err)
+		}
 	}
 
-	// Validate status transition
-	currentStatus := "active"
-	if didDoc.Metadata != nil {
-		currentStatus = didDoc.Metadata.Status
+	return &types.MsgSetUpdatePolicyResponse{}, nil
+}
+
+// ProposeDIDUpdate stores DID messages as a pending update
+func (k msgServer) ProposeDIDUpdate(goCtx context.Context, msg *types.MsgProposeDIDUpdate) (*types.MsgProposeDIDUpdateResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	update, executed, err := k.Keeper.ProposeUpdate(ctx, msg.Id, msg.Messages, msg.Proposer)
+	if err != nil {
+		return nil, err
 	}
 
-	if !k.Keeper.IsValidStatusTransition(ctx, currentStatus, msg.Status) {
-		return nil, errorsmod.Wrapf(types.ErrInvalidStatusTransition, "invalid status transition from %s to %s", currentStatus, msg.Status)
+	return &types.MsgProposeDIDUpdateResponse{
+		UpdateId: update.Id,
+		Executed: executed,
+	}, nil
+}
+
+// ApproveDIDUpdate approves a pending update
+func (k msgServer) ApproveDIDUpdate(goCtx context.Context, msg *types.MsgApproveDIDUpdate) (*types.MsgApproveDIDUpdateResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	executed, err := k.Keeper.ApproveUpdate(ctx, msg.UpdateId, msg.Approver)
+	if err != nil {
+		return nil, err
 	}
 
-	// Update document status
-	now := time.Now()
-	didDoc.Updated = now.Format(time.RFC3339)
-	didDoc.Version++
+	return &types.MsgApproveDIDUpdateResponse{
+		Executed: executed,
+	}, nil
+}
+
+// ExecuteDIDUpdate executes DID messages approved by every transaction signer
+func (k msgServer) ExecuteDIDUpdate(goCtx context.Context, msg *types.MsgExecuteDIDUpdate) (*types.MsgExecuteDIDUpdateResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	if didDoc.Metadata == nil {
-		didDoc.Metadata = &types.DocumentMetadata{}
+	msgs, err := msg.GetMsgs()
+	if err != nil {
+		return nil, err
+	}
+	if err := k.Keeper.ExecuteUpdate(ctx, msg.Id, msgs, msg.Signers); err != nil {
+		return nil, err
 	}
-	didDoc.Metadata.Status = msg.Status
-	didDoc.Metadata.UpdatedAt = now.Unix()
-	didDoc.Metadata.Version = didDoc.Version
-	didDoc.Metadata.UpdatedBy = msg.Controller
 
-	// Store the updated document
-	k.Keeper.SetDIDDocument(ctx, didDoc)
+	return &types.MsgExecuteDIDUpdateResponse{}, nil
+}
+
+// DelegateCapability stores a capability signed by the delegator DID
+func (k msgServer) DelegateCapability(goCtx context.Context, msg *types.MsgDelegateCapability) (*types.MsgDelegateCapabilityResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	id, err := k.Keeper.DelegateCapability(ctx, msg.Capability())
+	if err != nil {
+		return nil, err
+	}
 
 	// Create audit entry
-	changes, _ := json.Marshal(map[string]interface{}{
-		"action":          "update_status",
-		"previous_status": currentStatus,
-		"new_status":      msg.Status,
-		"reason":          msg.Reason,
-	})
-	auditEntry := types.AuditEntry{
-		Timestamp: now.Unix(),
-		Action:    "update_status",
-		Actor:     msg.Controller,
-		DID:       msg.Id,
-		Changes:   string(changes),
-		IPAddress: msg.Metadata.GetIPAddress(),
-		UserAgent: msg.Metadata.GetUserAgent(),
-	}
-	k.Keeper.AddAuditEntry(ctx, msg.Id, auditEntry)
+	if k.auditEnabled {
+		if err := k.recordAuditLog(ctx, "delegate_capability", msg.Delegator, msg.Submitter, map[string]interface{}{
+			"capability":        id,
+			"parent_capability": msg.ParentCapability,
+			"invoker":           msg.Invoker,
+			"invocation_target": msg.InvocationTarget,
+		}); err != nil {
+			k.Logger(ctx).Error("Failed to record audit log", "did", msg.Delegator, "error", err)
+		}
+	}
 
-	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.EventTypeUpdateDIDStatus,
-			sdk.NewAttribute(types.AttributeKeyDID, msg.Id),
-			sdk.NewAttribute(types.AttributeKeyController, msg.Controller),
-			sdk.NewAttribute(types.AttributeKeyStatus, msg.Status),
-			sdk.NewAttribute(types.AttributeKeyReason, msg.Reason),
-		),
-	)
-
-	return &types.MsgUpdateDIDStatusResponse{
-		Success: true,
+	return &types.MsgDelegateCapabilityResponse{
+		CapabilityId: id,
 	}, nil
 }
 
-// RecoverDID recovers a DID document using guardian multi-party control
-func (k msgServer) RecoverDID(goCtx context.Context, msg *types.MsgRecoverDID) (*types.MsgRecoverDIDResponse, error) {
+// InvokeCapability verifies an invocation and counts its use
+func (k msgServer) InvokeCapability(goCtx context.Context, msg *types.MsgInvokeCapability) (*types.MsgInvokeCapabilityResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	// Get existing DID document
-	didDoc, found := k.Keeper.GetDIDDocument(ctx, msg.Id)
-	if !found {
-		return nil, errorsmod.Wrapf(types.ErrDIDNotFound, "DID %s not found", msg.Id)
+	if _, err := k.Keeper.InvokeCapability(ctx, msg.Invocation); err != nil {
+		return nil, err
 	}
 
-	// Validate guardian signatures and threshold
-	if didDoc.Metadata == nil || didDoc.Metadata.GuardianConfig == nil {
-		return nil, errorsmod.Wrapf(types.ErrNoGuardianConfig, "no guardian configuration for DID %s", msg.Id)
-	}
+	return &types.MsgInvokeCapabilityResponse{}, nil
+}
+
+// RevokeCapability revokes a capability and those derived from it
+func (k msgServer) RevokeCapability(goCtx context.Context, msg *types.MsgRevokeCapability) (*types.MsgRevokeCapabilityResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	guardianConfig := didDoc.Metadata.GuardianConfig
-	if len(msg.GuardianSignatures) < int(guardianConfig.Threshold) {
-		return nil, errorsmod.Wrapf(types.ErrInsufficientGuardianSignatures, "insufficient guardian signatures: %d required, %d provided", guardianConfig.Threshold, len(msg.GuardianSignatures))
+	if err := k.Keeper.RevokeCapability(ctx, msg.CapabilityId, msg.Revoker, msg.Controller); err != nil {
+		return nil, err
 	}
 
-	// Validate each guardian signature
-	validSignatures := 0
-	for _, sig := range msg.GuardianSignatures {
-		if k.Keeper.IsValidGuardianSignature(ctx, msg.Id, sig) {
-			validSignatures++
+	// Create audit entry
+	if k.auditEnabled {
+		if err := k.recordAuditLog(ctx, "revoke_capability", msg.Revoker, msg.Controller, map[string]interface{}{
+			"capability": msg.CapabilityId,
+		}); err != nil {
+			k.Logger(ctx).Error("Failed to record audit log", "did", msg.Revoker, "error", err)
 		}
 	}
 
-	if validSignatures < int(guardianConfig.Threshold) {
-		return nil, errorsmod.Wrapf(types.ErrInvalidGuardianSignatures, "invalid guardian signatures: %d valid required, %d provided", guardianConfig.Threshold, validSignatures)
+	return &types.MsgRevokeCapabilityResponse{}, nil
+}
+
+// CreateResource publishes a DID-linked resource
+func (k msgServer) CreateResource(goCtx context.Context, msg *types.MsgCreateResource) (*types.MsgCreateResourceResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	metadata, err := k.Keeper.CreateResource(ctx, msg.Metadata(), msg.Data, msg.Controller)
+	if err != nil {
+		return nil, err
 	}
 
-	// Apply recovery changes
-	if msg.NewDocument != nil {
-		// Validate the new document
-		if err := msg.NewDocument.Validate(); err != nil {
-			return nil, errorsmod.Wrapf(types.ErrInvalidDIDDocument, "validation failed: %v", err)
+	// Create audit entry
+	if k.auditEnabled {
+		if err := k.recordAuditLog(ctx, "create_resource", msg.Did, msg.Controller, map[string]interface{}{
+			"resource":      metadata.Id,
+			"name":          metadata.Name,
+			"resource_type": metadata.ResourceType,
+			"checksum":      metadata.Checksum,
+		}); err != nil {
+			k.Logger(ctx).Error("Failed to record audit log", "did", msg.Did, "error", err)
 		}
+	}
 
-		// Preserve essential metadata
-		msg.NewDocument.Id = msg.Id
-		msg.NewDocument.Created = didDoc.Created
-		msg.NewDocument.Version = didDoc.Version + 1
-		
-		now := time.Now()
-		msg.NewDocument.Updated = now.Format(time.RFC3339)
+	return &types.MsgCreateResourceResponse{
+		Checksum: metadata.Checksum,
+	}, nil
+}
 
-		if msg.NewDocument.Metadata == nil {
-			msg.NewDocument.Metadata = didDoc.Metadata
-		}
-		msg.NewDocument.Metadata.UpdatedAt = now.Unix()
-		msg.NewDocument.Metadata.Version = msg.NewDocument.Version
-		msg.NewDocument.Metadata.UpdatedBy = "guardian-recovery"
-		msg.NewDocument.Metadata.Status = "active"
-
-		// Store the recovered document
-		k.Keeper.SetDIDDocument(ctx, *msg.NewDocument)
-		didDoc = *msg.NewDocument
-	} else {
-		// Just reactivate the existing document
-		now := time.Now()
-		didDoc.Updated = now.Format(time.RFC3339)
-		didDoc.Version++
-
-		if didDoc.Metadata != nil {
-			didDoc.Metadata.Status = "active"
-			didDoc.Metadata.UpdatedAt = now.Unix()
-			didDoc.Metadata.Version = didDoc.Version
-			didDoc.Metadata.UpdatedBy = "guardian-recovery"
-			didDoc.Metadata.DeactivatedAt = nil
-			didDoc.Metadata.DeactivationReason = ""
-		}
+// RequestRemoteResolution resolves a DID on another chain over IBC
+func (k msgServer) RequestRemoteResolution(goCtx context.Context, msg *types.MsgRequestRemoteResolution) (*types.MsgRequestRemoteResolutionResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
 
-		k.Keeper.SetDIDDocument(ctx, didDoc)
+	sequence, err := k.Keeper.RequestRemoteResolution(ctx, msg.ChannelId, msg.Did, msg.Creator)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create audit entry
-	changes, _ := json.Marshal(map[string]interface{}{
-		"action":               "recover",
-		"guardian_signatures":  len(msg.GuardianSignatures),
-		"threshold_met":        validSignatures >= int(guardianConfig.Threshold),
-		"reason":              msg.Reason,
-		"new_document_provided": msg.NewDocument != nil,
-	})
-	auditEntry := types.AuditEntry{
-		Timestamp: time.Now().Unix(),
-		Action:    "recover",
-		Actor:     "guardian-recovery",
-		DID:       msg.Id,
-		Changes:   string(changes),
-		IPAddress: msg.Metadata.GetIPAddress(),
-		UserAgent: msg.Metadata.GetUserAgent(),
-	}
-	k.Keeper.AddAuditEntry(ctx, msg.Id, auditEntry)
-
-	// Emit recovery event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.EventTypeRecoverDID,
-			sdk.NewAttribute(types.AttributeKeyDID, msg.Id),
-			sdk.NewAttribute(types.AttributeKeyGuardians, fmt.Sprintf("%d", validSignatures)),
-			sdk.NewAttribute(types.AttributeKeyReason, msg.Reason),
-		),
-	)
-
-	return &types.MsgRecoverDIDResponse{
-		Success: true,
-		Version: didDoc.Version,
+	return &types.MsgRequestRemoteResolutionResponse{
+		Sequence: sequence,
 	}, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,246 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestCreateDIDDocument(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID)
+	msgServer := keeper.NewMsgServerImpl(k)
+	creator := testAddress("creator")
+
+	const did = "did:persona:abc"
+	key := newEd25519Key(t, did, "key-1")
+	createTestDID(t, ctx, k, "did:persona:existing", creator, newEd25519Key(t, "did:persona:existing", "key-1"))
+
+	tests := []struct {
+		name   string
+		doc    types.DIDDocument
+		proofs []types.KeyProof
+		err    error
+	}{
+		{
+			name:   "valid",
+			doc:    newTestDocument(did, creator, key),
+			proofs: []types.KeyProof{key.proof(did, 1)},
+		},
+		{
+			name: "missing proof",
+			doc:  newTestDocument(did, creator, key),
+			err:  types.ErrMissingKeyProof,
+		},
+		{
+			name: "invalid DID",
+			doc:  newTestDocument("persona:abc", creator),
+			err:  types.ErrInvalidDID,
+		},
+		{
+			name: "already exists",
+			doc:  newTestDocument("did:persona:existing", creator),
+			err:  types.ErrDIDAlreadyExists,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			res, err := msgServer.CreateDIDDocument(cacheCtx, &types.MsgCreateDIDDocument{
+				Creator:     creator,
+				DidDocument: tc.doc,
+				KeyProofs:   tc.proofs,
+			})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, uint64(1), res.Version)
+
+			stored, found := k.GetDidDocument(cacheCtx, did)
+			require.True(t, found)
+			require.Equal(t, creator, stored.Creator)
+			require.Equal(t, types.DIDStateActive, stored.Status.State)
+			require.Equal(t, "1", stored.Metadata.VersionID)
+		})
+	}
+}
+
+func TestUpdateDIDDocument(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID)
+	msgServer := keeper.NewMsgServerImpl(k)
+	creator := testAddress("creator")
+
+	const did = "did:persona:abc"
+	key := newEd25519Key(t, did, "key-1")
+	created := createTestDID(t, ctx, k, did, creator, key)
+	added := newEd25519Key(t, did, "key-2")
+
+	withKey := created
+	withKey.VerificationMethod = append(append([]types.VerificationMethod{}, created.VerificationMethod...), added.vm)
+	withKey.AssertionMethod = []string{added.vm.ID}
+
+	// A different key reusing the ID of the stored one
+	swapped := newEd25519Key(t, did, "key-1")
+	withSwappedKey := created
+	withSwappedKey.VerificationMethod = []types.VerificationMethod{swapped.vm}
+
+	tests := []struct {
+		name       string
+		controller string
+		doc        types.DIDDocument
+		proofs     []types.KeyProof
+		err        error
+	}{
+		{name: "unchanged keys", controller: creator, doc: created},
+		{name: "added key with proof", controller: creator, doc: withKey, proofs: []types.KeyProof{added.proof(did, 2)}},
+		{name: "added key without proof", controller: creator, doc: withKey, err: types.ErrMissingKeyProof},
+		{name: "swapped key with proof", controller: creator, doc: withSwappedKey, proofs: []types.KeyProof{swapped.proof(did, 2)}},
+		{name: "swapped key without proof", controller: creator, doc: withSwappedKey, err: types.ErrMissingKeyProof},
+		{name: "unauthorized controller", controller: testAddress("mallory"), doc: created, err: types.ErrUnauthorized},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			res, err := msgServer.UpdateDIDDocument(cacheCtx, &types.MsgUpdateDIDDocument{
+				Controller:  tc.controller,
+				Id:          did,
+				DidDocument: tc.doc,
+				KeyProofs:   tc.proofs,
+			})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, uint64(2), res.Version)
+
+			stored, found := k.GetDidDocument(cacheCtx, did)
+			require.True(t, found)
+			require.Equal(t, uint64(2), stored.Version)
+			require.Equal(t, creator, stored.Creator)
+			require.Equal(t, tc.controller, stored.UpdatedBy)
+
+			archived, found := k.GetDocumentVersion(cacheCtx, did, 1)
+			require.True(t, found)
+			require.Equal(t, uint64(1), archived.Version)
+		})
+	}
+}
+
+func TestUpdateDIDDocumentKeepsKeyState(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID).WithBlockTime(time.Unix(1_000_000, 0))
+	msgServer := keeper.NewMsgServerImpl(k)
+	creator := testAddress("creator")
+
+	const did = "did:persona:abc"
+	key := newEd25519Key(t, did, "key-1")
+	old := newEd25519Key(t, did, "key-2")
+	created := createTestDID(t, ctx, k, did, creator, key, old)
+
+	// Revoke key-2 and let key-1 expire, as the chain would
+	revokedAt := time.Unix(1_000_100, 0).UTC()
+	expiresAt := time.Unix(2_000_000, 0).UTC()
+	managed := created
+	managed.VerificationMethod = append([]types.VerificationMethod{}, created.VerificationMethod...)
+	managed.VerificationMethod[0].ExpiresAt = &expiresAt
+	managed.VerificationMethod[1].Revoked = true
+	managed.VerificationMethod[1].RevokedAt = &revokedAt
+	managed.Version = 2
+	require.NoError(t, k.SetDidDocument(ctx, managed))
+
+	// The controller resubmits the methods without the chain-managed fields
+	update := managed
+	update.VerificationMethod = []types.VerificationMethod{key.vm, old.vm}
+	_, err := msgServer.UpdateDIDDocument(ctx, &types.MsgUpdateDIDDocument{Controller: creator, Id: did, DidDocument: update})
+	require.NoError(t, err)
+
+	stored, found := k.GetDidDocument(ctx, did)
+	require.True(t, found)
+	require.Equal(t, uint64(3), stored.Version)
+	require.Len(t, stored.VerificationMethod, 2)
+	require.Equal(t, created.VerificationMethod[0].CreatedAt, stored.VerificationMethod[0].CreatedAt)
+	require.Equal(t, &expiresAt, stored.VerificationMethod[0].ExpiresAt)
+	require.True(t, stored.VerificationMethod[1].Revoked)
+	require.Equal(t, &revokedAt, stored.VerificationMethod[1].RevokedAt)
+}
+
+func TestUpdateDid(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID)
+	msgServer := keeper.NewMsgServerImpl(k)
+	creator := testAddress("creator")
+
+	const did = "did:persona:abc"
+	key := newEd25519Key(t, did, "key-1")
+	createTestDID(t, ctx, k, did, creator, key)
+
+	legacyJSON := func(vm types.VerificationMethod) string {
+		return `{"@context":"https://www.w3.org/ns/did/v1","id":"` + did + `","verificationMethod":[{"id":"` + vm.ID +
+			`","type":"` + vm.Type + `","controller":"` + did + `","publicKeyMultibase":"` + vm.PublicKeyMultibase +
+			`"}],"authentication":["` + vm.ID + `"]}`
+	}
+
+	tests := []struct {
+		name string
+		json string
+		err  error
+	}{
+		{name: "unchanged key", json: legacyJSON(key.vm)},
+		{name: "swapped key", json: legacyJSON(newEd25519Key(t, did, "key-1").vm), err: types.ErrMissingKeyProof},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			_, err := msgServer.UpdateDid(cacheCtx, &types.MsgUpdateDid{Creator: creator, Id: did, DidDocument: tc.json})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			stored, found := k.GetDidDocument(cacheCtx, did)
+			require.True(t, found)
+			require.Equal(t, uint64(2), stored.Version)
+			require.Equal(t, key.vm.PublicKeyMultibase, stored.VerificationMethod[0].PublicKeyMultibase)
+		})
+	}
+}
+
+func TestDeactivateDIDDocument(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID)
+	msgServer := keeper.NewMsgServerImpl(k)
+	creator := testAddress("creator")
+
+	const did = "did:persona:abc"
+	createTestDID(t, ctx, k, did, creator, newEd25519Key(t, did, "key-1"))
+
+	_, err := msgServer.DeactivateDIDDocument(ctx, &types.MsgDeactivateDIDDocument{Controller: testAddress("mallory"), Id: did})
+	require.ErrorIs(t, err, types.ErrUnauthorized)
+
+	_, err = msgServer.DeactivateDIDDocument(ctx, &types.MsgDeactivateDIDDocument{Controller: creator, Id: did, Reason: "retired"})
+	require.NoError(t, err)
+
+	stored, found := k.GetDidDocument(ctx, did)
+	require.True(t, found)
+	require.True(t, stored.IsDeactivated())
+	require.Equal(t, "retired", stored.Status.Reason)
+
+	_, err = msgServer.DeactivateDIDDocument(ctx, &types.MsgDeactivateDIDDocument{Controller: creator, Id: did})
+	require.ErrorIs(t, err, types.ErrDIDDeactivated)
+
+	_, err = msgServer.UpdateDIDDocument(ctx, &types.MsgUpdateDIDDocument{Controller: creator, Id: did, DidDocument: stored})
+	require.ErrorIs(t, err, types.ErrDIDDeactivated)
+}
@@ -0,0 +1,46 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// GetParams get all parameters as types.Params. Keys missing from the
+// subspace, such as those added after the chain started, keep their defaults.
+func (k Keeper) GetParams(ctx context.Context) (params types.Params) {
+	params = types.DefaultParams()
+	k.paramstore.GetParamSetIfExists(sdk.UnwrapSDKContext(ctx), &params)
+	return params
+}
+
+// SetParams set the params
+func (k Keeper) SetParams(ctx context.Context, params types.Params) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	k.paramstore.SetParamSet(sdk.UnwrapSDKContext(ctx), &params)
+	return nil
+}
+
+// ValidateVerificationMethodKey checks the key material of a verification
+// method. Built-in types are decoded and validated cryptographically, other
+// types must be allowlisted in the module params.
+func (k Keeper) ValidateVerificationMethodKey(ctx context.Context, vm types.VerificationMethod) error {
+	if types.IsSupportedVerificationMethodType(vm.Type) {
+		return vm.ValidateKeyMaterial()
+	}
+
+	if !k.GetParams(ctx).IsVerificationMethodTypeAllowed(vm.Type) {
+		return errors.Wrapf(types.ErrInvalidKeyType, "verification method type %q is not allowed", vm.Type)
+	}
+
+	if vm.PublicKeyMultibase == "" && len(vm.PublicKeyJwk) == 0 {
+		return errors.Wrap(types.ErrInvalidPublicKey, "verification method must have either publicKeyMultibase or publicKeyJwk")
+	}
+
+	return nil
+}
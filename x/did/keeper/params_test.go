@@ -0,0 +1,125 @@
+package keeper_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestValidateVerificationMethodKey(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+
+	params := types.DefaultParams()
+	params.AllowedVerificationMethodTypes = []string{"RsaVerificationKey2018"}
+	require.NoError(t, k.SetParams(ctx, params))
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		vm   types.VerificationMethod
+		err  error
+	}{
+		{
+			name: "built-in type with valid key",
+			vm: types.VerificationMethod{
+				Type:               types.VerificationMethodTypeEd25519VerificationKey2020,
+				PublicKeyMultibase: types.EncodeMultibaseMultikey(types.MulticodecEd25519Pub, pub),
+			},
+		},
+		{
+			name: "built-in type with invalid key",
+			vm: types.VerificationMethod{
+				Type:               types.VerificationMethodTypeEd25519VerificationKey2020,
+				PublicKeyMultibase: types.EncodeMultibaseMultikey(types.MulticodecEd25519Pub, pub[:16]),
+			},
+			err: types.ErrInvalidPublicKey,
+		},
+		{
+			name: "allowlisted type",
+			vm:   types.VerificationMethod{Type: "RsaVerificationKey2018", PublicKeyMultibase: "zAAAA"},
+		},
+		{
+			name: "allowlisted type without key",
+			vm:   types.VerificationMethod{Type: "RsaVerificationKey2018"},
+			err:  types.ErrInvalidPublicKey,
+		},
+		{
+			name: "type not allowlisted",
+			vm:   types.VerificationMethod{Type: "Bls12381G2Key2020", PublicKeyMultibase: "zAAAA"},
+			err:  types.ErrInvalidKeyType,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := k.ValidateVerificationMethodKey(ctx, tc.vm)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestGetParamsMissingKeys(t *testing.T) {
+	defaults := types.DefaultParams()
+
+	tests := []struct {
+		name  string
+		key   []byte
+		check func(t *testing.T, params types.Params)
+	}{
+		{
+			name: "remote resolution TTL",
+			key:  types.KeyRemoteResolutionTTL,
+			check: func(t *testing.T, params types.Params) {
+				require.Equal(t, defaults.RemoteResolutionTTL, params.RemoteResolutionTTL)
+			},
+		},
+		{
+			name: "channel jurisdictions",
+			key:  types.KeyChannelJurisdictions,
+			check: func(t *testing.T, params types.Params) {
+				require.Equal(t, defaults.ChannelJurisdictions, params.ChannelJurisdictions)
+			},
+		},
+		{
+			name: "handle fee",
+			key:  types.KeyHandleFee,
+			check: func(t *testing.T, params types.Params) {
+				require.Equal(t, defaults.HandleFee, params.HandleFee)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k, ctx, storeKey := keepertest.DidKeeperWithStoreKey(t)
+
+			params := types.DefaultParams()
+			params.AllowSharedKeys = true
+			require.NoError(t, k.SetParams(ctx, params))
+			paramStore(ctx, storeKey).Delete(tc.key)
+
+			got := k.GetParams(ctx)
+			tc.check(t, got)
+			require.True(t, got.AllowSharedKeys)
+		})
+	}
+}
+
+// paramStore is the raw subspace store testutil registers the params under
+func paramStore(ctx sdk.Context, storeKey storetypes.StoreKey) prefix.Store {
+	return prefix.NewStore(ctx.KVStore(storeKey), []byte("DidParams/"))
+}
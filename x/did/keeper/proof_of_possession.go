@@ -0,0 +1,132 @@
+package keeper
+
+import (
+	"context"
+	"maps"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// signingRelationships are the verification relationships whose keys are
+// used to produce signatures
+var signingRelationships = map[string]bool{
+	types.RelationshipAuthentication:       true,
+	types.RelationshipAssertionMethod:      true,
+	types.RelationshipCapabilityInvocation: true,
+	types.RelationshipCapabilityDelegation: true,
+}
+
+// VerifyKeyPossession checks that the submitter controls the private key of a
+// verification method being added to doc, the document as it will be stored.
+// version is the document version the addition produces. Key agreement only
+// keys and governance allowlisted types without a known signature scheme
+// cannot sign, so they are exempt unless doc uses them for signing.
+func (k Keeper) VerifyKeyPossession(ctx context.Context, doc *types.DIDDocument, vm types.VerificationMethod, version uint64, proof *types.KeyProof) error {
+	if !types.IsSupportedVerificationMethodType(vm.Type) || !vm.CanSign() {
+		return checkNotSigningKey(doc, vm)
+	}
+
+	if proof == nil || len(proof.Signature) == 0 {
+		return errors.Wrapf(types.ErrMissingKeyProof, "verification method %s", vm.ID)
+	}
+	if proof.VerificationMethodId != vm.ID {
+		return errors.Wrapf(types.ErrInvalidKeyProof, "proof is for %s, not %s", proof.VerificationMethodId, vm.ID)
+	}
+
+	challenge := types.PossessionChallenge(doc.ID, vm.ID, sdk.UnwrapSDKContext(ctx).ChainID(), version)
+	if err := vm.VerifySignature(challenge, proof.Signature); err != nil {
+		return errors.Wrapf(types.ErrInvalidKeyProof, "verification method %s: %v", vm.ID, err)
+	}
+
+	return nil
+}
+
+// VerifyKeyPossessions checks a proof of possession for every verification
+// method added to doc, matching proofs by verification method ID. Keys
+// already in doc that cannot prove possession must not be moved into a
+// signing relationship either.
+func (k Keeper) VerifyKeyPossessions(ctx context.Context, doc *types.DIDDocument, vms []types.VerificationMethod, version uint64, proofs []types.KeyProof) error {
+	byID := make(map[string]*types.KeyProof, len(proofs))
+	for i := range proofs {
+		if _, ok := byID[proofs[i].VerificationMethodId]; ok {
+			return errors.Wrapf(types.ErrInvalidKeyProof, "duplicate proof for %s", proofs[i].VerificationMethodId)
+		}
+		byID[proofs[i].VerificationMethodId] = &proofs[i]
+	}
+
+	added := make(map[string]bool, len(vms))
+	for _, vm := range vms {
+		if err := k.VerifyKeyPossession(ctx, doc, vm, version, byID[vm.ID]); err != nil {
+			return err
+		}
+		added[vm.ID] = true
+	}
+
+	for _, vm := range doc.VerificationMethod {
+		if added[vm.ID] || (types.IsSupportedVerificationMethodType(vm.Type) && vm.CanSign()) {
+			continue
+		}
+		if err := checkNotSigningKey(doc, vm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addedVerificationMethods returns the verification methods of doc that
+// previous does not hold with the same ID and key material, after validating
+// their keys. A method whose key changed under an existing ID counts as added.
+// Unchanged methods get back the chain-managed fields stored in previous.
+func (k Keeper) addedVerificationMethods(ctx context.Context, previous types.DIDDocument, doc *types.DIDDocument) ([]types.VerificationMethod, error) {
+	existing := make(map[string]types.VerificationMethod, len(previous.VerificationMethod))
+	for _, vm := range previous.VerificationMethod {
+		existing[vm.ID] = vm
+	}
+
+	var added []types.VerificationMethod
+	for i := range doc.VerificationMethod {
+		vm := &doc.VerificationMethod[i]
+		if prev, ok := existing[vm.ID]; ok && sameKeyMaterial(prev, *vm) {
+			vm.CreatedAt = prev.CreatedAt
+			vm.ExpiresAt = prev.ExpiresAt
+			vm.Revoked = prev.Revoked
+			vm.RevokedAt = prev.RevokedAt
+			continue
+		}
+		if err := k.ValidateVerificationMethodKey(ctx, *vm); err != nil {
+			return nil, errors.Wrapf(err, "verification method %s", vm.ID)
+		}
+		added = append(added, *vm)
+	}
+	return added, nil
+}
+
+// sameKeyMaterial reports whether two verification methods hold the same
+// public key. Keys without a fingerprint, such as governance allowlisted
+// types, are compared by their type and encoded key.
+func sameKeyMaterial(a, b types.VerificationMethod) bool {
+	fa, errA := a.KeyFingerprint()
+	fb, errB := b.KeyFingerprint()
+	if errA == nil && errB == nil {
+		return fa == fb
+	}
+	if errA == nil || errB == nil {
+		return false
+	}
+	return a.Type == b.Type && a.PublicKeyMultibase == b.PublicKeyMultibase && maps.Equal(a.PublicKeyJwk, b.PublicKeyJwk)
+}
+
+// checkNotSigningKey rejects a verification method that cannot prove
+// possession of its key when doc references it from a signing relationship
+func checkNotSigningKey(doc *types.DIDDocument, vm types.VerificationMethod) error {
+	for _, rel := range doc.VerificationRelationships(vm.ID) {
+		if signingRelationships[rel] {
+			return errors.Wrapf(types.ErrInvalidKeyType, "verification method %s of type %s cannot prove possession and must not be used for %s", vm.ID, vm.Type, rel)
+		}
+	}
+	return nil
+}
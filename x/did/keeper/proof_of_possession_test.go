@@ -0,0 +1,219 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestVerifyKeyPossession(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID)
+
+	const did = "did:persona:abc"
+	ed := newEd25519Key(t, did, "ed")
+	k1 := newSecp256k1Key(t, did, "k1")
+	p256 := newP256Key(t, did, "p256")
+	x := newX25519Key(t, did, "x")
+	other := newEd25519Key(t, did, "other")
+	rsa := types.VerificationMethod{
+		ID:                 did + "#rsa",
+		Type:               "RsaVerificationKey2018",
+		Controller:         did,
+		PublicKeyMultibase: "zAAAA",
+	}
+
+	withProof := func(key testKey, version uint64) *types.KeyProof {
+		proof := key.proof(did, version)
+		return &proof
+	}
+
+	tests := []struct {
+		name         string
+		vm           types.VerificationMethod
+		relationship string
+		proof        *types.KeyProof
+		err          error
+	}{
+		{name: "ed25519", vm: ed.vm, relationship: types.RelationshipAuthentication, proof: withProof(ed, 2)},
+		{name: "secp256k1", vm: k1.vm, relationship: types.RelationshipAssertionMethod, proof: withProof(k1, 2)},
+		{name: "P-256", vm: p256.vm, relationship: types.RelationshipCapabilityInvocation, proof: withProof(p256, 2)},
+		{name: "signing key outside any relationship", vm: ed.vm, proof: withProof(ed, 2)},
+		{name: "missing proof", vm: ed.vm, relationship: types.RelationshipAuthentication, err: types.ErrMissingKeyProof},
+		{
+			name:         "empty signature",
+			vm:           ed.vm,
+			relationship: types.RelationshipAuthentication,
+			proof:        &types.KeyProof{VerificationMethodId: ed.vm.ID},
+			err:          types.ErrMissingKeyProof,
+		},
+		{name: "proof for another key", vm: ed.vm, relationship: types.RelationshipAuthentication, proof: withProof(other, 2), err: types.ErrInvalidKeyProof},
+		{name: "proof for another version", vm: ed.vm, relationship: types.RelationshipAuthentication, proof: withProof(ed, 1), err: types.ErrInvalidKeyProof},
+		{
+			name:         "signature by another key",
+			vm:           ed.vm,
+			relationship: types.RelationshipAuthentication,
+			proof:        &types.KeyProof{VerificationMethodId: ed.vm.ID, Signature: withProof(other, 2).Signature},
+			err:          types.ErrInvalidKeyProof,
+		},
+		{name: "x25519 key agreement without proof", vm: x.vm, relationship: types.RelationshipKeyAgreement},
+		{name: "x25519 authentication", vm: x.vm, relationship: types.RelationshipAuthentication, err: types.ErrInvalidKeyType},
+		{name: "allowlisted type for key agreement", vm: rsa, relationship: types.RelationshipKeyAgreement},
+		{name: "allowlisted type for authentication", vm: rsa, relationship: types.RelationshipAuthentication, err: types.ErrInvalidKeyType},
+		{name: "allowlisted type for assertion", vm: rsa, relationship: types.RelationshipAssertionMethod, err: types.ErrInvalidKeyType},
+		{name: "allowlisted type for capability invocation", vm: rsa, relationship: types.RelationshipCapabilityInvocation, err: types.ErrInvalidKeyType},
+		{name: "allowlisted type for capability delegation", vm: rsa, relationship: types.RelationshipCapabilityDelegation, err: types.ErrInvalidKeyType},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := newTestDocument(did, "")
+			doc.VerificationMethod = []types.VerificationMethod{tc.vm}
+			switch tc.relationship {
+			case types.RelationshipAuthentication:
+				doc.Authentication = []string{tc.vm.ID}
+			case types.RelationshipAssertionMethod:
+				doc.AssertionMethod = []string{tc.vm.ID}
+			case types.RelationshipKeyAgreement:
+				doc.KeyAgreement = []string{tc.vm.ID}
+			case types.RelationshipCapabilityInvocation:
+				doc.CapabilityInvocation = []string{tc.vm.ID}
+			case types.RelationshipCapabilityDelegation:
+				doc.CapabilityDelegation = []string{tc.vm.ID}
+			}
+
+			err := k.VerifyKeyPossession(ctx, &doc, tc.vm, 2, tc.proof)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestVerifyKeyPossessions(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID)
+
+	const did = "did:persona:abc"
+	a := newEd25519Key(t, did, "a")
+	b := newEd25519Key(t, did, "b")
+	x := newX25519Key(t, did, "x")
+
+	tests := []struct {
+		name   string
+		added  []testKey
+		proofs []types.KeyProof
+		mutate func(doc *types.DIDDocument)
+		err    error
+	}{
+		{
+			name:   "every added key proven",
+			added:  []testKey{a, b},
+			proofs: []types.KeyProof{a.proof(did, 3), b.proof(did, 3)},
+		},
+		{
+			name:   "one added key unproven",
+			added:  []testKey{a, b},
+			proofs: []types.KeyProof{a.proof(did, 3)},
+			err:    types.ErrMissingKeyProof,
+		},
+		{
+			name:   "duplicate proof",
+			added:  []testKey{a},
+			proofs: []types.KeyProof{a.proof(did, 3), a.proof(did, 3)},
+			err:    types.ErrInvalidKeyProof,
+		},
+		{
+			name:  "existing key agreement key moved into capability delegation",
+			added: nil,
+			mutate: func(doc *types.DIDDocument) {
+				doc.CapabilityDelegation = append(doc.CapabilityDelegation, x.vm.ID)
+			},
+			err: types.ErrInvalidKeyType,
+		},
+		{
+			name:  "existing key agreement key referenced by fragment",
+			added: nil,
+			mutate: func(doc *types.DIDDocument) {
+				doc.Authentication = append(doc.Authentication, "#x")
+			},
+			err: types.ErrInvalidKeyType,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := newTestDocument(did, "")
+			doc.VerificationMethod = append(doc.VerificationMethod, x.vm)
+			doc.KeyAgreement = []string{x.vm.ID}
+			var vms []types.VerificationMethod
+			for _, key := range tc.added {
+				doc.VerificationMethod = append(doc.VerificationMethod, key.vm)
+				doc.Authentication = append(doc.Authentication, key.vm.ID)
+				vms = append(vms, key.vm)
+			}
+			if tc.mutate != nil {
+				tc.mutate(&doc)
+			}
+
+			err := k.VerifyKeyPossessions(ctx, &doc, vms, 3, tc.proofs)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestLegacyMessagesCannotAddSigningKeys(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID)
+	msgServer := keeper.NewMsgServerImpl(k)
+	creator := testAddress("creator")
+
+	const did = "did:persona:legacy"
+	ed := newEd25519Key(t, did, "ed")
+	x := newX25519Key(t, did, "x")
+
+	tests := []struct {
+		name     string
+		document string
+		err      error
+	}{
+		{
+			name: "signing key",
+			document: `{"id":"` + did + `","verificationMethod":[{"id":"` + ed.vm.ID + `","type":"` + ed.vm.Type +
+				`","controller":"` + did + `","publicKeyMultibase":"` + ed.vm.PublicKeyMultibase + `"}],"authentication":["` + ed.vm.ID + `"]}`,
+			err: types.ErrMissingKeyProof,
+		},
+		{
+			name: "key agreement key",
+			document: `{"id":"` + did + `","verificationMethod":[{"id":"` + x.vm.ID + `","type":"` + x.vm.Type +
+				`","controller":"` + did + `","publicKeyMultibase":"` + x.vm.PublicKeyMultibase + `"}],"keyAgreement":["` + x.vm.ID + `"]}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			_, err := msgServer.CreateDid(cacheCtx, &types.MsgCreateDid{
+				Creator:     creator,
+				Id:          did,
+				DidDocument: tc.document,
+			})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				require.ErrorContains(t, err, "MsgCreateDIDDocument")
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package keeper
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"cosmossdk.io/errors"
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+
+	"github.com/persona-chain/persona-chain/internal/clock"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// SetICS4Wrapper sets the IBC channel interface used to send remote
+// resolution packets
+func (k *Keeper) SetICS4Wrapper(wrapper types.ICS4Wrapper) {
+	k.ics4Wrapper = wrapper
+}
+
+// GetRemoteDidDocument returns the cache entry of a DID resolved over an IBC
+// channel
+func (k Keeper) GetRemoteDidDocument(ctx context.Context, did, channelID string) (types.RemoteDIDDocument, bool) {
+	b := k.policyStore(ctx, types.RemoteDIDKeyPrefix).Get(types.RemoteDIDKey(did, channelID))
+	if b == nil {
+		return types.RemoteDIDDocument{}, false
+	}
+
+	var remote types.RemoteDIDDocument
+	if err := k.cdc.Unmarshal(b, &remote); err != nil {
+		return types.RemoteDIDDocument{}, false
+	}
+	return remote, true
+}
+
+// GetRemoteDidDocuments returns the cache entries of a DID on every channel
+// it was resolved over
+func (k Keeper) GetRemoteDidDocuments(ctx context.Context, did string) []types.RemoteDIDDocument {
+	iterator := storetypes.KVStorePrefixIterator(k.policyStore(ctx, types.RemoteDIDKeyPrefix), types.DIDIndexValuePrefix(did))
+	defer iterator.Close()
+
+	var entries []types.RemoteDIDDocument
+	for ; iterator.Valid(); iterator.Next() {
+		var remote types.RemoteDIDDocument
+		if err := k.cdc.Unmarshal(iterator.Value(), &remote); err != nil {
+			continue
+		}
+		entries = append(entries, remote)
+	}
+	return entries
+}
+
+func (k Keeper) setRemoteDidDocument(ctx context.Context, remote types.RemoteDIDDocument) {
+	key := types.RemoteDIDKey(remote.DID, remote.ChannelId)
+	k.policyStore(ctx, types.RemoteDIDKeyPrefix).Set(key, k.cdc.MustMarshal(&remote))
+}
+
+// RequestRemoteResolution sends a packet asking the chain at the other end
+// of a did-2 channel to resolve a DID. The DID resolves from that channel
+// from now on, and a document already cached for it there stays in use until
+// the new resolution completes. Returns the packet sequence.
+func (k Keeper) RequestRemoteResolution(ctx context.Context, channelID, did, requester string) (uint64, error) {
+	if k.ics4Wrapper == nil {
+		return 0, errors.Wrap(types.ErrCrossChainNotSupported, "IBC is not enabled")
+	}
+	if version := k.GetChannelVersion(ctx, channelID); version != types.IBCVersion2 {
+		return 0, errors.Wrapf(types.ErrUnsupportedIBCVersion, "channel %s speaks %s, remote resolution requires %s", channelID, version, types.IBCVersion2)
+	}
+	if k.DidDocumentExists(ctx, did) {
+		return 0, errors.Wrapf(types.ErrDIDAlreadyExists, "DID %s is native to this chain", did)
+	}
+
+	req, err := json.Marshal(types.DIDResolutionRequest{DID: did})
+	if err != nil {
+		return 0, err
+	}
+	data, err := json.Marshal(types.IBCPacketData{Type: types.PacketTypeDIDResolution, Data: req})
+	if err != nil {
+		return 0, err
+	}
+
+	now := clock.Now(ctx)
+	timeout := uint64(now.Add(types.DefaultRemoteResolutionTimeout).UnixNano())
+	sequence, err := k.ics4Wrapper.SendPacket(sdk.UnwrapSDKContext(ctx), types.PortID, channelID, clienttypes.ZeroHeight(), timeout, data)
+	if err != nil {
+		return 0, err
+	}
+
+	remote, _ := k.GetRemoteDidDocument(ctx, did, channelID)
+	remote.DID = did
+	remote.ChannelId = channelID
+	remote.Status = types.RemoteResolutionPending
+	remote.Error = ""
+	remote.RequestedBy = requester
+	remote.RequestedAt = now
+	remote.PacketSequence = sequence
+	k.setRemoteDidDocument(ctx, remote)
+
+	if err := sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventRemoteResolutionRequested{
+		Did:       did,
+		ChannelId: channelID,
+		Sequence:  sequence,
+		Requester: requester,
+	}); err != nil {
+		return 0, err
+	}
+	return sequence, nil
+}
+
+// OnRemoteResolutionAck caches the document acknowledged for the resolution
+// packet of a DID. Acknowledgements of packets superseded by a newer request
+// are ignored.
+func (k Keeper) OnRemoteResolutionAck(ctx context.Context, channelID string, sequence uint64, did string, res types.DIDResolutionResponse) error {
+	remote, found := k.GetRemoteDidDocument(ctx, did, channelID)
+	if !found || remote.PacketSequence != sequence {
+		return nil
+	}
+	if res.Error != "" {
+		return k.failRemoteResolution(ctx, remote, res.Error)
+	}
+	if res.DID != did || res.Document.ID != did {
+		return k.failRemoteResolution(ctx, remote, fmt.Sprintf("requested %s, received %s", did, res.Document.ID))
+	}
+	if err := res.Document.Validate(); err != nil {
+		return k.failRemoteResolution(ctx, remote, fmt.Sprintf("invalid document: %v", err))
+	}
+
+	bz, err := json.Marshal(res.Document)
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(bz)
+
+	now := clock.Now(ctx)
+	expiresAt := now.Add(k.GetParams(ctx).RemoteResolutionTTL)
+	resolvedAt := res.Timestamp
+	remote.Status = types.RemoteResolutionResolved
+	remote.Error = ""
+	remote.Document = &res.Document
+	remote.SourceChainId = res.ChainID
+	remote.ResolvedAt = &resolvedAt
+	remote.ProofHeight = sdk.UnwrapSDKContext(ctx).BlockHeight()
+	remote.DocumentHash = hex.EncodeToString(hash[:])
+	remote.ExpiresAt = &expiresAt
+	k.setRemoteDidDocument(ctx, remote)
+
+	return sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventRemoteResolutionCompleted{
+		Did:       remote.DID,
+		ChannelId: channelID,
+		Sequence:  sequence,
+		Status:    remote.Status,
+	})
+}
+
+// OnRemoteResolutionFailure records that a resolution packet was refused or
+// timed out, so clients can retry. Failures of packets superseded by a newer
+// request are ignored.
+func (k Keeper) OnRemoteResolutionFailure(ctx context.Context, channelID string, sequence uint64, did, reason string) error {
+	remote, found := k.GetRemoteDidDocument(ctx, did, channelID)
+	if !found || remote.PacketSequence != sequence {
+		return nil
+	}
+	return k.failRemoteResolution(ctx, remote, reason)
+}
+
+func (k Keeper) failRemoteResolution(ctx context.Context, remote types.RemoteDIDDocument, reason string) error {
+	remote.Status = types.RemoteResolutionFailed
+	remote.Error = reason
+	k.setRemoteDidDocument(ctx, remote)
+
+	return sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventRemoteResolutionCompleted{
+		Did:       remote.DID,
+		ChannelId: remote.ChannelId,
+		Sequence:  remote.PacketSequence,
+		Status:    remote.Status,
+		Error:     reason,
+	})
+}
+
+// cachedRemoteDocument returns the document of a DID cached on the channel
+// it was last requested over, if it is still cached at the given time.
+// Entries on other channels are ignored so that one counterparty cannot
+// shadow a DID resolved from another chain.
+func (k Keeper) cachedRemoteDocument(ctx context.Context, did string, now time.Time) (types.RemoteDIDDocument, bool) {
+	var (
+		latest types.RemoteDIDDocument
+		found  bool
+	)
+	for _, remote := range k.GetRemoteDidDocuments(ctx, did) {
+		if !found || remote.RequestedAt.After(latest.RequestedAt) {
+			latest, found = remote, true
+		}
+	}
+	if !found || !latest.CachedAt(now) {
+		return types.RemoteDIDDocument{}, false
+	}
+	return latest, true
+}
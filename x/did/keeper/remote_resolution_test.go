@@ -0,0 +1,238 @@
+package keeper_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// remoteResolutionKeeper returns a keeper sending packets through the
+// returned mock, with channel-0 negotiated as did-2 and channel-1 as did-1,
+// and remote documents cached for an hour
+func remoteResolutionKeeper(t *testing.T) (keeper.Keeper, sdk.Context, *keepertest.MockICS4Wrapper) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithBlockTime(time.Unix(1_700_000_000, 0).UTC()).WithBlockHeight(10)
+	ics4Wrapper := &keepertest.MockICS4Wrapper{}
+	k.SetICS4Wrapper(ics4Wrapper)
+	k.SetChannelVersion(ctx, "channel-0", types.IBCVersion2)
+	k.SetChannelVersion(ctx, "channel-1", types.IBCVersion1)
+
+	params := k.GetParams(ctx)
+	params.RemoteResolutionTTL = time.Hour
+	require.NoError(t, k.SetParams(ctx, params))
+	return k, ctx, ics4Wrapper
+}
+
+func TestRequestRemoteResolution(t *testing.T) {
+	k, ctx, ics4Wrapper := remoteResolutionKeeper(t)
+	const native, remote = "did:persona:abc", "did:persona:remote"
+	requester := testAddress("alice")
+	require.NoError(t, k.SetDidDocument(ctx, newTestDocument(native, requester)))
+
+	withoutIBC := k
+	withoutIBC.SetICS4Wrapper(nil)
+
+	tests := []struct {
+		name    string
+		keeper  keeper.Keeper
+		channel string
+		did     string
+		err     error
+	}{
+		{name: "did-2 channel", keeper: k, channel: "channel-0", did: remote},
+		{name: "did-1 channel", keeper: k, channel: "channel-1", did: remote, err: types.ErrUnsupportedIBCVersion},
+		{name: "channel without version", keeper: k, channel: "channel-2", did: remote, err: types.ErrUnsupportedIBCVersion},
+		{name: "native DID", keeper: k, channel: "channel-0", did: native, err: types.ErrDIDAlreadyExists},
+		{name: "IBC not enabled", keeper: withoutIBC, channel: "channel-0", did: remote, err: types.ErrCrossChainNotSupported},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sent := len(ics4Wrapper.Sent)
+			cacheCtx, _ := ctx.CacheContext()
+			cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+			sequence, err := tc.keeper.RequestRemoteResolution(cacheCtx, tc.channel, tc.did, requester)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				require.Len(t, ics4Wrapper.Sent, sent)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 1, countEvents(cacheCtx, &types.EventRemoteResolutionRequested{}))
+
+			// The packet asks for the DID and times out after the default
+			packet := ics4Wrapper.Sent[len(ics4Wrapper.Sent)-1]
+			require.Equal(t, sequence, packet.Sequence)
+			require.Equal(t, tc.channel, packet.SourceChannel)
+			require.Equal(t, uint64(ctx.BlockTime().Add(types.DefaultRemoteResolutionTimeout).UnixNano()), packet.TimeoutTimestamp)
+			var data types.IBCPacketData
+			require.NoError(t, json.Unmarshal(packet.Data, &data))
+			require.Equal(t, types.PacketTypeDIDResolution, data.Type)
+			var req types.DIDResolutionRequest
+			require.NoError(t, json.Unmarshal(data.Data, &req))
+			require.Equal(t, tc.did, req.DID)
+
+			entry, found := k.GetRemoteDidDocument(cacheCtx, tc.did, tc.channel)
+			require.True(t, found)
+			require.Equal(t, types.RemoteResolutionPending, entry.Status)
+			require.Equal(t, sequence, entry.PacketSequence)
+			require.Equal(t, requester, entry.RequestedBy)
+		})
+	}
+}
+
+func TestRemoteResolutionCache(t *testing.T) {
+	k, ctx, _ := remoteResolutionKeeper(t)
+	const did, channel = "did:persona:remote", "channel-0"
+	requester := testAddress("alice")
+	doc := newTestDocument(did, testAddress("bob"))
+	response := types.DIDResolutionResponse{DID: did, Document: doc, Timestamp: ctx.BlockTime(), ChainID: "partner-1"}
+
+	resolve := func(ctx sdk.Context) types.QueryResolveResponse {
+		return k.ResolveDID(ctx, did, "", "", "")
+	}
+
+	// Nothing is resolved before the remote chain answers
+	sequence, err := k.RequestRemoteResolution(ctx, channel, did, requester)
+	require.NoError(t, err)
+	res := resolve(ctx)
+	require.Equal(t, types.ResolutionErrorNotFound, res.DidResolutionMetadata.Error)
+	require.Contains(t, res.DidResolutionMetadata.ErrorMessage, "pending")
+
+	tests := []struct {
+		name     string
+		apply    func(ctx sdk.Context) error
+		status   string
+		error    string
+		resolved bool
+	}{
+		{
+			name:     "document acknowledged",
+			apply:    func(ctx sdk.Context) error { return k.OnRemoteResolutionAck(ctx, channel, sequence, did, response) },
+			status:   types.RemoteResolutionResolved,
+			resolved: true,
+		},
+		{
+			name: "refused by the remote chain",
+			apply: func(ctx sdk.Context) error {
+				refused := response
+				refused.Document, refused.Error = types.DIDDocument{}, "data residency violation"
+				return k.OnRemoteResolutionAck(ctx, channel, sequence, did, refused)
+			},
+			status: types.RemoteResolutionFailed,
+			error:  "data residency violation",
+		},
+		{
+			name: "other DID acknowledged",
+			apply: func(ctx sdk.Context) error {
+				other := response
+				other.Document = newTestDocument("did:persona:other", testAddress("bob"))
+				return k.OnRemoteResolutionAck(ctx, channel, sequence, did, other)
+			},
+			status: types.RemoteResolutionFailed,
+			error:  "requested did:persona:remote, received did:persona:other",
+		},
+		{
+			name: "invalid document acknowledged",
+			apply: func(ctx sdk.Context) error {
+				invalid := response
+				invalid.Document.Context = nil
+				return k.OnRemoteResolutionAck(ctx, channel, sequence, did, invalid)
+			},
+			status: types.RemoteResolutionFailed,
+			error:  "invalid document: DID context is required: invalid DID format",
+		},
+		{
+			name: "packet timed out",
+			apply: func(ctx sdk.Context) error {
+				return k.OnRemoteResolutionFailure(ctx, channel, sequence, did, "resolution packet timed out")
+			},
+			status: types.RemoteResolutionFailed,
+			error:  "resolution packet timed out",
+		},
+		{
+			name:   "acknowledgement of a superseded packet",
+			apply:  func(ctx sdk.Context) error { return k.OnRemoteResolutionAck(ctx, channel, sequence-1, did, response) },
+			status: types.RemoteResolutionPending,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			require.NoError(t, tc.apply(cacheCtx))
+
+			entry, found := k.GetRemoteDidDocument(cacheCtx, did, channel)
+			require.True(t, found)
+			require.Equal(t, tc.status, entry.Status)
+			require.Equal(t, tc.error, entry.Error)
+
+			res := resolve(cacheCtx)
+			if !tc.resolved {
+				require.Equal(t, types.ResolutionErrorNotFound, res.DidResolutionMetadata.Error)
+				if tc.error != "" {
+					require.Contains(t, res.DidResolutionMetadata.ErrorMessage, tc.error)
+				}
+				return
+			}
+
+			// The cached document resolves as remote until it expires
+			require.Empty(t, res.DidResolutionMetadata.Error)
+			require.True(t, res.DidResolutionMetadata.Remote)
+			require.Equal(t, channel, res.DidResolutionMetadata.SourceChannel)
+			require.Equal(t, "partner-1", res.DidResolutionMetadata.SourceChainId)
+			require.Equal(t, ctx.BlockHeight(), res.DidResolutionMetadata.ProofHeight)
+			require.Equal(t, ctx.BlockTime().Add(time.Hour).Format(time.RFC3339), res.DidResolutionMetadata.CacheExpires)
+			require.NotEmpty(t, entry.DocumentHash)
+
+			var resolved types.DIDDocument
+			require.NoError(t, json.Unmarshal(res.DidDocument, &resolved))
+			require.Equal(t, did, resolved.ID)
+
+			expired := resolve(cacheCtx.WithBlockTime(ctx.BlockTime().Add(time.Hour)))
+			require.Equal(t, types.ResolutionErrorNotFound, expired.DidResolutionMetadata.Error)
+		})
+	}
+}
+
+func TestRemoteResolutionChannelScope(t *testing.T) {
+	k, ctx, _ := remoteResolutionKeeper(t)
+	k.SetChannelVersion(ctx, "channel-2", types.IBCVersion2)
+	const did = "did:persona:remote"
+	requester := testAddress("alice")
+
+	// acknowledge answers the request of sequence over channel with version
+	// of the document from chainID
+	acknowledge := func(ctx sdk.Context, channel string, sequence, version uint64, chainID string) {
+		doc := newTestDocument(did, testAddress("bob"))
+		doc.Version = version
+		response := types.DIDResolutionResponse{DID: did, Document: doc, Timestamp: ctx.BlockTime(), ChainID: chainID}
+		require.NoError(t, k.OnRemoteResolutionAck(ctx, channel, sequence, did, response))
+	}
+
+	sequence, err := k.RequestRemoteResolution(ctx, "channel-2", did, requester)
+	require.NoError(t, err)
+	acknowledge(ctx, "channel-2", sequence, 5, "other-1")
+
+	// Once the DID is requested over channel-0, the higher version cached on
+	// channel-2 no longer resolves
+	later := ctx.WithBlockTime(ctx.BlockTime().Add(time.Minute))
+	sequence, err = k.RequestRemoteResolution(later, "channel-0", did, requester)
+	require.NoError(t, err)
+	res := k.ResolveDID(later, did, "", "", "")
+	require.Equal(t, types.ResolutionErrorNotFound, res.DidResolutionMetadata.Error)
+
+	acknowledge(later, "channel-0", sequence, 1, "partner-1")
+	res = k.ResolveDID(later, did, "", "", "")
+	require.Empty(t, res.DidResolutionMetadata.Error)
+	require.Equal(t, "channel-0", res.DidResolutionMetadata.SourceChannel)
+	require.Equal(t, "partner-1", res.DidResolutionMetadata.SourceChainId)
+	require.Equal(t, "1", res.DidDocumentMetadata.VersionId)
+}
@@ -0,0 +1,73 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// CheckDataResidency returns an error if the data residency policy of a DID
+// document forbids exporting it over an IBC channel. A channel without a
+// jurisdiction in the params only receives unrestricted documents. Every
+// refusal is recorded in the DID's audit log, whether or not auditing is
+// enabled, since it is evidence the policy was enforced.
+func (k Keeper) CheckDataResidency(ctx context.Context, doc types.DIDDocument, channelID string) error {
+	jurisdiction, _ := k.GetParams(ctx).JurisdictionOf(channelID)
+	if doc.Compliance.DataResidency.Permits(jurisdiction) {
+		return nil
+	}
+
+	if err := k.recordAuditLog(ctx, "data_residency_refused", doc.ID, channelID, map[string]interface{}{
+		"channel_id":         channelID,
+		"jurisdiction":       jurisdiction,
+		"allowed_regions":    doc.Compliance.DataResidency.AllowedRegions,
+		"prohibited_regions": doc.Compliance.DataResidency.ProhibitedRegions,
+	}); err != nil {
+		return err
+	}
+	if err := sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventDataResidencyRefused{
+		Did:          doc.ID,
+		ChannelId:    channelID,
+		Jurisdiction: jurisdiction,
+	}); err != nil {
+		return err
+	}
+
+	if jurisdiction == "" {
+		return errors.Wrapf(types.ErrDataResidencyViolation, "DID %s may not be exported over channel %s, which has no registered jurisdiction", doc.ID, channelID)
+	}
+	return errors.Wrapf(types.ErrDataResidencyViolation, "DID %s may not be exported to %s over channel %s", doc.ID, jurisdiction, channelID)
+}
+
+// ExportDidUpdatePacket returns the packet data carrying a DID document to
+// the chain at the other end of an IBC channel, after checking the
+// document's data residency policy permits it. Receiving chains only accept
+// the document once its controller has signed the packet's foreign update
+// challenge and set Proof.
+func (k Keeper) ExportDidUpdatePacket(ctx context.Context, did, channelID string) (types.DidUpdatePacketData, error) {
+	doc, found := k.GetDidDocument(ctx, did)
+	if !found {
+		return types.DidUpdatePacketData{}, errors.Wrapf(types.ErrDIDNotFound, "DID %s", did)
+	}
+	if err := k.CheckDataResidency(ctx, doc, channelID); err != nil {
+		return types.DidUpdatePacketData{}, err
+	}
+
+	bz, err := json.Marshal(doc)
+	if err != nil {
+		return types.DidUpdatePacketData{}, err
+	}
+	return types.DidUpdatePacketData{
+		DidId:         doc.ID,
+		DidDocument:   string(bz),
+		Controller:    doc.Creator,
+		Active:        !doc.IsDeactivated(),
+		UpdatedAt:     doc.UpdatedAt.Unix(),
+		Version:       doc.Version,
+		SourceChainId: sdk.UnwrapSDKContext(ctx).ChainID(),
+	}, nil
+}
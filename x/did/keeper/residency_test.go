@@ -0,0 +1,136 @@
+package keeper_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// residencyRefusals returns how many refused exports of did are in its audit
+// log
+func residencyRefusals(t *testing.T, ctx sdk.Context, k keeper.Keeper, did string) int {
+	entries, err := k.GetAuditLog(ctx, did, 0)
+	require.NoError(t, err)
+	count := 0
+	for _, entry := range entries {
+		if entry.Action == "data_residency_refused" {
+			count++
+		}
+	}
+	return count
+}
+
+func TestExportDidUpdatePacket(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID)
+	const (
+		open       = "did:persona:open"
+		euOnly     = "did:persona:eu"
+		notInUS    = "did:persona:notus"
+		euChannel  = "channel-0"
+		usChannel  = "channel-1"
+		newChannel = "channel-2"
+	)
+	creator := testAddress("creator")
+
+	params := k.GetParams(ctx)
+	params.ChannelJurisdictions = []types.ChannelJurisdiction{
+		{ChannelId: euChannel, Jurisdiction: "EU"},
+		{ChannelId: usChannel, Jurisdiction: "US"},
+	}
+	require.NoError(t, k.SetParams(ctx, params))
+
+	for did, residency := range map[string]types.DataResidency{
+		open:    {},
+		euOnly:  {AllowedRegions: []string{"EU"}},
+		notInUS: {ProhibitedRegions: []string{"US"}},
+	} {
+		doc := newTestDocument(did, creator)
+		doc.Compliance.DataResidency = residency
+		require.NoError(t, k.SetDidDocument(ctx, doc))
+	}
+
+	tests := []struct {
+		name    string
+		did     string
+		channel string
+		refused bool
+		err     error
+	}{
+		{name: "unrestricted to registered channel", did: open, channel: usChannel},
+		{name: "unrestricted to unregistered channel", did: open, channel: newChannel},
+		{name: "allowed jurisdiction", did: euOnly, channel: euChannel},
+		{name: "jurisdiction not allowed", did: euOnly, channel: usChannel, refused: true},
+		{name: "restricted to unregistered channel", did: euOnly, channel: newChannel, refused: true},
+		{name: "prohibited jurisdiction", did: notInUS, channel: usChannel, refused: true},
+		{name: "jurisdiction not prohibited", did: notInUS, channel: euChannel},
+		{name: "unknown DID", did: "did:persona:xyz", channel: euChannel, err: types.ErrDIDNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+			data, err := k.ExportDidUpdatePacket(cacheCtx, tc.did, tc.channel)
+
+			switch {
+			case tc.err != nil:
+				require.ErrorIs(t, err, tc.err)
+			case tc.refused:
+				// Every refusal is audited and announced
+				require.ErrorIs(t, err, types.ErrDataResidencyViolation)
+				require.Equal(t, 1, residencyRefusals(t, cacheCtx, k, tc.did))
+				require.Equal(t, 1, countEvents(cacheCtx, &types.EventDataResidencyRefused{}))
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tc.did, data.DidId)
+				require.Equal(t, testChainID, data.SourceChainId)
+				require.Zero(t, residencyRefusals(t, cacheCtx, k, tc.did))
+
+				var doc types.DIDDocument
+				require.NoError(t, json.Unmarshal([]byte(data.DidDocument), &doc))
+				require.Equal(t, tc.did, doc.ID)
+			}
+		})
+	}
+}
+
+func TestDataResidencyRefusalsInOneBlock(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	ctx = ctx.WithChainID(testChainID).WithBlockTime(time.Unix(1_700_000_000, 0).UTC())
+	const did = "did:persona:eu"
+
+	params := k.GetParams(ctx)
+	params.ChannelJurisdictions = []types.ChannelJurisdiction{{ChannelId: "channel-1", Jurisdiction: "US"}}
+	require.NoError(t, k.SetParams(ctx, params))
+
+	doc := newTestDocument(did, testAddress("creator"))
+	doc.Compliance.DataResidency = types.DataResidency{AllowedRegions: []string{"EU"}}
+	require.NoError(t, k.SetDidDocument(ctx, doc))
+
+	tests := []struct {
+		name     string
+		channels []string
+	}{
+		{name: "same channel", channels: []string{"channel-1", "channel-1"}},
+		{name: "different channels", channels: []string{"channel-1", "channel-2"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			for _, channel := range tc.channels {
+				_, err := k.ExportDidUpdatePacket(cacheCtx, did, channel)
+				require.ErrorIs(t, err, types.ErrDataResidencyViolation)
+			}
+			require.Equal(t, len(tc.channels), residencyRefusals(t, cacheCtx, k, did))
+		})
+	}
+}
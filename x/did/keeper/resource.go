@@ -0,0 +1,156 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/errors"
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/persona-chain/persona-chain/internal/clock"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// GetResource returns a DID-linked resource
+func (k Keeper) GetResource(ctx context.Context, did, id string) (types.Resource, bool) {
+	b := k.policyStore(ctx, types.ResourceKeyPrefix).Get(types.ResourceKey(did, id))
+	if b == nil {
+		return types.Resource{}, false
+	}
+
+	var resource types.Resource
+	if err := k.cdc.Unmarshal(b, &resource); err != nil {
+		return types.Resource{}, false
+	}
+	return resource, true
+}
+
+// GetLatestResource returns the latest version of the resources of a DID
+// sharing a name and type
+func (k Keeper) GetLatestResource(ctx context.Context, did, name, resourceType string) (types.Resource, bool) {
+	id := k.policyStore(ctx, types.ResourceLatestPrefix).Get(types.ResourceLatestKey(did, name, resourceType))
+	if id == nil {
+		return types.Resource{}, false
+	}
+	return k.GetResource(ctx, did, string(id))
+}
+
+// GetResourcesByDID returns the metadata of every resource of a DID
+func (k Keeper) GetResourcesByDID(ctx context.Context, did string) []types.ResourceMetadata {
+	iterator := storetypes.KVStorePrefixIterator(k.policyStore(ctx, types.ResourceKeyPrefix), types.DIDIndexValuePrefix(did))
+	defer iterator.Close()
+
+	var resources []types.ResourceMetadata
+	for ; iterator.Valid(); iterator.Next() {
+		var resource types.Resource
+		if err := k.cdc.Unmarshal(iterator.Value(), &resource); err != nil {
+			continue
+		}
+		resources = append(resources, resource.Metadata)
+	}
+	return resources
+}
+
+func (k Keeper) setResource(ctx context.Context, resource types.Resource) {
+	key := types.ResourceKey(resource.Metadata.Did, resource.Metadata.Id)
+	k.policyStore(ctx, types.ResourceKeyPrefix).Set(key, k.cdc.MustMarshal(&resource))
+}
+
+// CreateResource stores content under an active DID. A resource sharing the
+// name and type of an existing one becomes its next version. Resources are
+// immutable and outlive the DID, so the storage deposit they owe is burned
+// rather than escrowed. Returns the stored metadata.
+func (k Keeper) CreateResource(ctx context.Context, metadata types.ResourceMetadata, data []byte, controllerAddr string) (types.ResourceMetadata, error) {
+	if err := k.ValidateControllerAuthorization(ctx, metadata.Did, controllerAddr); err != nil {
+		return types.ResourceMetadata{}, err
+	}
+	if _, err := k.activeDocument(ctx, metadata.Did); err != nil {
+		return types.ResourceMetadata{}, err
+	}
+	if err := metadata.ValidateBasic(); err != nil {
+		return types.ResourceMetadata{}, err
+	}
+
+	params := k.GetParams(ctx)
+	size := uint64(len(data))
+	if size == 0 {
+		return types.ResourceMetadata{}, errors.Wrap(types.ErrInvalidResource, "resource content is empty")
+	}
+	if size > params.MaxResourceSize {
+		return types.ResourceMetadata{}, errors.Wrapf(types.ErrResourceTooLarge, "%d bytes exceeds %d", size, params.MaxResourceSize)
+	}
+	if _, found := k.GetResource(ctx, metadata.Did, metadata.Id); found {
+		return types.ResourceMetadata{}, errors.Wrapf(types.ErrResourceExists, "resource %s", metadata.URL())
+	}
+
+	if err := k.chargeResourceFee(ctx, controllerAddr, params.StorageDepositFor(size), size); err != nil {
+		return types.ResourceMetadata{}, err
+	}
+
+	metadata.Checksum = types.ResourceChecksum(data)
+	metadata.Size = size
+	metadata.CreatedAt = clock.Now(ctx)
+	metadata.CreatedBy = controllerAddr
+	metadata.PreviousVersionId = ""
+	metadata.NextVersionId = ""
+
+	// Link the new version after the latest one of the same name and type
+	if previous, found := k.GetLatestResource(ctx, metadata.Did, metadata.Name, metadata.ResourceType); found {
+		previous.Metadata.NextVersionId = metadata.Id
+		k.setResource(ctx, previous)
+		metadata.PreviousVersionId = previous.Metadata.Id
+	}
+	k.setResource(ctx, types.Resource{Metadata: metadata, Data: data})
+	k.policyStore(ctx, types.ResourceLatestPrefix).Set(types.ResourceLatestKey(metadata.Did, metadata.Name, metadata.ResourceType), []byte(metadata.Id))
+
+	if err := sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventResourceCreated{
+		Did:          metadata.Did,
+		ResourceId:   metadata.Id,
+		Name:         metadata.Name,
+		ResourceType: metadata.ResourceType,
+		Checksum:     metadata.Checksum,
+		Size:         size,
+	}); err != nil {
+		return types.ResourceMetadata{}, err
+	}
+	return metadata, nil
+}
+
+// chargeResourceFee burns the storage deposit owed for size bytes of
+// resource content from the payer
+func (k Keeper) chargeResourceFee(ctx context.Context, payer string, fee sdk.Coins, size uint64) error {
+	if fee.IsZero() {
+		return nil
+	}
+
+	payerAddr, err := sdk.AccAddressFromBech32(payer)
+	if err != nil {
+		return errors.Wrapf(types.ErrInvalidController, "invalid payer address (%s)", err)
+	}
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, payerAddr, types.ModuleName, fee); err != nil {
+		return errors.Wrapf(types.ErrInsufficientDeposit, "%s owes %s for %d bytes: %v", payer, fee, size, err)
+	}
+	return k.bankKeeper.BurnCoins(ctx, types.ModuleName, fee)
+}
+
+// dereferenceResource resolves a DID URL addressing a resource by its
+// /resources/<id> path or by the resourceName and resourceType parameters.
+// The second result is false if the URL does not address a resource.
+func (k Keeper) dereferenceResource(ctx context.Context, parsed types.DIDURL) (types.DereferencedResource, bool, error) {
+	var (
+		resource types.Resource
+		found    bool
+	)
+	if id, ok := types.ResourceIDFromPath(parsed.Path); ok {
+		resource, found = k.GetResource(ctx, parsed.DID, id)
+	} else if parsed.Path == "" && parsed.ResourceName() != "" {
+		resource, found = k.GetLatestResource(ctx, parsed.DID, parsed.ResourceName(), parsed.ResourceType())
+	} else {
+		return types.DereferencedResource{}, false, nil
+	}
+
+	if !found {
+		return types.DereferencedResource{}, true, errors.Wrapf(types.ErrResourceNotFound, "no resource at %s", parsed.String())
+	}
+	return types.DereferencedResource{Resource: &resource}, true, nil
+}
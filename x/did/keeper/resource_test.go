@@ -0,0 +1,190 @@
+package keeper_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// chargeResources makes the keeper burn one stake per byte of resource
+// content and accept resources of up to 16 bytes. Documents stored before
+// are free.
+func chargeResources(t *testing.T, ctx sdk.Context, k keeper.Keeper) {
+	params := k.GetParams(ctx)
+	params.StorageDepositPerByte = sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)
+	params.MaxResourceSize = 16
+	require.NoError(t, k.SetParams(ctx, params))
+}
+
+func createResourceMsg(did, controller, id, name string, data []byte) *types.MsgCreateResource {
+	return &types.MsgCreateResource{
+		Controller:   controller,
+		Did:          did,
+		Id:           id,
+		Name:         name,
+		Version:      "1",
+		ResourceType: "JsonSchema",
+		MediaType:    "application/schema+json",
+		Data:         data,
+	}
+}
+
+func TestCreateResource(t *testing.T) {
+	const (
+		did      = "did:persona:abc"
+		poor     = "did:persona:poor"
+		gone     = "did:persona:gone"
+		existing = "11111111-1111-4111-8111-111111111111"
+		fresh    = "22222222-2222-4222-8222-222222222222"
+	)
+	alice, carol := testAddress("alice"), testAddress("carol")
+
+	k, ctx, bank := keepertest.DidKeeperWithBank(t)
+	ctx = ctx.WithBlockTime(time.Unix(1_700_000_000, 0).UTC())
+	require.NoError(t, k.SetDidDocument(ctx, newTestDocument(did, alice)))
+	require.NoError(t, k.SetDidDocument(ctx, newTestDocument(poor, carol)))
+	goneDoc := newTestDocument(gone, alice)
+	goneDoc.Metadata.Deactivated = true
+	require.NoError(t, k.SetDidDocument(ctx, goneDoc))
+	chargeResources(t, ctx, k)
+	bank.Fund(sdk.MustAccAddressFromBech32(alice), stake(100))
+
+	msgServer := keeper.NewMsgServerImpl(k)
+	_, err := msgServer.CreateResource(ctx, createResourceMsg(did, alice, existing, "schema", []byte("{}")))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		msg  *types.MsgCreateResource
+		err  error
+	}{
+		{name: "new resource", msg: createResourceMsg(did, alice, fresh, "other", []byte(`{"type":"obj"}`))},
+		{name: "largest allowed", msg: createResourceMsg(did, alice, fresh, "other", []byte(strings.Repeat("x", 16)))},
+		{name: "not a controller", msg: createResourceMsg(did, carol, fresh, "other", []byte("{}")), err: types.ErrUnauthorized},
+		{name: "unknown DID", msg: createResourceMsg("did:persona:xyz", alice, fresh, "other", []byte("{}")), err: types.ErrDIDNotFound},
+		{name: "deactivated DID", msg: createResourceMsg(gone, alice, fresh, "other", []byte("{}")), err: types.ErrDIDDeactivated},
+		{name: "empty content", msg: createResourceMsg(did, alice, fresh, "other", nil), err: types.ErrInvalidResource},
+		{name: "too large", msg: createResourceMsg(did, alice, fresh, "other", []byte(strings.Repeat("x", 17))), err: types.ErrResourceTooLarge},
+		{name: "ID is not a UUID", msg: createResourceMsg(did, alice, "schema-2", "other", []byte("{}")), err: types.ErrInvalidResource},
+		{name: "ID taken", msg: createResourceMsg(did, alice, existing, "other", []byte("{}")), err: types.ErrResourceExists},
+		{name: "controller cannot pay", msg: createResourceMsg(poor, carol, fresh, "other", []byte("{}")), err: types.ErrInsufficientDeposit},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			payer := sdk.MustAccAddressFromBech32(tc.msg.Controller)
+			balance := bank.GetBalance(ctx, payer, sdk.DefaultBondDenom)
+			burned := bank.Burned
+
+			cacheCtx, _ := ctx.CacheContext()
+			res, err := msgServer.CreateResource(cacheCtx, tc.msg)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				require.Equal(t, balance, bank.GetBalance(ctx, payer, sdk.DefaultBondDenom))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, types.ResourceChecksum(tc.msg.Data), res.Checksum)
+			require.Equal(t, 1, countEvents(cacheCtx, &types.EventResourceCreated{}))
+
+			// The deposit for the content is burned, not escrowed
+			fee := int64(len(tc.msg.Data))
+			require.Equal(t, balance.Amount.Int64()-fee, bank.GetBalance(ctx, payer, sdk.DefaultBondDenom).Amount.Int64())
+			require.Equal(t, burned.Add(stake(fee)...), bank.Burned)
+
+			stored, found := k.GetResource(cacheCtx, tc.msg.Did, tc.msg.Id)
+			require.True(t, found)
+			require.Equal(t, tc.msg.Data, stored.Data)
+			require.Equal(t, uint64(fee), stored.Metadata.Size)
+			require.Equal(t, tc.msg.Controller, stored.Metadata.CreatedBy)
+			require.Equal(t, ctx.BlockTime(), stored.Metadata.CreatedAt)
+			require.Empty(t, stored.Metadata.PreviousVersionId)
+		})
+	}
+}
+
+func TestResourceVersions(t *testing.T) {
+	k, ctx, bank := keepertest.DidKeeperWithBank(t)
+	ctx = ctx.WithBlockTime(time.Unix(1_700_000_000, 0).UTC())
+	msgServer := keeper.NewMsgServerImpl(k)
+	const (
+		did = "did:persona:abc"
+		v1  = "11111111-1111-4111-8111-111111111111"
+		v2  = "22222222-2222-4222-8222-222222222222"
+		v3  = "33333333-3333-4333-8333-333333333333"
+		doc = "44444444-4444-4444-8444-444444444444"
+	)
+	alice := testAddress("alice")
+	require.NoError(t, k.SetDidDocument(ctx, newTestDocument(did, alice)))
+	chargeResources(t, ctx, k)
+	bank.Fund(sdk.MustAccAddressFromBech32(alice), stake(100))
+
+	for _, msg := range []*types.MsgCreateResource{
+		createResourceMsg(did, alice, v1, "kyc", []byte(`{"v":1}`)),
+		createResourceMsg(did, alice, v2, "kyc", []byte(`{"v":2}`)),
+		createResourceMsg(did, alice, doc, "kyc", []byte("terms")),
+		createResourceMsg(did, alice, v3, "kyc", []byte(`{"v":3}`)),
+	} {
+		if msg.Id == doc {
+			msg.ResourceType, msg.MediaType = "Document", "text/plain"
+		}
+		_, err := msgServer.CreateResource(ctx, msg)
+		require.NoError(t, err)
+	}
+
+	// Versions of the same name and type link in creation order
+	links := map[string][2]string{v1: {"", v2}, v2: {v1, v3}, v3: {v2, ""}, doc: {"", ""}}
+	for id, link := range links {
+		res, err := k.Resource(ctx, &types.QueryResourceRequest{Did: did, Id: id})
+		require.NoError(t, err)
+		require.Equal(t, link[0], res.Resource.Metadata.PreviousVersionId, id)
+		require.Equal(t, link[1], res.Resource.Metadata.NextVersionId, id)
+	}
+
+	res, err := k.Resources(ctx, &types.QueryResourcesRequest{Did: did})
+	require.NoError(t, err)
+	require.Len(t, res.Resources, 4)
+
+	_, err = k.Resource(ctx, &types.QueryResourceRequest{Did: did, Id: "55555555-5555-4555-8555-555555555555"})
+	require.Error(t, err)
+
+	// Resources stay addressable after their DID is deactivated
+	deactivated, _ := k.GetDidDocument(ctx, did)
+	deactivated.Metadata.Deactivated = true
+	deactivated.Version++
+	require.NoError(t, k.SetDidDocument(ctx, deactivated))
+
+	tests := []struct {
+		name string
+		url  string
+		id   string
+		err  error
+	}{
+		{name: "by path", url: did + "/resources/" + v1, id: v1},
+		{name: "latest by name and type", url: did + "?resourceName=kyc&resourceType=JsonSchema", id: v3},
+		{name: "latest of another type", url: did + "?resourceName=kyc&resourceType=Document", id: doc},
+		{name: "unknown ID", url: did + "/resources/55555555-5555-4555-8555-555555555555", err: types.ErrResourceNotFound},
+		{name: "unknown name", url: did + "?resourceName=aml&resourceType=JsonSchema", err: types.ErrResourceNotFound},
+		{name: "name without type", url: did + "?resourceName=kyc", err: types.ErrInvalidDIDURL},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := k.DereferenceDIDURL(ctx, tc.url)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, res.Resource)
+			require.Equal(t, tc.id, res.Resource.Metadata.Id)
+		})
+	}
+}
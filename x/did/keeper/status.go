@@ -0,0 +1,158 @@
+package keeper
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/persona-chain/persona-chain/internal/clock"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// StatusRoleOf returns the highest role an address holds over a DID: the
+// governance authority, an admin of the organization named in its metadata,
+// or a controller meeting the DID's update policy
+func (k Keeper) StatusRoleOf(ctx context.Context, doc types.DIDDocument, addr string) (types.StatusRole, error) {
+	if addr == k.authority {
+		return types.StatusRoleGovernance, nil
+	}
+	if k.GetParams(ctx).IsOrganizationAdmin(doc.Metadata.OrganizationID, addr) {
+		return types.StatusRoleOrganizationAdmin, nil
+	}
+	err := k.ValidateControllerAuthorization(ctx, doc.ID, addr)
+	if err == nil {
+		return types.StatusRoleController, nil
+	}
+	if errors.IsOf(err, types.ErrThresholdNotMet) {
+		return "", err
+	}
+	return "", errors.Wrapf(types.ErrUnauthorized, "%s holds no role over %s", addr, doc.ID)
+}
+
+// TransitionStatus moves a DID document to a new state on behalf of actor.
+// A suspension can only be lifted by a role at least as high as the one
+// that imposed it, though any role may revoke. Only governance can end a
+// recovery. until optionally schedules the end of a suspension. Returns the
+// role the actor acted in.
+func (k Keeper) TransitionStatus(ctx context.Context, id string, newState types.DIDState, reason string, until *time.Time, actor string) (types.StatusRole, error) {
+	doc, found := k.GetDidDocument(ctx, id)
+	if !found {
+		return "", types.ErrDIDNotFound
+	}
+
+	role, err := k.StatusRoleOf(ctx, doc, actor)
+	if err != nil {
+		return "", err
+	}
+
+	switch doc.Status.State {
+	case types.DIDStateSuspended:
+		if newState != types.DIDStateRevoked && role.Rank() < doc.Status.UpdatedByRole.Rank() {
+			return "", errors.Wrapf(types.ErrUnauthorized, "suspension imposed by %s cannot be lifted by %s", doc.Status.UpdatedByRole, role)
+		}
+	case types.DIDStateRecovering:
+		if role != types.StatusRoleGovernance {
+			return "", errors.Wrapf(types.ErrUnauthorized, "recovery cannot be ended by %s", role)
+		}
+	}
+
+	if until != nil {
+		if newState != types.DIDStateSuspended {
+			return "", errors.Wrap(types.ErrInvalidDIDState, "only suspensions can have an end time")
+		}
+		if !until.After(clock.Now(ctx)) {
+			return "", errors.Wrap(types.ErrInvalidDIDState, "suspension must end in the future")
+		}
+	}
+
+	if err := k.setDocumentStatus(ctx, doc, newState, reason, actor, role, until); err != nil {
+		return "", err
+	}
+	return role, nil
+}
+
+// setDocumentStatus validates and applies a state transition, stores the new
+// version and emits EventDIDStatusChanged
+func (k Keeper) setDocumentStatus(ctx context.Context, doc types.DIDDocument, newState types.DIDState, reason, updatedBy string, role types.StatusRole, until *time.Time) error {
+	previous := doc.Status.State
+	if !k.isValidStateTransition(previous, newState) {
+		return errors.Wrapf(types.ErrInvalidDIDState, "invalid transition from %s to %s", previous, newState)
+	}
+
+	now := clock.Now(ctx)
+	doc.Status.State = newState
+	doc.Status.Reason = reason
+	doc.Status.UpdatedAt = now
+	doc.Status.UpdatedBy = updatedBy
+	doc.Status.UpdatedByRole = role
+	doc.UpdatedBy = updatedBy
+	doc.Status.SuspendedUntil = nil
+	if newState == types.DIDStateSuspended {
+		doc.Status.SuspendedUntil = until
+	}
+	doc.Version++
+	doc.Metadata.VersionID = fmt.Sprintf("%d", doc.Version)
+
+	// Update health check if deactivating
+	if newState == types.DIDStateInactive || newState == types.DIDStateRevoked {
+		doc.Status.HealthCheck.Status = "unhealthy"
+		doc.Status.HealthCheck.LastChecked = now
+		doc.Status.HealthCheck.Errors = append(doc.Status.HealthCheck.Errors, fmt.Sprintf("Document %s: %s", newState, reason))
+	}
+
+	if err := k.SetDidDocument(ctx, doc); err != nil {
+		return err
+	}
+
+	return sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventDIDStatusChanged{
+		Did:            doc.ID,
+		PreviousState:  string(previous),
+		NewState:       string(newState),
+		Reason:         reason,
+		Actor:          updatedBy,
+		Role:           string(role),
+		SuspendedUntil: doc.Status.SuspendedUntil,
+	})
+}
+
+func (k Keeper) suspensionQueueStore(ctx context.Context) prefix.Store {
+	storeAdapter := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	return prefix.NewStore(storeAdapter, types.KeyPrefix(types.DIDSuspensionQueuePrefix))
+}
+
+// setSuspensionQueue schedules the end of a timed suspension
+func (k Keeper) setSuspensionQueue(ctx context.Context, doc types.DIDDocument) {
+	until := doc.Status.SuspendedUntil
+	if doc.Status.State != types.DIDStateSuspended || until == nil {
+		return
+	}
+	k.suspensionQueueStore(ctx).Set(types.DIDExpiryQueueKey(*until, doc.ID, ""), []byte{})
+}
+
+// removeSuspensionQueue unschedules the end of a timed suspension
+func (k Keeper) removeSuspensionQueue(ctx context.Context, doc types.DIDDocument) {
+	if until := doc.Status.SuspendedUntil; until != nil {
+		k.suspensionQueueStore(ctx).Delete(types.DIDExpiryQueueKey(*until, doc.ID, ""))
+	}
+}
+
+// processSuspensionEnd reactivates a document whose timed suspension ended
+func (k Keeper) processSuspensionEnd(ctx sdk.Context, did string, now time.Time) error {
+	doc, found := k.GetDidDocument(ctx, did)
+	if !found || doc.Status.State != types.DIDStateSuspended {
+		return nil
+	}
+
+	// The entry is stale if the suspension was replaced or extended
+	until := doc.Status.SuspendedUntil
+	if until == nil || now.Before(*until) {
+		return nil
+	}
+
+	return k.setDocumentStatus(ctx, doc, types.DIDStateActive, "suspension ended", types.ModuleName, types.StatusRoleModule, nil)
+}
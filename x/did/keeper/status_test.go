@@ -0,0 +1,226 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestUpdateDIDStatus(t *testing.T) {
+	const did = "did:persona:abc"
+	start := time.Unix(1_700_000_000, 0).UTC()
+	controller, admin, stranger := testAddress("controller"), testAddress("admin"), testAddress("stranger")
+
+	// transition is one MsgUpdateDIDStatus; actor "gov" is the authority
+	type transition struct {
+		actor string
+		state types.DIDState
+		until time.Duration
+		role  types.StatusRole
+		err   error
+	}
+
+	tests := []struct {
+		name        string
+		state       types.DIDState
+		role        types.StatusRole
+		transitions []transition
+	}{
+		{
+			name:  "controller suspends and lifts",
+			state: types.DIDStateActive,
+			transitions: []transition{
+				{actor: controller, state: types.DIDStateSuspended, role: types.StatusRoleController},
+				{actor: controller, state: types.DIDStateActive, role: types.StatusRoleController},
+			},
+		},
+		{
+			name:  "admin suspension outranks the controller",
+			state: types.DIDStateActive,
+			transitions: []transition{
+				{actor: admin, state: types.DIDStateSuspended, role: types.StatusRoleOrganizationAdmin},
+				{actor: controller, state: types.DIDStateActive, err: types.ErrUnauthorized},
+				{actor: admin, state: types.DIDStateActive, role: types.StatusRoleOrganizationAdmin},
+			},
+		},
+		{
+			name:  "governance suspension can be revoked by anyone",
+			state: types.DIDStateActive,
+			transitions: []transition{
+				{actor: "gov", state: types.DIDStateSuspended, role: types.StatusRoleGovernance},
+				{actor: admin, state: types.DIDStateActive, err: types.ErrUnauthorized},
+				{actor: controller, state: types.DIDStateRevoked, role: types.StatusRoleController},
+			},
+		},
+		{
+			name:  "automatic suspension is lifted by the controller",
+			state: types.DIDStateSuspended,
+			role:  types.StatusRoleModule,
+			transitions: []transition{
+				{actor: controller, state: types.DIDStateActive, role: types.StatusRoleController},
+			},
+		},
+		{
+			name:  "recovery is ended by governance only",
+			state: types.DIDStateRecovering,
+			transitions: []transition{
+				{actor: controller, state: types.DIDStateActive, err: types.ErrUnauthorized},
+				{actor: admin, state: types.DIDStateActive, err: types.ErrUnauthorized},
+				{actor: "gov", state: types.DIDStateActive, role: types.StatusRoleGovernance},
+			},
+		},
+		{
+			name:  "no role",
+			state: types.DIDStateActive,
+			transitions: []transition{
+				{actor: stranger, state: types.DIDStateSuspended, err: types.ErrUnauthorized},
+			},
+		},
+		{
+			name:  "invalid transition",
+			state: types.DIDStateActive,
+			transitions: []transition{
+				{actor: controller, state: types.DIDStateRecovering, err: types.ErrInvalidDIDState},
+			},
+		},
+		{
+			name:  "end time on a revocation",
+			state: types.DIDStateActive,
+			transitions: []transition{
+				{actor: controller, state: types.DIDStateRevoked, until: time.Hour, err: types.ErrInvalidDIDState},
+			},
+		},
+		{
+			name:  "end time in the past",
+			state: types.DIDStateActive,
+			transitions: []transition{
+				{actor: controller, state: types.DIDStateSuspended, until: -time.Hour, err: types.ErrInvalidDIDState},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k, ctx := keepertest.DidKeeper(t)
+			ctx = ctx.WithBlockTime(start)
+			msgServer := keeper.NewMsgServerImpl(k)
+
+			params := types.DefaultParams()
+			params.OrganizationAdmins = []types.OrganizationAdmin{{OrganizationId: "acme", Address: admin}}
+			require.NoError(t, k.SetParams(ctx, params))
+
+			doc := newTestDocument(did, controller)
+			doc.Metadata.OrganizationID = "acme"
+			doc.Status.State = tc.state
+			doc.Status.UpdatedByRole = tc.role
+			require.NoError(t, k.SetDidDocument(ctx, doc))
+
+			for i, tr := range tc.transitions {
+				actor := tr.actor
+				if actor == "gov" {
+					actor = k.GetAuthority()
+				}
+				msg := &types.MsgUpdateDIDStatus{Controller: actor, Id: did, Status: string(tr.state), Reason: "test"}
+				if tr.until != 0 {
+					until := start.Add(tr.until)
+					msg.Until = &until
+				}
+
+				_, err := msgServer.UpdateDIDStatus(ctx, msg)
+				if tr.err != nil {
+					require.ErrorIs(t, err, tr.err, "transition %d", i)
+					continue
+				}
+				require.NoError(t, err, "transition %d", i)
+
+				stored, found := k.GetDidDocument(ctx, did)
+				require.True(t, found)
+				require.Equal(t, tr.state, stored.Status.State)
+				require.Equal(t, tr.role, stored.Status.UpdatedByRole)
+				require.Equal(t, actor, stored.Status.UpdatedBy)
+			}
+		})
+	}
+}
+
+func TestTimedSuspension(t *testing.T) {
+	const did = "did:persona:abc"
+	start := time.Unix(1_700_000_000, 0).UTC()
+	controller := testAddress("controller")
+
+	tests := []struct {
+		name string
+		// liftAfter lifts the suspension by hand before it ends when non-zero
+		liftAfter time.Duration
+		checks    map[time.Duration]types.DIDState
+	}{
+		{
+			name: "lifted at its end",
+			checks: map[time.Duration]types.DIDState{
+				30 * time.Minute: types.DIDStateSuspended,
+				time.Hour:        types.DIDStateActive,
+			},
+		},
+		{
+			name:      "lifted early",
+			liftAfter: 10 * time.Minute,
+			checks: map[time.Duration]types.DIDState{
+				time.Hour: types.DIDStateActive,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k, ctx := keepertest.DidKeeper(t)
+			ctx = ctx.WithBlockTime(start)
+			msgServer := keeper.NewMsgServerImpl(k)
+
+			doc := newTestDocument(did, controller)
+			doc.Status.State = types.DIDStateActive
+			require.NoError(t, k.SetDidDocument(ctx, doc))
+
+			until := start.Add(time.Hour)
+			_, err := msgServer.UpdateDIDStatus(ctx, &types.MsgUpdateDIDStatus{
+				Controller: controller, Id: did, Status: string(types.DIDStateSuspended), Until: &until,
+			})
+			require.NoError(t, err)
+
+			var liftedVersion uint64
+			if tc.liftAfter != 0 {
+				liftCtx := ctx.WithBlockTime(start.Add(tc.liftAfter))
+				_, err := msgServer.UpdateDIDStatus(liftCtx, &types.MsgUpdateDIDStatus{
+					Controller: controller, Id: did, Status: string(types.DIDStateActive),
+				})
+				require.NoError(t, err)
+				stored, _ := k.GetDidDocument(liftCtx, did)
+				liftedVersion = stored.Version
+			}
+
+			for _, offset := range []time.Duration{30 * time.Minute, time.Hour} {
+				want, ok := tc.checks[offset]
+				if !ok {
+					continue
+				}
+				blockCtx := ctx.WithBlockTime(start.Add(offset))
+				require.NoError(t, k.EndBlocker(blockCtx))
+
+				stored, found := k.GetDidDocument(blockCtx, did)
+				require.True(t, found)
+				require.Equal(t, want, stored.Status.State, "state at %s", offset)
+				if liftedVersion != 0 {
+					// The stale queue entry must not write another version
+					require.Equal(t, liftedVersion, stored.Version)
+				} else if want == types.DIDStateActive {
+					require.Equal(t, types.StatusRoleModule, stored.Status.UpdatedByRole)
+					require.Nil(t, stored.Status.SuspendedUntil)
+				}
+			}
+		})
+	}
+}
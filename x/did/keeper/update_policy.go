@@ -0,0 +1,388 @@
+package keeper
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"time"
+
+	"cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/persona-chain/persona-chain/internal/clock"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// approvalsKey is the context key under which an executing update carries
+// the controllers that approved it
+type approvalsKey struct{}
+
+type updateApprovals struct {
+	did     string
+	signers []string
+}
+
+// withApprovals returns a context in which signers have approved changes to
+// did
+func withApprovals(ctx sdk.Context, did string, signers []string) sdk.Context {
+	return ctx.WithValue(approvalsKey{}, updateApprovals{did: did, signers: signers})
+}
+
+// approvalsFor returns the controllers that approved the update executing in
+// ctx if it changes did
+func approvalsFor(ctx context.Context, did string) []string {
+	approvals, ok := ctx.Value(approvalsKey{}).(updateApprovals)
+	if !ok || approvals.did != did {
+		return nil
+	}
+	return approvals.signers
+}
+
+// authorizeByPolicy checks that controllerAddr, together with the approvals
+// carried by ctx, meets the policy threshold
+func authorizeByPolicy(ctx context.Context, policy types.UpdatePolicy, controllerAddr string) error {
+	signers := append([]string{controllerAddr}, approvalsFor(ctx, policy.Did)...)
+	if weight := policy.WeightOf(signers); weight < policy.Threshold {
+		return errors.Wrapf(types.ErrThresholdNotMet, "approvals carry weight %d of %d required for DID %s", weight, policy.Threshold, policy.Did)
+	}
+	return nil
+}
+
+func (k Keeper) policyStore(ctx context.Context, storePrefix string) prefix.Store {
+	storeAdapter := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	return prefix.NewStore(storeAdapter, types.KeyPrefix(storePrefix))
+}
+
+// GetUpdatePolicy returns the threshold policy of a DID
+func (k Keeper) GetUpdatePolicy(ctx context.Context, did string) (types.UpdatePolicy, bool) {
+	b := k.policyStore(ctx, types.UpdatePolicyKeyPrefix).Get([]byte(did))
+	if b == nil {
+		return types.UpdatePolicy{}, false
+	}
+
+	var policy types.UpdatePolicy
+	if err := k.cdc.Unmarshal(b, &policy); err != nil {
+		return types.UpdatePolicy{}, false
+	}
+	return policy, true
+}
+
+// SetUpdatePolicy sets the threshold policy of an active DID. Replacing or
+// removing an existing policy needs the approvals of that policy. A zero
+// threshold with no controllers removes the policy.
+func (k Keeper) SetUpdatePolicy(ctx context.Context, did string, threshold uint64, controllers []types.WeightedController, controllerAddr string) error {
+	if err := k.ValidateControllerAuthorization(ctx, did, controllerAddr); err != nil {
+		return err
+	}
+	doc, _ := k.GetDidDocument(ctx, did)
+	if doc.IsDeactivated() {
+		return errors.Wrapf(types.ErrDIDDeactivated, "DID %s", did)
+	}
+
+	store := k.policyStore(ctx, types.UpdatePolicyKeyPrefix)
+	if threshold == 0 && len(controllers) == 0 {
+		store.Delete([]byte(did))
+	} else {
+		policy := types.UpdatePolicy{Did: did, Threshold: threshold, Controllers: controllers}
+		if err := policy.Validate(); err != nil {
+			return err
+		}
+		store.Set([]byte(did), k.cdc.MustMarshal(&policy))
+	}
+
+	return sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventUpdatePolicySet{
+		Did:        did,
+		Controller: controllerAddr,
+		Threshold:  threshold,
+	})
+}
+
+// GetPendingUpdate returns a pending update by ID
+func (k Keeper) GetPendingUpdate(ctx context.Context, id uint64) (types.PendingUpdate, bool) {
+	b := k.policyStore(ctx, types.PendingUpdateKeyPrefix).Get(types.PendingUpdateKey(id))
+	if b == nil {
+		return types.PendingUpdate{}, false
+	}
+
+	var update types.PendingUpdate
+	if err := k.cdc.Unmarshal(b, &update); err != nil {
+		return types.PendingUpdate{}, false
+	}
+	return update, true
+}
+
+// GetPendingUpdatesByDID returns the pending updates of a DID in the order
+// they were proposed
+func (k Keeper) GetPendingUpdatesByDID(ctx context.Context, did string) []types.PendingUpdate {
+	iterator := storetypes.KVStorePrefixIterator(k.indexStore(ctx, types.PendingUpdateDIDIndexPrefix, did), []byte{})
+	defer iterator.Close()
+
+	var updates []types.PendingUpdate
+	for ; iterator.Valid(); iterator.Next() {
+		if update, found := k.GetPendingUpdate(ctx, binary.BigEndian.Uint64(iterator.Key())); found {
+			updates = append(updates, update)
+		}
+	}
+	return updates
+}
+
+func (k Keeper) setPendingUpdate(ctx context.Context, update types.PendingUpdate) {
+	key := types.PendingUpdateKey(update.Id)
+	k.policyStore(ctx, types.PendingUpdateKeyPrefix).Set(key, k.cdc.MustMarshal(&update))
+	k.policyStore(ctx, types.PendingUpdateDIDIndexPrefix).Set(types.DIDIndexKey(update.Did, string(key)), []byte{})
+	k.policyStore(ctx, types.PendingUpdateQueuePrefix).Set(pendingUpdateQueueKey(update), []byte{})
+}
+
+func (k Keeper) removePendingUpdate(ctx context.Context, update types.PendingUpdate) {
+	key := types.PendingUpdateKey(update.Id)
+	k.policyStore(ctx, types.PendingUpdateKeyPrefix).Delete(key)
+	k.policyStore(ctx, types.PendingUpdateDIDIndexPrefix).Delete(types.DIDIndexKey(update.Did, string(key)))
+	k.policyStore(ctx, types.PendingUpdateQueuePrefix).Delete(pendingUpdateQueueKey(update))
+}
+
+func pendingUpdateQueueKey(update types.PendingUpdate) []byte {
+	return types.DIDExpiryQueueKey(update.ExpiresAt, update.Did, strconv.FormatUint(update.Id, 10))
+}
+
+func (k Keeper) nextPendingUpdateID(ctx context.Context) uint64 {
+	store := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	key := types.KeyPrefix(types.PendingUpdateSequenceKey)
+	var id uint64
+	if b := store.Get(key); b != nil {
+		id = binary.BigEndian.Uint64(b)
+	}
+	id++
+	store.Set(key, types.PendingUpdateKey(id))
+	return id
+}
+
+// ProposeUpdate stores msgs as a pending update of a DID carrying the
+// proposer's approval. The update executes at once if the proposer alone
+// meets the threshold. Returns the update and whether it executed.
+func (k Keeper) ProposeUpdate(ctx context.Context, did string, msgs []*cdctypes.Any, proposer string) (types.PendingUpdate, bool, error) {
+	policy, found := k.GetUpdatePolicy(ctx, did)
+	if !found {
+		return types.PendingUpdate{}, false, errors.Wrapf(types.ErrNoUpdatePolicy, "DID %s", did)
+	}
+	if !policy.IsController(proposer) {
+		return types.PendingUpdate{}, false, errors.Wrapf(types.ErrUnauthorized, "%s is not a controller under the update policy of %s", proposer, did)
+	}
+
+	update := types.PendingUpdate{
+		Did:       did,
+		Proposer:  proposer,
+		Messages:  msgs,
+		Approvals: []string{proposer},
+	}
+	unpacked, err := update.GetMsgs()
+	if err != nil {
+		return types.PendingUpdate{}, false, err
+	}
+	for i, msg := range unpacked {
+		if _, err := k.checkUpdateMessage(ctx, did, msg); err != nil {
+			return types.PendingUpdate{}, false, errors.Wrapf(err, "message %d", i)
+		}
+	}
+
+	now := clock.Now(ctx)
+	update.Id = k.nextPendingUpdateID(ctx)
+	update.CreatedAt = now
+	update.ExpiresAt = now.Add(k.GetParams(ctx).PendingUpdatePeriod)
+
+	if err := sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventDIDUpdateProposed{
+		UpdateId:  update.Id,
+		Did:       did,
+		Proposer:  proposer,
+		ExpiresAt: update.ExpiresAt,
+	}); err != nil {
+		return types.PendingUpdate{}, false, err
+	}
+
+	executed, err := k.settlePendingUpdate(ctx, policy, update)
+	if err != nil {
+		return types.PendingUpdate{}, false, err
+	}
+	return update, executed, nil
+}
+
+// ApproveUpdate adds a policy controller's approval to a pending update and
+// executes it once the approvals meet the threshold. Approvals are weighed
+// against the policy in force when they are counted. Returns whether the
+// update executed.
+func (k Keeper) ApproveUpdate(ctx context.Context, id uint64, approver string) (bool, error) {
+	update, found := k.GetPendingUpdate(ctx, id)
+	if !found {
+		return false, errors.Wrapf(types.ErrPendingUpdateNotFound, "update %d", id)
+	}
+	if update.IsExpiredAt(clock.Now(ctx)) {
+		return false, errors.Wrapf(types.ErrPendingUpdateExpired, "update %d", id)
+	}
+
+	policy, found := k.GetUpdatePolicy(ctx, update.Did)
+	if !found {
+		return false, errors.Wrapf(types.ErrNoUpdatePolicy, "DID %s", update.Did)
+	}
+	if !policy.IsController(approver) {
+		return false, errors.Wrapf(types.ErrUnauthorized, "%s is not a controller under the update policy of %s", approver, update.Did)
+	}
+	if update.HasApproved(approver) {
+		return false, errors.Wrapf(types.ErrAlreadyApproved, "%s approved update %d", approver, id)
+	}
+	update.Approvals = append(update.Approvals, approver)
+
+	if err := sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventDIDUpdateApproved{
+		UpdateId:  id,
+		Did:       update.Did,
+		Approver:  approver,
+		Weight:    policy.WeightOf(update.Approvals),
+		Threshold: policy.Threshold,
+	}); err != nil {
+		return false, err
+	}
+
+	return k.settlePendingUpdate(ctx, policy, update)
+}
+
+// settlePendingUpdate executes an update whose approvals meet the policy
+// threshold and otherwise stores it to collect more
+func (k Keeper) settlePendingUpdate(ctx context.Context, policy types.UpdatePolicy, update types.PendingUpdate) (bool, error) {
+	if policy.WeightOf(update.Approvals) < policy.Threshold {
+		k.setPendingUpdate(ctx, update)
+		return false, nil
+	}
+
+	k.removePendingUpdate(ctx, update)
+	msgs, err := update.GetMsgs()
+	if err != nil {
+		return false, err
+	}
+	if err := k.ExecuteUpdate(ctx, update.Did, msgs, update.Approvals); err != nil {
+		return false, errors.Wrapf(err, "update %d", update.Id)
+	}
+	return true, nil
+}
+
+// ExecuteUpdate runs DID module messages that change did on behalf of
+// signers. Each message must be signed by one of them, and every
+// authorization check they reach counts all signers' approvals against the
+// update policy. Either every message succeeds or the update fails.
+func (k Keeper) ExecuteUpdate(ctx context.Context, did string, msgs []sdk.Msg, signers []string) error {
+	if k.router == nil {
+		return errors.Wrap(types.ErrFeatureNotEnabled, "message router is not set")
+	}
+
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	execCtx := withApprovals(sdkCtx, did, signers)
+	for i, msg := range msgs {
+		signer, err := k.checkUpdateMessage(ctx, did, msg)
+		if err != nil {
+			return errors.Wrapf(err, "message %d", i)
+		}
+		if !containsString(signers, signer) {
+			return errors.Wrapf(types.ErrUnauthorized, "message %d is signed by %s, who has not approved the update", i, signer)
+		}
+
+		handler := k.router.Handler(msg)
+		if handler == nil {
+			return errors.Wrapf(types.ErrInvalidConfiguration, "no handler for %s", sdk.MsgTypeURL(msg))
+		}
+		res, err := handler(execCtx, msg)
+		if err != nil {
+			return errors.Wrapf(err, "message %d", i)
+		}
+		for _, event := range res.GetEvents() {
+			sdkCtx.EventManager().EmitEvent(sdk.Event(event))
+		}
+	}
+
+	return sdkCtx.EventManager().EmitTypedEvent(&types.EventDIDUpdateExecuted{
+		Did:     did,
+		Signers: signers,
+	})
+}
+
+// checkUpdateMessage checks that msg is a DID module message changing did
+// and returns the address that signs it
+func (k Keeper) checkUpdateMessage(ctx context.Context, did string, msg sdk.Msg) (string, error) {
+	var target, signer string
+	switch m := msg.(type) {
+	case *types.MsgUpdateDid:
+		target, signer = m.Id, m.Creator
+	case *types.MsgDeactivateDid:
+		target, signer = m.Id, m.Creator
+	case *types.MsgUpdateDIDDocument:
+		target, signer = m.Id, m.Controller
+	case *types.MsgDeactivateDIDDocument:
+		target, signer = m.Id, m.Controller
+	case *types.MsgAddVerificationMethod:
+		target, signer = m.Id, m.Controller
+	case *types.MsgRevokeVerificationMethod:
+		target, signer = m.Id, m.Controller
+	case *types.MsgAddService:
+		target, signer = m.Id, m.Controller
+	case *types.MsgRemoveService:
+		target, signer = m.Id, m.Controller
+	case *types.MsgUpdateDIDStatus:
+		target, signer = m.Id, m.Controller
+	case *types.MsgRotateKeys:
+		target, signer = m.Id, m.Controller
+	case *types.MsgBatchUpdateDID:
+		target, signer = m.Id, m.Controller
+	case *types.MsgSetUpdatePolicy:
+		target, signer = m.Id, m.Controller
+	case *types.MsgRegisterHandle:
+		target, signer = m.Did, m.Controller
+	case *types.MsgSetPrimaryHandle:
+		target, signer = m.Did, m.Controller
+	case *types.MsgRenewHandle:
+		handle, _ := k.GetHandle(ctx, m.Handle)
+		target, signer = handle.Did, m.Controller
+	case *types.MsgTransferHandle:
+		handle, _ := k.GetHandle(ctx, m.Handle)
+		target, signer = handle.Did, m.Controller
+	case *types.MsgRevokeCapability:
+		target, signer = m.Revoker, m.Controller
+	case *types.MsgCreateResource:
+		target, signer = m.Did, m.Controller
+	default:
+		return "", errors.Wrapf(types.ErrUnauthorized, "%s cannot be part of a DID update", sdk.MsgTypeURL(msg))
+	}
+
+	if target != did {
+		return "", errors.Wrapf(types.ErrUnauthorized, "%s changes %q, not %s", sdk.MsgTypeURL(msg), target, did)
+	}
+	return signer, nil
+}
+
+// processPendingUpdateExpiry deletes a pending update whose approval window
+// closed
+func (k Keeper) processPendingUpdateExpiry(ctx sdk.Context, idValue string, now time.Time) error {
+	id, err := strconv.ParseUint(idValue, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid pending update ID %q: %w", idValue, err)
+	}
+	update, found := k.GetPendingUpdate(ctx, id)
+	if !found || !update.IsExpiredAt(now) {
+		return nil
+	}
+	k.removePendingUpdate(ctx, update)
+
+	return ctx.EventManager().EmitTypedEvent(&types.EventPendingUpdateExpired{
+		UpdateId: update.Id,
+		Did:      update.Did,
+	})
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
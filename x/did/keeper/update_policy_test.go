@@ -0,0 +1,276 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/persona-chain/persona-chain/testutil/keeper"
+	"github.com/persona-chain/persona-chain/x/did/keeper"
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+// packMsgs wraps msgs for a pending or multi-signer update
+func packMsgs(t testing.TB, msgs ...sdk.Msg) []*cdctypes.Any {
+	anys := make([]*cdctypes.Any, 0, len(msgs))
+	for _, msg := range msgs {
+		a, err := cdctypes.NewAnyWithValue(msg)
+		require.NoError(t, err)
+		anys = append(anys, a)
+	}
+	return anys
+}
+
+// policyDID creates did owned by alice under a policy where alice weighs 2,
+// bob and carol 1 each, and 3 is required
+func policyDID(t testing.TB, ctx sdk.Context, k keeper.Keeper, did string) (alice, bob, carol string) {
+	alice, bob, carol = testAddress("alice"), testAddress("bob"), testAddress("carol")
+	require.NoError(t, k.SetDidDocument(ctx, newTestDocument(did, alice)))
+	_, err := keeper.NewMsgServerImpl(k).SetUpdatePolicy(ctx, &types.MsgSetUpdatePolicy{
+		Controller: alice,
+		Id:         did,
+		Threshold:  3,
+		Controllers: []types.WeightedController{
+			{Address: alice, Weight: 2},
+			{Address: bob, Weight: 1},
+			{Address: carol, Weight: 1},
+		},
+	})
+	require.NoError(t, err)
+	return alice, bob, carol
+}
+
+func addServiceMsg(did, controller, id string) *types.MsgAddService {
+	return &types.MsgAddService{
+		Controller: controller,
+		Id:         did,
+		Service:    types.Service{ID: id, Type: "Hub", ServiceEndpoint: "https://hub.example"},
+	}
+}
+
+func TestExecuteDIDUpdate(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	msgServer := keeper.NewMsgServerImpl(k)
+	const did = "did:persona:abc"
+	alice, bob, carol := policyDID(t, ctx, k, did)
+	dave := testAddress("dave")
+	require.NoError(t, k.SetDidDocument(ctx, newTestDocument("did:persona:other", alice)))
+
+	tests := []struct {
+		name    string
+		signers []string
+		msgs    []sdk.Msg
+		err     error
+	}{
+		{
+			name:    "threshold met",
+			signers: []string{alice, bob},
+			msgs:    []sdk.Msg{addServiceMsg(did, alice, "#hub")},
+		},
+		{
+			name:    "outsider adds no weight",
+			signers: []string{bob, carol, dave},
+			msgs:    []sdk.Msg{addServiceMsg(did, bob, "#bob")},
+			err:     types.ErrThresholdNotMet,
+		},
+		{
+			name:    "single controller",
+			signers: []string{alice},
+			msgs:    []sdk.Msg{addServiceMsg(did, alice, "#alice")},
+			err:     types.ErrThresholdNotMet,
+		},
+		{
+			name:    "message signed by a non-signer",
+			signers: []string{alice, bob},
+			msgs:    []sdk.Msg{addServiceMsg(did, carol, "#carol")},
+			err:     types.ErrUnauthorized,
+		},
+		{
+			name:    "message changing another DID",
+			signers: []string{alice, bob},
+			msgs:    []sdk.Msg{addServiceMsg("did:persona:other", alice, "#other")},
+			err:     types.ErrUnauthorized,
+		},
+		{
+			name:    "message outside the DID module",
+			signers: []string{alice, bob},
+			msgs:    []sdk.Msg{&types.MsgCreateDIDDocument{Creator: alice, DidDocument: newTestDocument(did, alice)}},
+			err:     types.ErrUnauthorized,
+		},
+		{
+			name:    "failing message fails the update",
+			signers: []string{alice, bob},
+			msgs:    []sdk.Msg{addServiceMsg(did, alice, "#dup"), addServiceMsg(did, bob, "#dup")},
+			err:     types.ErrDuplicateService,
+		},
+		{
+			name:    "policy removed",
+			signers: []string{alice, carol},
+			msgs:    []sdk.Msg{&types.MsgSetUpdatePolicy{Controller: alice, Id: did}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, write := ctx.CacheContext()
+			_, err := msgServer.ExecuteDIDUpdate(cacheCtx, &types.MsgExecuteDIDUpdate{
+				Signers:  tc.signers,
+				Id:       did,
+				Messages: packMsgs(t, tc.msgs...),
+			})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 1, countEvents(cacheCtx, &types.EventDIDUpdateExecuted{}))
+			write()
+		})
+	}
+
+	doc, found := k.GetDidDocument(ctx, did)
+	require.True(t, found)
+	require.Len(t, doc.Service, 1)
+	_, found = k.GetUpdatePolicy(ctx, did)
+	require.False(t, found)
+
+	// Without a policy the creator alone is enough again
+	_, err := msgServer.AddService(ctx, addServiceMsg(did, alice, "#inbox"))
+	require.NoError(t, err)
+}
+
+func TestPolicyGuardsDirectUpdates(t *testing.T) {
+	k, ctx := keepertest.DidKeeper(t)
+	msgServer := keeper.NewMsgServerImpl(k)
+	const did = "did:persona:abc"
+	alice, bob, _ := policyDID(t, ctx, k, did)
+
+	_, err := msgServer.AddService(ctx, addServiceMsg(did, alice, "#hub"))
+	require.ErrorIs(t, err, types.ErrThresholdNotMet)
+
+	_, err = msgServer.SetUpdatePolicy(ctx, &types.MsgSetUpdatePolicy{Controller: alice, Id: did})
+	require.ErrorIs(t, err, types.ErrThresholdNotMet)
+
+	_, err = msgServer.SetUpdatePolicy(ctx, &types.MsgSetUpdatePolicy{
+		Controller:  bob,
+		Id:          did,
+		Threshold:   1,
+		Controllers: []types.WeightedController{{Address: bob, Weight: 1}},
+	})
+	require.ErrorIs(t, err, types.ErrThresholdNotMet)
+
+	policy, found := k.GetUpdatePolicy(ctx, did)
+	require.True(t, found)
+	require.Equal(t, uint64(3), policy.Threshold)
+}
+
+func TestPendingDIDUpdates(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0).UTC()
+	period := types.DefaultParams().PendingUpdatePeriod
+	const did = "did:persona:abc"
+	dave := testAddress("dave")
+
+	// step is one proposal, approval or block at start+offset
+	type step struct {
+		offset   time.Duration
+		propose  string
+		approve  string
+		endBlock bool
+		executed bool
+		pending  int
+		err      error
+	}
+
+	tests := []struct {
+		name  string
+		steps func(alice, bob, carol string) []step
+	}{
+		{
+			name: "approvals accumulate to the threshold",
+			steps: func(alice, bob, carol string) []step {
+				return []step{
+					{propose: bob, pending: 1},
+					{approve: carol, pending: 1},
+					{approve: carol, pending: 1, err: types.ErrAlreadyApproved},
+					{approve: dave, pending: 1, err: types.ErrUnauthorized},
+					{approve: alice, executed: true},
+				}
+			},
+		},
+		{
+			name: "proposal by outsider",
+			steps: func(alice, bob, carol string) []step {
+				return []step{{propose: dave, err: types.ErrUnauthorized}}
+			},
+		},
+		{
+			name: "unapproved update expires",
+			steps: func(alice, bob, carol string) []step {
+				return []step{
+					{propose: alice, pending: 1},
+					{offset: period - time.Second, endBlock: true, pending: 1},
+					{offset: period, approve: bob, pending: 1, err: types.ErrPendingUpdateExpired},
+					{offset: period, endBlock: true},
+					{offset: period, approve: bob, err: types.ErrPendingUpdateNotFound},
+				}
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k, ctx := keepertest.DidKeeper(t)
+			ctx = ctx.WithBlockTime(start)
+			msgServer := keeper.NewMsgServerImpl(k)
+			alice, bob, carol := policyDID(t, ctx, k, did)
+
+			var updateID uint64
+			for i, s := range tc.steps(alice, bob, carol) {
+				stepCtx := ctx.WithBlockTime(start.Add(s.offset))
+
+				var executed bool
+				var err error
+				switch {
+				case s.propose != "":
+					var res *types.MsgProposeDIDUpdateResponse
+					res, err = msgServer.ProposeDIDUpdate(stepCtx, &types.MsgProposeDIDUpdate{
+						Proposer: s.propose,
+						Id:       did,
+						Messages: packMsgs(t, addServiceMsg(did, s.propose, "#hub")),
+					})
+					if err == nil {
+						updateID, executed = res.UpdateId, res.Executed
+					}
+				case s.approve != "":
+					var res *types.MsgApproveDIDUpdateResponse
+					res, err = msgServer.ApproveDIDUpdate(stepCtx, &types.MsgApproveDIDUpdate{Approver: s.approve, UpdateId: updateID})
+					if err == nil {
+						executed = res.Executed
+					}
+				case s.endBlock:
+					stepCtx = stepCtx.WithEventManager(sdk.NewEventManager())
+					err = k.EndBlocker(stepCtx)
+					if s.pending == 0 {
+						require.Equal(t, 1, countEvents(stepCtx, &types.EventPendingUpdateExpired{}), "step %d", i)
+					}
+				}
+				if s.err != nil {
+					require.ErrorIs(t, err, s.err, "step %d", i)
+				} else {
+					require.NoError(t, err, "step %d", i)
+				}
+				require.Equal(t, s.executed, executed, "step %d", i)
+
+				res, err := k.PendingUpdates(stepCtx, &types.QueryPendingUpdatesRequest{Did: did})
+				require.NoError(t, err)
+				require.Len(t, res.Updates, s.pending, "step %d", i)
+
+				doc, _ := k.GetDidDocument(stepCtx, did)
+				require.Equal(t, s.executed, len(doc.Service) == 1, "step %d", i)
+			}
+		})
+	}
+}
@@ -123,6 +123,11 @@ func (am AppModule) RegisterInvariants(_ sdk.InvariantRegistry) {}
 func (am AppModule) RegisterServices(cfg module.Configurator) {
 	types.RegisterMsgServer(cfg.MsgServer(), keeper.NewMsgServerImpl(am.keeper))
 	types.RegisterQueryServer(cfg.QueryServer(), am.keeper)
+
+	m := keeper.NewMigrator(am.keeper)
+	if err := cfg.RegisterMigration(types.ModuleName, 2, m.Migrate2to3); err != nil {
+		panic(fmt.Sprintf("failed to migrate x/%s from version 2 to 3: %v", types.ModuleName, err))
+	}
 }
 
 // InitGenesis performs the did module's genesis initialization It returns
@@ -143,7 +148,28 @@ func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.Raw
 }
 
 // ConsensusVersion implements ConsensusVersion.
-func (AppModule) ConsensusVersion() uint64 { return 2 }
+func (AppModule) ConsensusVersion() uint64 { return 3 }
+
+// InitGenesis initializes the did module's state from a provided genesis
+// state.
+func InitGenesis(ctx sdk.Context, k keeper.Keeper, genState types.GenesisState) {
+	if err := k.SetParams(ctx, genState.Params); err != nil {
+		panic(fmt.Sprintf("failed to set x/%s params: %v", types.ModuleName, err))
+	}
+	for _, doc := range genState.DidDocuments {
+		if err := k.ImportDidDocument(ctx, doc); err != nil {
+			panic(fmt.Sprintf("failed to import %s: %v", doc.ID, err))
+		}
+	}
+}
+
+// ExportGenesis returns the did module's exported genesis.
+func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
+	genesis := types.DefaultGenesis()
+	genesis.Params = k.GetParams(ctx)
+	genesis.DidDocuments = k.GetAllDidDocument(ctx)
+	return genesis
+}
 
 // BeginBlock executes all ABCI BeginBlock logic respective to the did module.
 func (am AppModule) BeginBlock(ctx context.Context) error {
@@ -153,7 +179,7 @@ func (am AppModule) BeginBlock(ctx context.Context) error {
 // EndBlock executes all ABCI EndBlock logic respective to the did module. It
 // returns no validator updates.
 func (am AppModule) EndBlock(ctx context.Context) error {
-	return nil
+	return am.keeper.EndBlocker(ctx)
 }
 
 // GenerateGenesisState creates a randomized GenState of the did module.
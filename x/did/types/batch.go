@@ -0,0 +1,103 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+)
+
+// MaxBatchOperations bounds the operations of one MsgBatchUpdateDID
+const MaxBatchOperations = 64
+
+// DIDOperation types applied by MsgBatchUpdateDID
+const (
+	DIDOperationAddVerificationMethod    = "add_verification_method"
+	DIDOperationRevokeVerificationMethod = "revoke_verification_method"
+	DIDOperationAddService               = "add_service"
+	DIDOperationRemoveService            = "remove_service"
+	DIDOperationAddRelationship          = "add_relationship"
+	DIDOperationRemoveRelationship       = "remove_relationship"
+	DIDOperationUpdateMetadata           = "update_metadata"
+)
+
+// ValidateBasic checks that an operation on the given DID carries the fields
+// its type requires
+func (op DIDOperation) ValidateBasic(did string) error {
+	switch op.Type {
+	case DIDOperationAddVerificationMethod:
+		if op.VerificationMethod == nil {
+			return errors.Wrap(ErrInvalidVerificationMethod, "verification method is required")
+		}
+		if err := op.VerificationMethod.Validate(); err != nil {
+			return errors.Wrapf(ErrInvalidVerificationMethod, "invalid verification method %s: %v", op.VerificationMethod.ID, err)
+		}
+		return ValidateVerificationMethodID(did, op.VerificationMethod.ID)
+	case DIDOperationAddService:
+		if op.Service == nil {
+			return errors.Wrap(ErrInvalidService, "service is required")
+		}
+		if err := op.Service.Validate(); err != nil {
+			return errors.Wrapf(ErrInvalidService, "invalid service %s: %v", op.Service.ID, err)
+		}
+		return nil
+	case DIDOperationRevokeVerificationMethod, DIDOperationRemoveService:
+		if op.TargetId == "" {
+			return errors.Wrapf(ErrInvalidDID, "%s requires a target ID", op.Type)
+		}
+		return nil
+	case DIDOperationAddRelationship, DIDOperationRemoveRelationship:
+		if op.TargetId == "" {
+			return errors.Wrapf(ErrInvalidDID, "%s requires a target ID", op.Type)
+		}
+		if !IsVerificationRelationship(op.Relationship) {
+			return errors.Wrapf(ErrInvalidDID, "unknown verification relationship %q", op.Relationship)
+		}
+		return nil
+	case DIDOperationUpdateMetadata:
+		if op.Metadata == nil {
+			return errors.Wrap(ErrInvalidDID, "metadata is required")
+		}
+		return nil
+	default:
+		return errors.Wrapf(ErrInvalidDID, "unknown operation type %q", op.Type)
+	}
+}
+
+// IsVerificationRelationship reports whether name is a verification
+// relationship of the DID Core data model
+func IsVerificationRelationship(name string) bool {
+	switch name {
+	case RelationshipAuthentication, RelationshipAssertionMethod, RelationshipKeyAgreement,
+		RelationshipCapabilityInvocation, RelationshipCapabilityDelegation:
+		return true
+	default:
+		return false
+	}
+}
+
+// RelationshipRefs returns the list of references held by a verification
+// relationship
+func (d *DIDDocument) RelationshipRefs(name string) (*[]string, bool) {
+	switch name {
+	case RelationshipAuthentication:
+		return &d.Authentication, true
+	case RelationshipAssertionMethod:
+		return &d.AssertionMethod, true
+	case RelationshipKeyAgreement:
+		return &d.KeyAgreement, true
+	case RelationshipCapabilityInvocation:
+		return &d.CapabilityInvocation, true
+	case RelationshipCapabilityDelegation:
+		return &d.CapabilityDelegation, true
+	default:
+		return nil, false
+	}
+}
+
+// Apply replaces the editable metadata of a document
+func (u DIDMetadataUpdate) Apply(m *DIDMetadata) {
+	m.Tags = u.Tags
+	m.Category = u.Category
+	m.OrganizationID = u.OrganizationId
+	m.BusinessUnit = u.BusinessUnit
+	m.Environment = u.Environment
+	m.CriticalityLevel = u.CriticalityLevel
+}
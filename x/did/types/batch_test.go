@@ -0,0 +1,64 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestDIDOperationValidateBasic(t *testing.T) {
+	const did = "did:persona:abc"
+	key := newEd25519VM(t, did+"#key-1")
+	key.Controller = did
+	vm := &key
+	foreign := key
+	foreign.ID = "did:persona:other#key-1"
+
+	tests := []struct {
+		name string
+		op   types.DIDOperation
+		err  error
+	}{
+		{name: "add verification method", op: types.DIDOperation{Type: types.DIDOperationAddVerificationMethod, VerificationMethod: vm}},
+		{name: "verification method missing", op: types.DIDOperation{Type: types.DIDOperationAddVerificationMethod}, err: types.ErrInvalidVerificationMethod},
+		{name: "verification method of another DID", op: types.DIDOperation{Type: types.DIDOperationAddVerificationMethod, VerificationMethod: &foreign}, err: types.ErrInvalidDIDURL},
+		{name: "add service", op: types.DIDOperation{Type: types.DIDOperationAddService, Service: &types.Service{ID: "#hub", Type: "Hub", ServiceEndpoint: "https://hub.example"}}},
+		{name: "service without endpoint", op: types.DIDOperation{Type: types.DIDOperationAddService, Service: &types.Service{ID: "#hub", Type: "Hub"}}, err: types.ErrInvalidService},
+		{name: "revoke", op: types.DIDOperation{Type: types.DIDOperationRevokeVerificationMethod, TargetId: vm.ID}},
+		{name: "remove service without target", op: types.DIDOperation{Type: types.DIDOperationRemoveService}, err: types.ErrInvalidDID},
+		{name: "add relationship", op: types.DIDOperation{Type: types.DIDOperationAddRelationship, TargetId: vm.ID, Relationship: types.RelationshipKeyAgreement}},
+		{name: "unknown relationship", op: types.DIDOperation{Type: types.DIDOperationRemoveRelationship, TargetId: vm.ID, Relationship: "owner"}, err: types.ErrInvalidDID},
+		{name: "metadata missing", op: types.DIDOperation{Type: types.DIDOperationUpdateMetadata}, err: types.ErrInvalidDID},
+		{name: "unknown type", op: types.DIDOperation{Type: "rename"}, err: types.ErrInvalidDID},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.op.ValidateBasic(did)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRelationshipRefs(t *testing.T) {
+	var doc types.DIDDocument
+	for _, name := range []string{
+		types.RelationshipAuthentication, types.RelationshipAssertionMethod, types.RelationshipKeyAgreement,
+		types.RelationshipCapabilityInvocation, types.RelationshipCapabilityDelegation,
+	} {
+		refs, ok := doc.RelationshipRefs(name)
+		require.True(t, ok, name)
+		*refs = append(*refs, "#"+name)
+	}
+	require.Equal(t, []string{"#" + types.RelationshipAuthentication}, doc.Authentication)
+	require.Equal(t, []string{"#" + types.RelationshipCapabilityDelegation}, doc.CapabilityDelegation)
+
+	_, ok := doc.RelationshipRefs("owner")
+	require.False(t, ok)
+}
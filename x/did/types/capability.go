@@ -0,0 +1,227 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"cosmossdk.io/errors"
+	proto "github.com/cosmos/gogoproto/proto"
+)
+
+// Domains separating capability signatures from any other signature made
+// with the same key
+const (
+	DelegationChallengeDomain = "persona-chain/did/capability-delegation/v1"
+	InvocationChallengeDomain = "persona-chain/did/capability-invocation/v1"
+)
+
+// CapabilityIDPrefix starts every capability ID
+const CapabilityIDPrefix = "urn:zcap:"
+
+// MaxDelegationDepth bounds the length of a delegation chain, counting the
+// root delegation
+const MaxDelegationDepth = 8
+
+// MaxCapabilityActions bounds the allowed actions of one capability
+const MaxCapabilityActions = 32
+
+// CapabilityProof is a signature by a verification method of the delegator
+// or invoker
+type CapabilityProof struct {
+	VerificationMethodId string `protobuf:"bytes,1,opt,name=verification_method_id,json=verificationMethodId,proto3" json:"verification_method_id,omitempty"`
+	Signature            []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *CapabilityProof) Reset()         { *m = CapabilityProof{} }
+func (m *CapabilityProof) String() string { return proto.CompactTextString(m) }
+func (*CapabilityProof) ProtoMessage()    {}
+
+// Capability authorizes the invoker DID to act on the invocation target. A
+// root capability is delegated by the DID the target belongs to; any other
+// is delegated by the invoker of its parent and can only narrow it.
+type Capability struct {
+	Id               string          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ParentCapability string          `protobuf:"bytes,2,opt,name=parent_capability,json=parentCapability,proto3" json:"parent_capability,omitempty"`
+	Delegator        string          `protobuf:"bytes,3,opt,name=delegator,proto3" json:"delegator,omitempty"`
+	Invoker          string          `protobuf:"bytes,4,opt,name=invoker,proto3" json:"invoker,omitempty"`
+	InvocationTarget string          `protobuf:"bytes,5,opt,name=invocation_target,json=invocationTarget,proto3" json:"invocation_target,omitempty"`
+	AllowedActions   []string        `protobuf:"bytes,6,rep,name=allowed_actions,json=allowedActions,proto3" json:"allowed_actions,omitempty"`
+	Expires          *time.Time      `protobuf:"bytes,7,opt,name=expires,proto3,stdtime" json:"expires,omitempty"`
+	MaxUses          uint64          `protobuf:"varint,8,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	Uses             uint64          `protobuf:"varint,9,opt,name=uses,proto3" json:"uses,omitempty"`
+	Proof            CapabilityProof `protobuf:"bytes,10,opt,name=proof,proto3" json:"proof"`
+	CreatedAt        time.Time       `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3,stdtime" json:"created_at"`
+	Revoked          bool            `protobuf:"varint,12,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	RevokedAt        *time.Time      `protobuf:"bytes,13,opt,name=revoked_at,json=revokedAt,proto3,stdtime" json:"revoked_at,omitempty"`
+	RevokedBy        string          `protobuf:"bytes,14,opt,name=revoked_by,json=revokedBy,proto3" json:"revoked_by,omitempty"`
+}
+
+func (m *Capability) Reset()         { *m = Capability{} }
+func (m *Capability) String() string { return proto.CompactTextString(m) }
+func (*Capability) ProtoMessage()    {}
+
+// CapabilityInvocation presents a capability to act on a target. The proof
+// is made by a capabilityInvocation key of the capability's invoker.
+type CapabilityInvocation struct {
+	Capability       string          `protobuf:"bytes,1,opt,name=capability,proto3" json:"capability,omitempty"`
+	InvocationTarget string          `protobuf:"bytes,2,opt,name=invocation_target,json=invocationTarget,proto3" json:"invocation_target,omitempty"`
+	Action           string          `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Proof            CapabilityProof `protobuf:"bytes,4,opt,name=proof,proto3" json:"proof"`
+}
+
+func (m *CapabilityInvocation) Reset()         { *m = CapabilityInvocation{} }
+func (m *CapabilityInvocation) String() string { return proto.CompactTextString(m) }
+func (*CapabilityInvocation) ProtoMessage()    {}
+
+// IsRoot reports whether the capability was delegated by the DID owning the
+// target rather than derived from another capability
+func (c Capability) IsRoot() bool {
+	return c.ParentCapability == ""
+}
+
+// IsExpiredAt reports whether the capability can no longer be invoked at t
+func (c Capability) IsExpiredAt(t time.Time) bool {
+	return c.Expires != nil && !t.Before(*c.Expires)
+}
+
+// IsExhausted reports whether the capability has been invoked its maximum
+// number of times
+func (c Capability) IsExhausted() bool {
+	return c.MaxUses > 0 && c.Uses >= c.MaxUses
+}
+
+// AllowsAction reports whether the capability permits an action. An empty
+// action list permits every action.
+func (c Capability) AllowsAction(action string) bool {
+	if len(c.AllowedActions) == 0 {
+		return true
+	}
+	for _, allowed := range c.AllowedActions {
+		if allowed == action {
+			return true
+		}
+	}
+	return false
+}
+
+// CoversTarget reports whether the capability applies to target: the
+// capability's own target or a path beneath it
+func (c Capability) CoversTarget(target string) bool {
+	return target == c.InvocationTarget || strings.HasPrefix(target, strings.TrimSuffix(c.InvocationTarget, "/")+"/")
+}
+
+// ValidateBasic checks the fields a delegator chooses
+func (c Capability) ValidateBasic() error {
+	if _, err := ParseDID(c.Delegator); err != nil {
+		return errors.Wrapf(ErrInvalidCapability, "delegator: %v", err)
+	}
+	if _, err := ParseDID(c.Invoker); err != nil {
+		return errors.Wrapf(ErrInvalidCapability, "invoker: %v", err)
+	}
+	if _, err := CapabilityTargetDID(c.InvocationTarget); err != nil {
+		return err
+	}
+	if c.ParentCapability != "" && !strings.HasPrefix(c.ParentCapability, CapabilityIDPrefix) {
+		return errors.Wrapf(ErrInvalidCapability, "invalid parent capability %q", c.ParentCapability)
+	}
+	if len(c.AllowedActions) > MaxCapabilityActions {
+		return errors.Wrapf(ErrInvalidCapability, "at most %d actions are allowed", MaxCapabilityActions)
+	}
+	seen := make(map[string]bool, len(c.AllowedActions))
+	for _, action := range c.AllowedActions {
+		if action == "" || seen[action] {
+			return errors.Wrapf(ErrInvalidCapability, "empty or duplicate action %q", action)
+		}
+		seen[action] = true
+	}
+	if c.Proof.VerificationMethodId == "" || len(c.Proof.Signature) == 0 {
+		return errors.Wrap(ErrInvalidCapability, "delegation proof requires a verification method ID and signature")
+	}
+	return nil
+}
+
+// CapabilityTargetDID returns the DID owning an invocation target. Targets
+// are DIDs or DID URLs, such as a credential schema or issuer role published
+// under the DID.
+func CapabilityTargetDID(target string) (string, error) {
+	parsed, err := ParseDIDURL(target)
+	if err != nil {
+		return "", errors.Wrapf(ErrInvalidCapability, "invocation target: %v", err)
+	}
+	return parsed.DID, nil
+}
+
+// delegationChallenge is serialized with fields in lexicographic order so
+// clients can reproduce the exact bytes
+type delegationChallenge struct {
+	AllowedActions   []string `json:"allowedActions"`
+	ChainID          string   `json:"chainId"`
+	Delegator        string   `json:"delegator"`
+	Domain           string   `json:"domain"`
+	Expires          string   `json:"expires"`
+	InvocationTarget string   `json:"invocationTarget"`
+	Invoker          string   `json:"invoker"`
+	MaxUses          string   `json:"maxUses"`
+	ParentCapability string   `json:"parentCapability"`
+}
+
+// DelegationChallenge returns the bytes the delegator signs to issue a
+// capability. Expires is RFC 3339 in UTC, or empty.
+func DelegationChallenge(c Capability, chainID string) []byte {
+	expires := ""
+	if c.Expires != nil {
+		expires = c.Expires.UTC().Format(time.RFC3339Nano)
+	}
+	actions := c.AllowedActions
+	if actions == nil {
+		actions = []string{}
+	}
+	bz, _ := json.Marshal(delegationChallenge{
+		AllowedActions:   actions,
+		ChainID:          chainID,
+		Delegator:        c.Delegator,
+		Domain:           DelegationChallengeDomain,
+		Expires:          expires,
+		InvocationTarget: c.InvocationTarget,
+		Invoker:          c.Invoker,
+		MaxUses:          strconv.FormatUint(c.MaxUses, 10),
+		ParentCapability: c.ParentCapability,
+	})
+	return bz
+}
+
+// CapabilityID derives the ID of a capability from its delegation challenge
+func CapabilityID(challenge []byte) string {
+	hash := sha256.Sum256(challenge)
+	return CapabilityIDPrefix + hex.EncodeToString(hash[:])
+}
+
+// invocationChallenge is serialized with fields in lexicographic order so
+// clients can reproduce the exact bytes
+type invocationChallenge struct {
+	Action           string `json:"action"`
+	Capability       string `json:"capability"`
+	ChainID          string `json:"chainId"`
+	Domain           string `json:"domain"`
+	InvocationTarget string `json:"invocationTarget"`
+	Nonce            string `json:"nonce"`
+}
+
+// InvocationChallenge returns the bytes the invoker signs to invoke a
+// capability. nonce is the number of times the capability has been invoked,
+// so every signature is good for one invocation.
+func InvocationChallenge(inv CapabilityInvocation, chainID string, nonce uint64) []byte {
+	bz, _ := json.Marshal(invocationChallenge{
+		Action:           inv.Action,
+		Capability:       inv.Capability,
+		ChainID:          chainID,
+		Domain:           InvocationChallengeDomain,
+		InvocationTarget: inv.InvocationTarget,
+		Nonce:            strconv.FormatUint(nonce, 10),
+	})
+	return bz
+}
@@ -0,0 +1,157 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestCapabilityValidateBasic(t *testing.T) {
+	valid := types.Capability{
+		Delegator:        "did:persona:issuer",
+		Invoker:          "did:persona:agent",
+		InvocationTarget: "did:persona:issuer/schemas",
+		AllowedActions:   []string{"issue"},
+		Proof:            types.CapabilityProof{VerificationMethodId: "did:persona:issuer#key-1", Signature: []byte{1}},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *types.Capability)
+		err    error
+	}{
+		{name: "root capability", modify: func(c *types.Capability) {}},
+		{name: "derived capability", modify: func(c *types.Capability) { c.ParentCapability = types.CapabilityIDPrefix + "00" }},
+		{name: "any action", modify: func(c *types.Capability) { c.AllowedActions = nil }},
+		{name: "invalid delegator", modify: func(c *types.Capability) { c.Delegator = "issuer" }, err: types.ErrInvalidCapability},
+		{name: "invalid invoker", modify: func(c *types.Capability) { c.Invoker = "" }, err: types.ErrInvalidCapability},
+		{name: "invalid target", modify: func(c *types.Capability) { c.InvocationTarget = "https://issuer.example" }, err: types.ErrInvalidCapability},
+		{name: "parent is not a capability ID", modify: func(c *types.Capability) { c.ParentCapability = "cap-1" }, err: types.ErrInvalidCapability},
+		{name: "empty action", modify: func(c *types.Capability) { c.AllowedActions = []string{""} }, err: types.ErrInvalidCapability},
+		{name: "duplicate action", modify: func(c *types.Capability) { c.AllowedActions = []string{"issue", "issue"} }, err: types.ErrInvalidCapability},
+		{
+			name: "too many actions",
+			modify: func(c *types.Capability) {
+				c.AllowedActions = nil
+				for i := 0; i <= types.MaxCapabilityActions; i++ {
+					c.AllowedActions = append(c.AllowedActions, strings.Repeat("a", i+1))
+				}
+			},
+			err: types.ErrInvalidCapability,
+		},
+		{name: "unsigned", modify: func(c *types.Capability) { c.Proof.Signature = nil }, err: types.ErrInvalidCapability},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := valid
+			c.AllowedActions = append([]string(nil), valid.AllowedActions...)
+			tc.modify(&c)
+			err := c.ValidateBasic()
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCapabilityCoversTarget(t *testing.T) {
+	tests := []struct {
+		name       string
+		capability string
+		target     string
+		covered    bool
+	}{
+		{name: "same target", capability: "did:persona:issuer/schemas", target: "did:persona:issuer/schemas", covered: true},
+		{name: "path beneath", capability: "did:persona:issuer/schemas", target: "did:persona:issuer/schemas/kyc", covered: true},
+		{name: "trailing slash", capability: "did:persona:issuer/schemas/", target: "did:persona:issuer/schemas/kyc", covered: true},
+		{name: "whole DID", capability: "did:persona:issuer", target: "did:persona:issuer/schemas", covered: true},
+		{name: "shared prefix", capability: "did:persona:issuer/schemas", target: "did:persona:issuer/schemas-v2", covered: false},
+		{name: "parent path", capability: "did:persona:issuer/schemas", target: "did:persona:issuer", covered: false},
+		{name: "other DID", capability: "did:persona:issuer", target: "did:persona:issuer2", covered: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := types.Capability{InvocationTarget: tc.capability}
+			require.Equal(t, tc.covered, c.CoversTarget(tc.target))
+		})
+	}
+}
+
+func TestCapabilityLimits(t *testing.T) {
+	expires := time.Unix(1_700_000_000, 0).UTC()
+	c := types.Capability{AllowedActions: []string{"issue"}, Expires: &expires, MaxUses: 2, Uses: 1}
+
+	require.True(t, c.AllowsAction("issue"))
+	require.False(t, c.AllowsAction("revoke"))
+	require.True(t, types.Capability{}.AllowsAction("revoke"))
+
+	require.False(t, c.IsExpiredAt(expires.Add(-time.Nanosecond)))
+	require.True(t, c.IsExpiredAt(expires))
+	require.False(t, types.Capability{}.IsExpiredAt(expires))
+
+	require.False(t, c.IsExhausted())
+	c.Uses++
+	require.True(t, c.IsExhausted())
+	require.False(t, types.Capability{Uses: 100}.IsExhausted())
+
+	require.True(t, c.IsRoot())
+	c.ParentCapability = types.CapabilityIDPrefix + "00"
+	require.False(t, c.IsRoot())
+}
+
+func TestDelegationChallenge(t *testing.T) {
+	expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+	c := types.Capability{
+		Delegator:        "did:persona:issuer",
+		Invoker:          "did:persona:agent",
+		InvocationTarget: "did:persona:issuer/schemas",
+		AllowedActions:   []string{"issue"},
+		Expires:          &expires,
+		MaxUses:          3,
+	}
+
+	// Clients reproduce these bytes to sign a delegation
+	require.Equal(t,
+		`{"allowedActions":["issue"],"chainId":"persona-1","delegator":"did:persona:issuer",`+
+			`"domain":"persona-chain/did/capability-delegation/v1","expires":"2030-01-02T02:04:05Z",`+
+			`"invocationTarget":"did:persona:issuer/schemas","invoker":"did:persona:agent","maxUses":"3","parentCapability":""}`,
+		string(types.DelegationChallenge(c, "persona-1")))
+
+	id := types.CapabilityID(types.DelegationChallenge(c, "persona-1"))
+	require.True(t, strings.HasPrefix(id, types.CapabilityIDPrefix))
+	require.Len(t, id, len(types.CapabilityIDPrefix)+64)
+
+	// Fields outside the delegator's choice do not change the ID
+	used := c
+	used.Uses = 2
+	used.Proof = types.CapabilityProof{VerificationMethodId: "did:persona:issuer#key-1", Signature: []byte{1}}
+	require.Equal(t, id, types.CapabilityID(types.DelegationChallenge(used, "persona-1")))
+
+	require.NotEqual(t, id, types.CapabilityID(types.DelegationChallenge(c, "persona-2")))
+	c.MaxUses = 4
+	require.NotEqual(t, id, types.CapabilityID(types.DelegationChallenge(c, "persona-1")))
+}
+
+func TestInvocationChallenge(t *testing.T) {
+	inv := types.CapabilityInvocation{
+		Capability:       types.CapabilityIDPrefix + "00",
+		InvocationTarget: "did:persona:issuer/schemas/kyc",
+		Action:           "issue",
+	}
+
+	require.Equal(t,
+		`{"action":"issue","capability":"urn:zcap:00","chainId":"persona-1",`+
+			`"domain":"persona-chain/did/capability-invocation/v1","invocationTarget":"did:persona:issuer/schemas/kyc","nonce":"0"}`,
+		string(types.InvocationChallenge(inv, "persona-1", 0)))
+
+	// Every use of a capability needs a fresh signature
+	require.NotEqual(t, types.InvocationChallenge(inv, "persona-1", 0), types.InvocationChallenge(inv, "persona-1", 1))
+}
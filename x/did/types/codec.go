@@ -11,6 +11,29 @@ func RegisterCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(&MsgCreateDid{}, "did/CreateDid", nil)
 	cdc.RegisterConcrete(&MsgUpdateDid{}, "did/UpdateDid", nil)
 	cdc.RegisterConcrete(&MsgDeactivateDid{}, "did/DeactivateDid", nil)
+	cdc.RegisterConcrete(&MsgCreateDIDDocument{}, "did/CreateDIDDocument", nil)
+	cdc.RegisterConcrete(&MsgUpdateDIDDocument{}, "did/UpdateDIDDocument", nil)
+	cdc.RegisterConcrete(&MsgDeactivateDIDDocument{}, "did/DeactivateDIDDocument", nil)
+	cdc.RegisterConcrete(&MsgAddVerificationMethod{}, "did/AddVerificationMethod", nil)
+	cdc.RegisterConcrete(&MsgRevokeVerificationMethod{}, "did/RevokeVerificationMethod", nil)
+	cdc.RegisterConcrete(&MsgAddService{}, "did/AddService", nil)
+	cdc.RegisterConcrete(&MsgRemoveService{}, "did/RemoveService", nil)
+	cdc.RegisterConcrete(&MsgUpdateDIDStatus{}, "did/UpdateDIDStatus", nil)
+	cdc.RegisterConcrete(&MsgRotateKeys{}, "did/RotateKeys", nil)
+	cdc.RegisterConcrete(&MsgRegisterHandle{}, "did/RegisterHandle", nil)
+	cdc.RegisterConcrete(&MsgRenewHandle{}, "did/RenewHandle", nil)
+	cdc.RegisterConcrete(&MsgTransferHandle{}, "did/TransferHandle", nil)
+	cdc.RegisterConcrete(&MsgSetPrimaryHandle{}, "did/SetPrimaryHandle", nil)
+	cdc.RegisterConcrete(&MsgBatchUpdateDID{}, "did/BatchUpdateDID", nil)
+	cdc.RegisterConcrete(&MsgSetUpdatePolicy{}, "did/SetUpdatePolicy", nil)
+	cdc.RegisterConcrete(&MsgProposeDIDUpdate{}, "did/ProposeDIDUpdate", nil)
+	cdc.RegisterConcrete(&MsgApproveDIDUpdate{}, "did/ApproveDIDUpdate", nil)
+	cdc.RegisterConcrete(&MsgExecuteDIDUpdate{}, "did/ExecuteDIDUpdate", nil)
+	cdc.RegisterConcrete(&MsgDelegateCapability{}, "did/DelegateCapability", nil)
+	cdc.RegisterConcrete(&MsgInvokeCapability{}, "did/InvokeCapability", nil)
+	cdc.RegisterConcrete(&MsgRevokeCapability{}, "did/RevokeCapability", nil)
+	cdc.RegisterConcrete(&MsgCreateResource{}, "did/CreateResource", nil)
+	cdc.RegisterConcrete(&MsgRequestRemoteResolution{}, "did/RequestRemoteResolution", nil)
 }
 
 func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
@@ -18,6 +41,29 @@ func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
 		&MsgCreateDid{},
 		&MsgUpdateDid{},
 		&MsgDeactivateDid{},
+		&MsgCreateDIDDocument{},
+		&MsgUpdateDIDDocument{},
+		&MsgDeactivateDIDDocument{},
+		&MsgAddVerificationMethod{},
+		&MsgRevokeVerificationMethod{},
+		&MsgAddService{},
+		&MsgRemoveService{},
+		&MsgUpdateDIDStatus{},
+		&MsgRotateKeys{},
+		&MsgRegisterHandle{},
+		&MsgRenewHandle{},
+		&MsgTransferHandle{},
+		&MsgSetPrimaryHandle{},
+		&MsgBatchUpdateDID{},
+		&MsgSetUpdatePolicy{},
+		&MsgProposeDIDUpdate{},
+		&MsgApproveDIDUpdate{},
+		&MsgExecuteDIDUpdate{},
+		&MsgDelegateCapability{},
+		&MsgInvokeCapability{},
+		&MsgRevokeCapability{},
+		&MsgCreateResource{},
+		&MsgRequestRemoteResolution{},
 	)
 
 	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
@@ -31,4 +77,4 @@ var (
 func init() {
 	RegisterCodec(Amino)
 	Amino.Seal()
-}
\ No newline at end of file
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	proto "github.com/cosmos/gogoproto/proto"
+)
+
+// StorageDeposit is the deposit escrowed for the state a DID document
+// occupies
+type StorageDeposit struct {
+	Did string `protobuf:"bytes,1,opt,name=did,proto3" json:"did,omitempty"`
+	// Amount is the deposit currently held in the module account
+	Amount sdk.Coins `protobuf:"bytes,2,rep,name=amount,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"amount"`
+	// Bytes is the largest document size the deposit covers
+	Bytes uint64 `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+}
+
+func (m *StorageDeposit) Reset()         { *m = StorageDeposit{} }
+func (m *StorageDeposit) String() string { return proto.CompactTextString(m) }
+func (*StorageDeposit) ProtoMessage()    {}
+
+// Prune drops the key material and services of a deactivated document so
+// that only its identifier, controllers and metadata stay in state. Earlier
+// versions remain available from the version archive.
+func (d *DIDDocument) Prune() {
+	d.VerificationMethod = nil
+	d.Authentication = nil
+	d.AssertionMethod = nil
+	d.KeyAgreement = nil
+	d.CapabilityInvocation = nil
+	d.CapabilityDelegation = nil
+	d.Service = nil
+	d.NextKeyCommitment = ""
+}
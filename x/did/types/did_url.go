@@ -0,0 +1,419 @@
+package types
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"cosmossdk.io/errors"
+)
+
+// DID URL query parameters defined by W3C DID Core section 3.2.1
+const (
+	DIDURLParamService     = "service"
+	DIDURLParamRelativeRef = "relativeRef"
+	DIDURLParamVersionID   = "versionId"
+	DIDURLParamVersionTime = "versionTime"
+	DIDURLParamHashLink    = "hl"
+)
+
+// DID URL query parameters selecting the latest version of a DID-linked
+// resource by name and type
+const (
+	DIDURLParamResourceName = "resourceName"
+	DIDURLParamResourceType = "resourceType"
+)
+
+// DIDURL is a parsed W3C DID or DID URL
+// (did:method:method-specific-id[/path][?query][#fragment])
+type DIDURL struct {
+	DID              string     `json:"did" yaml:"did"`
+	Method           string     `json:"method" yaml:"method"`
+	MethodSpecificID string     `json:"methodSpecificId" yaml:"methodSpecificId"`
+	Path             string     `json:"path,omitempty" yaml:"path,omitempty"`
+	Query            string     `json:"query,omitempty" yaml:"query,omitempty"`
+	Params           url.Values `json:"params,omitempty" yaml:"params,omitempty"`
+	Fragment         string     `json:"fragment,omitempty" yaml:"fragment,omitempty"`
+}
+
+// IsDID reports whether the URL is a bare DID without path, query or fragment
+func (u DIDURL) IsDID() bool {
+	return u.Path == "" && u.Query == "" && u.Fragment == ""
+}
+
+// VersionID returns the versionId query parameter, if any
+func (u DIDURL) VersionID() string {
+	return u.Params.Get(DIDURLParamVersionID)
+}
+
+// VersionTime returns the versionTime query parameter, if any
+func (u DIDURL) VersionTime() string {
+	return u.Params.Get(DIDURLParamVersionTime)
+}
+
+// Service returns the service query parameter, if any
+func (u DIDURL) Service() string {
+	return u.Params.Get(DIDURLParamService)
+}
+
+// RelativeRef returns the relativeRef query parameter, if any
+func (u DIDURL) RelativeRef() string {
+	return u.Params.Get(DIDURLParamRelativeRef)
+}
+
+// ResourceName returns the resourceName query parameter, if any
+func (u DIDURL) ResourceName() string {
+	return u.Params.Get(DIDURLParamResourceName)
+}
+
+// ResourceType returns the resourceType query parameter, if any
+func (u DIDURL) ResourceType() string {
+	return u.Params.Get(DIDURLParamResourceType)
+}
+
+// String reassembles the DID URL
+func (u DIDURL) String() string {
+	var b strings.Builder
+	b.WriteString(u.DID)
+	b.WriteString(u.Path)
+	if u.Query != "" {
+		b.WriteString("?")
+		b.WriteString(u.Query)
+	}
+	if u.Fragment != "" {
+		b.WriteString("#")
+		b.WriteString(u.Fragment)
+	}
+	return b.String()
+}
+
+// ParseDID parses a bare DID. Paths, queries and fragments are rejected.
+func ParseDID(did string) (DIDURL, error) {
+	parsed, err := ParseDIDURL(did)
+	if err != nil {
+		return DIDURL{}, err
+	}
+	if !parsed.IsDID() {
+		return DIDURL{}, errors.Wrapf(ErrInvalidDID, "%q is a DID URL, not a DID", did)
+	}
+	return parsed, nil
+}
+
+// ParseDIDURL parses a DID URL following the DID Core ABNF:
+//
+//	did-url            = did path-abempty [ "?" query ] [ "#" fragment ]
+//	did                = "did:" method-name ":" method-specific-id
+//	method-name        = 1*method-char
+//	method-char        = %x61-7A / DIGIT
+//	method-specific-id = *( *idchar ":" ) 1*idchar
+//	idchar             = ALPHA / DIGIT / "." / "-" / "_" / pct-encoded
+func ParseDIDURL(input string) (DIDURL, error) {
+	if !strings.HasPrefix(input, "did:") {
+		return DIDURL{}, errors.Wrapf(ErrInvalidDID, "%q must start with \"did:\"", input)
+	}
+
+	rest := input
+	var result DIDURL
+
+	// Split off fragment first, then query, then path
+	if i := strings.IndexByte(rest, '#'); i >= 0 {
+		result.Fragment = rest[i+1:]
+		if err := validateURIComponent(result.Fragment, true); err != nil {
+			return DIDURL{}, errors.Wrapf(ErrInvalidDIDURL, "invalid fragment: %v", err)
+		}
+		rest = rest[:i]
+	}
+	if i := strings.IndexByte(rest, '?'); i >= 0 {
+		result.Query = rest[i+1:]
+		if err := validateURIComponent(result.Query, true); err != nil {
+			return DIDURL{}, errors.Wrapf(ErrInvalidDIDURL, "invalid query: %v", err)
+		}
+		rest = rest[:i]
+	}
+	if i := strings.IndexByte(rest, '/'); i >= 0 {
+		result.Path = rest[i:]
+		for _, segment := range strings.Split(result.Path[1:], "/") {
+			if err := validateURIComponent(segment, false); err != nil {
+				return DIDURL{}, errors.Wrapf(ErrInvalidDIDURL, "invalid path: %v", err)
+			}
+		}
+		rest = rest[:i]
+	}
+
+	// rest is now the bare DID
+	parts := strings.SplitN(rest[len("did:"):], ":", 2)
+	if len(parts) != 2 {
+		return DIDURL{}, errors.Wrapf(ErrInvalidDID, "%q is missing a method-specific identifier", rest)
+	}
+	method, msid := parts[0], parts[1]
+
+	if method == "" {
+		return DIDURL{}, errors.Wrapf(ErrInvalidDID, "%q has an empty method name", rest)
+	}
+	for i := 0; i < len(method); i++ {
+		c := method[i]
+		if !(c >= 'a' && c <= 'z') && !isDigit(c) {
+			return DIDURL{}, errors.Wrapf(ErrInvalidDID, "invalid character %q in method name %q", c, method)
+		}
+	}
+
+	if err := validateMethodSpecificID(msid); err != nil {
+		return DIDURL{}, errors.Wrapf(ErrInvalidDID, "invalid method-specific identifier %q: %v", msid, err)
+	}
+
+	result.DID = rest
+	result.Method = method
+	result.MethodSpecificID = msid
+
+	if result.Query != "" {
+		params, err := parseDIDURLQuery(result.Query)
+		if err != nil {
+			return DIDURL{}, errors.Wrapf(ErrInvalidDIDURL, "invalid query: %v", err)
+		}
+		result.Params = params
+	}
+
+	return result, nil
+}
+
+// validateMethodSpecificID checks *( *idchar ":" ) 1*idchar
+func validateMethodSpecificID(msid string) error {
+	if msid == "" {
+		return fmt.Errorf("identifier is empty")
+	}
+	if strings.HasSuffix(msid, ":") {
+		return fmt.Errorf("identifier must not end with ':'")
+	}
+	for i := 0; i < len(msid); i++ {
+		c := msid[i]
+		switch {
+		case isAlpha(c), isDigit(c), c == '.', c == '-', c == '_', c == ':':
+		case c == '%':
+			if i+2 >= len(msid) || !isHex(msid[i+1]) || !isHex(msid[i+2]) {
+				return fmt.Errorf("malformed percent-encoding at offset %d", i)
+			}
+			i += 2
+		default:
+			return fmt.Errorf("invalid character %q at offset %d", c, i)
+		}
+	}
+	return nil
+}
+
+// validateURIComponent checks RFC 3986 pchar, optionally allowing "/" and "?"
+// as in query and fragment components
+func validateURIComponent(s string, allowSlashAndQuestion bool) error {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case isAlpha(c), isDigit(c):
+		case strings.IndexByte("-._~", c) >= 0: // unreserved
+		case strings.IndexByte("!$&'()*+,;=", c) >= 0: // sub-delims
+		case c == ':' || c == '@':
+		case allowSlashAndQuestion && (c == '/' || c == '?'):
+		case c == '%':
+			if i+2 >= len(s) || !isHex(s[i+1]) || !isHex(s[i+2]) {
+				return fmt.Errorf("malformed percent-encoding at offset %d", i)
+			}
+			i += 2
+		default:
+			return fmt.Errorf("invalid character %q at offset %d", c, i)
+		}
+	}
+	return nil
+}
+
+// parseDIDURLQuery parses the query and validates the DID parameters
+// that have defined semantics
+func parseDIDURLQuery(query string) (url.Values, error) {
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range []string{
+		DIDURLParamService,
+		DIDURLParamRelativeRef,
+		DIDURLParamVersionID,
+		DIDURLParamVersionTime,
+		DIDURLParamHashLink,
+		DIDURLParamResourceName,
+		DIDURLParamResourceType,
+	} {
+		if values, ok := params[name]; ok {
+			if len(values) > 1 {
+				return nil, fmt.Errorf("parameter %s must not be repeated", name)
+			}
+			if values[0] == "" {
+				return nil, fmt.Errorf("parameter %s must not be empty", name)
+			}
+		}
+	}
+
+	if params.Get(DIDURLParamVersionID) != "" && params.Get(DIDURLParamVersionTime) != "" {
+		return nil, fmt.Errorf("%s and %s are mutually exclusive", DIDURLParamVersionID, DIDURLParamVersionTime)
+	}
+	if vt := params.Get(DIDURLParamVersionTime); vt != "" {
+		if _, err := time.Parse(time.RFC3339, vt); err != nil {
+			return nil, fmt.Errorf("%s must be an RFC 3339 timestamp: %v", DIDURLParamVersionTime, err)
+		}
+	}
+	if rr := params.Get(DIDURLParamRelativeRef); rr != "" {
+		if params.Get(DIDURLParamService) == "" {
+			return nil, fmt.Errorf("%s requires the %s parameter", DIDURLParamRelativeRef, DIDURLParamService)
+		}
+		if _, err := url.Parse(rr); err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", DIDURLParamRelativeRef, err)
+		}
+	}
+
+	if (params.Get(DIDURLParamResourceName) == "") != (params.Get(DIDURLParamResourceType) == "") {
+		return nil, fmt.Errorf("%s and %s must be used together", DIDURLParamResourceName, DIDURLParamResourceType)
+	}
+
+	return params, nil
+}
+
+func isAlpha(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHex(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// ===== DID URL DEREFERENCING =====
+
+// DereferencedResource is the result of dereferencing a DID URL against a
+// DID document. Exactly one of VerificationMethod, Service, Document or
+// Resource is set.
+type DereferencedResource struct {
+	VerificationMethod *VerificationMethod `json:"verificationMethod,omitempty" yaml:"verificationMethod,omitempty"`
+	Service            *Service            `json:"service,omitempty" yaml:"service,omitempty"`
+	Document           *DIDDocument        `json:"document,omitempty" yaml:"document,omitempty"`
+	Resource           *Resource           `json:"resource,omitempty" yaml:"resource,omitempty"`
+
+	// ServiceEndpoint is set when the URL selects a service endpoint via
+	// the service (and optional relativeRef) query parameters
+	ServiceEndpoint string `json:"serviceEndpoint,omitempty" yaml:"serviceEndpoint,omitempty"`
+}
+
+// Dereference resolves a DID URL against the given document. Fragments select
+// a verification method or service, the service query parameter selects a
+// service endpoint, and a bare DID returns the document itself.
+func (d *DIDDocument) Dereference(didURL string) (DereferencedResource, error) {
+	parsed, err := ParseDIDURL(didURL)
+	if err != nil {
+		return DereferencedResource{}, err
+	}
+	if parsed.DID != d.ID {
+		return DereferencedResource{}, errors.Wrapf(ErrInvalidDIDURL, "DID URL %s does not belong to %s", didURL, d.ID)
+	}
+	if parsed.Path != "" {
+		return DereferencedResource{}, errors.Wrapf(ErrDIDURLDereferenceFailed, "path dereferencing is not supported for %s", didURL)
+	}
+
+	if svcID := parsed.Service(); svcID != "" {
+		svc, found := d.findService(svcID)
+		if !found {
+			return DereferencedResource{}, errors.Wrapf(ErrServiceNotFound, "service %s not found in %s", svcID, d.ID)
+		}
+		endpoint := svc.ServiceEndpoint
+		if rel := parsed.RelativeRef(); rel != "" {
+			base, err := url.Parse(endpoint)
+			if err != nil {
+				return DereferencedResource{}, errors.Wrapf(ErrInvalidServiceEndpoint, "service %s endpoint %q: %v", svcID, endpoint, err)
+			}
+			ref, _ := url.Parse(rel) // already validated in ParseDIDURL
+			endpoint = base.ResolveReference(ref).String()
+		}
+		return DereferencedResource{Service: &svc, ServiceEndpoint: endpoint}, nil
+	}
+
+	if parsed.Fragment == "" {
+		doc := *d
+		return DereferencedResource{Document: &doc}, nil
+	}
+
+	if vm, found := d.FindVerificationMethod(parsed.Fragment); found {
+		return DereferencedResource{VerificationMethod: &vm}, nil
+	}
+	if svc, found := d.findService(parsed.Fragment); found {
+		return DereferencedResource{Service: &svc}, nil
+	}
+
+	return DereferencedResource{}, errors.Wrapf(ErrDIDURLDereferenceFailed, "no resource with fragment #%s in %s", parsed.Fragment, d.ID)
+}
+
+// FindVerificationMethod looks up a verification method by fragment or by
+// its absolute or relative ID
+func (d *DIDDocument) FindVerificationMethod(ref string) (VerificationMethod, bool) {
+	for _, vm := range d.VerificationMethod {
+		if d.matchesFragment(vm.ID, ref) {
+			return vm, true
+		}
+	}
+	return VerificationMethod{}, false
+}
+
+func (d *DIDDocument) findService(ref string) (Service, bool) {
+	for _, svc := range d.Service {
+		if d.matchesFragment(svc.ID, ref) {
+			return svc, true
+		}
+	}
+	return Service{}, false
+}
+
+// matchesFragment compares an ID such as "did:persona:abc#key-1" or "#key-1"
+// with a reference given as "key-1", "#key-1" or the absolute form
+func (d *DIDDocument) matchesFragment(id, ref string) bool {
+	if id == ref {
+		return true
+	}
+	return fragmentOf(d.ID, id) != "" && fragmentOf(d.ID, id) == fragmentOf(d.ID, ref)
+}
+
+// fragmentOf returns the fragment of an absolute or relative DID URL that
+// belongs to did, or the reference itself if it has no '#'
+func fragmentOf(did, ref string) string {
+	i := strings.IndexByte(ref, '#')
+	if i < 0 {
+		return ref
+	}
+	if i > 0 && ref[:i] != did {
+		return ""
+	}
+	return ref[i+1:]
+}
+
+// ValidateVerificationMethodID checks that a verification method ID is either a relative fragment reference ("#key-1") or a DID URL with a
+// fragment under the given DID
+func ValidateVerificationMethodID(did, id string) error {
+	if strings.HasPrefix(id, "#") {
+		if len(id) == 1 {
+			return errors.Wrap(ErrInvalidDIDURL, "fragment must not be empty")
+		}
+		if err := validateURIComponent(id[1:], true); err != nil {
+			return errors.Wrapf(ErrInvalidDIDURL, "invalid fragment %q: %v", id, err)
+		}
+		return nil
+	}
+
+	parsed, err := ParseDIDURL(id)
+	if err != nil {
+		return err
+	}
+	if parsed.Fragment == "" {
+		return errors.Wrapf(ErrInvalidDIDURL, "%q must contain a fragment", id)
+	}
+	if parsed.DID != did {
+		return errors.Wrapf(ErrInvalidDIDURL, "%q is not under %s", id, did)
+	}
+	return nil
+}
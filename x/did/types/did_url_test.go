@@ -0,0 +1,211 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestParseDIDURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected types.DIDURL
+		err      error
+	}{
+		{
+			name:  "bare DID",
+			input: "did:persona:abc123",
+			expected: types.DIDURL{
+				DID:              "did:persona:abc123",
+				Method:           "persona",
+				MethodSpecificID: "abc123",
+			},
+		},
+		{
+			name:  "colon separated and percent-encoded identifier",
+			input: "did:web:example.com%3A8443:users:alice",
+			expected: types.DIDURL{
+				DID:              "did:web:example.com%3A8443:users:alice",
+				Method:           "web",
+				MethodSpecificID: "example.com%3A8443:users:alice",
+			},
+		},
+		{
+			name:  "path and fragment",
+			input: "did:persona:abc/docs/1#key-1",
+			expected: types.DIDURL{
+				DID:              "did:persona:abc",
+				Method:           "persona",
+				MethodSpecificID: "abc",
+				Path:             "/docs/1",
+				Fragment:         "key-1",
+			},
+		},
+		{
+			name:  "version query",
+			input: "did:persona:abc?versionId=2",
+			expected: types.DIDURL{
+				DID:              "did:persona:abc",
+				Method:           "persona",
+				MethodSpecificID: "abc",
+				Query:            "versionId=2",
+				Params:           map[string][]string{"versionId": {"2"}},
+			},
+		},
+		{name: "missing did prefix", input: "persona:abc", err: types.ErrInvalidDID},
+		{name: "missing method-specific id", input: "did:persona", err: types.ErrInvalidDID},
+		{name: "empty method", input: "did::abc", err: types.ErrInvalidDID},
+		{name: "uppercase method", input: "did:Persona:abc", err: types.ErrInvalidDID},
+		{name: "empty method-specific id", input: "did:persona:", err: types.ErrInvalidDID},
+		{name: "trailing colon", input: "did:persona:abc:", err: types.ErrInvalidDID},
+		{name: "invalid idchar", input: "did:persona:ab c", err: types.ErrInvalidDID},
+		{name: "malformed percent-encoding", input: "did:persona:ab%2", err: types.ErrInvalidDID},
+		{name: "invalid fragment", input: "did:persona:abc#key 1", err: types.ErrInvalidDIDURL},
+		{name: "invalid path", input: "did:persona:abc/a b", err: types.ErrInvalidDIDURL},
+		{name: "repeated parameter", input: "did:persona:abc?versionId=1&versionId=2", err: types.ErrInvalidDIDURL},
+		{name: "empty parameter", input: "did:persona:abc?service=", err: types.ErrInvalidDIDURL},
+		{name: "versionId with versionTime", input: "did:persona:abc?versionId=1&versionTime=2024-01-01T00:00:00Z", err: types.ErrInvalidDIDURL},
+		{name: "versionTime not RFC 3339", input: "did:persona:abc?versionTime=yesterday", err: types.ErrInvalidDIDURL},
+		{name: "relativeRef without service", input: "did:persona:abc?relativeRef=%2Fpath", err: types.ErrInvalidDIDURL},
+		{name: "resourceName without resourceType", input: "did:persona:abc?resourceName=schema", err: types.ErrInvalidDIDURL},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := types.ParseDIDURL(tc.input)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected.DID, parsed.DID)
+			require.Equal(t, tc.expected.Method, parsed.Method)
+			require.Equal(t, tc.expected.MethodSpecificID, parsed.MethodSpecificID)
+			require.Equal(t, tc.expected.Path, parsed.Path)
+			require.Equal(t, tc.expected.Query, parsed.Query)
+			require.Equal(t, tc.expected.Fragment, parsed.Fragment)
+			for name, values := range tc.expected.Params {
+				require.Equal(t, values, parsed.Params[name])
+			}
+			require.Equal(t, tc.input, parsed.String())
+		})
+	}
+}
+
+func TestParseDID(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{name: "bare DID", input: "did:persona:abc"},
+		{name: "fragment", input: "did:persona:abc#key-1", err: types.ErrInvalidDID},
+		{name: "query", input: "did:persona:abc?versionId=1", err: types.ErrInvalidDID},
+		{name: "path", input: "did:persona:abc/path", err: types.ErrInvalidDID},
+		{name: "not a DID", input: "abc", err: types.ErrInvalidDID},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := types.ParseDID(tc.input)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDereference(t *testing.T) {
+	doc := types.DIDDocument{
+		ID: "did:persona:abc",
+		VerificationMethod: []types.VerificationMethod{
+			{ID: "did:persona:abc#key-1", Type: types.VerificationMethodTypeEd25519VerificationKey2020},
+		},
+		Service: []types.Service{
+			{ID: "#hub", Type: "LinkedDomains", ServiceEndpoint: "https://example.com/hub/"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		url      string
+		vmID     string
+		svcID    string
+		endpoint string
+		document bool
+		err      error
+	}{
+		{name: "bare DID", url: "did:persona:abc", document: true},
+		{name: "verification method fragment", url: "did:persona:abc#key-1", vmID: "did:persona:abc#key-1"},
+		{name: "service fragment", url: "did:persona:abc#hub", svcID: "#hub"},
+		{name: "service parameter", url: "did:persona:abc?service=hub", svcID: "#hub", endpoint: "https://example.com/hub/"},
+		{name: "service with relativeRef", url: "did:persona:abc?service=hub&relativeRef=inbox", svcID: "#hub", endpoint: "https://example.com/hub/inbox"},
+		{name: "unknown service", url: "did:persona:abc?service=missing", err: types.ErrServiceNotFound},
+		{name: "unknown fragment", url: "did:persona:abc#key-2", err: types.ErrDIDURLDereferenceFailed},
+		{name: "path", url: "did:persona:abc/path", err: types.ErrDIDURLDereferenceFailed},
+		{name: "other DID", url: "did:persona:xyz#key-1", err: types.ErrInvalidDIDURL},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := doc.Dereference(tc.url)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.document {
+				require.NotNil(t, res.Document)
+				require.Equal(t, doc.ID, res.Document.ID)
+			} else {
+				require.Nil(t, res.Document)
+			}
+			if tc.vmID != "" {
+				require.NotNil(t, res.VerificationMethod)
+				require.Equal(t, tc.vmID, res.VerificationMethod.ID)
+			} else {
+				require.Nil(t, res.VerificationMethod)
+			}
+			if tc.svcID != "" {
+				require.NotNil(t, res.Service)
+				require.Equal(t, tc.svcID, res.Service.ID)
+			} else {
+				require.Nil(t, res.Service)
+			}
+			require.Equal(t, tc.endpoint, res.ServiceEndpoint)
+		})
+	}
+}
+
+func TestValidateVerificationMethodID(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		err  error
+	}{
+		{name: "relative fragment", id: "#key-1"},
+		{name: "absolute fragment", id: "did:persona:abc#key-1"},
+		{name: "empty fragment", id: "#", err: types.ErrInvalidDIDURL},
+		{name: "invalid fragment", id: "#key 1", err: types.ErrInvalidDIDURL},
+		{name: "no fragment", id: "did:persona:abc", err: types.ErrInvalidDIDURL},
+		{name: "other DID", id: "did:persona:xyz#key-1", err: types.ErrInvalidDIDURL},
+		{name: "not a DID URL", id: "key-1", err: types.ErrInvalidDID},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := types.ValidateVerificationMethodID("did:persona:abc", tc.id)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
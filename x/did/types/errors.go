@@ -17,6 +17,8 @@ var (
 	ErrUnauthorized              = errors.Register(ErrInvalidDIDCodespace, 1004, "unauthorized operation")
 	ErrInvalidController         = errors.Register(ErrInvalidDIDCodespace, 1005, "invalid controller")
 	ErrInvalidCreator            = errors.Register(ErrInvalidDIDCodespace, 1006, "invalid creator address")
+	ErrInvalidDIDURL             = errors.Register(ErrInvalidDIDCodespace, 1007, "invalid DID URL")
+	ErrDIDURLDereferenceFailed   = errors.Register(ErrInvalidDIDCodespace, 1008, "DID URL dereferencing failed")
 	
 	// Verification method errors
 	ErrInvalidVerificationMethod = errors.Register(ErrInvalidDIDCodespace, 1101, "invalid verification method")
@@ -25,6 +27,18 @@ var (
 	ErrInvalidKeyType            = errors.Register(ErrInvalidDIDCodespace, 1104, "invalid key type")
 	ErrKeyRevoked                = errors.Register(ErrInvalidDIDCodespace, 1105, "key has been revoked")
 	ErrKeyExpired                = errors.Register(ErrInvalidDIDCodespace, 1106, "key has expired")
+	ErrInvalidMultibase          = errors.Register(ErrInvalidDIDCodespace, 1107, "invalid multibase encoding")
+	ErrUnsupportedMulticodec     = errors.Register(ErrInvalidDIDCodespace, 1108, "unsupported multicodec key type")
+	ErrInvalidPublicKeyJwk       = errors.Register(ErrInvalidDIDCodespace, 1109, "invalid public key JWK")
+	ErrInvalidPublicKey          = errors.Register(ErrInvalidDIDCodespace, 1110, "invalid public key material")
+	ErrUnsupportedCurve          = errors.Register(ErrInvalidDIDCodespace, 1111, "unsupported key curve")
+	ErrInvalidSignature          = errors.Register(ErrInvalidDIDCodespace, 1112, "invalid signature")
+	ErrMissingKeyProof           = errors.Register(ErrInvalidDIDCodespace, 1113, "missing proof of possession")
+	ErrInvalidKeyProof           = errors.Register(ErrInvalidDIDCodespace, 1114, "invalid proof of possession")
+	ErrKeyAlreadyRegistered      = errors.Register(ErrInvalidDIDCodespace, 1115, "key is already active on another DID")
+	ErrKeyCommitmentMismatch     = errors.Register(ErrInvalidDIDCodespace, 1116, "keys do not match the pre-rotation commitment")
+	ErrNoKeyCommitment           = errors.Register(ErrInvalidDIDCodespace, 1117, "DID has no pre-rotation commitment")
+	ErrPreRotationRequired       = errors.Register(ErrInvalidDIDCodespace, 1118, "authentication keys can only change by rotation")
 	
 	// Service errors
 	ErrInvalidService            = errors.Register(ErrInvalidDIDCodespace, 1201, "invalid service")
@@ -37,11 +51,13 @@ var (
 	ErrDIDRevoked                = errors.Register(ErrInvalidDIDCodespace, 1302, "DID document is revoked")
 	ErrDIDSuspended              = errors.Register(ErrInvalidDIDCodespace, 1303, "DID document is suspended")
 	ErrInvalidDIDState           = errors.Register(ErrInvalidDIDCodespace, 1304, "invalid DID state transition")
+	ErrInsufficientDeposit       = errors.Register(ErrInvalidDIDCodespace, 1305, "insufficient funds for storage deposit")
 	
 	// Version control errors
 	ErrInvalidVersion            = errors.Register(ErrInvalidDIDCodespace, 1401, "invalid document version")
 	ErrVersionConflict           = errors.Register(ErrInvalidDIDCodespace, 1402, "version conflict detected")
 	ErrVersionNotFound           = errors.Register(ErrInvalidDIDCodespace, 1403, "document version not found")
+	ErrLegacyConversion          = errors.Register(ErrInvalidDIDCodespace, 1404, "legacy DID document cannot be converted")
 	
 	// Guardian and recovery errors
 	ErrInvalidGuardian           = errors.Register(ErrInvalidDIDCodespace, 1501, "invalid guardian configuration")
@@ -120,6 +136,37 @@ var (
 	ErrInvalidAPIResponse        = errors.Register(ErrInvalidDIDCodespace, 2503, "invalid API response")
 	ErrAuthenticationFailed      = errors.Register(ErrInvalidDIDCodespace, 2504, "authentication failed")
 	ErrAuthorizationFailed       = errors.Register(ErrInvalidDIDCodespace, 2505, "authorization failed")
+	
+	// Handle errors
+	ErrInvalidHandle             = errors.Register(ErrInvalidDIDCodespace, 2601, "invalid handle")
+	ErrHandleNotFound            = errors.Register(ErrInvalidDIDCodespace, 2602, "handle not found")
+	ErrHandleTaken               = errors.Register(ErrInvalidDIDCodespace, 2603, "handle is already registered")
+	ErrHandleReserved            = errors.Register(ErrInvalidDIDCodespace, 2604, "handle is reserved")
+	ErrHandleExpired             = errors.Register(ErrInvalidDIDCodespace, 2605, "handle has expired")
+	
+	// Update policy errors
+	ErrInvalidUpdatePolicy       = errors.Register(ErrInvalidDIDCodespace, 2701, "invalid update policy")
+	ErrThresholdNotMet           = errors.Register(ErrInvalidDIDCodespace, 2702, "controller approval threshold not met")
+	ErrNoUpdatePolicy            = errors.Register(ErrInvalidDIDCodespace, 2703, "DID has no update policy")
+	ErrPendingUpdateNotFound     = errors.Register(ErrInvalidDIDCodespace, 2704, "pending update not found")
+	ErrPendingUpdateExpired      = errors.Register(ErrInvalidDIDCodespace, 2705, "pending update has expired")
+	ErrAlreadyApproved           = errors.Register(ErrInvalidDIDCodespace, 2706, "controller has already approved the update")
+	
+	// Capability errors
+	ErrInvalidCapability         = errors.Register(ErrInvalidDIDCodespace, 2801, "invalid capability")
+	ErrCapabilityNotFound        = errors.Register(ErrInvalidDIDCodespace, 2802, "capability not found")
+	ErrCapabilityExists          = errors.Register(ErrInvalidDIDCodespace, 2803, "capability already exists")
+	ErrCapabilityRevoked         = errors.Register(ErrInvalidDIDCodespace, 2804, "capability has been revoked")
+	ErrCapabilityExpired         = errors.Register(ErrInvalidDIDCodespace, 2805, "capability has expired")
+	ErrCapabilityExhausted       = errors.Register(ErrInvalidDIDCodespace, 2806, "capability has no uses left")
+	ErrInvalidDelegation         = errors.Register(ErrInvalidDIDCodespace, 2807, "invalid capability delegation")
+	ErrInvalidInvocation         = errors.Register(ErrInvalidDIDCodespace, 2808, "invalid capability invocation")
+	
+	// Resource errors
+	ErrInvalidResource           = errors.Register(ErrInvalidDIDCodespace, 2901, "invalid resource")
+	ErrResourceNotFound          = errors.Register(ErrInvalidDIDCodespace, 2902, "resource not found")
+	ErrResourceExists            = errors.Register(ErrInvalidDIDCodespace, 2903, "resource already exists")
+	ErrResourceTooLarge          = errors.Register(ErrInvalidDIDCodespace, 2904, "resource exceeds the maximum size")
 )
 
 // Error categories for better error handling
@@ -141,13 +188,23 @@ const (
 // GetErrorCategory returns the category of an error for better handling
 func GetErrorCategory(err error) ErrorCategory {
 	switch {
-	case errors.IsOf(err, ErrInvalidDID, ErrInvalidVerificationMethod, ErrInvalidService, ErrInvalidVersion):
+	case errors.IsOf(err, ErrInvalidDID, ErrInvalidDIDURL, ErrInvalidVerificationMethod, ErrInvalidService, ErrInvalidVersion,
+		ErrInvalidKeyType, ErrInvalidMultibase, ErrUnsupportedMulticodec, ErrInvalidPublicKeyJwk, ErrInvalidPublicKey, ErrUnsupportedCurve,
+		ErrLegacyConversion, ErrInvalidHandle, ErrInvalidUpdatePolicy, ErrInvalidCapability,
+		ErrInvalidResource, ErrResourceTooLarge):
 		return ErrorCategoryValidation
-	case errors.IsOf(err, ErrUnauthorized, ErrAccessDenied, ErrAuthenticationFailed, ErrAuthorizationFailed):
+	case errors.IsOf(err, ErrUnauthorized, ErrAccessDenied, ErrAuthenticationFailed, ErrAuthorizationFailed,
+		ErrInvalidSignature, ErrMissingKeyProof, ErrInvalidKeyProof, ErrKeyCommitmentMismatch, ErrNoKeyCommitment,
+		ErrPreRotationRequired, ErrThresholdNotMet, ErrInvalidDelegation, ErrInvalidInvocation, ErrCapabilityRevoked,
+		ErrCapabilityExpired, ErrCapabilityExhausted):
 		return ErrorCategoryAuthorization
-	case errors.IsOf(err, ErrDIDNotFound, ErrVerificationMethodNotFound, ErrServiceNotFound):
+	case errors.IsOf(err, ErrDIDNotFound, ErrVerificationMethodNotFound, ErrServiceNotFound, ErrDIDURLDereferenceFailed,
+		ErrHandleNotFound, ErrHandleExpired, ErrNoUpdatePolicy, ErrPendingUpdateNotFound, ErrPendingUpdateExpired,
+		ErrCapabilityNotFound, ErrResourceNotFound):
 		return ErrorCategoryNotFound
-	case errors.IsOf(err, ErrDIDAlreadyExists, ErrDuplicateVerificationMethod, ErrVersionConflict):
+	case errors.IsOf(err, ErrDIDAlreadyExists, ErrDuplicateVerificationMethod, ErrVersionConflict, ErrKeyAlreadyRegistered,
+		ErrHandleTaken, ErrHandleReserved, ErrAlreadyApproved, ErrCapabilityExists,
+		ErrResourceExists):
 		return ErrorCategoryConflict
 	case errors.IsOf(err, ErrSecurityPolicyViolation, ErrRateLimitExceeded, ErrInvalidSecurityLevel):
 		return ErrorCategorySecurity
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	"cosmossdk.io/errors"
+)
+
+// ForeignUpdateChallengeDomain separates signatures over DID documents sent
+// over IBC from any other signature made with the same key
+const ForeignUpdateChallengeDomain = "persona-chain/did/ibc-update/v1"
+
+// foreignUpdateChallenge is serialized with fields in lexicographic order so
+// clients can reproduce the exact bytes
+type foreignUpdateChallenge struct {
+	DID           string `json:"did"`
+	Digest        string `json:"digest"`
+	Domain        string `json:"domain"`
+	SourceChainID string `json:"sourceChainId"`
+	Version       string `json:"version"`
+}
+
+// ForeignUpdateChallenge returns the bytes an authentication key signs to
+// authorize sending a version of a DID document over IBC. document is the
+// JSON encoded document carried by the packet; only its SHA-256 digest is
+// signed.
+func ForeignUpdateChallenge(did, sourceChainID string, version uint64, document string) []byte {
+	digest := sha256.Sum256([]byte(document))
+	bz, _ := json.Marshal(foreignUpdateChallenge{
+		DID:           did,
+		Digest:        hex.EncodeToString(digest[:]),
+		Domain:        ForeignUpdateChallengeDomain,
+		SourceChainID: sourceChainID,
+		Version:       strconv.FormatUint(version, 10),
+	})
+	return bz
+}
+
+// ValidateBasic checks the packet carries a signed, versioned DID document
+func (m DidUpdatePacketData) ValidateBasic() error {
+	if _, err := ParseDID(m.DidId); err != nil {
+		return err
+	}
+	if m.DidDocument == "" {
+		return errors.Wrap(ErrEmptyPacketData, "missing DID document")
+	}
+	if m.Version == 0 {
+		return errors.Wrap(ErrInvalidVersion, "version must be positive")
+	}
+	if m.SourceChainId == "" {
+		return errors.Wrap(ErrInvalidChainID, "missing source chain ID")
+	}
+	if m.Proof == nil || len(m.Proof.Signature) == 0 {
+		return errors.Wrapf(ErrMissingKeyProof, "update of %s is not signed", m.DidId)
+	}
+	return nil
+}
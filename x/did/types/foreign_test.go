@@ -0,0 +1,63 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestForeignUpdateChallenge(t *testing.T) {
+	challenge := types.ForeignUpdateChallenge("did:persona:abc", "partner-1", 2, "{}")
+	require.Equal(t, `{"did":"did:persona:abc","digest":"44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","domain":"persona-chain/did/ibc-update/v1","sourceChainId":"partner-1","version":"2"}`, string(challenge))
+
+	// Every field the receiving chain relies on is bound by the signature
+	for name, other := range map[string][]byte{
+		"DID":          types.ForeignUpdateChallenge("did:persona:xyz", "partner-1", 2, "{}"),
+		"source chain": types.ForeignUpdateChallenge("did:persona:abc", "partner-2", 2, "{}"),
+		"version":      types.ForeignUpdateChallenge("did:persona:abc", "partner-1", 3, "{}"),
+		"document":     types.ForeignUpdateChallenge("did:persona:abc", "partner-1", 2, `{"id":"did:persona:abc"}`),
+	} {
+		require.NotEqual(t, challenge, other, name)
+	}
+}
+
+func TestDidUpdatePacketDataValidateBasic(t *testing.T) {
+	valid := func() types.DidUpdatePacketData {
+		return types.DidUpdatePacketData{
+			DidId:         "did:persona:abc",
+			DidDocument:   `{"id":"did:persona:abc"}`,
+			Version:       1,
+			SourceChainId: "partner-1",
+			Proof:         &types.KeyProof{VerificationMethodId: "did:persona:abc#key-1", Signature: []byte{1}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(d *types.DidUpdatePacketData)
+		err    error
+	}{
+		{name: "valid", modify: func(d *types.DidUpdatePacketData) {}},
+		{name: "invalid DID", modify: func(d *types.DidUpdatePacketData) { d.DidId = "abc" }, err: types.ErrInvalidDID},
+		{name: "no document", modify: func(d *types.DidUpdatePacketData) { d.DidDocument = "" }, err: types.ErrEmptyPacketData},
+		{name: "no version", modify: func(d *types.DidUpdatePacketData) { d.Version = 0 }, err: types.ErrInvalidVersion},
+		{name: "no source chain", modify: func(d *types.DidUpdatePacketData) { d.SourceChainId = "" }, err: types.ErrInvalidChainID},
+		{name: "no proof", modify: func(d *types.DidUpdatePacketData) { d.Proof = nil }, err: types.ErrMissingKeyProof},
+		{name: "no signature", modify: func(d *types.DidUpdatePacketData) { d.Proof.Signature = nil }, err: types.ErrMissingKeyProof},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := valid()
+			tc.modify(&d)
+			err := d.ValidateBasic()
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
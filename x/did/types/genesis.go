@@ -0,0 +1,48 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+	proto "github.com/cosmos/gogoproto/proto"
+)
+
+// GenesisState defines the did module's genesis state.
+type GenesisState struct {
+	// params defines all the parameters of the module.
+	Params Params `protobuf:"bytes,1,opt,name=params,proto3" json:"params"`
+	// did_documents are the typed DID documents stored at genesis.
+	DidDocuments []DIDDocument `protobuf:"bytes,2,rep,name=did_documents,json=didDocuments,proto3" json:"did_documents"`
+}
+
+func (m *GenesisState) Reset()         { *m = GenesisState{} }
+func (m *GenesisState) String() string { return proto.CompactTextString(m) }
+func (*GenesisState) ProtoMessage()    {}
+
+// DefaultGenesis returns the default genesis state
+func DefaultGenesis() *GenesisState {
+	return &GenesisState{
+		Params:       DefaultParams(),
+		DidDocuments: []DIDDocument{},
+	}
+}
+
+// Validate performs basic genesis state validation returning an error upon any
+// failure.
+func (gs GenesisState) Validate() error {
+	if err := gs.Params.Validate(); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(gs.DidDocuments))
+	for i := range gs.DidDocuments {
+		doc := gs.DidDocuments[i]
+		if seen[doc.ID] {
+			return errors.Wrapf(ErrDIDAlreadyExists, "duplicate DID %s in genesis", doc.ID)
+		}
+		seen[doc.ID] = true
+
+		if err := doc.Validate(); err != nil {
+			return errors.Wrapf(ErrInvalidDID, "%s: %v", doc.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"strings"
+	"time"
+
+	"cosmossdk.io/errors"
+	proto "github.com/cosmos/gogoproto/proto"
+)
+
+// HandleSuffix ends every handle, e.g. alice.persona
+const HandleSuffix = ".persona"
+
+// Handle length limits, not counting the suffix
+const (
+	MinHandleLength = 3
+	MaxHandleLength = 63
+)
+
+// Handle is a human-readable name registered to a DID
+type Handle struct {
+	Name         string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Did          string    `protobuf:"bytes,2,opt,name=did,proto3" json:"did,omitempty"`
+	RegisteredAt time.Time `protobuf:"bytes,3,opt,name=registered_at,json=registeredAt,proto3,stdtime" json:"registered_at"`
+	ExpiresAt    time.Time `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3,stdtime" json:"expires_at"`
+}
+
+func (m *Handle) Reset()         { *m = Handle{} }
+func (m *Handle) String() string { return proto.CompactTextString(m) }
+func (*Handle) ProtoMessage()    {}
+
+// IsExpiredAt reports whether the registration has lapsed at t
+func (h Handle) IsExpiredAt(t time.Time) bool {
+	return !t.Before(h.ExpiresAt)
+}
+
+// ValidateHandle checks that a handle is a lowercase label of letters,
+// digits and inner hyphens followed by HandleSuffix
+func ValidateHandle(name string) error {
+	label, ok := strings.CutSuffix(name, HandleSuffix)
+	if !ok {
+		return errors.Wrapf(ErrInvalidHandle, "%q must end with %s", name, HandleSuffix)
+	}
+	if len(label) < MinHandleLength || len(label) > MaxHandleLength {
+		return errors.Wrapf(ErrInvalidHandle, "%q must have %d to %d characters before %s", name, MinHandleLength, MaxHandleLength, HandleSuffix)
+	}
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return errors.Wrapf(ErrInvalidHandle, "%q cannot start or end with a hyphen", name)
+	}
+	for _, c := range label {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return errors.Wrapf(ErrInvalidHandle, "%q may only contain lowercase letters, digits and hyphens", name)
+		}
+	}
+	return nil
+}
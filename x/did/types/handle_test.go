@@ -0,0 +1,53 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestValidateHandle(t *testing.T) {
+	tests := []struct {
+		name   string
+		handle string
+		valid  bool
+	}{
+		{name: "letters", handle: "alice.persona", valid: true},
+		{name: "digits and inner hyphens", handle: "acme-2-labs.persona", valid: true},
+		{name: "shortest label", handle: "abc.persona", valid: true},
+		{name: "longest label", handle: strings.Repeat("a", types.MaxHandleLength) + ".persona", valid: true},
+		{name: "missing suffix", handle: "alice"},
+		{name: "other suffix", handle: "alice.eth"},
+		{name: "label too short", handle: "ab.persona"},
+		{name: "label too long", handle: strings.Repeat("a", types.MaxHandleLength+1) + ".persona"},
+		{name: "uppercase", handle: "Alice.persona"},
+		{name: "leading hyphen", handle: "-alice.persona"},
+		{name: "trailing hyphen", handle: "alice-.persona"},
+		{name: "nested name", handle: "a.alice.persona"},
+		{name: "non-ASCII letter", handle: "alicé.persona"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := types.ValidateHandle(tc.handle)
+			if !tc.valid {
+				require.ErrorIs(t, err, types.ErrInvalidHandle)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestHandleIsExpiredAt(t *testing.T) {
+	expiresAt := time.Unix(1_700_000_000, 0).UTC()
+	handle := types.Handle{Name: "alice.persona", ExpiresAt: expiresAt}
+
+	require.False(t, handle.IsExpiredAt(expiresAt.Add(-time.Nanosecond)))
+	require.True(t, handle.IsExpiredAt(expiresAt))
+	require.True(t, handle.IsExpiredAt(expiresAt.Add(time.Second)))
+}
@@ -0,0 +1,40 @@
+package types
+
+import "time"
+
+// Packet types carried in IBCPacketData
+const (
+	PacketTypeDIDResolution      = "did_resolution"
+	PacketTypeVCVerification     = "vc_verification"
+	PacketTypeCrossChainProof    = "cross_chain_proof"
+	PacketTypeGovernanceProposal = "governance_proposal"
+)
+
+// MaxPacketDataSize is the largest payload an IBCPacketData may carry
+const MaxPacketDataSize = 64 * 1024
+
+// DefaultRemoteResolutionTimeout is how long a resolution packet may take to
+// be received by the counterparty chain
+const DefaultRemoteResolutionTimeout = 10 * time.Minute
+
+// IBCPacketData is the JSON envelope of the interchain DID packets
+type IBCPacketData struct {
+	Type string `json:"type"`
+	Data []byte `json:"data"`
+}
+
+// DIDResolutionRequest asks the counterparty chain to resolve a DID
+type DIDResolutionRequest struct {
+	DID string `json:"did"`
+}
+
+// DIDResolutionResponse is the acknowledgement of a DIDResolutionRequest. A
+// document that cannot be returned is reported in Error with a successful
+// acknowledgement, so the responder's audit of the refusal is kept.
+type DIDResolutionResponse struct {
+	DID       string      `json:"did"`
+	Document  DIDDocument `json:"document"`
+	Timestamp time.Time   `json:"timestamp"`
+	ChainID   string      `json:"chainId"`
+	Error     string      `json:"error,omitempty"`
+}
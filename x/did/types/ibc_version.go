@@ -0,0 +1,60 @@
+package types
+
+import (
+	"encoding/json"
+
+	"cosmossdk.io/errors"
+)
+
+// ValidateIBCVersion checks version is a DID protocol version this chain
+// speaks
+func ValidateIBCVersion(version string) error {
+	if version != IBCVersion1 && version != IBCVersion2 {
+		return errors.Wrapf(ErrUnsupportedIBCVersion, "unsupported version: %q", version)
+	}
+	return nil
+}
+
+// NegotiateIBCVersion returns the DID protocol version to open a channel
+// with. An empty proposal selects the latest version.
+func NegotiateIBCVersion(proposed string) (string, error) {
+	if proposed == "" {
+		return IBCVersion2, nil
+	}
+	if err := ValidateIBCVersion(proposed); err != nil {
+		return "", err
+	}
+	return proposed, nil
+}
+
+// ValidateIBCVersionUpgrade checks a channel may be upgraded from one DID
+// protocol version to another. Downgrades are refused since packets of the
+// newer version may still be in flight.
+func ValidateIBCVersionUpgrade(current, proposed string) error {
+	if err := ValidateIBCVersion(proposed); err != nil {
+		return err
+	}
+	if current == IBCVersion2 && proposed == IBCVersion1 {
+		return errors.Wrapf(ErrUnsupportedIBCVersion, "cannot downgrade channel from %s to %s", current, proposed)
+	}
+	return nil
+}
+
+// PacketVersion returns the DID protocol version a packet was encoded for.
+// did-2 packets are IBCPacketData envelopes with a type; anything else is
+// treated as did-1 DidPacketData.
+func PacketVersion(data []byte) string {
+	var envelope IBCPacketData
+	if err := json.Unmarshal(data, &envelope); err == nil && envelope.Type != "" {
+		return IBCVersion2
+	}
+	return IBCVersion1
+}
+
+// ChannelAccepts reports whether a channel negotiated at channelVersion
+// processes packets encoded for packetVersion. did-2 channels keep accepting
+// did-1 packets so that packets in flight when a channel is upgraded are
+// still delivered.
+func ChannelAccepts(channelVersion, packetVersion string) bool {
+	return packetVersion == IBCVersion1 || channelVersion == IBCVersion2
+}
@@ -0,0 +1,98 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestNegotiateIBCVersion(t *testing.T) {
+	tests := []struct {
+		proposed string
+		version  string
+		err      error
+	}{
+		{proposed: "", version: types.IBCVersion2},
+		{proposed: types.IBCVersion1, version: types.IBCVersion1},
+		{proposed: types.IBCVersion2, version: types.IBCVersion2},
+		{proposed: "did-3", err: types.ErrUnsupportedIBCVersion},
+		{proposed: "ics20-1", err: types.ErrUnsupportedIBCVersion},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.proposed, func(t *testing.T) {
+			version, err := types.NegotiateIBCVersion(tc.proposed)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.version, version)
+		})
+	}
+}
+
+func TestValidateIBCVersionUpgrade(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  string
+		proposed string
+		err      error
+	}{
+		{name: "upgrade", current: types.IBCVersion1, proposed: types.IBCVersion2},
+		{name: "same version", current: types.IBCVersion2, proposed: types.IBCVersion2},
+		{name: "downgrade", current: types.IBCVersion2, proposed: types.IBCVersion1, err: types.ErrUnsupportedIBCVersion},
+		{name: "unknown version", current: types.IBCVersion1, proposed: "did-3", err: types.ErrUnsupportedIBCVersion},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := types.ValidateIBCVersionUpgrade(tc.current, tc.proposed)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPacketVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		version string
+	}{
+		{name: "typed envelope", data: `{"type":"did_resolution","data":"e30="}`, version: types.IBCVersion2},
+		{name: "update packet", data: `{"did_update_packet_data":{"did_id":"did:persona:abc"}}`, version: types.IBCVersion1},
+		{name: "envelope without type", data: `{"type":"","data":"e30="}`, version: types.IBCVersion1},
+		{name: "not JSON", data: "did", version: types.IBCVersion1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.version, types.PacketVersion([]byte(tc.data)))
+		})
+	}
+}
+
+func TestChannelAccepts(t *testing.T) {
+	tests := []struct {
+		channel  string
+		packet   string
+		accepted bool
+	}{
+		{channel: types.IBCVersion1, packet: types.IBCVersion1, accepted: true},
+		{channel: types.IBCVersion1, packet: types.IBCVersion2},
+		{channel: types.IBCVersion2, packet: types.IBCVersion1, accepted: true},
+		{channel: types.IBCVersion2, packet: types.IBCVersion2, accepted: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.packet+" on "+tc.channel, func(t *testing.T) {
+			require.Equal(t, tc.accepted, types.ChannelAccepts(tc.channel, tc.packet))
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSON Patch operation names used by DiffDocuments
+const (
+	JSONPatchAdd     = "add"
+	JSONPatchRemove  = "remove"
+	JSONPatchReplace = "replace"
+)
+
+// NewDIDVersionInfo summarizes a version of a DID document for its history
+func NewDIDVersionInfo(doc DIDDocument) DIDVersionInfo {
+	return DIDVersionInfo{
+		Version:     doc.Version,
+		BlockHeight: doc.BlockHeight,
+		TxHash:      doc.TxHash,
+		Actor:       doc.UpdatedBy,
+		UpdatedAt:   doc.UpdatedAt,
+		State:       string(doc.Status.State),
+		Deactivated: doc.IsDeactivated(),
+	}
+}
+
+// DiffDocuments returns the RFC 6902 JSON Patch that transforms the JSON
+// representation of from into that of to. Object members are compared in
+// sorted order and arrays element by element, so the patch is deterministic.
+func DiffDocuments(from, to DIDDocument) ([]JSONPatchOperation, error) {
+	a, err := toJSONValue(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := toJSONValue(to)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := []JSONPatchOperation{}
+	if err := diffJSON("", a, b, &ops); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+// toJSONValue converts v to its generic JSON form, keeping numbers exact
+func toJSONValue(v interface{}) (interface{}, error) {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber()
+	var out interface{}
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func diffJSON(path string, a, b interface{}, ops *[]JSONPatchOperation) error {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			return appendPatch(ops, JSONPatchReplace, path, b)
+		}
+		for _, key := range sortedKeys(av) {
+			child := path + "/" + escapeJSONPointer(key)
+			if bval, found := bv[key]; found {
+				if err := diffJSON(child, av[key], bval, ops); err != nil {
+					return err
+				}
+			} else if err := appendPatch(ops, JSONPatchRemove, child, nil); err != nil {
+				return err
+			}
+		}
+		for _, key := range sortedKeys(bv) {
+			if _, found := av[key]; !found {
+				if err := appendPatch(ops, JSONPatchAdd, path+"/"+escapeJSONPointer(key), bv[key]); err != nil {
+					return err
+				}
+			}
+		}
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok {
+			return appendPatch(ops, JSONPatchReplace, path, b)
+		}
+		common := len(av)
+		if len(bv) < common {
+			common = len(bv)
+		}
+		for i := 0; i < common; i++ {
+			if err := diffJSON(path+"/"+strconv.Itoa(i), av[i], bv[i], ops); err != nil {
+				return err
+			}
+		}
+		// Remove from the end so earlier indexes stay valid
+		for i := len(av) - 1; i >= common; i-- {
+			if err := appendPatch(ops, JSONPatchRemove, path+"/"+strconv.Itoa(i), nil); err != nil {
+				return err
+			}
+		}
+		for i := common; i < len(bv); i++ {
+			if err := appendPatch(ops, JSONPatchAdd, path+"/"+strconv.Itoa(i), bv[i]); err != nil {
+				return err
+			}
+		}
+	default:
+		if !reflect.DeepEqual(a, b) {
+			return appendPatch(ops, JSONPatchReplace, path, b)
+		}
+	}
+	return nil
+}
+
+func appendPatch(ops *[]JSONPatchOperation, op, path string, value interface{}) error {
+	patch := JSONPatchOperation{Op: op, Path: path}
+	if op != JSONPatchRemove {
+		bz, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		patch.Value = string(bz)
+	}
+	*ops = append(*ops, patch)
+	return nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escapeJSONPointer escapes a reference token as RFC 6901 requires
+func escapeJSONPointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
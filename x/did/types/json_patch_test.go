@@ -0,0 +1,102 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestDiffDocuments(t *testing.T) {
+	base := func() types.DIDDocument {
+		return types.DIDDocument{
+			Context: []string{"https://www.w3.org/ns/did/v1"},
+			ID:      "did:persona:abc",
+			Version: 1,
+			VerificationMethod: []types.VerificationMethod{{
+				ID:           "did:persona:abc#key-1",
+				Type:         types.VerificationMethodTypeJsonWebKey2020,
+				Controller:   "did:persona:abc",
+				PublicKeyJwk: map[string]string{"a/b~c": "1"},
+			}},
+			Authentication: []string{"#key-1", "#key-2", "#key-3"},
+			Service: []types.Service{
+				{ID: "#hub", Type: "Hub", ServiceEndpoint: "https://a.example"},
+				{ID: "#inbox", Type: "Inbox", ServiceEndpoint: "https://b.example"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(doc *types.DIDDocument)
+		ops    []types.JSONPatchOperation
+	}{
+		{
+			name:   "identical",
+			mutate: func(*types.DIDDocument) {},
+			ops:    []types.JSONPatchOperation{},
+		},
+		{
+			name:   "scalar replaced",
+			mutate: func(doc *types.DIDDocument) { doc.Service[0].ServiceEndpoint = "https://c.example" },
+			ops:    []types.JSONPatchOperation{{Op: types.JSONPatchReplace, Path: "/service/0/serviceEndpoint", Value: `"https://c.example"`}},
+		},
+		{
+			name:   "number replaced",
+			mutate: func(doc *types.DIDDocument) { doc.Version = 2 },
+			ops:    []types.JSONPatchOperation{{Op: types.JSONPatchReplace, Path: "/version", Value: "2"}},
+		},
+		{
+			name:   "member added",
+			mutate: func(doc *types.DIDDocument) { doc.Controller = []string{"did:persona:org"} },
+			ops:    []types.JSONPatchOperation{{Op: types.JSONPatchAdd, Path: "/controller", Value: `["did:persona:org"]`}},
+		},
+		{
+			name:   "omitted member removed",
+			mutate: func(doc *types.DIDDocument) { doc.Service = nil },
+			ops:    []types.JSONPatchOperation{{Op: types.JSONPatchRemove, Path: "/service"}},
+		},
+		{
+			name:   "array shrunk from the end",
+			mutate: func(doc *types.DIDDocument) { doc.Authentication = doc.Authentication[:1] },
+			ops: []types.JSONPatchOperation{
+				{Op: types.JSONPatchRemove, Path: "/authentication/2"},
+				{Op: types.JSONPatchRemove, Path: "/authentication/1"},
+			},
+		},
+		{
+			name:   "array grown",
+			mutate: func(doc *types.DIDDocument) { doc.Authentication = append(doc.Authentication, "#key-4") },
+			ops:    []types.JSONPatchOperation{{Op: types.JSONPatchAdd, Path: "/authentication/3", Value: `"#key-4"`}},
+		},
+		{
+			name:   "pointer tokens escaped",
+			mutate: func(doc *types.DIDDocument) { doc.VerificationMethod[0].PublicKeyJwk = map[string]string{"a/b~c": "2"} },
+			ops:    []types.JSONPatchOperation{{Op: types.JSONPatchReplace, Path: "/verificationMethod/0/publicKeyJwk/a~1b~0c", Value: `"2"`}},
+		},
+		{
+			name: "changes in sorted member order",
+			mutate: func(doc *types.DIDDocument) {
+				doc.Version = 3
+				doc.Authentication[0] = "#key-0"
+			},
+			ops: []types.JSONPatchOperation{
+				{Op: types.JSONPatchReplace, Path: "/authentication/0", Value: `"#key-0"`},
+				{Op: types.JSONPatchReplace, Path: "/version", Value: "3"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			to := base()
+			tc.mutate(&to)
+
+			ops, err := types.DiffDocuments(base(), to)
+			require.NoError(t, err)
+			require.Equal(t, tc.ops, ops)
+		})
+	}
+}
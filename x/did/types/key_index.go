@@ -0,0 +1,146 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"cosmossdk.io/errors"
+	proto "github.com/cosmos/gogoproto/proto"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// Verification relationship names as used in DID documents
+const (
+	RelationshipAuthentication       = "authentication"
+	RelationshipAssertionMethod      = "assertionMethod"
+	RelationshipKeyAgreement         = "keyAgreement"
+	RelationshipCapabilityInvocation = "capabilityInvocation"
+	RelationshipCapabilityDelegation = "capabilityDelegation"
+)
+
+// KeyReference is a key index entry locating a verification method by the
+// fingerprint of its public key
+type KeyReference struct {
+	Did                  string   `protobuf:"bytes,1,opt,name=did,proto3" json:"did,omitempty"`
+	VerificationMethodId string   `protobuf:"bytes,2,opt,name=verification_method_id,json=verificationMethodId,proto3" json:"verification_method_id,omitempty"`
+	Relationships        []string `protobuf:"bytes,3,rep,name=relationships,proto3" json:"relationships,omitempty"`
+}
+
+func (m *KeyReference) Reset()         { *m = KeyReference{} }
+func (m *KeyReference) String() string { return proto.CompactTextString(m) }
+func (*KeyReference) ProtoMessage()    {}
+
+// KeyFingerprint returns the RFC 7638 JWK thumbprint of the verification
+// method's public key. The thumbprint is computed from the decoded key, so a
+// key has the same fingerprint whether it is published as a multikey or as a
+// JWK. Only keys of supported verification method types have a fingerprint.
+func (vm *VerificationMethod) KeyFingerprint() (string, error) {
+	algo, key, err := vm.DecodePublicKey()
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	var members map[string]string
+	switch algo {
+	case KeyAlgorithmEd25519, KeyAlgorithmX25519:
+		members = map[string]string{"crv": algo, "kty": "OKP", "x": enc.EncodeToString(key)}
+	case KeyAlgorithmSecp256k1:
+		pub, err := secp256k1.ParsePubKey(key)
+		if err != nil {
+			return "", errors.Wrapf(ErrInvalidPublicKey, "secp256k1: %v", err)
+		}
+		point := pub.SerializeUncompressed()
+		members = map[string]string{"crv": JWKCurveSecp256k1, "kty": "EC", "x": enc.EncodeToString(point[1:33]), "y": enc.EncodeToString(point[33:])}
+	case KeyAlgorithmP256, KeyAlgorithmP384:
+		size := (len(key) - 1) / 2
+		members = map[string]string{"crv": algo, "kty": "EC", "x": enc.EncodeToString(key[1 : 1+size]), "y": enc.EncodeToString(key[1+size:])}
+	default:
+		return "", errors.Wrapf(ErrInvalidKeyType, "cannot fingerprint %s keys", algo)
+	}
+
+	// encoding/json sorts map keys and emits no whitespace, which is the
+	// canonical form RFC 7638 requires
+	bz, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(bz)
+	return enc.EncodeToString(sum[:]), nil
+}
+
+// KeyFingerprintFromMultibase returns the fingerprint of a multikey
+func KeyFingerprintFromMultibase(publicKeyMultibase string) (string, error) {
+	vm := VerificationMethod{Type: VerificationMethodTypeMultikey, PublicKeyMultibase: publicKeyMultibase}
+	return vm.KeyFingerprint()
+}
+
+// KeyFingerprintFromJwk returns the fingerprint of a public JWK
+func KeyFingerprintFromJwk(jwk map[string]string) (string, error) {
+	vm := VerificationMethod{Type: VerificationMethodTypeJsonWebKey2020, PublicKeyJwk: jwk}
+	return vm.KeyFingerprint()
+}
+
+// IsActiveAt reports whether the verification method is neither revoked nor
+// expired at the given time
+func (vm *VerificationMethod) IsActiveAt(t time.Time) bool {
+	return !vm.Revoked && (vm.ExpiresAt == nil || t.Before(*vm.ExpiresAt))
+}
+
+// VerificationRelationships returns the names of the verification
+// relationships that reference a verification method, by absolute ID or by
+// fragment
+func (d *DIDDocument) VerificationRelationships(vmID string) []string {
+	fragment := ""
+	if i := strings.Index(vmID, "#"); i >= 0 {
+		fragment = vmID[i:]
+	}
+
+	var relationships []string
+	for _, rel := range []struct {
+		name string
+		refs []string
+	}{
+		{RelationshipAuthentication, d.Authentication},
+		{RelationshipAssertionMethod, d.AssertionMethod},
+		{RelationshipKeyAgreement, d.KeyAgreement},
+		{RelationshipCapabilityInvocation, d.CapabilityInvocation},
+		{RelationshipCapabilityDelegation, d.CapabilityDelegation},
+	} {
+		for _, ref := range rel.refs {
+			if ref == vmID || (fragment != "" && ref == fragment) {
+				relationships = append(relationships, rel.name)
+				break
+			}
+		}
+	}
+	return relationships
+}
+
+// IsRelatedTo reports whether two documents share a controller or one
+// controls the other
+func (d *DIDDocument) IsRelatedTo(other *DIDDocument) bool {
+	if d.ID == other.ID || (d.Creator != "" && d.Creator == other.Creator) {
+		return true
+	}
+
+	controllers := make(map[string]bool, len(d.Controller)+1)
+	controllers[d.ID] = true
+	for _, c := range d.Controller {
+		if c != "" {
+			controllers[c] = true
+		}
+	}
+	if controllers[other.ID] {
+		return true
+	}
+	for _, c := range other.Controller {
+		if controllers[c] {
+			return true
+		}
+	}
+	return false
+}
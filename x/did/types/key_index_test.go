@@ -0,0 +1,189 @@
+package types_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestKeyFingerprint(t *testing.T) {
+	// RFC 8037 appendix A.3
+	rfcKey := map[string]string{"kty": "OKP", "crv": "Ed25519", "x": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}
+	rfcPub, err := base64.RawURLEncoding.DecodeString(rfcKey["x"])
+	require.NoError(t, err)
+
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	k1Priv, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	k1Pub := k1Priv.PubKey().ToECDSA()
+
+	p256Priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	p384Priv, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		vm          types.VerificationMethod
+		same        types.VerificationMethod
+		fingerprint string
+		err         error
+	}{
+		{
+			name:        "RFC 8037 Ed25519 thumbprint",
+			vm:          jwk(rfcKey),
+			same:        multikey(types.MulticodecEd25519Pub, rfcPub),
+			fingerprint: "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k",
+		},
+		{
+			name: "Ed25519 2020 key and multikey",
+			vm: types.VerificationMethod{
+				Type:               types.VerificationMethodTypeEd25519VerificationKey2020,
+				PublicKeyMultibase: types.EncodeMultibaseMultikey(types.MulticodecEd25519Pub, edPub),
+			},
+			same: multikey(types.MulticodecEd25519Pub, edPub),
+		},
+		{
+			name: "secp256k1 compressed multikey and JWK",
+			vm:   multikey(types.MulticodecSecp256k1Pub, k1Priv.PubKey().SerializeCompressed()),
+			same: jwk(map[string]string{
+				"kty": "EC",
+				"crv": types.JWKCurveSecp256k1,
+				"x":   base64.RawURLEncoding.EncodeToString(k1Pub.X.FillBytes(make([]byte, 32))),
+				"y":   base64.RawURLEncoding.EncodeToString(k1Pub.Y.FillBytes(make([]byte, 32))),
+			}),
+		},
+		{
+			name: "P-256 compressed multikey and JWK",
+			vm:   multikey(types.MulticodecP256Pub, elliptic.MarshalCompressed(elliptic.P256(), p256Priv.X, p256Priv.Y)),
+			same: jwk(ecJwk(types.JWKCurveP256, &p256Priv.PublicKey)),
+		},
+		{
+			name: "P-384 compressed multikey and JWK",
+			vm:   multikey(types.MulticodecP384Pub, elliptic.MarshalCompressed(elliptic.P384(), p384Priv.X, p384Priv.Y)),
+			same: jwk(ecJwk(types.JWKCurveP384, &p384Priv.PublicKey)),
+		},
+		{
+			name: "invalid key",
+			vm:   multikey(types.MulticodecEd25519Pub, edPub[:16]),
+			err:  types.ErrInvalidPublicKey,
+		},
+		{
+			name: "unsupported type",
+			vm:   types.VerificationMethod{Type: "RsaVerificationKey2018", PublicKeyMultibase: "zAAAA"},
+			err:  types.ErrInvalidKeyType,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fingerprint, err := tc.vm.KeyFingerprint()
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			if tc.fingerprint != "" {
+				require.Equal(t, tc.fingerprint, fingerprint)
+			}
+
+			same, err := tc.same.KeyFingerprint()
+			require.NoError(t, err)
+			require.Equal(t, fingerprint, same)
+		})
+	}
+
+	// Distinct keys have distinct fingerprints
+	edVM, rfcVM := multikey(types.MulticodecEd25519Pub, edPub), multikey(types.MulticodecEd25519Pub, rfcPub)
+	a, err := edVM.KeyFingerprint()
+	require.NoError(t, err)
+	b, err := rfcVM.KeyFingerprint()
+	require.NoError(t, err)
+	require.NotEqual(t, a, b)
+}
+
+func TestVerificationRelationships(t *testing.T) {
+	doc := types.DIDDocument{
+		ID:                   "did:persona:abc",
+		Authentication:       []string{"did:persona:abc#key-1", "#key-2"},
+		AssertionMethod:      []string{"#key-1"},
+		KeyAgreement:         []string{"did:persona:abc#key-3"},
+		CapabilityInvocation: []string{"did:persona:other#key-1"},
+	}
+
+	tests := []struct {
+		vmID          string
+		relationships []string
+	}{
+		{vmID: "did:persona:abc#key-1", relationships: []string{types.RelationshipAuthentication, types.RelationshipAssertionMethod}},
+		{vmID: "did:persona:abc#key-2", relationships: []string{types.RelationshipAuthentication}},
+		{vmID: "did:persona:abc#key-3", relationships: []string{types.RelationshipKeyAgreement}},
+		{vmID: "did:persona:abc#key-4"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.vmID, func(t *testing.T) {
+			require.Equal(t, tc.relationships, doc.VerificationRelationships(tc.vmID))
+		})
+	}
+}
+
+func TestIsRelatedTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    types.DIDDocument
+		related bool
+	}{
+		{
+			name:    "same creator",
+			a:       types.DIDDocument{ID: "did:persona:a", Creator: "alice"},
+			b:       types.DIDDocument{ID: "did:persona:b", Creator: "alice"},
+			related: true,
+		},
+		{
+			name:    "shared controller",
+			a:       types.DIDDocument{ID: "did:persona:a", Creator: "alice", Controller: []string{"did:persona:org"}},
+			b:       types.DIDDocument{ID: "did:persona:b", Creator: "bob", Controller: []string{"did:persona:org"}},
+			related: true,
+		},
+		{
+			name:    "a controls b",
+			a:       types.DIDDocument{ID: "did:persona:a", Creator: "alice"},
+			b:       types.DIDDocument{ID: "did:persona:b", Creator: "bob", Controller: []string{"did:persona:a"}},
+			related: true,
+		},
+		{
+			name:    "b controls a",
+			a:       types.DIDDocument{ID: "did:persona:a", Creator: "alice", Controller: []string{"did:persona:b"}},
+			b:       types.DIDDocument{ID: "did:persona:b", Creator: "bob"},
+			related: true,
+		},
+		{
+			name: "unrelated",
+			a:    types.DIDDocument{ID: "did:persona:a", Creator: "alice"},
+			b:    types.DIDDocument{ID: "did:persona:b", Creator: "bob"},
+		},
+		{
+			name: "empty creators",
+			a:    types.DIDDocument{ID: "did:persona:a"},
+			b:    types.DIDDocument{ID: "did:persona:b"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.related, tc.a.IsRelatedTo(&tc.b))
+			require.Equal(t, tc.related, tc.b.IsRelatedTo(&tc.a))
+		})
+	}
+}
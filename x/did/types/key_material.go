@@ -0,0 +1,375 @@
+package types
+
+import (
+	"crypto/ecdh"
+	"encoding/base64"
+	"math/big"
+
+	"cosmossdk.io/errors"
+	"filippo.io/edwards25519"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// Verification method types validated by the module
+const (
+	VerificationMethodTypeEd25519VerificationKey2020 = "Ed25519VerificationKey2020"
+	VerificationMethodTypeMultikey                   = "Multikey"
+	VerificationMethodTypeJsonWebKey2020             = "JsonWebKey2020"
+)
+
+// SupportedVerificationMethodTypes lists the types whose key material is
+// cryptographically validated
+var SupportedVerificationMethodTypes = []string{
+	VerificationMethodTypeEd25519VerificationKey2020,
+	VerificationMethodTypeMultikey,
+	VerificationMethodTypeJsonWebKey2020,
+}
+
+// Multicodec public key codes (unsigned varint encoded)
+// See https://github.com/multiformats/multicodec/blob/master/table.csv
+const (
+	MulticodecEd25519Pub   uint64 = 0xed
+	MulticodecX25519Pub    uint64 = 0xec
+	MulticodecSecp256k1Pub uint64 = 0xe7
+	MulticodecP256Pub      uint64 = 0x1200
+	MulticodecP384Pub      uint64 = 0x1201
+)
+
+// JWK curve names
+const (
+	JWKCurveEd25519   = "Ed25519"
+	JWKCurveSecp256k1 = "secp256k1"
+	JWKCurveP256      = "P-256"
+	JWKCurveP384      = "P-384"
+)
+
+// IsSupportedVerificationMethodType reports whether the module knows how to
+// validate key material of the given type
+func IsSupportedVerificationMethodType(vmType string) bool {
+	for _, t := range SupportedVerificationMethodTypes {
+		if t == vmType {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateKeyMaterial decodes and checks the public key of a verification
+// method of a supported type. Unsupported types return ErrInvalidKeyType.
+func (vm *VerificationMethod) ValidateKeyMaterial() error {
+	switch vm.Type {
+	case VerificationMethodTypeEd25519VerificationKey2020:
+		if len(vm.PublicKeyJwk) > 0 {
+			return errors.Wrapf(ErrInvalidKeyType, "%s must not carry publicKeyJwk", vm.Type)
+		}
+		codec, key, err := DecodeMultibaseMultikey(vm.PublicKeyMultibase)
+		if err != nil {
+			return err
+		}
+		if codec != MulticodecEd25519Pub {
+			return errors.Wrapf(ErrUnsupportedMulticodec, "%s requires an ed25519-pub key, got multicodec 0x%x", vm.Type, codec)
+		}
+		return validateEd25519Key(key)
+
+	case VerificationMethodTypeMultikey:
+		if len(vm.PublicKeyJwk) > 0 {
+			return errors.Wrapf(ErrInvalidKeyType, "%s must not carry publicKeyJwk", vm.Type)
+		}
+		codec, key, err := DecodeMultibaseMultikey(vm.PublicKeyMultibase)
+		if err != nil {
+			return err
+		}
+		return validateMulticodecKey(codec, key)
+
+	case VerificationMethodTypeJsonWebKey2020:
+		if vm.PublicKeyMultibase != "" {
+			return errors.Wrapf(ErrInvalidKeyType, "%s must not carry publicKeyMultibase", vm.Type)
+		}
+		return ValidatePublicKeyJwk(vm.PublicKeyJwk)
+
+	default:
+		return errors.Wrapf(ErrInvalidKeyType, "unsupported verification method type %q", vm.Type)
+	}
+}
+
+// DecodeMultibaseMultikey decodes a base58btc multibase string ('z' prefix)
+// into its multicodec code and raw key bytes
+func DecodeMultibaseMultikey(value string) (uint64, []byte, error) {
+	if value == "" {
+		return 0, nil, errors.Wrap(ErrInvalidMultibase, "publicKeyMultibase is required")
+	}
+	if value[0] != 'z' {
+		return 0, nil, errors.Wrapf(ErrInvalidMultibase, "unsupported multibase prefix %q, expected 'z' (base58btc)", value[0])
+	}
+
+	raw, err := decodeBase58(value[1:])
+	if err != nil {
+		return 0, nil, errors.Wrapf(ErrInvalidMultibase, "base58btc: %v", err)
+	}
+
+	codec, n := decodeUvarint(raw)
+	if n <= 0 {
+		return 0, nil, errors.Wrap(ErrUnsupportedMulticodec, "missing or malformed multicodec prefix")
+	}
+
+	return codec, raw[n:], nil
+}
+
+// EncodeMultibaseMultikey encodes raw key bytes with their multicodec code
+// as a base58btc multibase string, the inverse of DecodeMultibaseMultikey
+func EncodeMultibaseMultikey(codec uint64, key []byte) string {
+	return "z" + encodeBase58(append(encodeUvarint(codec), key...))
+}
+
+// validateMulticodecKey validates raw key bytes for a multicodec code
+func validateMulticodecKey(codec uint64, key []byte) error {
+	switch codec {
+	case MulticodecEd25519Pub:
+		return validateEd25519Key(key)
+	case MulticodecX25519Pub:
+		if len(key) != 32 {
+			return errors.Wrapf(ErrInvalidPublicKey, "x25519 key must be 32 bytes, got %d", len(key))
+		}
+		if _, err := ecdh.X25519().NewPublicKey(key); err != nil {
+			return errors.Wrapf(ErrInvalidPublicKey, "x25519: %v", err)
+		}
+		return nil
+	case MulticodecSecp256k1Pub:
+		if len(key) != secp256k1.PubKeyBytesLenCompressed {
+			return errors.Wrapf(ErrInvalidPublicKey, "secp256k1 key must be %d bytes compressed, got %d", secp256k1.PubKeyBytesLenCompressed, len(key))
+		}
+		if _, err := secp256k1.ParsePubKey(key); err != nil {
+			return errors.Wrapf(ErrInvalidPublicKey, "secp256k1: %v", err)
+		}
+		return nil
+	case MulticodecP256Pub:
+		return validateCompressedNISTKey(ecdh.P256(), JWKCurveP256, 32, key)
+	case MulticodecP384Pub:
+		return validateCompressedNISTKey(ecdh.P384(), JWKCurveP384, 48, key)
+	default:
+		return errors.Wrapf(ErrUnsupportedMulticodec, "unsupported multicodec 0x%x", codec)
+	}
+}
+
+// ValidatePublicKeyJwk checks the key type, curve and coordinates of a
+// public JWK. JWKs carrying private key material are rejected.
+func ValidatePublicKeyJwk(jwk map[string]string) error {
+	if len(jwk) == 0 {
+		return errors.Wrap(ErrInvalidPublicKeyJwk, "publicKeyJwk is required")
+	}
+	if _, ok := jwk["d"]; ok {
+		return errors.Wrap(ErrInvalidPublicKeyJwk, "publicKeyJwk must not contain private key material")
+	}
+
+	kty, crv := jwk["kty"], jwk["crv"]
+	x, err := decodeJwkCoordinate(jwk, "x")
+	if err != nil {
+		return err
+	}
+
+	switch kty {
+	case "OKP":
+		if crv != JWKCurveEd25519 {
+			return errors.Wrapf(ErrUnsupportedCurve, "unsupported OKP curve %q", crv)
+		}
+		if _, ok := jwk["y"]; ok {
+			return errors.Wrap(ErrInvalidPublicKeyJwk, "OKP keys must not have a y coordinate")
+		}
+		return validateEd25519Key(x)
+
+	case "EC":
+		y, err := decodeJwkCoordinate(jwk, "y")
+		if err != nil {
+			return err
+		}
+		switch crv {
+		case JWKCurveSecp256k1:
+			if len(x) != 32 || len(y) != 32 {
+				return errors.Wrapf(ErrInvalidPublicKey, "%s coordinates must be 32 bytes", crv)
+			}
+			if _, err := secp256k1.ParsePubKey(uncompressedPoint(x, y)); err != nil {
+				return errors.Wrapf(ErrInvalidPublicKey, "%s: %v", crv, err)
+			}
+			return nil
+		case JWKCurveP256:
+			return validateNISTPoint(ecdh.P256(), crv, 32, x, y)
+		case JWKCurveP384:
+			return validateNISTPoint(ecdh.P384(), crv, 48, x, y)
+		default:
+			return errors.Wrapf(ErrUnsupportedCurve, "unsupported EC curve %q", crv)
+		}
+
+	default:
+		return errors.Wrapf(ErrInvalidPublicKeyJwk, "unsupported kty %q", kty)
+	}
+}
+
+func decodeJwkCoordinate(jwk map[string]string, name string) ([]byte, error) {
+	value, ok := jwk[name]
+	if !ok || value == "" {
+		return nil, errors.Wrapf(ErrInvalidPublicKeyJwk, "missing %q coordinate", name)
+	}
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidPublicKeyJwk, "%q is not base64url: %v", name, err)
+	}
+	return b, nil
+}
+
+func validateEd25519Key(key []byte) error {
+	if len(key) != 32 {
+		return errors.Wrapf(ErrInvalidPublicKey, "ed25519 key must be 32 bytes, got %d", len(key))
+	}
+	if _, err := new(edwards25519.Point).SetBytes(key); err != nil {
+		return errors.Wrapf(ErrInvalidPublicKey, "ed25519: %v", err)
+	}
+	return nil
+}
+
+func validateNISTPoint(curve ecdh.Curve, name string, size int, x, y []byte) error {
+	if len(x) != size || len(y) != size {
+		return errors.Wrapf(ErrInvalidPublicKey, "%s coordinates must be %d bytes", name, size)
+	}
+	if _, err := curve.NewPublicKey(uncompressedPoint(x, y)); err != nil {
+		return errors.Wrapf(ErrInvalidPublicKey, "%s: %v", name, err)
+	}
+	return nil
+}
+
+// validateCompressedNISTKey checks that a SEC1 compressed point lies on the curve
+func validateCompressedNISTKey(curve ecdh.Curve, name string, size int, key []byte) error {
+	x, y, err := decompressNISTKey(name, size, key)
+	if err != nil {
+		return err
+	}
+	return validateNISTPoint(curve, name, size, x, y)
+}
+
+// decompressNISTKey recovers the affine coordinates of a SEC1 compressed
+// P-256 or P-384 point
+func decompressNISTKey(name string, size int, key []byte) ([]byte, []byte, error) {
+	if len(key) != size+1 || (key[0] != 0x02 && key[0] != 0x03) {
+		return nil, nil, errors.Wrapf(ErrInvalidPublicKey, "%s key must be %d bytes SEC1 compressed", name, size+1)
+	}
+
+	var p, b *big.Int
+	switch name {
+	case JWKCurveP256:
+		p, _ = new(big.Int).SetString("ffffffff00000001000000000000000000000000ffffffffffffffffffffffff", 16)
+		b, _ = new(big.Int).SetString("5ac635d8aa3a93e7b3ebbd55769886bc651d06b0cc53b0f63bce3c3e27d2604b", 16)
+	case JWKCurveP384:
+		p, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffeffffffff0000000000000000ffffffff", 16)
+		b, _ = new(big.Int).SetString("b3312fa7e23ee7e4988e056be3f82d19181d9c6efe8141120314088f5013875ac656398d8a2ed19d2a85c8edd3ec2aef", 16)
+	default:
+		return nil, nil, errors.Wrapf(ErrUnsupportedCurve, "unsupported curve %q", name)
+	}
+
+	// y^2 = x^3 - 3x + b (mod p)
+	x := new(big.Int).SetBytes(key[1:])
+	if x.Cmp(p) >= 0 {
+		return nil, nil, errors.Wrapf(ErrInvalidPublicKey, "%s x coordinate out of range", name)
+	}
+	y2 := new(big.Int).Exp(x, big.NewInt(3), p)
+	y2.Sub(y2, new(big.Int).Mul(x, big.NewInt(3)))
+	y2.Add(y2, b)
+	y2.Mod(y2, p)
+	y := new(big.Int).ModSqrt(y2, p)
+	if y == nil {
+		return nil, nil, errors.Wrapf(ErrInvalidPublicKey, "%s point is not on the curve", name)
+	}
+	if y.Bit(0) != uint(key[0]&1) {
+		y.Sub(p, y)
+	}
+
+	return x.FillBytes(make([]byte, size)), y.FillBytes(make([]byte, size)), nil
+}
+
+func uncompressedPoint(x, y []byte) []byte {
+	point := make([]byte, 0, 1+len(x)+len(y))
+	point = append(point, 0x04)
+	point = append(point, x...)
+	return append(point, y...)
+}
+
+// decodeUvarint decodes a multiformats unsigned varint (at most 9 bytes)
+func decodeUvarint(b []byte) (uint64, int) {
+	var v uint64
+	for i := 0; i < len(b) && i < 9; i++ {
+		v |= uint64(b[i]&0x7f) << (7 * uint(i))
+		if b[i]&0x80 == 0 {
+			return v, i + 1
+		}
+	}
+	return 0, 0
+}
+
+// encodeUvarint encodes a multiformats unsigned varint
+func encodeUvarint(v uint64) []byte {
+	var b []byte
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(b, byte(v))
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// decodeBase58 decodes a Bitcoin-alphabet base58 string
+func decodeBase58(s string) ([]byte, error) {
+	if s == "" {
+		return nil, errors.Wrap(ErrInvalidMultibase, "empty base58 string")
+	}
+
+	var index [256]int
+	for i := range index {
+		index[i] = -1
+	}
+	for i := 0; i < len(base58Alphabet); i++ {
+		index[base58Alphabet[i]] = i
+	}
+
+	// Leading '1's encode leading zero bytes
+	zeros := 0
+	for zeros < len(s) && s[zeros] == '1' {
+		zeros++
+	}
+
+	num := new(big.Int)
+	radix := big.NewInt(58)
+	for i := zeros; i < len(s); i++ {
+		d := index[s[i]]
+		if d < 0 {
+			return nil, errors.Wrapf(ErrInvalidMultibase, "invalid base58 character %q", s[i])
+		}
+		num.Mul(num, radix)
+		num.Add(num, big.NewInt(int64(d)))
+	}
+
+	return append(make([]byte, zeros), num.Bytes()...), nil
+}
+
+// encodeBase58 encodes bytes with the Bitcoin base58 alphabet
+func encodeBase58(b []byte) string {
+	// Leading zero bytes encode as leading '1's
+	zeros := 0
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+
+	num := new(big.Int).SetBytes(b)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	var digits []byte
+	for num.Sign() > 0 {
+		num.DivMod(num, radix, mod)
+		digits = append(digits, base58Alphabet[mod.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		digits = append(digits, '1')
+	}
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return string(digits)
+}
@@ -0,0 +1,228 @@
+package types_test
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func multikey(codec uint64, key []byte) types.VerificationMethod {
+	return types.VerificationMethod{
+		Type:               types.VerificationMethodTypeMultikey,
+		PublicKeyMultibase: types.EncodeMultibaseMultikey(codec, key),
+	}
+}
+
+func jwk(fields map[string]string) types.VerificationMethod {
+	return types.VerificationMethod{
+		Type:         types.VerificationMethodTypeJsonWebKey2020,
+		PublicKeyJwk: fields,
+	}
+}
+
+func ecJwk(crv string, pub *ecdsa.PublicKey) map[string]string {
+	size := (pub.Curve.Params().BitSize + 7) / 8
+	return map[string]string{
+		"kty": "EC",
+		"crv": crv,
+		"x":   base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, size))),
+		"y":   base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, size))),
+	}
+}
+
+// offCurveCompressedKey returns a SEC1 compressed encoding whose x
+// coordinate has no matching point on the curve
+func offCurveCompressedKey(t *testing.T, curve elliptic.Curve) []byte {
+	size := (curve.Params().BitSize + 7) / 8
+	for i := 1; i < 256; i++ {
+		key := make([]byte, size+1)
+		key[0] = 0x02
+		key[size] = byte(i)
+		if x, _ := elliptic.UnmarshalCompressed(curve, key); x == nil {
+			return key
+		}
+	}
+	t.Fatal("no off-curve x coordinate found")
+	return nil
+}
+
+func TestValidateKeyMaterial(t *testing.T) {
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	xPriv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	k1Priv, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	p256Priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	p384Priv, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	k1Point := k1Priv.PubKey().SerializeUncompressed()
+	k1Jwk := map[string]string{
+		"kty": "EC",
+		"crv": types.JWKCurveSecp256k1,
+		"x":   base64.RawURLEncoding.EncodeToString(k1Point[1:33]),
+		"y":   base64.RawURLEncoding.EncodeToString(k1Point[33:]),
+	}
+	offCurveJwk := ecJwk(types.JWKCurveP256, &p256Priv.PublicKey)
+	offCurveJwk["y"] = offCurveJwk["x"]
+	privateJwk := ecJwk(types.JWKCurveP256, &p256Priv.PublicKey)
+	privateJwk["d"] = base64.RawURLEncoding.EncodeToString(p256Priv.D.Bytes())
+
+	ed2020 := func(codec uint64, key []byte) types.VerificationMethod {
+		vm := multikey(codec, key)
+		vm.Type = types.VerificationMethodTypeEd25519VerificationKey2020
+		return vm
+	}
+	// y = 2 has no matching x on edwards25519
+	offCurveEd25519 := make([]byte, 32)
+	offCurveEd25519[0] = 2
+	highX := append([]byte{0x02}, make([]byte, 32)...)
+	for i := 1; i < len(highX); i++ {
+		highX[i] = 0xff
+	}
+
+	tests := []struct {
+		name string
+		vm   types.VerificationMethod
+		err  error
+	}{
+		{name: "Ed25519VerificationKey2020", vm: ed2020(types.MulticodecEd25519Pub, edPub)},
+		{name: "Ed25519VerificationKey2020 with x25519 key", vm: ed2020(types.MulticodecX25519Pub, xPriv.PublicKey().Bytes()), err: types.ErrUnsupportedMulticodec},
+		{name: "multikey ed25519", vm: multikey(types.MulticodecEd25519Pub, edPub)},
+		{name: "multikey x25519", vm: multikey(types.MulticodecX25519Pub, xPriv.PublicKey().Bytes())},
+		{name: "multikey secp256k1", vm: multikey(types.MulticodecSecp256k1Pub, k1Priv.PubKey().SerializeCompressed())},
+		{name: "multikey P-256", vm: multikey(types.MulticodecP256Pub, elliptic.MarshalCompressed(elliptic.P256(), p256Priv.X, p256Priv.Y))},
+		{name: "multikey P-384", vm: multikey(types.MulticodecP384Pub, elliptic.MarshalCompressed(elliptic.P384(), p384Priv.X, p384Priv.Y))},
+		{name: "short ed25519 key", vm: multikey(types.MulticodecEd25519Pub, edPub[:31]), err: types.ErrInvalidPublicKey},
+		{name: "ed25519 point not on curve", vm: multikey(types.MulticodecEd25519Pub, offCurveEd25519), err: types.ErrInvalidPublicKey},
+		{name: "uncompressed secp256k1 key", vm: multikey(types.MulticodecSecp256k1Pub, k1Point), err: types.ErrInvalidPublicKey},
+		{name: "P-256 key with uncompressed prefix", vm: multikey(types.MulticodecP256Pub, append([]byte{0x04}, highX[1:]...)), err: types.ErrInvalidPublicKey},
+		{name: "P-256 x coordinate out of range", vm: multikey(types.MulticodecP256Pub, highX), err: types.ErrInvalidPublicKey},
+		{name: "P-256 point not on curve", vm: multikey(types.MulticodecP256Pub, offCurveCompressedKey(t, elliptic.P256())), err: types.ErrInvalidPublicKey},
+		{name: "P-384 point not on curve", vm: multikey(types.MulticodecP384Pub, offCurveCompressedKey(t, elliptic.P384())), err: types.ErrInvalidPublicKey},
+		{name: "unknown multicodec", vm: multikey(0x1234, edPub), err: types.ErrUnsupportedMulticodec},
+		{
+			name: "non-base58btc multibase",
+			vm:   types.VerificationMethod{Type: types.VerificationMethodTypeMultikey, PublicKeyMultibase: "mAAAA"},
+			err:  types.ErrInvalidMultibase,
+		},
+		{
+			name: "invalid base58 character",
+			vm:   types.VerificationMethod{Type: types.VerificationMethodTypeMultikey, PublicKeyMultibase: "z0OIl"},
+			err:  types.ErrInvalidMultibase,
+		},
+		{
+			name: "multikey with JWK",
+			vm: types.VerificationMethod{
+				Type:               types.VerificationMethodTypeMultikey,
+				PublicKeyMultibase: types.EncodeMultibaseMultikey(types.MulticodecEd25519Pub, edPub),
+				PublicKeyJwk:       k1Jwk,
+			},
+			err: types.ErrInvalidKeyType,
+		},
+		{
+			name: "JWK Ed25519",
+			vm:   jwk(map[string]string{"kty": "OKP", "crv": types.JWKCurveEd25519, "x": base64.RawURLEncoding.EncodeToString(edPub)}),
+		},
+		{name: "JWK secp256k1", vm: jwk(k1Jwk)},
+		{name: "JWK P-256", vm: jwk(ecJwk(types.JWKCurveP256, &p256Priv.PublicKey))},
+		{name: "JWK P-384", vm: jwk(ecJwk(types.JWKCurveP384, &p384Priv.PublicKey))},
+		{name: "JWK point not on curve", vm: jwk(offCurveJwk), err: types.ErrInvalidPublicKey},
+		{name: "JWK with private key", vm: jwk(privateJwk), err: types.ErrInvalidPublicKeyJwk},
+		{name: "JWK curve mismatch", vm: jwk(ecJwk(types.JWKCurveP384, &p256Priv.PublicKey)), err: types.ErrInvalidPublicKey},
+		{
+			name: "JWK unsupported curve",
+			vm:   jwk(map[string]string{"kty": "OKP", "crv": "X448", "x": base64.RawURLEncoding.EncodeToString(edPub)}),
+			err:  types.ErrUnsupportedCurve,
+		},
+		{
+			name: "JWK unsupported kty",
+			vm:   jwk(map[string]string{"kty": "RSA", "n": "AQAB", "e": "AQAB", "x": "AQAB"}),
+			err:  types.ErrInvalidPublicKeyJwk,
+		},
+		{
+			name: "JWK missing coordinate",
+			vm:   jwk(map[string]string{"kty": "EC", "crv": types.JWKCurveP256}),
+			err:  types.ErrInvalidPublicKeyJwk,
+		},
+		{
+			name: "unsupported type",
+			vm:   types.VerificationMethod{Type: "RsaVerificationKey2018", PublicKeyMultibase: "zAAAA"},
+			err:  types.ErrInvalidKeyType,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.vm.ValidateKeyMaterial()
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDecodePublicKeyDecompressesNISTKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		curve elliptic.Curve
+		codec uint64
+		algo  string
+	}{
+		{name: "P-256", curve: elliptic.P256(), codec: types.MulticodecP256Pub, algo: types.KeyAlgorithmP256},
+		{name: "P-384", curve: elliptic.P384(), codec: types.MulticodecP384Pub, algo: types.KeyAlgorithmP384},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Enough keys that both y parities are decompressed
+			for i := 0; i < 16; i++ {
+				priv, err := ecdsa.GenerateKey(tc.curve, rand.Reader)
+				require.NoError(t, err)
+				pub, err := priv.PublicKey.ECDH()
+				require.NoError(t, err)
+
+				vm := multikey(tc.codec, elliptic.MarshalCompressed(tc.curve, priv.X, priv.Y))
+				algo, key, err := vm.DecodePublicKey()
+				require.NoError(t, err)
+				require.Equal(t, tc.algo, algo)
+				require.Equal(t, pub.Bytes(), key)
+			}
+		})
+	}
+}
+
+func TestMultibaseMultikeyRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		codec uint64
+		key   []byte
+	}{
+		{name: "single byte varint", codec: types.MulticodecEd25519Pub, key: []byte{1, 2, 3}},
+		{name: "two byte varint", codec: types.MulticodecP256Pub, key: []byte{4, 5, 6}},
+		{name: "leading zero bytes", codec: types.MulticodecX25519Pub, key: []byte{0, 0, 7}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			encoded := types.EncodeMultibaseMultikey(tc.codec, tc.key)
+			codec, key, err := types.DecodeMultibaseMultikey(encoded)
+			require.NoError(t, err)
+			require.Equal(t, tc.codec, codec)
+			require.Equal(t, tc.key, key)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"cosmossdk.io/errors"
+)
+
+// NextKeyCommitment returns the pre-rotation commitment to a set of keys: the
+// base64url SHA-256 digest of the JSON array of their sorted fingerprints.
+// The commitment depends only on the public keys, so it can be computed
+// before the verification methods carrying them are written.
+func NextKeyCommitment(vms []VerificationMethod) (string, error) {
+	if len(vms) == 0 {
+		return "", errors.Wrap(ErrInvalidVerificationMethod, "commitment requires at least one key")
+	}
+
+	fingerprints := make([]string, 0, len(vms))
+	seen := make(map[string]bool, len(vms))
+	for _, vm := range vms {
+		fingerprint, err := vm.KeyFingerprint()
+		if err != nil {
+			return "", errors.Wrapf(err, "verification method %s", vm.ID)
+		}
+		if seen[fingerprint] {
+			return "", errors.Wrapf(ErrDuplicateVerificationMethod, "key of %s appears twice", vm.ID)
+		}
+		seen[fingerprint] = true
+		fingerprints = append(fingerprints, fingerprint)
+	}
+	sort.Strings(fingerprints)
+
+	bz, err := json.Marshal(fingerprints)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(bz)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// ValidateKeyCommitment checks that a commitment is a base64url SHA-256 digest
+func ValidateKeyCommitment(commitment string) error {
+	bz, err := base64.RawURLEncoding.DecodeString(commitment)
+	if err != nil || len(bz) != sha256.Size {
+		return errors.Wrap(ErrInvalidDID, "next key commitment must be a base64url SHA-256 digest")
+	}
+	return nil
+}
+
+// ActiveAuthenticationKeys returns the verification methods referenced by
+// the authentication relationship that are active at the given time
+func (d *DIDDocument) ActiveAuthenticationKeys(t time.Time) []VerificationMethod {
+	var keys []VerificationMethod
+	for _, vm := range d.VerificationMethod {
+		if !vm.IsActiveAt(t) {
+			continue
+		}
+		for _, rel := range d.VerificationRelationships(vm.ID) {
+			if rel == RelationshipAuthentication {
+				keys = append(keys, vm)
+				break
+			}
+		}
+	}
+	return keys
+}
+
+// SameKeySet reports whether two sets of verification methods hold the same
+// public keys, regardless of their IDs and order
+func SameKeySet(a, b []VerificationMethod) bool {
+	set := func(vms []VerificationMethod) map[string]bool {
+		keys := make(map[string]bool, len(vms))
+		for _, vm := range vms {
+			fingerprint, err := vm.KeyFingerprint()
+			if err != nil {
+				// Fall back to the ID for keys without a fingerprint
+				fingerprint = "id:" + vm.ID
+			}
+			keys[fingerprint] = true
+		}
+		return keys
+	}
+
+	sa, sb := set(a), set(b)
+	if len(sa) != len(sb) {
+		return false
+	}
+	for k := range sa {
+		if !sb[k] {
+			return false
+		}
+	}
+	return true
+}
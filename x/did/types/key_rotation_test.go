@@ -0,0 +1,111 @@
+package types_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func newEd25519VM(t *testing.T, id string) types.VerificationMethod {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	vm := multikey(types.MulticodecEd25519Pub, pub)
+	vm.ID = id
+	return vm
+}
+
+func TestNextKeyCommitment(t *testing.T) {
+	a := newEd25519VM(t, "did:persona:abc#a")
+	b := newEd25519VM(t, "did:persona:abc#b")
+	renamed := a
+	renamed.ID = "did:persona:abc#renamed"
+
+	ab, err := types.NextKeyCommitment([]types.VerificationMethod{a, b})
+	require.NoError(t, err)
+	require.NoError(t, types.ValidateKeyCommitment(ab))
+
+	tests := []struct {
+		name string
+		vms  []types.VerificationMethod
+		same bool
+		err  error
+	}{
+		{name: "reordered", vms: []types.VerificationMethod{b, a}, same: true},
+		{name: "renamed verification method", vms: []types.VerificationMethod{renamed, b}, same: true},
+		{name: "subset", vms: []types.VerificationMethod{a}},
+		{name: "empty", err: types.ErrInvalidVerificationMethod},
+		{name: "same key twice", vms: []types.VerificationMethod{a, renamed}, err: types.ErrDuplicateVerificationMethod},
+		{name: "invalid key", vms: []types.VerificationMethod{multikey(types.MulticodecEd25519Pub, make([]byte, 16))}, err: types.ErrInvalidPublicKey},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			commitment, err := types.NextKeyCommitment(tc.vms)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.same, commitment == ab)
+		})
+	}
+}
+
+func TestValidateKeyCommitment(t *testing.T) {
+	tests := []struct {
+		name       string
+		commitment string
+		valid      bool
+	}{
+		{name: "SHA-256 digest", commitment: strings.Repeat("A", 43), valid: true},
+		{name: "empty", commitment: ""},
+		{name: "padded", commitment: strings.Repeat("A", 43) + "="},
+		{name: "standard alphabet", commitment: strings.Repeat("+", 43)},
+		{name: "short digest", commitment: strings.Repeat("A", 42)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := types.ValidateKeyCommitment(tc.commitment)
+			if !tc.valid {
+				require.ErrorIs(t, err, types.ErrInvalidDID)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestActiveAuthenticationKeys(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0).UTC()
+	past := now.Add(-time.Second)
+
+	active := newEd25519VM(t, "did:persona:abc#active")
+	revoked := newEd25519VM(t, "did:persona:abc#revoked")
+	revoked.Revoked = true
+	expired := newEd25519VM(t, "did:persona:abc#expired")
+	expired.ExpiresAt = &past
+	assertion := newEd25519VM(t, "did:persona:abc#assertion")
+
+	doc := types.DIDDocument{
+		ID:                 "did:persona:abc",
+		VerificationMethod: []types.VerificationMethod{active, revoked, expired, assertion},
+		Authentication:     []string{"#active", "#revoked", "did:persona:abc#expired"},
+		AssertionMethod:    []string{"#assertion"},
+	}
+	require.Equal(t, []types.VerificationMethod{active}, doc.ActiveAuthenticationKeys(now))
+
+	// Replacing the verification method but keeping its key is the same set
+	renamed := active
+	renamed.ID = "did:persona:abc#renamed"
+	require.True(t, types.SameKeySet(doc.ActiveAuthenticationKeys(now), []types.VerificationMethod{renamed}))
+	require.False(t, types.SameKeySet(doc.ActiveAuthenticationKeys(now), []types.VerificationMethod{active, assertion}))
+	require.False(t, types.SameKeySet(doc.ActiveAuthenticationKeys(now), []types.VerificationMethod{assertion}))
+	require.False(t, types.SameKeySet(doc.ActiveAuthenticationKeys(now), nil))
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"time"
@@ -22,9 +23,11 @@ const (
 	// PortID for IBC operations
 	PortID = "did"
 
-	// Version defines the current IBC version
+	// IBCVersion1 carries DidPacketData update and sync packets
 	IBCVersion1 = "did-1"
-	IBCVersion2 = "did-2" // Latest version with enhanced features
+	// IBCVersion2 adds IBCPacketData resolution, VC, proof and governance
+	// packets on top of did-1
+	IBCVersion2 = "did-2"
 )
 
 func KeyPrefix(p string) []byte {
@@ -43,6 +46,71 @@ const (
 	DIDGuardianKeyPrefix      = "DIDGuardian/value/"
 	DIDCrossChainKeyPrefix    = "DIDCrossChain/value/"
 	DIDComplianceKeyPrefix    = "DIDCompliance/value/"
+
+	// Secondary index prefixes, keyed by indexed value then DID
+	DIDControllerIndexPrefix   = "DIDIndex/controller/"
+	DIDOrganizationIndexPrefix = "DIDIndex/organization/"
+	DIDStateIndexPrefix        = "DIDIndex/state/"
+	DIDTagIndexPrefix          = "DIDIndex/tag/"
+	DIDEnvironmentIndexPrefix  = "DIDIndex/environment/"
+
+	// DIDKeyIndexPrefix maps key fingerprints to verification methods
+	DIDKeyIndexPrefix = "DIDKeyIndex/value/"
+
+	// DIDExpiryQueuePrefix orders key expiries and document update
+	// deadlines by time for the EndBlocker
+	DIDExpiryQueuePrefix = "DIDExpiryQueue/value/"
+
+	// DIDSuspensionQueuePrefix orders timed suspensions by their end, using
+	// the expiry queue key layout
+	DIDSuspensionQueuePrefix = "DIDSuspensionQueue/value/"
+
+	// DIDStorageDepositPrefix holds the storage deposit escrowed per DID
+	DIDStorageDepositPrefix = "DIDStorageDeposit/value/"
+
+	// HandleKeyPrefix holds handle registrations by name
+	HandleKeyPrefix = "Handle/value/"
+	// HandleDIDIndexPrefix lists the handles of a DID, keyed by DID then
+	// handle
+	HandleDIDIndexPrefix = "HandleIndex/did/"
+	// PrimaryHandleKeyPrefix maps a DID to its primary handle
+	PrimaryHandleKeyPrefix = "PrimaryHandle/value/"
+
+	// UpdatePolicyKeyPrefix holds the threshold update policy of a DID
+	UpdatePolicyKeyPrefix = "UpdatePolicy/value/"
+	// PendingUpdateKeyPrefix holds pending updates by ID
+	PendingUpdateKeyPrefix = "PendingUpdate/value/"
+	// PendingUpdateDIDIndexPrefix lists the pending updates of a DID, keyed
+	// by DID then update ID
+	PendingUpdateDIDIndexPrefix = "PendingUpdateIndex/did/"
+	// PendingUpdateQueuePrefix orders pending updates by expiry, using the
+	// expiry queue key layout with the update ID in place of a verification
+	// method
+	PendingUpdateQueuePrefix = "PendingUpdateQueue/value/"
+	// PendingUpdateSequenceKey stores the ID of the last pending update
+	PendingUpdateSequenceKey = "PendingUpdateSequence"
+	// DIDAuditSequenceKey stores the number of the last audit entry
+	DIDAuditSequenceKey = "DIDAuditSequence"
+
+	// CapabilityKeyPrefix holds delegated capabilities by ID
+	CapabilityKeyPrefix = "Capability/value/"
+
+	// ResourceKeyPrefix holds DID-linked resources, keyed by DID then
+	// resource ID
+	ResourceKeyPrefix = "Resource/value/"
+	// ResourceLatestPrefix maps a DID, resource name and type to the ID of
+	// the latest version
+	ResourceLatestPrefix = "ResourceLatest/value/"
+
+	// ForeignDIDKeyPrefix holds DID documents received over IBC, keyed by
+	// channel then DID
+	ForeignDIDKeyPrefix = "ForeignDID/value/"
+	// RemoteDIDKeyPrefix caches DID documents resolved from other chains,
+	// keyed by DID then channel
+	RemoteDIDKeyPrefix = "RemoteDID/value/"
+	// ChannelVersionKeyPrefix holds the DID protocol version negotiated for
+	// each IBC channel, keyed by channel
+	ChannelVersionKeyPrefix = "ChannelVersion/value/"
 )
 
 // Key construction functions
@@ -99,30 +167,83 @@ func DIDComplianceKey(id string) []byte {
 	return []byte(id)
 }
 
-// Additional enterprise types
-
-// DocumentMetadata represents efficient queryable metadata
-type DocumentMetadata struct {
-	ID               string            `json:"id"`
-	Creator          string            `json:"creator"`
-	CreatedAt        time.Time         `json:"createdAt"`
-	UpdatedAt        time.Time         `json:"updatedAt"`
-	Version          uint64            `json:"version"`
-	State            DIDState          `json:"state"`
-	CriticalityLevel CriticalityLevel  `json:"criticalityLevel"`
-	Environment      string            `json:"environment"`
-	OrganizationID   string            `json:"organizationId,omitempty"`
-	Tags             []string          `json:"tags,omitempty"`
-}
-
-// AuditEntry represents an audit log entry
-type AuditEntry struct {
-	ID          string      `json:"id"`
-	DID         string      `json:"did"`
-	Action      string      `json:"action"`
-	Actor       string      `json:"actor"`
-	Timestamp   time.Time   `json:"timestamp"`
-	BlockHeight int64       `json:"blockHeight"`
-	TxHash      string      `json:"txHash"`
-	Data        interface{} `json:"data,omitempty"`
-}
\ No newline at end of file
+// ResourceKey returns the store key of a DID-linked resource
+func ResourceKey(did, id string) []byte {
+	return DIDIndexKey(did, id)
+}
+
+// ResourceLatestKey returns the store key of the latest version of the
+// resources of a DID sharing a name and type
+func ResourceLatestKey(did, name, resourceType string) []byte {
+	key := append(DIDIndexValuePrefix(did), name...)
+	key = append(key, 0)
+	return append(key, resourceType...)
+}
+
+// ForeignDIDKey returns the store key of a DID document received over an
+// IBC channel
+func ForeignDIDKey(channelID, did string) []byte {
+	return DIDIndexKey(channelID, did)
+}
+
+// RemoteDIDKey returns the store key of a DID resolved over an IBC channel
+func RemoteDIDKey(did, channelID string) []byte {
+	return DIDIndexKey(did, channelID)
+}
+
+// PendingUpdateKey returns the store key of a pending update
+func PendingUpdateKey(id uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, id)
+	return key
+}
+
+// DIDIndexKey returns the key of a secondary index entry. The value and DID
+// are separated by a zero byte so that one indexed value is never a prefix of
+// another.
+func DIDIndexKey(value, id string) []byte {
+	return append(DIDIndexValuePrefix(value), id...)
+}
+
+// DIDIndexValuePrefix returns the prefix of all index entries for a value
+func DIDIndexValuePrefix(value string) []byte {
+	return append([]byte(value), 0)
+}
+
+// DIDExpiryQueueKey returns the expiry queue key of a verification method,
+// or of the document's next update deadline when vmID is empty. Keys sort by
+// time first, rounded up to the second so an entry is never due early.
+func DIDExpiryQueueKey(t time.Time, did, vmID string) []byte {
+	if rounded := t.Truncate(time.Second); rounded.Before(t) {
+		t = rounded.Add(time.Second)
+	}
+	key := DIDExpiryQueueTimePrefix(t)
+	key = append(key, did...)
+	key = append(key, 0)
+	return append(key, vmID...)
+}
+
+// DIDExpiryQueueTimePrefix returns the prefix of all expiry queue entries
+// due at the second of t
+func DIDExpiryQueueTimePrefix(t time.Time) []byte {
+	seconds := t.Unix()
+	if seconds < 0 {
+		seconds = 0
+	}
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(seconds))
+	return key
+}
+
+// ParseDIDExpiryQueueKey splits an expiry queue key into its DID and
+// verification method ID
+func ParseDIDExpiryQueueKey(key []byte) (did, vmID string, err error) {
+	if len(key) < 8 {
+		return "", "", fmt.Errorf("expiry queue key too short")
+	}
+	i := bytes.IndexByte(key[8:], 0)
+	if i < 0 {
+		return "", "", fmt.Errorf("malformed expiry queue key")
+	}
+	return string(key[8 : 8+i]), string(key[8+i+1:]), nil
+}
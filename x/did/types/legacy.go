@@ -0,0 +1,181 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"cosmossdk.io/errors"
+	proto "github.com/cosmos/gogoproto/proto"
+)
+
+// DidDocumentKeyPrefix is the store prefix of legacy DidDocument records
+// written by CreateDid/UpdateDid before consensus version 3
+const DidDocumentKeyPrefix = "DidDocument/value/"
+
+// DidDocumentKey returns the store key of a legacy DidDocument record
+func DidDocumentKey(id string) []byte {
+	return []byte(id + "/")
+}
+
+// DidDocument is the legacy DID record. The document itself is kept as an
+// opaque JSON string.
+type DidDocument struct {
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DidDocument string `protobuf:"bytes,2,opt,name=did_document,json=didDocument,proto3" json:"did_document,omitempty"`
+	Creator     string `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
+	Active      bool   `protobuf:"varint,4,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt   int64  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   int64  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (m *DidDocument) Reset()         { *m = DidDocument{} }
+func (m *DidDocument) String() string { return proto.CompactTextString(m) }
+func (*DidDocument) ProtoMessage()    {}
+
+// legacyDocumentJSON accepts the W3C forms that the typed document does not:
+// string or array @context and controller, embedded verification methods in
+// relationships and non-string service endpoints
+type legacyDocumentJSON struct {
+	Context              json.RawMessage      `json:"@context"`
+	ID                   string               `json:"id"`
+	Controller           json.RawMessage      `json:"controller"`
+	VerificationMethod   []VerificationMethod `json:"verificationMethod"`
+	Authentication       []json.RawMessage    `json:"authentication"`
+	AssertionMethod      []json.RawMessage    `json:"assertionMethod"`
+	KeyAgreement         []json.RawMessage    `json:"keyAgreement"`
+	CapabilityInvocation []json.RawMessage    `json:"capabilityInvocation"`
+	CapabilityDelegation []json.RawMessage    `json:"capabilityDelegation"`
+	Service              []legacyServiceJSON  `json:"service"`
+}
+
+type legacyServiceJSON struct {
+	ID              string          `json:"id"`
+	Type            string          `json:"type"`
+	ServiceEndpoint json.RawMessage `json:"serviceEndpoint"`
+	Description     string          `json:"description"`
+}
+
+// ToDIDDocument converts a legacy record into version 1 of a typed DID
+// document. Creator, activity and timestamps are carried over from the record
+// and everything else is parsed from the embedded JSON.
+func (d DidDocument) ToDIDDocument() (DIDDocument, error) {
+	doc := *NewDIDDocument(d.Id, nil, time.Unix(d.CreatedAt, 0).UTC())
+
+	var raw legacyDocumentJSON
+	if s := bytes.TrimSpace([]byte(d.DidDocument)); len(s) > 0 {
+		if err := json.Unmarshal(s, &raw); err != nil {
+			return DIDDocument{}, errors.Wrapf(ErrLegacyConversion, "%s: malformed document JSON: %v", d.Id, err)
+		}
+	}
+	if raw.ID != "" && raw.ID != d.Id {
+		return DIDDocument{}, errors.Wrapf(ErrLegacyConversion, "%s: embedded document has id %s", d.Id, raw.ID)
+	}
+
+	var err error
+	if len(raw.Context) > 0 {
+		if doc.Context, err = stringOrList(raw.Context); err != nil {
+			return DIDDocument{}, errors.Wrapf(ErrLegacyConversion, "%s: @context: %v", d.Id, err)
+		}
+	}
+	if len(raw.Controller) > 0 {
+		if doc.Controller, err = stringOrList(raw.Controller); err != nil {
+			return DIDDocument{}, errors.Wrapf(ErrLegacyConversion, "%s: controller: %v", d.Id, err)
+		}
+	}
+	doc.VerificationMethod = raw.VerificationMethod
+
+	relationships := []struct {
+		name    string
+		entries []json.RawMessage
+		target  *[]string
+	}{
+		{RelationshipAuthentication, raw.Authentication, &doc.Authentication},
+		{RelationshipAssertionMethod, raw.AssertionMethod, &doc.AssertionMethod},
+		{RelationshipKeyAgreement, raw.KeyAgreement, &doc.KeyAgreement},
+		{RelationshipCapabilityInvocation, raw.CapabilityInvocation, &doc.CapabilityInvocation},
+		{RelationshipCapabilityDelegation, raw.CapabilityDelegation, &doc.CapabilityDelegation},
+	}
+	for _, rel := range relationships {
+		for _, entry := range rel.entries {
+			var ref string
+			if err := json.Unmarshal(entry, &ref); err == nil {
+				*rel.target = append(*rel.target, ref)
+				continue
+			}
+			var vm VerificationMethod
+			if err := json.Unmarshal(entry, &vm); err != nil {
+				return DIDDocument{}, errors.Wrapf(ErrLegacyConversion, "%s: %s entry: %v", d.Id, rel.name, err)
+			}
+			if _, found := doc.FindVerificationMethod(vm.ID); !found {
+				doc.VerificationMethod = append(doc.VerificationMethod, vm)
+			}
+			*rel.target = append(*rel.target, vm.ID)
+		}
+	}
+
+	for _, svc := range raw.Service {
+		endpoint := string(svc.ServiceEndpoint)
+		var s string
+		if err := json.Unmarshal(svc.ServiceEndpoint, &s); err == nil {
+			endpoint = s
+		} else {
+			// Maps and sets of endpoints are kept as compact JSON
+			var buf bytes.Buffer
+			if err := json.Compact(&buf, svc.ServiceEndpoint); err != nil {
+				return DIDDocument{}, errors.Wrapf(ErrLegacyConversion, "%s: service %s endpoint: %v", d.Id, svc.ID, err)
+			}
+			endpoint = buf.String()
+		}
+		doc.Service = append(doc.Service, Service{
+			ID:              svc.ID,
+			Type:            svc.Type,
+			ServiceEndpoint: endpoint,
+			Description:     svc.Description,
+		})
+	}
+
+	createdAt := time.Unix(d.CreatedAt, 0).UTC()
+	updatedAt := time.Unix(d.UpdatedAt, 0).UTC()
+	for i := range doc.VerificationMethod {
+		if doc.VerificationMethod[i].CreatedAt.IsZero() {
+			doc.VerificationMethod[i].CreatedAt = createdAt
+		}
+	}
+
+	doc.Creator = d.Creator
+	doc.UpdatedBy = d.Creator
+	doc.CreatedAt = createdAt
+	doc.UpdatedAt = updatedAt
+	doc.Version = 1
+	doc.Metadata.Created = createdAt
+	doc.Metadata.Updated = updatedAt
+	doc.Status.UpdatedAt = updatedAt
+	doc.Status.UpdatedBy = d.Creator
+	doc.Status.HealthCheck.LastChecked = updatedAt
+	if !d.Active {
+		doc.Metadata.Deactivated = true
+		doc.Metadata.DeactivatedAt = &updatedAt
+		doc.Status.State = DIDStateRevoked
+		doc.Status.Reason = "deactivated before migration"
+	}
+
+	if err := doc.Validate(); err != nil {
+		return DIDDocument{}, errors.Wrapf(ErrLegacyConversion, "%s: %v", d.Id, err)
+	}
+	return doc, nil
+}
+
+// stringOrList decodes a JSON value that is either a string or an array of
+// strings
+func stringOrList(raw json.RawMessage) ([]string, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return []string{s}, nil
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
@@ -1,8 +1,13 @@
 package types
 
 import (
+	"strings"
+
 	errorsmod "cosmossdk.io/errors"
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
 )
 
 const (
@@ -10,6 +15,30 @@ const (
 	TypeMsgCreateDid     = "create_did"
 	TypeMsgUpdateDid     = "update_did"
 	TypeMsgDeactivateDid = "deactivate_did"
+
+	TypeMsgCreateDIDDocument        = "create_did_document"
+	TypeMsgUpdateDIDDocument        = "update_did_document"
+	TypeMsgDeactivateDIDDocument    = "deactivate_did_document"
+	TypeMsgAddVerificationMethod    = "add_verification_method"
+	TypeMsgRevokeVerificationMethod = "revoke_verification_method"
+	TypeMsgAddService               = "add_service"
+	TypeMsgRemoveService            = "remove_service"
+	TypeMsgUpdateDIDStatus          = "update_did_status"
+	TypeMsgRotateKeys               = "rotate_keys"
+	TypeMsgRegisterHandle           = "register_handle"
+	TypeMsgRenewHandle              = "renew_handle"
+	TypeMsgTransferHandle           = "transfer_handle"
+	TypeMsgSetPrimaryHandle         = "set_primary_handle"
+	TypeMsgBatchUpdateDID           = "batch_update_did"
+	TypeMsgSetUpdatePolicy          = "set_update_policy"
+	TypeMsgProposeDIDUpdate         = "propose_did_update"
+	TypeMsgApproveDIDUpdate         = "approve_did_update"
+	TypeMsgExecuteDIDUpdate         = "execute_did_update"
+	TypeMsgDelegateCapability       = "delegate_capability"
+	TypeMsgInvokeCapability         = "invoke_capability"
+	TypeMsgRevokeCapability         = "revoke_capability"
+	TypeMsgCreateResource           = "create_resource"
+	TypeMsgRequestRemoteResolution  = "request_remote_resolution"
 )
 
 // Verify that protobuf-generated types implement sdk.Msg
@@ -17,6 +46,32 @@ var (
 	_ sdk.Msg = &MsgCreateDid{}
 	_ sdk.Msg = &MsgUpdateDid{}
 	_ sdk.Msg = &MsgDeactivateDid{}
+	_ sdk.Msg = &MsgCreateDIDDocument{}
+	_ sdk.Msg = &MsgUpdateDIDDocument{}
+	_ sdk.Msg = &MsgDeactivateDIDDocument{}
+	_ sdk.Msg = &MsgAddVerificationMethod{}
+	_ sdk.Msg = &MsgRevokeVerificationMethod{}
+	_ sdk.Msg = &MsgAddService{}
+	_ sdk.Msg = &MsgRemoveService{}
+	_ sdk.Msg = &MsgUpdateDIDStatus{}
+	_ sdk.Msg = &MsgRotateKeys{}
+	_ sdk.Msg = &MsgRegisterHandle{}
+	_ sdk.Msg = &MsgRenewHandle{}
+	_ sdk.Msg = &MsgTransferHandle{}
+	_ sdk.Msg = &MsgSetPrimaryHandle{}
+	_ sdk.Msg = &MsgBatchUpdateDID{}
+	_ sdk.Msg = &MsgSetUpdatePolicy{}
+	_ sdk.Msg = &MsgProposeDIDUpdate{}
+	_ sdk.Msg = &MsgApproveDIDUpdate{}
+	_ sdk.Msg = &MsgExecuteDIDUpdate{}
+
+	_ cdctypes.UnpackInterfacesMessage = &MsgProposeDIDUpdate{}
+	_ cdctypes.UnpackInterfacesMessage = &MsgExecuteDIDUpdate{}
+	_ sdk.Msg                          = &MsgDelegateCapability{}
+	_ sdk.Msg                          = &MsgInvokeCapability{}
+	_ sdk.Msg                          = &MsgRevokeCapability{}
+	_ sdk.Msg                          = &MsgCreateResource{}
+	_ sdk.Msg                          = &MsgRequestRemoteResolution{}
 )
 
 // ===== MESSAGE IMPLEMENTATIONS FOR PROTOBUF TYPES =====
@@ -57,8 +112,8 @@ func (msg *MsgCreateDid) ValidateBasic() error {
 		return errorsmod.Wrapf(ErrInvalidCreator, "invalid creator address (%s)", err)
 	}
 
-	if msg.Id == "" {
-		return errorsmod.Wrap(ErrInvalidDID, "ID cannot be empty")
+	if _, err := ParseDID(msg.Id); err != nil {
+		return err
 	}
 
 	if msg.DidDocument == "" {
@@ -104,8 +159,8 @@ func (msg *MsgUpdateDid) ValidateBasic() error {
 		return errorsmod.Wrapf(ErrInvalidCreator, "invalid creator address (%s)", err)
 	}
 
-	if msg.Id == "" {
-		return errorsmod.Wrap(ErrInvalidDID, "ID cannot be empty")
+	if _, err := ParseDID(msg.Id); err != nil {
+		return err
 	}
 
 	if msg.DidDocument == "" {
@@ -150,9 +205,958 @@ func (msg *MsgDeactivateDid) ValidateBasic() error {
 		return errorsmod.Wrapf(ErrInvalidCreator, "invalid creator address (%s)", err)
 	}
 
-	if msg.Id == "" {
-		return errorsmod.Wrap(ErrInvalidDID, "ID cannot be empty")
+	if _, err := ParseDID(msg.Id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ===== DID DOCUMENT MESSAGES =====
+
+// MsgCreateDIDDocument implementations
+func (msg *MsgCreateDIDDocument) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgCreateDIDDocument) Type() string {
+	return TypeMsgCreateDIDDocument
+}
+
+func (msg *MsgCreateDIDDocument) GetSigners() []sdk.AccAddress {
+	creator, err := sdk.AccAddressFromBech32(msg.Creator)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{creator}
+}
+
+func (msg *MsgCreateDIDDocument) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgCreateDIDDocument) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Creator)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidCreator, "invalid creator address (%s)", err)
+	}
+
+	if err := msg.DidDocument.Validate(); err != nil {
+		return err
+	}
+
+	return validateKeyProofs(msg.KeyProofs)
+}
+
+// MsgUpdateDIDDocument implementations
+func (msg *MsgUpdateDIDDocument) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgUpdateDIDDocument) Type() string {
+	return TypeMsgUpdateDIDDocument
+}
+
+func (msg *MsgUpdateDIDDocument) GetSigners() []sdk.AccAddress {
+	controller, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{controller}
+}
+
+func (msg *MsgUpdateDIDDocument) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgUpdateDIDDocument) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid controller address (%s)", err)
+	}
+
+	if _, err := ParseDID(msg.Id); err != nil {
+		return err
+	}
+
+	if msg.DidDocument.ID != msg.Id {
+		return errorsmod.Wrapf(ErrInvalidDID, "document ID %s does not match %s", msg.DidDocument.ID, msg.Id)
+	}
+
+	if err := msg.DidDocument.Validate(); err != nil {
+		return err
+	}
+
+	return validateKeyProofs(msg.KeyProofs)
+}
+
+// MsgDeactivateDIDDocument implementations
+func (msg *MsgDeactivateDIDDocument) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgDeactivateDIDDocument) Type() string {
+	return TypeMsgDeactivateDIDDocument
+}
+
+func (msg *MsgDeactivateDIDDocument) GetSigners() []sdk.AccAddress {
+	controller, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{controller}
+}
+
+func (msg *MsgDeactivateDIDDocument) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgDeactivateDIDDocument) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid controller address (%s)", err)
+	}
+
+	if _, err := ParseDID(msg.Id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MsgAddVerificationMethod implementations
+func (msg *MsgAddVerificationMethod) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgAddVerificationMethod) Type() string {
+	return TypeMsgAddVerificationMethod
+}
+
+func (msg *MsgAddVerificationMethod) GetSigners() []sdk.AccAddress {
+	controller, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{controller}
+}
+
+func (msg *MsgAddVerificationMethod) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgAddVerificationMethod) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid controller address (%s)", err)
+	}
+
+	if _, err := ParseDID(msg.Id); err != nil {
+		return err
+	}
+
+	if err := msg.VerificationMethod.Validate(); err != nil {
+		return errorsmod.Wrap(ErrInvalidVerificationMethod, err.Error())
+	}
+
+	return ValidateVerificationMethodID(msg.Id, msg.VerificationMethod.ID)
+}
+
+// MsgRevokeVerificationMethod implementations
+func (msg *MsgRevokeVerificationMethod) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgRevokeVerificationMethod) Type() string {
+	return TypeMsgRevokeVerificationMethod
+}
+
+func (msg *MsgRevokeVerificationMethod) GetSigners() []sdk.AccAddress {
+	controller, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{controller}
+}
+
+func (msg *MsgRevokeVerificationMethod) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgRevokeVerificationMethod) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid controller address (%s)", err)
+	}
+
+	if _, err := ParseDID(msg.Id); err != nil {
+		return err
+	}
+
+	if msg.MethodId == "" {
+		return errorsmod.Wrap(ErrInvalidVerificationMethod, "verification method ID cannot be empty")
+	}
+
+	return nil
+}
+
+// MsgAddService implementations
+func (msg *MsgAddService) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgAddService) Type() string {
+	return TypeMsgAddService
+}
+
+func (msg *MsgAddService) GetSigners() []sdk.AccAddress {
+	controller, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{controller}
+}
+
+func (msg *MsgAddService) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgAddService) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid controller address (%s)", err)
+	}
+
+	if _, err := ParseDID(msg.Id); err != nil {
+		return err
+	}
+
+	if err := msg.Service.Validate(); err != nil {
+		return errorsmod.Wrap(ErrInvalidService, err.Error())
+	}
+
+	return nil
+}
+
+// MsgRemoveService implementations
+func (msg *MsgRemoveService) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgRemoveService) Type() string {
+	return TypeMsgRemoveService
+}
+
+func (msg *MsgRemoveService) GetSigners() []sdk.AccAddress {
+	controller, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{controller}
+}
+
+func (msg *MsgRemoveService) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgRemoveService) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid controller address (%s)", err)
+	}
+
+	if _, err := ParseDID(msg.Id); err != nil {
+		return err
+	}
+
+	if msg.ServiceId == "" {
+		return errorsmod.Wrap(ErrInvalidService, "service ID cannot be empty")
+	}
+
+	return nil
+}
+
+// MsgUpdateDIDStatus implementations
+func (msg *MsgUpdateDIDStatus) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgUpdateDIDStatus) Type() string {
+	return TypeMsgUpdateDIDStatus
+}
+
+func (msg *MsgUpdateDIDStatus) GetSigners() []sdk.AccAddress {
+	controller, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{controller}
+}
+
+func (msg *MsgUpdateDIDStatus) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgUpdateDIDStatus) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid controller address (%s)", err)
+	}
+
+	if _, err := ParseDID(msg.Id); err != nil {
+		return err
+	}
+
+	if msg.Status == "" {
+		return errorsmod.Wrap(ErrInvalidDIDState, "status cannot be empty")
+	}
+
+	if msg.Until != nil && DIDState(msg.Status) != DIDStateSuspended {
+		return errorsmod.Wrap(ErrInvalidDIDState, "only suspensions can have an end time")
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// MsgRotateKeys implementations
+func (msg *MsgRotateKeys) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgRotateKeys) Type() string {
+	return TypeMsgRotateKeys
+}
+
+func (msg *MsgRotateKeys) GetSigners() []sdk.AccAddress {
+	controller, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{controller}
+}
+
+func (msg *MsgRotateKeys) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgRotateKeys) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid controller address (%s)", err)
+	}
+
+	if _, err := ParseDID(msg.Id); err != nil {
+		return err
+	}
+
+	if len(msg.NewKeys) == 0 {
+		return errorsmod.Wrap(ErrInvalidVerificationMethod, "rotation requires at least one new key")
+	}
+	for _, vm := range msg.NewKeys {
+		if err := vm.Validate(); err != nil {
+			return errorsmod.Wrapf(ErrInvalidVerificationMethod, "invalid verification method %s: %v", vm.ID, err)
+		}
+		if err := ValidateVerificationMethodID(msg.Id, vm.ID); err != nil {
+			return errorsmod.Wrapf(ErrInvalidVerificationMethod, "invalid verification method ID %s: %v", vm.ID, err)
+		}
+	}
+
+	// Rotating without a new commitment would leave the DID unprotected
+	if err := ValidateKeyCommitment(msg.NextKeyCommitment); err != nil {
+		return err
+	}
+
+	return validateKeyProofs(msg.KeyProofs)
+}
+
+// MsgRegisterHandle implementations
+func (msg *MsgRegisterHandle) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgRegisterHandle) Type() string {
+	return TypeMsgRegisterHandle
+}
+
+func (msg *MsgRegisterHandle) GetSigners() []sdk.AccAddress {
+	controller, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{controller}
+}
+
+func (msg *MsgRegisterHandle) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgRegisterHandle) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid controller address (%s)", err)
+	}
+
+	if _, err := ParseDID(msg.Did); err != nil {
+		return err
+	}
+
+	return ValidateHandle(msg.Handle)
+}
+
+// MsgRenewHandle implementations
+func (msg *MsgRenewHandle) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgRenewHandle) Type() string {
+	return TypeMsgRenewHandle
+}
+
+func (msg *MsgRenewHandle) GetSigners() []sdk.AccAddress {
+	controller, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{controller}
+}
+
+func (msg *MsgRenewHandle) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgRenewHandle) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid controller address (%s)", err)
+	}
+
+	return ValidateHandle(msg.Handle)
+}
+
+// MsgTransferHandle implementations
+func (msg *MsgTransferHandle) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgTransferHandle) Type() string {
+	return TypeMsgTransferHandle
+}
+
+func (msg *MsgTransferHandle) GetSigners() []sdk.AccAddress {
+	controller, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{controller}
+}
+
+func (msg *MsgTransferHandle) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgTransferHandle) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid controller address (%s)", err)
+	}
+
+	if _, err := ParseDID(msg.NewDid); err != nil {
+		return err
+	}
+
+	return ValidateHandle(msg.Handle)
+}
+
+// MsgSetPrimaryHandle implementations
+func (msg *MsgSetPrimaryHandle) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgSetPrimaryHandle) Type() string {
+	return TypeMsgSetPrimaryHandle
+}
+
+func (msg *MsgSetPrimaryHandle) GetSigners() []sdk.AccAddress {
+	controller, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{controller}
+}
+
+func (msg *MsgSetPrimaryHandle) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgSetPrimaryHandle) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid controller address (%s)", err)
+	}
+
+	if _, err := ParseDID(msg.Did); err != nil {
+		return err
+	}
+
+	return ValidateHandle(msg.Handle)
+}
+
+// MsgBatchUpdateDID implementations
+func (msg *MsgBatchUpdateDID) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgBatchUpdateDID) Type() string {
+	return TypeMsgBatchUpdateDID
+}
+
+func (msg *MsgBatchUpdateDID) GetSigners() []sdk.AccAddress {
+	controller, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{controller}
+}
+
+func (msg *MsgBatchUpdateDID) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgBatchUpdateDID) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid controller address (%s)", err)
+	}
+
+	if _, err := ParseDID(msg.Id); err != nil {
+		return err
+	}
+
+	if len(msg.Operations) == 0 {
+		return errorsmod.Wrap(ErrInvalidDID, "batch requires at least one operation")
+	}
+	if len(msg.Operations) > MaxBatchOperations {
+		return errorsmod.Wrapf(ErrInvalidDID, "batch has %d operations, at most %d are allowed", len(msg.Operations), MaxBatchOperations)
+	}
+	for i, op := range msg.Operations {
+		if err := op.ValidateBasic(msg.Id); err != nil {
+			return errorsmod.Wrapf(err, "operation %d", i)
+		}
+	}
+
+	return validateKeyProofs(msg.KeyProofs)
+}
+
+// MsgSetUpdatePolicy implementations
+func (msg *MsgSetUpdatePolicy) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgSetUpdatePolicy) Type() string {
+	return TypeMsgSetUpdatePolicy
+}
+
+func (msg *MsgSetUpdatePolicy) GetSigners() []sdk.AccAddress {
+	controller, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{controller}
+}
+
+func (msg *MsgSetUpdatePolicy) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgSetUpdatePolicy) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid controller address (%s)", err)
+	}
+
+	if _, err := ParseDID(msg.Id); err != nil {
+		return err
+	}
+
+	// A zero threshold with no controllers removes the policy
+	if msg.Threshold == 0 && len(msg.Controllers) == 0 {
+		return nil
+	}
+	policy := UpdatePolicy{Did: msg.Id, Threshold: msg.Threshold, Controllers: msg.Controllers}
+	return policy.Validate()
+}
+
+// MsgProposeDIDUpdate implementations
+func (msg *MsgProposeDIDUpdate) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgProposeDIDUpdate) Type() string {
+	return TypeMsgProposeDIDUpdate
+}
+
+func (msg *MsgProposeDIDUpdate) GetSigners() []sdk.AccAddress {
+	proposer, err := sdk.AccAddressFromBech32(msg.Proposer)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{proposer}
+}
+
+func (msg *MsgProposeDIDUpdate) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgProposeDIDUpdate) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Proposer)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid proposer address (%s)", err)
+	}
+
+	if _, err := ParseDID(msg.Id); err != nil {
+		return err
+	}
+
+	return validateUpdateMessages(msg.Messages)
+}
+
+// GetMsgs unpacks the messages proposed for the update
+func (msg *MsgProposeDIDUpdate) GetMsgs() ([]sdk.Msg, error) {
+	return sdktx.GetMsgs(msg.Messages, "MsgProposeDIDUpdate")
+}
+
+// UnpackInterfaces implements UnpackInterfacesMessage
+func (msg *MsgProposeDIDUpdate) UnpackInterfaces(unpacker cdctypes.AnyUnpacker) error {
+	return sdktx.UnpackInterfaces(unpacker, msg.Messages)
+}
+
+// MsgApproveDIDUpdate implementations
+func (msg *MsgApproveDIDUpdate) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgApproveDIDUpdate) Type() string {
+	return TypeMsgApproveDIDUpdate
+}
+
+func (msg *MsgApproveDIDUpdate) GetSigners() []sdk.AccAddress {
+	approver, err := sdk.AccAddressFromBech32(msg.Approver)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{approver}
+}
+
+func (msg *MsgApproveDIDUpdate) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgApproveDIDUpdate) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Approver)
+	if err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid approver address (%s)", err)
+	}
+
+	if msg.UpdateId == 0 {
+		return errorsmod.Wrap(ErrPendingUpdateNotFound, "update ID is required")
+	}
+
+	return nil
+}
+
+// MsgExecuteDIDUpdate implementations
+func (msg *MsgExecuteDIDUpdate) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgExecuteDIDUpdate) Type() string {
+	return TypeMsgExecuteDIDUpdate
+}
+
+func (msg *MsgExecuteDIDUpdate) GetSigners() []sdk.AccAddress {
+	signers := make([]sdk.AccAddress, len(msg.Signers))
+	for i, signer := range msg.Signers {
+		addr, err := sdk.AccAddressFromBech32(signer)
+		if err != nil {
+			panic(err)
+		}
+		signers[i] = addr
+	}
+	return signers
+}
+
+func (msg *MsgExecuteDIDUpdate) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgExecuteDIDUpdate) ValidateBasic() error {
+	if len(msg.Signers) == 0 {
+		return errorsmod.Wrap(ErrInvalidController, "at least one signer is required")
+	}
+	seen := make(map[string]bool, len(msg.Signers))
+	for _, signer := range msg.Signers {
+		if _, err := sdk.AccAddressFromBech32(signer); err != nil {
+			return errorsmod.Wrapf(ErrInvalidController, "invalid signer address (%s)", err)
+		}
+		if seen[signer] {
+			return errorsmod.Wrapf(ErrInvalidController, "duplicate signer %s", signer)
+		}
+		seen[signer] = true
+	}
+
+	if _, err := ParseDID(msg.Id); err != nil {
+		return err
+	}
+
+	return validateUpdateMessages(msg.Messages)
+}
+
+// GetMsgs unpacks the messages to execute
+func (msg *MsgExecuteDIDUpdate) GetMsgs() ([]sdk.Msg, error) {
+	return sdktx.GetMsgs(msg.Messages, "MsgExecuteDIDUpdate")
+}
+
+// UnpackInterfaces implements UnpackInterfacesMessage
+func (msg *MsgExecuteDIDUpdate) UnpackInterfaces(unpacker cdctypes.AnyUnpacker) error {
+	return sdktx.UnpackInterfaces(unpacker, msg.Messages)
+}
+
+// MsgDelegateCapability implementations
+func (msg *MsgDelegateCapability) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgDelegateCapability) Type() string {
+	return TypeMsgDelegateCapability
+}
+
+func (msg *MsgDelegateCapability) GetSigners() []sdk.AccAddress {
+	submitter, err := sdk.AccAddressFromBech32(msg.Submitter)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{submitter}
+}
+
+func (msg *MsgDelegateCapability) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgDelegateCapability) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Submitter); err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid submitter address (%s)", err)
+	}
+
+	return msg.Capability().ValidateBasic()
+}
+
+// Capability returns the capability the message delegates
+func (msg *MsgDelegateCapability) Capability() Capability {
+	return Capability{
+		ParentCapability: msg.ParentCapability,
+		Delegator:        msg.Delegator,
+		Invoker:          msg.Invoker,
+		InvocationTarget: msg.InvocationTarget,
+		AllowedActions:   msg.AllowedActions,
+		Expires:          msg.Expires,
+		MaxUses:          msg.MaxUses,
+		Proof:            msg.Proof,
+	}
+}
+
+// MsgInvokeCapability implementations
+func (msg *MsgInvokeCapability) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgInvokeCapability) Type() string {
+	return TypeMsgInvokeCapability
+}
+
+func (msg *MsgInvokeCapability) GetSigners() []sdk.AccAddress {
+	submitter, err := sdk.AccAddressFromBech32(msg.Submitter)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{submitter}
+}
+
+func (msg *MsgInvokeCapability) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgInvokeCapability) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Submitter); err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid submitter address (%s)", err)
+	}
+
+	inv := msg.Invocation
+	if !strings.HasPrefix(inv.Capability, CapabilityIDPrefix) {
+		return errorsmod.Wrapf(ErrInvalidInvocation, "invalid capability %q", inv.Capability)
+	}
+	if _, err := CapabilityTargetDID(inv.InvocationTarget); err != nil {
+		return err
+	}
+	if inv.Action == "" {
+		return errorsmod.Wrap(ErrInvalidInvocation, "action is required")
+	}
+	if inv.Proof.VerificationMethodId == "" || len(inv.Proof.Signature) == 0 {
+		return errorsmod.Wrap(ErrInvalidInvocation, "invocation proof requires a verification method ID and signature")
+	}
+
+	return nil
+}
+
+// MsgRevokeCapability implementations
+func (msg *MsgRevokeCapability) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgRevokeCapability) Type() string {
+	return TypeMsgRevokeCapability
+}
+
+func (msg *MsgRevokeCapability) GetSigners() []sdk.AccAddress {
+	controller, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{controller}
+}
+
+func (msg *MsgRevokeCapability) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgRevokeCapability) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Controller); err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid controller address (%s)", err)
+	}
+
+	if _, err := ParseDID(msg.Revoker); err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(msg.CapabilityId, CapabilityIDPrefix) {
+		return errorsmod.Wrapf(ErrInvalidCapability, "invalid capability %q", msg.CapabilityId)
+	}
+
+	return nil
+}
+
+// MsgCreateResource implementations
+func (msg *MsgCreateResource) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgCreateResource) Type() string {
+	return TypeMsgCreateResource
+}
+
+func (msg *MsgCreateResource) GetSigners() []sdk.AccAddress {
+	controller, err := sdk.AccAddressFromBech32(msg.Controller)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{controller}
+}
+
+func (msg *MsgCreateResource) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgCreateResource) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Controller); err != nil {
+		return errorsmod.Wrapf(ErrInvalidController, "invalid controller address (%s)", err)
+	}
+
+	if len(msg.Data) == 0 {
+		return errorsmod.Wrap(ErrInvalidResource, "resource content is empty")
+	}
+
+	return msg.Metadata().ValidateBasic()
+}
+
+// Metadata returns the metadata of the resource the message creates
+func (msg *MsgCreateResource) Metadata() ResourceMetadata {
+	return ResourceMetadata{
+		Did:          msg.Did,
+		Id:           msg.Id,
+		Name:         msg.Name,
+		Version:      msg.Version,
+		ResourceType: msg.ResourceType,
+		MediaType:    msg.MediaType,
+	}
+}
+
+// MsgRequestRemoteResolution implementations
+func (msg *MsgRequestRemoteResolution) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgRequestRemoteResolution) Type() string {
+	return TypeMsgRequestRemoteResolution
+}
+
+func (msg *MsgRequestRemoteResolution) GetSigners() []sdk.AccAddress {
+	creator, err := sdk.AccAddressFromBech32(msg.Creator)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{creator}
+}
+
+func (msg *MsgRequestRemoteResolution) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgRequestRemoteResolution) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Creator); err != nil {
+		return errorsmod.Wrapf(ErrInvalidCreator, "invalid creator address (%s)", err)
+	}
+
+	if err := host.ChannelIdentifierValidator(msg.ChannelId); err != nil {
+		return errorsmod.Wrapf(ErrChannelNotFound, "invalid channel ID (%s)", err)
+	}
+
+	_, err := ParseDID(msg.Did)
+	return err
+}
+
+// validateKeyProofs rejects empty or duplicate proofs of possession
+func validateKeyProofs(proofs []KeyProof) error {
+	seen := make(map[string]bool, len(proofs))
+	for _, proof := range proofs {
+		if proof.VerificationMethodId == "" || len(proof.Signature) == 0 {
+			return errorsmod.Wrap(ErrInvalidKeyProof, "key proof requires a verification method ID and signature")
+		}
+		if seen[proof.VerificationMethodId] {
+			return errorsmod.Wrapf(ErrInvalidKeyProof, "duplicate proof for %s", proof.VerificationMethodId)
+		}
+		seen[proof.VerificationMethodId] = true
+	}
+	return nil
+}
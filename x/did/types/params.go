@@ -0,0 +1,447 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	proto "github.com/cosmos/gogoproto/proto"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+)
+
+var _ paramtypes.ParamSet = (*Params)(nil)
+
+// Parameter store keys
+var (
+	KeyAllowedVerificationMethodTypes = []byte("AllowedVerificationMethodTypes")
+	KeyAllowSharedKeys                = []byte("AllowSharedKeys")
+	KeyAuthenticationExpiredState     = []byte("AuthenticationExpiredState")
+	KeyOrganizationAdmins             = []byte("OrganizationAdmins")
+	KeyStorageDepositPerByte          = []byte("StorageDepositPerByte")
+	KeyDepositRefundRatio             = []byte("DepositRefundRatio")
+	KeyHandleFee                      = []byte("HandleFee")
+	KeyHandlePeriod                   = []byte("HandlePeriod")
+	KeyReservedHandles                = []byte("ReservedHandles")
+	KeyPendingUpdatePeriod            = []byte("PendingUpdatePeriod")
+	KeyMaxResourceSize                = []byte("MaxResourceSize")
+	KeyChannelJurisdictions           = []byte("ChannelJurisdictions")
+	KeyRemoteResolutionTTL            = []byte("RemoteResolutionTTL")
+)
+
+// ParamKeyTable returns the parameter key table for the DID module
+func ParamKeyTable() paramtypes.KeyTable {
+	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// Params defines the parameters for the DID module
+type Params struct {
+	// AllowedVerificationMethodTypes lists additional verification method
+	// types accepted without cryptographic validation. The built-in types
+	// (see SupportedVerificationMethodTypes) are always accepted.
+	AllowedVerificationMethodTypes []string `protobuf:"bytes,1,rep,name=allowed_verification_method_types,json=allowedVerificationMethodTypes,proto3" json:"allowed_verification_method_types,omitempty"`
+	// AllowSharedKeys permits one key to be active on unrelated DIDs
+	AllowSharedKeys bool `protobuf:"varint,2,opt,name=allow_shared_keys,json=allowSharedKeys,proto3" json:"allow_shared_keys,omitempty"`
+	// AuthenticationExpiredState is the state a document is moved to when its
+	// last usable authentication key expires. Empty leaves the state alone.
+	AuthenticationExpiredState string `protobuf:"bytes,3,opt,name=authentication_expired_state,json=authenticationExpiredState,proto3" json:"authentication_expired_state,omitempty"`
+	// OrganizationAdmins may change the status of DIDs whose metadata names
+	// their organization
+	OrganizationAdmins []OrganizationAdmin `protobuf:"bytes,4,rep,name=organization_admins,json=organizationAdmins,proto3" json:"organization_admins"`
+	// StorageDepositPerByte is escrowed for every byte a DID document
+	// occupies in state. A zero amount disables the deposit.
+	StorageDepositPerByte sdk.Coin `protobuf:"bytes,5,opt,name=storage_deposit_per_byte,json=storageDepositPerByte,proto3" json:"storage_deposit_per_byte"`
+	// DepositRefundRatio is the share of the deposit returned when a DID is
+	// deactivated. The rest is burned.
+	DepositRefundRatio math.LegacyDec `protobuf:"bytes,6,opt,name=deposit_refund_ratio,json=depositRefundRatio,proto3,customtype=cosmossdk.io/math.LegacyDec" json:"deposit_refund_ratio"`
+	// HandleFee is burned for every registration or renewal of a handle
+	HandleFee sdk.Coin `protobuf:"bytes,7,opt,name=handle_fee,json=handleFee,proto3" json:"handle_fee"`
+	// HandlePeriod is how long a registration or renewal lasts
+	HandlePeriod time.Duration `protobuf:"bytes,8,opt,name=handle_period,json=handlePeriod,proto3,stdduration" json:"handle_period"`
+	// ReservedHandles cannot be registered
+	ReservedHandles []string `protobuf:"bytes,9,rep,name=reserved_handles,json=reservedHandles,proto3" json:"reserved_handles,omitempty"`
+	// PendingUpdatePeriod is how long a pending update collects controller
+	// approvals before it expires
+	PendingUpdatePeriod time.Duration `protobuf:"bytes,10,opt,name=pending_update_period,json=pendingUpdatePeriod,proto3,stdduration" json:"pending_update_period"`
+	// MaxResourceSize is the largest DID-linked resource in bytes
+	MaxResourceSize uint64 `protobuf:"varint,11,opt,name=max_resource_size,json=maxResourceSize,proto3" json:"max_resource_size,omitempty"`
+	// ChannelJurisdictions maps IBC channels to the jurisdiction of the
+	// counterparty chain. DID documents are only exported over channels
+	// whose jurisdiction their data residency policy permits.
+	ChannelJurisdictions []ChannelJurisdiction `protobuf:"bytes,12,rep,name=channel_jurisdictions,json=channelJurisdictions,proto3" json:"channel_jurisdictions"`
+	// RemoteResolutionTTL is how long a DID document resolved from another
+	// chain is served from the cache
+	RemoteResolutionTTL time.Duration `protobuf:"bytes,13,opt,name=remote_resolution_ttl,json=remoteResolutionTtl,proto3,stdduration" json:"remote_resolution_ttl"`
+}
+
+// OrganizationAdmin grants an address administrative rights over the DIDs of
+// an organization
+type OrganizationAdmin struct {
+	OrganizationId string `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	Address        string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (m *OrganizationAdmin) Reset()         { *m = OrganizationAdmin{} }
+func (m *OrganizationAdmin) String() string { return proto.CompactTextString(m) }
+func (*OrganizationAdmin) ProtoMessage()    {}
+
+// ChannelJurisdiction records the jurisdiction, such as EU or US, of the
+// chain at the other end of an IBC channel
+type ChannelJurisdiction struct {
+	ChannelId    string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Jurisdiction string `protobuf:"bytes,2,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"`
+}
+
+func (m *ChannelJurisdiction) Reset()         { *m = ChannelJurisdiction{} }
+func (m *ChannelJurisdiction) String() string { return proto.CompactTextString(m) }
+func (*ChannelJurisdiction) ProtoMessage()    {}
+
+// NewParams creates a new Params instance
+func NewParams(
+	allowedVerificationMethodTypes []string,
+	allowSharedKeys bool,
+	authenticationExpiredState string,
+	organizationAdmins []OrganizationAdmin,
+	storageDepositPerByte sdk.Coin,
+	depositRefundRatio math.LegacyDec,
+	handleFee sdk.Coin,
+	handlePeriod time.Duration,
+	reservedHandles []string,
+	pendingUpdatePeriod time.Duration,
+	maxResourceSize uint64,
+	channelJurisdictions []ChannelJurisdiction,
+	remoteResolutionTTL time.Duration,
+) Params {
+	return Params{
+		AllowedVerificationMethodTypes: allowedVerificationMethodTypes,
+		AllowSharedKeys:                allowSharedKeys,
+		AuthenticationExpiredState:     authenticationExpiredState,
+		OrganizationAdmins:             organizationAdmins,
+		StorageDepositPerByte:          storageDepositPerByte,
+		DepositRefundRatio:             depositRefundRatio,
+		HandleFee:                      handleFee,
+		HandlePeriod:                   handlePeriod,
+		ReservedHandles:                reservedHandles,
+		PendingUpdatePeriod:            pendingUpdatePeriod,
+		MaxResourceSize:                maxResourceSize,
+		ChannelJurisdictions:           channelJurisdictions,
+		RemoteResolutionTTL:            remoteResolutionTTL,
+	}
+}
+
+// DefaultParams returns the default parameters
+func DefaultParams() Params {
+	return NewParams(
+		[]string{},
+		false,
+		string(DIDStateSuspended),
+		[]OrganizationAdmin{},
+		sdk.NewInt64Coin(sdk.DefaultBondDenom, 0),
+		math.LegacyNewDecWithPrec(8, 1),
+		sdk.NewInt64Coin(sdk.DefaultBondDenom, 0),
+		365*24*time.Hour,
+		[]string{},
+		7*24*time.Hour,
+		200*1024,
+		[]ChannelJurisdiction{},
+		time.Hour,
+	)
+}
+
+// ParamSetPairs implements the params.ParamSet interface
+func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
+	return paramtypes.ParamSetPairs{
+		paramtypes.NewParamSetPair(KeyAllowedVerificationMethodTypes, &p.AllowedVerificationMethodTypes, validateAllowedVerificationMethodTypes),
+		paramtypes.NewParamSetPair(KeyAllowSharedKeys, &p.AllowSharedKeys, validateBool),
+		paramtypes.NewParamSetPair(KeyAuthenticationExpiredState, &p.AuthenticationExpiredState, validateAuthenticationExpiredState),
+		paramtypes.NewParamSetPair(KeyOrganizationAdmins, &p.OrganizationAdmins, validateOrganizationAdmins),
+		paramtypes.NewParamSetPair(KeyStorageDepositPerByte, &p.StorageDepositPerByte, validateStorageDepositPerByte),
+		paramtypes.NewParamSetPair(KeyDepositRefundRatio, &p.DepositRefundRatio, validateDepositRefundRatio),
+		paramtypes.NewParamSetPair(KeyHandleFee, &p.HandleFee, validateHandleFee),
+		paramtypes.NewParamSetPair(KeyHandlePeriod, &p.HandlePeriod, validateHandlePeriod),
+		paramtypes.NewParamSetPair(KeyReservedHandles, &p.ReservedHandles, validateReservedHandles),
+		paramtypes.NewParamSetPair(KeyPendingUpdatePeriod, &p.PendingUpdatePeriod, validatePendingUpdatePeriod),
+		paramtypes.NewParamSetPair(KeyMaxResourceSize, &p.MaxResourceSize, validateMaxResourceSize),
+		paramtypes.NewParamSetPair(KeyChannelJurisdictions, &p.ChannelJurisdictions, validateChannelJurisdictions),
+		paramtypes.NewParamSetPair(KeyRemoteResolutionTTL, &p.RemoteResolutionTTL, validateRemoteResolutionTTL),
+	}
+}
+
+// Validate validates the set of params
+func (p Params) Validate() error {
+	if err := validateAllowedVerificationMethodTypes(p.AllowedVerificationMethodTypes); err != nil {
+		return err
+	}
+	if err := validateAuthenticationExpiredState(p.AuthenticationExpiredState); err != nil {
+		return err
+	}
+	if err := validateOrganizationAdmins(p.OrganizationAdmins); err != nil {
+		return err
+	}
+	if err := validateStorageDepositPerByte(p.StorageDepositPerByte); err != nil {
+		return err
+	}
+	if err := validateDepositRefundRatio(p.DepositRefundRatio); err != nil {
+		return err
+	}
+	if err := validateHandleFee(p.HandleFee); err != nil {
+		return err
+	}
+	if err := validateHandlePeriod(p.HandlePeriod); err != nil {
+		return err
+	}
+	if err := validateReservedHandles(p.ReservedHandles); err != nil {
+		return err
+	}
+	if err := validatePendingUpdatePeriod(p.PendingUpdatePeriod); err != nil {
+		return err
+	}
+	if err := validateMaxResourceSize(p.MaxResourceSize); err != nil {
+		return err
+	}
+	if err := validateChannelJurisdictions(p.ChannelJurisdictions); err != nil {
+		return err
+	}
+	return validateRemoteResolutionTTL(p.RemoteResolutionTTL)
+}
+
+// IsVerificationMethodTypeAllowed reports whether a verification method type
+// is built in or allowlisted by governance
+func (p Params) IsVerificationMethodTypeAllowed(vmType string) bool {
+	if IsSupportedVerificationMethodType(vmType) {
+		return true
+	}
+	for _, allowed := range p.AllowedVerificationMethodTypes {
+		if allowed == vmType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOrganizationAdmin reports whether an address administers an organization
+func (p Params) IsOrganizationAdmin(organizationID, address string) bool {
+	if organizationID == "" {
+		return false
+	}
+	for _, admin := range p.OrganizationAdmins {
+		if admin.OrganizationId == organizationID && admin.Address == address {
+			return true
+		}
+	}
+	return false
+}
+
+// JurisdictionOf returns the jurisdiction governance recorded for an
+// IBC channel
+func (p Params) JurisdictionOf(channelID string) (string, bool) {
+	for _, entry := range p.ChannelJurisdictions {
+		if entry.ChannelId == channelID {
+			return entry.Jurisdiction, true
+		}
+	}
+	return "", false
+}
+
+// StorageDepositFor returns the deposit due for the given number of bytes
+func (p Params) StorageDepositFor(bytes uint64) sdk.Coins {
+	amount := p.StorageDepositPerByte.Amount.Mul(math.NewIntFromUint64(bytes))
+	return sdk.NewCoins(sdk.NewCoin(p.StorageDepositPerByte.Denom, amount))
+}
+
+// IsHandleReserved reports whether governance reserved a handle
+func (p Params) IsHandleReserved(name string) bool {
+	for _, reserved := range p.ReservedHandles {
+		if reserved == name {
+			return true
+		}
+	}
+	return false
+}
+
+func validateAllowedVerificationMethodTypes(i interface{}) error {
+	types, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	seen := make(map[string]bool, len(types))
+	for _, t := range types {
+		if t == "" {
+			return fmt.Errorf("verification method type cannot be empty")
+		}
+		if seen[t] {
+			return fmt.Errorf("duplicate verification method type: %s", t)
+		}
+		seen[t] = true
+	}
+
+	return nil
+}
+
+func validateBool(i interface{}) error {
+	if _, ok := i.(bool); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateAuthenticationExpiredState(i interface{}) error {
+	state, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	switch DIDState(state) {
+	case "", DIDStateInactive, DIDStateSuspended, DIDStateRevoked:
+		return nil
+	default:
+		return fmt.Errorf("invalid authentication expired state: %q", state)
+	}
+}
+
+func validateOrganizationAdmins(i interface{}) error {
+	admins, ok := i.([]OrganizationAdmin)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	seen := make(map[OrganizationAdmin]bool, len(admins))
+	for _, admin := range admins {
+		if admin.OrganizationId == "" {
+			return fmt.Errorf("organization admin requires an organization ID")
+		}
+		if _, err := sdk.AccAddressFromBech32(admin.Address); err != nil {
+			return fmt.Errorf("invalid organization admin address %q: %w", admin.Address, err)
+		}
+		if seen[admin] {
+			return fmt.Errorf("duplicate admin %s for organization %s", admin.Address, admin.OrganizationId)
+		}
+		seen[admin] = true
+	}
+
+	return nil
+}
+
+func validateStorageDepositPerByte(i interface{}) error {
+	coin, ok := i.(sdk.Coin)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if err := coin.Validate(); err != nil {
+		return fmt.Errorf("invalid storage deposit per byte: %w", err)
+	}
+	return nil
+}
+
+func validateDepositRefundRatio(i interface{}) error {
+	ratio, ok := i.(math.LegacyDec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if ratio.IsNil() {
+		return fmt.Errorf("deposit refund ratio cannot be nil")
+	}
+	if ratio.IsNegative() || ratio.GT(math.LegacyOneDec()) {
+		return fmt.Errorf("deposit refund ratio must be between 0 and 1: %s", ratio)
+	}
+	return nil
+}
+
+func validateHandleFee(i interface{}) error {
+	coin, ok := i.(sdk.Coin)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if err := coin.Validate(); err != nil {
+		return fmt.Errorf("invalid handle fee: %w", err)
+	}
+	return nil
+}
+
+func validateHandlePeriod(i interface{}) error {
+	period, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if period <= 0 {
+		return fmt.Errorf("handle period must be positive: %s", period)
+	}
+	return nil
+}
+
+func validateReservedHandles(i interface{}) error {
+	names, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if err := ValidateHandle(name); err != nil {
+			return err
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate reserved handle: %s", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+func validatePendingUpdatePeriod(i interface{}) error {
+	period, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if period <= 0 {
+		return fmt.Errorf("pending update period must be positive: %s", period)
+	}
+	return nil
+}
+
+func validateMaxResourceSize(i interface{}) error {
+	size, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if size == 0 {
+		return fmt.Errorf("max resource size must be positive")
+	}
+	return nil
+}
+
+func validateChannelJurisdictions(i interface{}) error {
+	entries, ok := i.([]ChannelJurisdiction)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if err := host.ChannelIdentifierValidator(entry.ChannelId); err != nil {
+			return fmt.Errorf("invalid channel ID %q: %w", entry.ChannelId, err)
+		}
+		if entry.Jurisdiction == "" {
+			return fmt.Errorf("channel %s requires a jurisdiction", entry.ChannelId)
+		}
+		if seen[entry.ChannelId] {
+			return fmt.Errorf("duplicate jurisdiction for channel %s", entry.ChannelId)
+		}
+		seen[entry.ChannelId] = true
+	}
+
+	return nil
+}
+
+func validateRemoteResolutionTTL(i interface{}) error {
+	ttl, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if ttl <= 0 {
+		return fmt.Errorf("remote resolution TTL must be positive: %s", ttl)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/json"
+	"math/big"
+	"strconv"
+
+	"cosmossdk.io/errors"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secp256k1ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// PossessionChallengeDomain separates proof-of-possession signatures from
+// any other signature made with the same key
+const PossessionChallengeDomain = "persona-chain/did/key-possession/v1"
+
+// Public key algorithms recognised for signature verification
+const (
+	KeyAlgorithmEd25519   = "Ed25519"
+	KeyAlgorithmX25519    = "X25519"
+	KeyAlgorithmSecp256k1 = "secp256k1"
+	KeyAlgorithmP256      = "P-256"
+	KeyAlgorithmP384      = "P-384"
+)
+
+// KeyProof is a signature by a newly added key over its possession challenge
+type KeyProof struct {
+	VerificationMethodId string `protobuf:"bytes,1,opt,name=verification_method_id,json=verificationMethodId,proto3" json:"verification_method_id,omitempty"`
+	Signature            []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+// possessionChallenge is serialized with fields in lexicographic order so
+// clients can reproduce the exact bytes
+type possessionChallenge struct {
+	ChainID            string `json:"chainId"`
+	DID                string `json:"did"`
+	Domain             string `json:"domain"`
+	VerificationMethod string `json:"verificationMethod"`
+	Version            string `json:"version"`
+}
+
+// PossessionChallenge returns the bytes a key must sign to prove possession
+// when it is added to a DID document. version is the document version that
+// the addition will produce.
+func PossessionChallenge(did, verificationMethodID, chainID string, version uint64) []byte {
+	bz, _ := json.Marshal(possessionChallenge{
+		ChainID:            chainID,
+		DID:                did,
+		Domain:             PossessionChallengeDomain,
+		VerificationMethod: verificationMethodID,
+		Version:            strconv.FormatUint(version, 10),
+	})
+	return bz
+}
+
+// DecodePublicKey returns the key algorithm and raw public key of a
+// verification method. Ed25519 and X25519 keys are 32 bytes, secp256k1 keys
+// are SEC1 compressed and NIST keys are SEC1 uncompressed.
+func (vm *VerificationMethod) DecodePublicKey() (string, []byte, error) {
+	if err := vm.ValidateKeyMaterial(); err != nil {
+		return "", nil, err
+	}
+
+	if vm.PublicKeyMultibase != "" {
+		codec, key, err := DecodeMultibaseMultikey(vm.PublicKeyMultibase)
+		if err != nil {
+			return "", nil, err
+		}
+		switch codec {
+		case MulticodecEd25519Pub:
+			return KeyAlgorithmEd25519, key, nil
+		case MulticodecX25519Pub:
+			return KeyAlgorithmX25519, key, nil
+		case MulticodecSecp256k1Pub:
+			return KeyAlgorithmSecp256k1, key, nil
+		case MulticodecP256Pub:
+			x, y, err := decompressNISTKey(JWKCurveP256, 32, key)
+			if err != nil {
+				return "", nil, err
+			}
+			return KeyAlgorithmP256, uncompressedPoint(x, y), nil
+		case MulticodecP384Pub:
+			x, y, err := decompressNISTKey(JWKCurveP384, 48, key)
+			if err != nil {
+				return "", nil, err
+			}
+			return KeyAlgorithmP384, uncompressedPoint(x, y), nil
+		default:
+			return "", nil, errors.Wrapf(ErrUnsupportedMulticodec, "unsupported multicodec 0x%x", codec)
+		}
+	}
+
+	x, err := decodeJwkCoordinate(vm.PublicKeyJwk, "x")
+	if err != nil {
+		return "", nil, err
+	}
+	if vm.PublicKeyJwk["kty"] == "OKP" {
+		return KeyAlgorithmEd25519, x, nil
+	}
+	y, err := decodeJwkCoordinate(vm.PublicKeyJwk, "y")
+	if err != nil {
+		return "", nil, err
+	}
+	switch crv := vm.PublicKeyJwk["crv"]; crv {
+	case JWKCurveSecp256k1:
+		pub, err := secp256k1.ParsePubKey(uncompressedPoint(x, y))
+		if err != nil {
+			return "", nil, errors.Wrapf(ErrInvalidPublicKey, "%s: %v", crv, err)
+		}
+		return KeyAlgorithmSecp256k1, pub.SerializeCompressed(), nil
+	case JWKCurveP256:
+		return KeyAlgorithmP256, uncompressedPoint(x, y), nil
+	case JWKCurveP384:
+		return KeyAlgorithmP384, uncompressedPoint(x, y), nil
+	default:
+		return "", nil, errors.Wrapf(ErrUnsupportedCurve, "unsupported EC curve %q", crv)
+	}
+}
+
+// CanSign reports whether the verification method holds a signing key.
+// X25519 keys are only usable for key agreement.
+func (vm *VerificationMethod) CanSign() bool {
+	algo, _, err := vm.DecodePublicKey()
+	return err == nil && algo != KeyAlgorithmX25519
+}
+
+// VerifySignature verifies a signature made by the verification method's key.
+// Ed25519 signatures are 64 bytes over the raw message. ECDSA signatures are
+// fixed-size r||s over SHA-256 (secp256k1, P-256) or SHA-384 (P-384), and
+// secp256k1 signatures must use low-S form.
+func (vm *VerificationMethod) VerifySignature(msg, sig []byte) error {
+	algo, key, err := vm.DecodePublicKey()
+	if err != nil {
+		return err
+	}
+
+	switch algo {
+	case KeyAlgorithmEd25519:
+		if len(sig) != ed25519.SignatureSize || !ed25519.Verify(ed25519.PublicKey(key), msg, sig) {
+			return errors.Wrap(ErrInvalidSignature, "ed25519 signature verification failed")
+		}
+		return nil
+
+	case KeyAlgorithmSecp256k1:
+		if len(sig) != 64 {
+			return errors.Wrapf(ErrInvalidSignature, "secp256k1 signature must be 64 bytes, got %d", len(sig))
+		}
+		pub, err := secp256k1.ParsePubKey(key)
+		if err != nil {
+			return errors.Wrapf(ErrInvalidPublicKey, "secp256k1: %v", err)
+		}
+		var r, s secp256k1.ModNScalar
+		if r.SetByteSlice(sig[:32]) || s.SetByteSlice(sig[32:]) || r.IsZero() || s.IsZero() || s.IsOverHalfOrder() {
+			return errors.Wrap(ErrInvalidSignature, "malformed secp256k1 signature")
+		}
+		hash := sha256.Sum256(msg)
+		if !secp256k1ecdsa.NewSignature(&r, &s).Verify(hash[:], pub) {
+			return errors.Wrap(ErrInvalidSignature, "secp256k1 signature verification failed")
+		}
+		return nil
+
+	case KeyAlgorithmP256:
+		hash := sha256.Sum256(msg)
+		return verifyNISTSignature(elliptic.P256(), 32, key, hash[:], sig)
+
+	case KeyAlgorithmP384:
+		hash := sha512.Sum384(msg)
+		return verifyNISTSignature(elliptic.P384(), 48, key, hash[:], sig)
+
+	default:
+		return errors.Wrapf(ErrInvalidSignature, "%s keys cannot produce signatures", algo)
+	}
+}
+
+func verifyNISTSignature(curve elliptic.Curve, size int, key, hash, sig []byte) error {
+	if len(sig) != 2*size {
+		return errors.Wrapf(ErrInvalidSignature, "%s signature must be %d bytes, got %d", curve.Params().Name, 2*size, len(sig))
+	}
+	pub := &ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(key[1 : 1+size]),
+		Y:     new(big.Int).SetBytes(key[1+size:]),
+	}
+	r := new(big.Int).SetBytes(sig[:size])
+	s := new(big.Int).SetBytes(sig[size:])
+	if !ecdsa.Verify(pub, hash, r, s) {
+		return errors.Wrapf(ErrInvalidSignature, "%s signature verification failed", curve.Params().Name)
+	}
+	return nil
+}
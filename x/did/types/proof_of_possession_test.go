@@ -0,0 +1,94 @@
+package types_test
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secp256k1ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/require"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestPossessionChallenge(t *testing.T) {
+	challenge := types.PossessionChallenge("did:persona:abc", "did:persona:abc#key-1", "persona-1", 2)
+	require.Equal(t,
+		`{"chainId":"persona-1","did":"did:persona:abc","domain":"persona-chain/did/key-possession/v1","verificationMethod":"did:persona:abc#key-1","version":"2"}`,
+		string(challenge))
+}
+
+func TestVerifySignature(t *testing.T) {
+	msg := types.PossessionChallenge("did:persona:abc", "did:persona:abc#key-1", "persona-1", 2)
+	sha := sha256.Sum256(msg)
+
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edVM := multikey(types.MulticodecEd25519Pub, edPub)
+	edSig := ed25519.Sign(edPriv, msg)
+
+	k1Priv, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	k1VM := multikey(types.MulticodecSecp256k1Pub, k1Priv.PubKey().SerializeCompressed())
+	k1Sig := secp256k1ecdsa.SignCompact(k1Priv, sha[:], true)[1:]
+	// Negating s gives the equally valid high-S form of the signature
+	var s secp256k1.ModNScalar
+	s.SetByteSlice(k1Sig[32:])
+	highS := s.Negate().Bytes()
+	k1HighS := append(append([]byte{}, k1Sig[:32]...), highS[:]...)
+
+	nistSig := func(priv *ecdsa.PrivateKey, hash []byte, size int) []byte {
+		r, s, err := ecdsa.Sign(rand.Reader, priv, hash)
+		require.NoError(t, err)
+		return append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...)
+	}
+	p256Priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	p256VM := multikey(types.MulticodecP256Pub, elliptic.MarshalCompressed(elliptic.P256(), p256Priv.X, p256Priv.Y))
+	p384Priv, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	p384VM := multikey(types.MulticodecP384Pub, elliptic.MarshalCompressed(elliptic.P384(), p384Priv.X, p384Priv.Y))
+	sha384 := sha512.Sum384(msg)
+
+	xPriv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	xVM := multikey(types.MulticodecX25519Pub, xPriv.PublicKey().Bytes())
+
+	tests := []struct {
+		name    string
+		vm      types.VerificationMethod
+		sig     []byte
+		canSign bool
+		err     error
+	}{
+		{name: "ed25519", vm: edVM, sig: edSig, canSign: true},
+		{name: "ed25519 over another message", vm: edVM, sig: ed25519.Sign(edPriv, []byte("other")), canSign: true, err: types.ErrInvalidSignature},
+		{name: "ed25519 truncated", vm: edVM, sig: edSig[:63], canSign: true, err: types.ErrInvalidSignature},
+		{name: "secp256k1", vm: k1VM, sig: k1Sig, canSign: true},
+		{name: "secp256k1 high-S", vm: k1VM, sig: k1HighS, canSign: true, err: types.ErrInvalidSignature},
+		{name: "secp256k1 DER encoded", vm: k1VM, sig: secp256k1ecdsa.Sign(k1Priv, sha[:]).Serialize(), canSign: true, err: types.ErrInvalidSignature},
+		{name: "P-256", vm: p256VM, sig: nistSig(p256Priv, sha[:], 32), canSign: true},
+		{name: "P-256 signed with SHA-384", vm: p256VM, sig: nistSig(p256Priv, sha384[:], 32), canSign: true, err: types.ErrInvalidSignature},
+		{name: "P-384", vm: p384VM, sig: nistSig(p384Priv, sha384[:], 48), canSign: true},
+		{name: "P-384 short signature", vm: p384VM, sig: nistSig(p384Priv, sha384[:], 48)[:64], canSign: true, err: types.ErrInvalidSignature},
+		{name: "x25519", vm: xVM, sig: edSig, err: types.ErrInvalidSignature},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.canSign, tc.vm.CanSign())
+			err := tc.vm.VerifySignature(msg, tc.sig)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
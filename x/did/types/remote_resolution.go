@@ -0,0 +1,16 @@
+package types
+
+import "time"
+
+// Statuses of a RemoteDIDDocument
+const (
+	RemoteResolutionPending  = "pending"
+	RemoteResolutionResolved = "resolved"
+	RemoteResolutionFailed   = "failed"
+)
+
+// CachedAt reports whether the entry holds a document that can be served at
+// the given time
+func (m RemoteDIDDocument) CachedAt(t time.Time) bool {
+	return m.Document != nil && m.ExpiresAt != nil && t.Before(*m.ExpiresAt)
+}
@@ -0,0 +1,40 @@
+package types
+
+import "strings"
+
+// GlobalRegion in AllowedRegions lets a DID document be exported to any
+// jurisdiction that is not prohibited
+const GlobalRegion = "global"
+
+// Restricted reports whether the policy limits where a DID document may be
+// exported
+func (r DataResidency) Restricted() bool {
+	return len(r.ProhibitedRegions) > 0 || !r.allowsAnyRegion()
+}
+
+// Permits reports whether a DID document under this policy may be exported
+// to jurisdiction. Regions are compared case-insensitively. An empty
+// jurisdiction stands for one that is not known and is only permitted when
+// the policy is unrestricted.
+func (r DataResidency) Permits(jurisdiction string) bool {
+	if jurisdiction == "" {
+		return !r.Restricted()
+	}
+	if containsRegion(r.ProhibitedRegions, jurisdiction) {
+		return false
+	}
+	return r.allowsAnyRegion() || containsRegion(r.AllowedRegions, jurisdiction)
+}
+
+func (r DataResidency) allowsAnyRegion() bool {
+	return len(r.AllowedRegions) == 0 || containsRegion(r.AllowedRegions, GlobalRegion)
+}
+
+func containsRegion(regions []string, region string) bool {
+	for _, r := range regions {
+		if strings.EqualFold(r, region) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestDataResidencyPermits(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       types.DataResidency
+		jurisdiction string
+		permitted    bool
+	}{
+		{name: "unrestricted", jurisdiction: "US", permitted: true},
+		{name: "unrestricted to unknown jurisdiction", permitted: true},
+		{name: "allowed region", policy: types.DataResidency{AllowedRegions: []string{"EU"}}, jurisdiction: "EU", permitted: true},
+		{name: "allowed region in another case", policy: types.DataResidency{AllowedRegions: []string{"eu"}}, jurisdiction: "EU", permitted: true},
+		{name: "region not allowed", policy: types.DataResidency{AllowedRegions: []string{"EU"}}, jurisdiction: "US"},
+		{name: "restricted to unknown jurisdiction", policy: types.DataResidency{AllowedRegions: []string{"EU"}}},
+		{name: "global", policy: types.DataResidency{AllowedRegions: []string{"EU", types.GlobalRegion}}, jurisdiction: "US", permitted: true},
+		{name: "prohibited region", policy: types.DataResidency{ProhibitedRegions: []string{"US"}}, jurisdiction: "US"},
+		{name: "prohibited overrides global", policy: types.DataResidency{AllowedRegions: []string{types.GlobalRegion}, ProhibitedRegions: []string{"US"}}, jurisdiction: "US"},
+		{name: "other region than prohibited", policy: types.DataResidency{ProhibitedRegions: []string{"US"}}, jurisdiction: "EU", permitted: true},
+		{name: "prohibited to unknown jurisdiction", policy: types.DataResidency{ProhibitedRegions: []string{"US"}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.permitted, tc.policy.Permits(tc.jurisdiction))
+		})
+	}
+}
+
+func TestChannelJurisdictionsParam(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []types.ChannelJurisdiction
+		valid   bool
+	}{
+		{name: "none", valid: true},
+		{name: "registered channels", entries: []types.ChannelJurisdiction{{ChannelId: "channel-0", Jurisdiction: "EU"}, {ChannelId: "channel-1", Jurisdiction: "US"}}, valid: true},
+		{name: "invalid channel ID", entries: []types.ChannelJurisdiction{{ChannelId: "ch", Jurisdiction: "EU"}}},
+		{name: "no jurisdiction", entries: []types.ChannelJurisdiction{{ChannelId: "channel-0"}}},
+		{name: "duplicate channel", entries: []types.ChannelJurisdiction{{ChannelId: "channel-0", Jurisdiction: "EU"}, {ChannelId: "channel-0", Jurisdiction: "US"}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			params := types.DefaultParams()
+			params.ChannelJurisdictions = tc.entries
+			err := params.Validate()
+			if !tc.valid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			for _, entry := range tc.entries {
+				jurisdiction, found := params.JurisdictionOf(entry.ChannelId)
+				require.True(t, found)
+				require.Equal(t, entry.Jurisdiction, jurisdiction)
+			}
+			_, found := params.JurisdictionOf("channel-99")
+			require.False(t, found)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package types
+
+// W3C DID Resolution content types
+const (
+	ContentTypeDIDJSON   = "application/did+json"
+	ContentTypeDIDLDJSON = "application/did+ld+json"
+)
+
+// W3C DID Resolution error codes reported in DIDResolutionMetadata.Error
+const (
+	ResolutionErrorInvalidDID                 = "invalidDid"
+	ResolutionErrorNotFound                   = "notFound"
+	ResolutionErrorDeactivated                = "deactivated"
+	ResolutionErrorRepresentationNotSupported = "representationNotSupported"
+	ResolutionErrorInvalidVersion             = "invalidVersion"
+	ResolutionErrorVersionNotFound            = "versionNotFound"
+	ResolutionErrorInternal                   = "internalError"
+)
+
+// IsDeactivated reports whether the document should be treated as
+// deactivated by resolvers
+func (d *DIDDocument) IsDeactivated() bool {
+	return d.Metadata.Deactivated ||
+		d.Status.State == DIDStateRevoked ||
+		d.Status.State == DIDStateInactive
+}
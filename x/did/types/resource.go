@@ -0,0 +1,118 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"mime"
+	"strings"
+	"time"
+
+	"cosmossdk.io/errors"
+	proto "github.com/cosmos/gogoproto/proto"
+)
+
+// ResourcePathPrefix starts the DID URL path of a DID-linked resource,
+// as in did:persona:abc/resources/<uuid>
+const ResourcePathPrefix = "/resources/"
+
+// Length limits of resource metadata
+const (
+	MaxResourceNameLength    = 128
+	MaxResourceTypeLength    = 64
+	MaxResourceVersionLength = 64
+)
+
+// ResourceMetadata describes a DID-linked resource. Resources sharing a DID,
+// name and type are versions of each other, linked in creation order.
+type ResourceMetadata struct {
+	Did               string    `protobuf:"bytes,1,opt,name=did,proto3" json:"did,omitempty"`
+	Id                string    `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Name              string    `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Version           string    `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	ResourceType      string    `protobuf:"bytes,5,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	MediaType         string    `protobuf:"bytes,6,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
+	Checksum          string    `protobuf:"bytes,7,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	Size              uint64    `protobuf:"varint,8,opt,name=size,proto3" json:"size,omitempty"`
+	CreatedAt         time.Time `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3,stdtime" json:"created_at"`
+	CreatedBy         string    `protobuf:"bytes,10,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	PreviousVersionId string    `protobuf:"bytes,11,opt,name=previous_version_id,json=previousVersionId,proto3" json:"previous_version_id,omitempty"`
+	NextVersionId     string    `protobuf:"bytes,12,opt,name=next_version_id,json=nextVersionId,proto3" json:"next_version_id,omitempty"`
+}
+
+func (m *ResourceMetadata) Reset()         { *m = ResourceMetadata{} }
+func (m *ResourceMetadata) String() string { return proto.CompactTextString(m) }
+func (*ResourceMetadata) ProtoMessage()    {}
+
+// Resource is a DID-linked resource and its content
+type Resource struct {
+	Metadata ResourceMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata"`
+	Data     []byte           `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *Resource) Reset()         { *m = Resource{} }
+func (m *Resource) String() string { return proto.CompactTextString(m) }
+func (*Resource) ProtoMessage()    {}
+
+// URL returns the DID URL addressing the resource
+func (m ResourceMetadata) URL() string {
+	return m.Did + ResourcePathPrefix + m.Id
+}
+
+// ValidateBasic checks the fields chosen by the resource's creator
+func (m ResourceMetadata) ValidateBasic() error {
+	if _, err := ParseDID(m.Did); err != nil {
+		return err
+	}
+	if !IsCanonicalUUID(m.Id) {
+		return errors.Wrapf(ErrInvalidResource, "resource ID %q must be a lowercase UUID", m.Id)
+	}
+	if m.Name == "" || len(m.Name) > MaxResourceNameLength {
+		return errors.Wrapf(ErrInvalidResource, "name must be between 1 and %d bytes", MaxResourceNameLength)
+	}
+	if m.ResourceType == "" || len(m.ResourceType) > MaxResourceTypeLength {
+		return errors.Wrapf(ErrInvalidResource, "resource type must be between 1 and %d bytes", MaxResourceTypeLength)
+	}
+	if len(m.Version) > MaxResourceVersionLength {
+		return errors.Wrapf(ErrInvalidResource, "version must be at most %d bytes", MaxResourceVersionLength)
+	}
+	if _, _, err := mime.ParseMediaType(m.MediaType); err != nil {
+		return errors.Wrapf(ErrInvalidResource, "media type %q: %v", m.MediaType, err)
+	}
+	return nil
+}
+
+// ResourceChecksum returns the hex encoded SHA-256 digest of resource content
+func ResourceChecksum(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// IsCanonicalUUID reports whether s is a UUID in its lowercase 8-4-4-4-12
+// hexadecimal form
+func IsCanonicalUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if !isDigit(s[i]) && !(s[i] >= 'a' && s[i] <= 'f') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ResourceIDFromPath returns the resource ID addressed by a DID URL path, if
+// the path is a resource path
+func ResourceIDFromPath(path string) (string, bool) {
+	if !strings.HasPrefix(path, ResourcePathPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(path, ResourcePathPrefix), true
+}
@@ -0,0 +1,99 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func TestResourceMetadataValidateBasic(t *testing.T) {
+	valid := types.ResourceMetadata{
+		Did:          "did:persona:abc",
+		Id:           "9f3c2a1e-7b4d-4e5f-8a6b-1c2d3e4f5a6b",
+		Name:         "KYC schema",
+		Version:      "1.0",
+		ResourceType: "JsonSchema",
+		MediaType:    "application/schema+json",
+	}
+
+	tests := []struct {
+		name   string
+		modify func(m *types.ResourceMetadata)
+		err    error
+	}{
+		{name: "valid", modify: func(m *types.ResourceMetadata) {}},
+		{name: "no version", modify: func(m *types.ResourceMetadata) { m.Version = "" }},
+		{name: "media type with parameters", modify: func(m *types.ResourceMetadata) { m.MediaType = "text/plain; charset=utf-8" }},
+		{name: "invalid DID", modify: func(m *types.ResourceMetadata) { m.Did = "abc" }, err: types.ErrInvalidDID},
+		{name: "uppercase UUID", modify: func(m *types.ResourceMetadata) { m.Id = strings.ToUpper(valid.Id) }, err: types.ErrInvalidResource},
+		{name: "ID is not a UUID", modify: func(m *types.ResourceMetadata) { m.Id = "schema-1" }, err: types.ErrInvalidResource},
+		{name: "no name", modify: func(m *types.ResourceMetadata) { m.Name = "" }, err: types.ErrInvalidResource},
+		{name: "name too long", modify: func(m *types.ResourceMetadata) { m.Name = strings.Repeat("n", types.MaxResourceNameLength+1) }, err: types.ErrInvalidResource},
+		{name: "no resource type", modify: func(m *types.ResourceMetadata) { m.ResourceType = "" }, err: types.ErrInvalidResource},
+		{name: "resource type too long", modify: func(m *types.ResourceMetadata) { m.ResourceType = strings.Repeat("t", types.MaxResourceTypeLength+1) }, err: types.ErrInvalidResource},
+		{name: "version too long", modify: func(m *types.ResourceMetadata) { m.Version = strings.Repeat("1", types.MaxResourceVersionLength+1) }, err: types.ErrInvalidResource},
+		{name: "no media type", modify: func(m *types.ResourceMetadata) { m.MediaType = "" }, err: types.ErrInvalidResource},
+		{name: "malformed media type", modify: func(m *types.ResourceMetadata) { m.MediaType = "application/" }, err: types.ErrInvalidResource},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := valid
+			tc.modify(&m)
+			err := m.ValidateBasic()
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestIsCanonicalUUID(t *testing.T) {
+	tests := []struct {
+		input string
+		valid bool
+	}{
+		{input: "9f3c2a1e-7b4d-4e5f-8a6b-1c2d3e4f5a6b", valid: true},
+		{input: "00000000-0000-0000-0000-000000000000", valid: true},
+		{input: "9F3C2A1E-7B4D-4E5F-8A6B-1C2D3E4F5A6B"},
+		{input: "9f3c2a1e7b4d4e5f8a6b1c2d3e4f5a6b"},
+		{input: "9f3c2a1e-7b4d-4e5f-8a6b-1c2d3e4f5a6"},
+		{input: "9f3c2a1e-7b4d-4e5f-8a6b_1c2d3e4f5a6b"},
+		{input: "9f3c2a1g-7b4d-4e5f-8a6b-1c2d3e4f5a6b"},
+		{input: "{9f3c2a1e-7b4d-4e5f-8a6b-1c2d3e4f5a6}"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			require.Equal(t, tc.valid, types.IsCanonicalUUID(tc.input))
+		})
+	}
+}
+
+func TestResourceURL(t *testing.T) {
+	m := types.ResourceMetadata{Did: "did:persona:abc", Id: "9f3c2a1e-7b4d-4e5f-8a6b-1c2d3e4f5a6b"}
+	require.Equal(t, "did:persona:abc/resources/9f3c2a1e-7b4d-4e5f-8a6b-1c2d3e4f5a6b", m.URL())
+
+	parsed, err := types.ParseDIDURL(m.URL())
+	require.NoError(t, err)
+	id, ok := types.ResourceIDFromPath(parsed.Path)
+	require.True(t, ok)
+	require.Equal(t, m.Id, id)
+
+	_, ok = types.ResourceIDFromPath("/docs/1")
+	require.False(t, ok)
+
+	parsed, err = types.ParseDIDURL("did:persona:abc?resourceName=KYC%20schema&resourceType=JsonSchema")
+	require.NoError(t, err)
+	require.Equal(t, "KYC schema", parsed.ResourceName())
+	require.Equal(t, "JsonSchema", parsed.ResourceType())
+}
+
+func TestResourceChecksum(t *testing.T) {
+	require.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", types.ResourceChecksum([]byte("hello")))
+}
@@ -0,0 +1,29 @@
+package types
+
+// StatusRole is the role in which a DID status transition was made
+type StatusRole string
+
+const (
+	// StatusRoleController acts for the DID itself
+	StatusRoleController StatusRole = "controller"
+	// StatusRoleOrganizationAdmin administers the DID's organization
+	StatusRoleOrganizationAdmin StatusRole = "organization_admin"
+	// StatusRoleGovernance is the module authority
+	StatusRoleGovernance StatusRole = "governance"
+	// StatusRoleModule marks automatic transitions made by the chain
+	StatusRoleModule StatusRole = "module"
+)
+
+// Rank orders roles by authority. A suspension can only be lifted by a role
+// ranked at least as high as the one that imposed it. Automatic suspensions
+// rank with the controller.
+func (r StatusRole) Rank() int {
+	switch r {
+	case StatusRoleGovernance:
+		return 3
+	case StatusRoleOrganizationAdmin:
+		return 2
+	default:
+		return 1
+	}
+}
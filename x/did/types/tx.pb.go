@@ -5,9 +5,11 @@ package types
 
 import (
 	fmt "fmt"
+	types "github.com/cosmos/cosmos-sdk/codec/types"
 	proto "github.com/cosmos/gogoproto/proto"
 	math "math"
 	math_bits "math/bits"
+	time "time"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -77,6 +79,538 @@ func (m *MsgDeactivateDidResponse) Reset()         { *m = MsgDeactivateDidRespon
 func (m *MsgDeactivateDidResponse) String() string { return proto.CompactTextString(m) }
 func (*MsgDeactivateDidResponse) ProtoMessage()    {}
 
+// MsgCreateDIDDocument creates a DID document. key_proofs must contain a
+// proof of possession for every signing verification method.
+type MsgCreateDIDDocument struct {
+	Creator     string      `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	DidDocument DIDDocument `protobuf:"bytes,2,opt,name=did_document,json=didDocument,proto3" json:"did_document,omitempty"`
+	KeyProofs   []KeyProof  `protobuf:"bytes,3,rep,name=key_proofs,json=keyProofs,proto3" json:"key_proofs,omitempty"`
+}
+
+func (m *MsgCreateDIDDocument) Reset()         { *m = MsgCreateDIDDocument{} }
+func (m *MsgCreateDIDDocument) String() string { return proto.CompactTextString(m) }
+func (*MsgCreateDIDDocument) ProtoMessage()    {}
+
+// MsgCreateDIDDocumentResponse defines the Msg/CreateDIDDocument response type.
+type MsgCreateDIDDocumentResponse struct {
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version uint64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (m *MsgCreateDIDDocumentResponse) Reset()         { *m = MsgCreateDIDDocumentResponse{} }
+func (m *MsgCreateDIDDocumentResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgCreateDIDDocumentResponse) ProtoMessage()    {}
+
+// MsgUpdateDIDDocument replaces a DID document. key_proofs must contain a
+// proof of possession for every signing verification method that is not
+// already part of the current document.
+type MsgUpdateDIDDocument struct {
+	Controller  string      `protobuf:"bytes,1,opt,name=controller,proto3" json:"controller,omitempty"`
+	Id          string      `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	DidDocument DIDDocument `protobuf:"bytes,3,opt,name=did_document,json=didDocument,proto3" json:"did_document,omitempty"`
+	KeyProofs   []KeyProof  `protobuf:"bytes,4,rep,name=key_proofs,json=keyProofs,proto3" json:"key_proofs,omitempty"`
+}
+
+func (m *MsgUpdateDIDDocument) Reset()         { *m = MsgUpdateDIDDocument{} }
+func (m *MsgUpdateDIDDocument) String() string { return proto.CompactTextString(m) }
+func (*MsgUpdateDIDDocument) ProtoMessage()    {}
+
+// MsgUpdateDIDDocumentResponse defines the Msg/UpdateDIDDocument response type.
+type MsgUpdateDIDDocumentResponse struct {
+	Version uint64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (m *MsgUpdateDIDDocumentResponse) Reset()         { *m = MsgUpdateDIDDocumentResponse{} }
+func (m *MsgUpdateDIDDocumentResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgUpdateDIDDocumentResponse) ProtoMessage()    {}
+
+// MsgDeactivateDIDDocument deactivates a DID document
+type MsgDeactivateDIDDocument struct {
+	Controller string `protobuf:"bytes,1,opt,name=controller,proto3" json:"controller,omitempty"`
+	Id         string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Reason     string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (m *MsgDeactivateDIDDocument) Reset()         { *m = MsgDeactivateDIDDocument{} }
+func (m *MsgDeactivateDIDDocument) String() string { return proto.CompactTextString(m) }
+func (*MsgDeactivateDIDDocument) ProtoMessage()    {}
+
+// MsgDeactivateDIDDocumentResponse defines the Msg/DeactivateDIDDocument response type.
+type MsgDeactivateDIDDocumentResponse struct {
+}
+
+func (m *MsgDeactivateDIDDocumentResponse) Reset()         { *m = MsgDeactivateDIDDocumentResponse{} }
+func (m *MsgDeactivateDIDDocumentResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgDeactivateDIDDocumentResponse) ProtoMessage()    {}
+
+// MsgAddVerificationMethod adds a verification method to a DID document
+type MsgAddVerificationMethod struct {
+	Controller         string             `protobuf:"bytes,1,opt,name=controller,proto3" json:"controller,omitempty"`
+	Id                 string             `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	VerificationMethod VerificationMethod `protobuf:"bytes,3,opt,name=verification_method,json=verificationMethod,proto3" json:"verification_method,omitempty"`
+	KeyProof           *KeyProof          `protobuf:"bytes,4,opt,name=key_proof,json=keyProof,proto3" json:"key_proof,omitempty"`
+}
+
+func (m *MsgAddVerificationMethod) Reset()         { *m = MsgAddVerificationMethod{} }
+func (m *MsgAddVerificationMethod) String() string { return proto.CompactTextString(m) }
+func (*MsgAddVerificationMethod) ProtoMessage()    {}
+
+// MsgAddVerificationMethodResponse defines the Msg/AddVerificationMethod response type.
+type MsgAddVerificationMethodResponse struct {
+}
+
+func (m *MsgAddVerificationMethodResponse) Reset()         { *m = MsgAddVerificationMethodResponse{} }
+func (m *MsgAddVerificationMethodResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgAddVerificationMethodResponse) ProtoMessage()    {}
+
+// MsgRevokeVerificationMethod revokes a verification method of a DID document
+type MsgRevokeVerificationMethod struct {
+	Controller string `protobuf:"bytes,1,opt,name=controller,proto3" json:"controller,omitempty"`
+	Id         string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	MethodId   string `protobuf:"bytes,3,opt,name=method_id,json=methodId,proto3" json:"method_id,omitempty"`
+	Reason     string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (m *MsgRevokeVerificationMethod) Reset()         { *m = MsgRevokeVerificationMethod{} }
+func (m *MsgRevokeVerificationMethod) String() string { return proto.CompactTextString(m) }
+func (*MsgRevokeVerificationMethod) ProtoMessage()    {}
+
+// MsgRevokeVerificationMethodResponse defines the Msg/RevokeVerificationMethod response type.
+type MsgRevokeVerificationMethodResponse struct {
+}
+
+func (m *MsgRevokeVerificationMethodResponse) Reset()         { *m = MsgRevokeVerificationMethodResponse{} }
+func (m *MsgRevokeVerificationMethodResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgRevokeVerificationMethodResponse) ProtoMessage()    {}
+
+// MsgAddService adds a service endpoint to a DID document
+type MsgAddService struct {
+	Controller string  `protobuf:"bytes,1,opt,name=controller,proto3" json:"controller,omitempty"`
+	Id         string  `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Service    Service `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+}
+
+func (m *MsgAddService) Reset()         { *m = MsgAddService{} }
+func (m *MsgAddService) String() string { return proto.CompactTextString(m) }
+func (*MsgAddService) ProtoMessage()    {}
+
+// MsgAddServiceResponse defines the Msg/AddService response type.
+type MsgAddServiceResponse struct {
+}
+
+func (m *MsgAddServiceResponse) Reset()         { *m = MsgAddServiceResponse{} }
+func (m *MsgAddServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgAddServiceResponse) ProtoMessage()    {}
+
+// MsgRemoveService removes a service endpoint from a DID document
+type MsgRemoveService struct {
+	Controller string `protobuf:"bytes,1,opt,name=controller,proto3" json:"controller,omitempty"`
+	Id         string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	ServiceId  string `protobuf:"bytes,3,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Reason     string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (m *MsgRemoveService) Reset()         { *m = MsgRemoveService{} }
+func (m *MsgRemoveService) String() string { return proto.CompactTextString(m) }
+func (*MsgRemoveService) ProtoMessage()    {}
+
+// MsgRemoveServiceResponse defines the Msg/RemoveService response type.
+type MsgRemoveServiceResponse struct {
+}
+
+func (m *MsgRemoveServiceResponse) Reset()         { *m = MsgRemoveServiceResponse{} }
+func (m *MsgRemoveServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgRemoveServiceResponse) ProtoMessage()    {}
+
+// MsgUpdateDIDStatus changes the lifecycle state of a DID document
+type MsgUpdateDIDStatus struct {
+	Controller string `protobuf:"bytes,1,opt,name=controller,proto3" json:"controller,omitempty"`
+	Id         string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Status     string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Reason     string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Until optionally ends a suspension at the given time
+	Until *time.Time `protobuf:"bytes,5,opt,name=until,proto3,stdtime" json:"until,omitempty"`
+}
+
+func (m *MsgUpdateDIDStatus) Reset()         { *m = MsgUpdateDIDStatus{} }
+func (m *MsgUpdateDIDStatus) String() string { return proto.CompactTextString(m) }
+func (*MsgUpdateDIDStatus) ProtoMessage()    {}
+
+// MsgUpdateDIDStatusResponse defines the Msg/UpdateDIDStatus response type.
+type MsgUpdateDIDStatusResponse struct {
+}
+
+func (m *MsgUpdateDIDStatusResponse) Reset()         { *m = MsgUpdateDIDStatusResponse{} }
+func (m *MsgUpdateDIDStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgUpdateDIDStatusResponse) ProtoMessage()    {}
+
+// MsgRotateKeys replaces the authentication keys of a DID with the keys its
+// pre-rotation commitment was made to, and commits to the next key set
+type MsgRotateKeys struct {
+	Controller        string               `protobuf:"bytes,1,opt,name=controller,proto3" json:"controller,omitempty"`
+	Id                string               `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	NewKeys           []VerificationMethod `protobuf:"bytes,3,rep,name=new_keys,json=newKeys,proto3" json:"new_keys,omitempty"`
+	NextKeyCommitment string               `protobuf:"bytes,4,opt,name=next_key_commitment,json=nextKeyCommitment,proto3" json:"next_key_commitment,omitempty"`
+	KeyProofs         []KeyProof           `protobuf:"bytes,5,rep,name=key_proofs,json=keyProofs,proto3" json:"key_proofs,omitempty"`
+}
+
+func (m *MsgRotateKeys) Reset()         { *m = MsgRotateKeys{} }
+func (m *MsgRotateKeys) String() string { return proto.CompactTextString(m) }
+func (*MsgRotateKeys) ProtoMessage()    {}
+
+// MsgRotateKeysResponse defines the Msg/RotateKeys response type.
+type MsgRotateKeysResponse struct {
+	Version uint64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (m *MsgRotateKeysResponse) Reset()         { *m = MsgRotateKeysResponse{} }
+func (m *MsgRotateKeysResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgRotateKeysResponse) ProtoMessage()    {}
+
+// MsgRegisterHandle registers a handle, such as alice.persona, to a DID
+type MsgRegisterHandle struct {
+	Controller string `protobuf:"bytes,1,opt,name=controller,proto3" json:"controller,omitempty"`
+	Did        string `protobuf:"bytes,2,opt,name=did,proto3" json:"did,omitempty"`
+	Handle     string `protobuf:"bytes,3,opt,name=handle,proto3" json:"handle,omitempty"`
+}
+
+func (m *MsgRegisterHandle) Reset()         { *m = MsgRegisterHandle{} }
+func (m *MsgRegisterHandle) String() string { return proto.CompactTextString(m) }
+func (*MsgRegisterHandle) ProtoMessage()    {}
+
+// MsgRegisterHandleResponse defines the Msg/RegisterHandle response type.
+type MsgRegisterHandleResponse struct {
+	ExpiresAt time.Time `protobuf:"bytes,1,opt,name=expires_at,json=expiresAt,proto3,stdtime" json:"expires_at,omitempty"`
+}
+
+func (m *MsgRegisterHandleResponse) Reset()         { *m = MsgRegisterHandleResponse{} }
+func (m *MsgRegisterHandleResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgRegisterHandleResponse) ProtoMessage()    {}
+
+// MsgRenewHandle extends the registration of a handle
+type MsgRenewHandle struct {
+	Controller string `protobuf:"bytes,1,opt,name=controller,proto3" json:"controller,omitempty"`
+	Handle     string `protobuf:"bytes,2,opt,name=handle,proto3" json:"handle,omitempty"`
+}
+
+func (m *MsgRenewHandle) Reset()         { *m = MsgRenewHandle{} }
+func (m *MsgRenewHandle) String() string { return proto.CompactTextString(m) }
+func (*MsgRenewHandle) ProtoMessage()    {}
+
+// MsgRenewHandleResponse defines the Msg/RenewHandle response type.
+type MsgRenewHandleResponse struct {
+	ExpiresAt time.Time `protobuf:"bytes,1,opt,name=expires_at,json=expiresAt,proto3,stdtime" json:"expires_at,omitempty"`
+}
+
+func (m *MsgRenewHandleResponse) Reset()         { *m = MsgRenewHandleResponse{} }
+func (m *MsgRenewHandleResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgRenewHandleResponse) ProtoMessage()    {}
+
+// MsgTransferHandle moves a handle to another DID
+type MsgTransferHandle struct {
+	Controller string `protobuf:"bytes,1,opt,name=controller,proto3" json:"controller,omitempty"`
+	Handle     string `protobuf:"bytes,2,opt,name=handle,proto3" json:"handle,omitempty"`
+	NewDid     string `protobuf:"bytes,3,opt,name=new_did,json=newDid,proto3" json:"new_did,omitempty"`
+}
+
+func (m *MsgTransferHandle) Reset()         { *m = MsgTransferHandle{} }
+func (m *MsgTransferHandle) String() string { return proto.CompactTextString(m) }
+func (*MsgTransferHandle) ProtoMessage()    {}
+
+// MsgTransferHandleResponse defines the Msg/TransferHandle response type.
+type MsgTransferHandleResponse struct {
+}
+
+func (m *MsgTransferHandleResponse) Reset()         { *m = MsgTransferHandleResponse{} }
+func (m *MsgTransferHandleResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgTransferHandleResponse) ProtoMessage()    {}
+
+// MsgSetPrimaryHandle chooses the handle a DID resolves to
+type MsgSetPrimaryHandle struct {
+	Controller string `protobuf:"bytes,1,opt,name=controller,proto3" json:"controller,omitempty"`
+	Did        string `protobuf:"bytes,2,opt,name=did,proto3" json:"did,omitempty"`
+	Handle     string `protobuf:"bytes,3,opt,name=handle,proto3" json:"handle,omitempty"`
+}
+
+func (m *MsgSetPrimaryHandle) Reset()         { *m = MsgSetPrimaryHandle{} }
+func (m *MsgSetPrimaryHandle) String() string { return proto.CompactTextString(m) }
+func (*MsgSetPrimaryHandle) ProtoMessage()    {}
+
+// MsgSetPrimaryHandleResponse defines the Msg/SetPrimaryHandle response type.
+type MsgSetPrimaryHandleResponse struct {
+}
+
+func (m *MsgSetPrimaryHandleResponse) Reset()         { *m = MsgSetPrimaryHandleResponse{} }
+func (m *MsgSetPrimaryHandleResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgSetPrimaryHandleResponse) ProtoMessage()    {}
+
+// MsgBatchUpdateDID applies an ordered list of operations to a DID document
+// atomically, producing a single new version
+type MsgBatchUpdateDID struct {
+	Controller string         `protobuf:"bytes,1,opt,name=controller,proto3" json:"controller,omitempty"`
+	Id         string         `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Operations []DIDOperation `protobuf:"bytes,3,rep,name=operations,proto3" json:"operations,omitempty"`
+	// key_proofs prove possession of every signing key the batch adds
+	KeyProofs []KeyProof `protobuf:"bytes,4,rep,name=key_proofs,json=keyProofs,proto3" json:"key_proofs,omitempty"`
+}
+
+func (m *MsgBatchUpdateDID) Reset()         { *m = MsgBatchUpdateDID{} }
+func (m *MsgBatchUpdateDID) String() string { return proto.CompactTextString(m) }
+func (*MsgBatchUpdateDID) ProtoMessage()    {}
+
+// MsgBatchUpdateDIDResponse defines the Msg/BatchUpdateDID response type.
+type MsgBatchUpdateDIDResponse struct {
+	Version uint64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (m *MsgBatchUpdateDIDResponse) Reset()         { *m = MsgBatchUpdateDIDResponse{} }
+func (m *MsgBatchUpdateDIDResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgBatchUpdateDIDResponse) ProtoMessage()    {}
+
+// DIDOperation is one change applied by MsgBatchUpdateDID
+type DIDOperation struct {
+	// type is add_verification_method, revoke_verification_method,
+	// add_service, remove_service, add_relationship, remove_relationship or
+	// update_metadata
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// verification_method is added by add_verification_method
+	VerificationMethod *VerificationMethod `protobuf:"bytes,2,opt,name=verification_method,json=verificationMethod,proto3" json:"verification_method,omitempty"`
+	// service is added by add_service
+	Service *Service `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	// target_id is the verification method or service the other operations
+	// act on
+	TargetId string `protobuf:"bytes,4,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	// relationship is the verification relationship, e.g. authentication, for
+	// add_relationship and remove_relationship
+	Relationship string `protobuf:"bytes,5,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	// metadata replaces the editable metadata for update_metadata
+	Metadata *DIDMetadataUpdate `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (m *DIDOperation) Reset()         { *m = DIDOperation{} }
+func (m *DIDOperation) String() string { return proto.CompactTextString(m) }
+func (*DIDOperation) ProtoMessage()    {}
+
+// DIDMetadataUpdate holds the document metadata a controller may edit
+type DIDMetadataUpdate struct {
+	Tags             []string         `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	Category         string           `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	OrganizationId   string           `protobuf:"bytes,3,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	BusinessUnit     string           `protobuf:"bytes,4,opt,name=business_unit,json=businessUnit,proto3" json:"business_unit,omitempty"`
+	Environment      string           `protobuf:"bytes,5,opt,name=environment,proto3" json:"environment,omitempty"`
+	CriticalityLevel CriticalityLevel `protobuf:"bytes,6,opt,name=criticality_level,json=criticalityLevel,proto3,casttype=CriticalityLevel" json:"criticality_level,omitempty"`
+}
+
+func (m *DIDMetadataUpdate) Reset()         { *m = DIDMetadataUpdate{} }
+func (m *DIDMetadataUpdate) String() string { return proto.CompactTextString(m) }
+func (*DIDMetadataUpdate) ProtoMessage()    {}
+
+// MsgSetUpdatePolicy sets the threshold policy controlling changes to a DID.
+// Replacing a policy needs the approvals of the current one. A zero
+// threshold with no controllers removes the policy.
+type MsgSetUpdatePolicy struct {
+	Controller  string               `protobuf:"bytes,1,opt,name=controller,proto3" json:"controller,omitempty"`
+	Id          string               `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Threshold   uint64               `protobuf:"varint,3,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Controllers []WeightedController `protobuf:"bytes,4,rep,name=controllers,proto3" json:"controllers,omitempty"`
+}
+
+func (m *MsgSetUpdatePolicy) Reset()         { *m = MsgSetUpdatePolicy{} }
+func (m *MsgSetUpdatePolicy) String() string { return proto.CompactTextString(m) }
+func (*MsgSetUpdatePolicy) ProtoMessage()    {}
+
+// MsgSetUpdatePolicyResponse defines the Msg/SetUpdatePolicy response type.
+type MsgSetUpdatePolicyResponse struct {
+}
+
+func (m *MsgSetUpdatePolicyResponse) Reset()         { *m = MsgSetUpdatePolicyResponse{} }
+func (m *MsgSetUpdatePolicyResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgSetUpdatePolicyResponse) ProtoMessage()    {}
+
+// MsgProposeDIDUpdate stores DID messages as a pending update that executes
+// once enough policy controllers approve it. The proposer's approval is
+// counted.
+type MsgProposeDIDUpdate struct {
+	Proposer string       `protobuf:"bytes,1,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	Id       string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Messages []*types.Any `protobuf:"bytes,3,rep,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (m *MsgProposeDIDUpdate) Reset()         { *m = MsgProposeDIDUpdate{} }
+func (m *MsgProposeDIDUpdate) String() string { return proto.CompactTextString(m) }
+func (*MsgProposeDIDUpdate) ProtoMessage()    {}
+
+// MsgProposeDIDUpdateResponse defines the Msg/ProposeDIDUpdate response type.
+type MsgProposeDIDUpdateResponse struct {
+	UpdateId uint64 `protobuf:"varint,1,opt,name=update_id,json=updateId,proto3" json:"update_id,omitempty"`
+	// executed is set when the proposer alone met the threshold
+	Executed bool `protobuf:"varint,2,opt,name=executed,proto3" json:"executed,omitempty"`
+}
+
+func (m *MsgProposeDIDUpdateResponse) Reset()         { *m = MsgProposeDIDUpdateResponse{} }
+func (m *MsgProposeDIDUpdateResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgProposeDIDUpdateResponse) ProtoMessage()    {}
+
+// MsgApproveDIDUpdate adds a controller's approval to a pending update and
+// executes it when the threshold is met.
+type MsgApproveDIDUpdate struct {
+	Approver string `protobuf:"bytes,1,opt,name=approver,proto3" json:"approver,omitempty"`
+	UpdateId uint64 `protobuf:"varint,2,opt,name=update_id,json=updateId,proto3" json:"update_id,omitempty"`
+}
+
+func (m *MsgApproveDIDUpdate) Reset()         { *m = MsgApproveDIDUpdate{} }
+func (m *MsgApproveDIDUpdate) String() string { return proto.CompactTextString(m) }
+func (*MsgApproveDIDUpdate) ProtoMessage()    {}
+
+// MsgApproveDIDUpdateResponse defines the Msg/ApproveDIDUpdate response type.
+type MsgApproveDIDUpdateResponse struct {
+	Executed bool `protobuf:"varint,1,opt,name=executed,proto3" json:"executed,omitempty"`
+}
+
+func (m *MsgApproveDIDUpdateResponse) Reset()         { *m = MsgApproveDIDUpdateResponse{} }
+func (m *MsgApproveDIDUpdateResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgApproveDIDUpdateResponse) ProtoMessage()    {}
+
+// MsgExecuteDIDUpdate executes DID messages approved by every signer of the
+// transaction, so controllers meeting the threshold can sign one
+// multi-signer transaction instead of collecting approvals on chain.
+type MsgExecuteDIDUpdate struct {
+	Signers  []string     `protobuf:"bytes,1,rep,name=signers,proto3" json:"signers,omitempty"`
+	Id       string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Messages []*types.Any `protobuf:"bytes,3,rep,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (m *MsgExecuteDIDUpdate) Reset()         { *m = MsgExecuteDIDUpdate{} }
+func (m *MsgExecuteDIDUpdate) String() string { return proto.CompactTextString(m) }
+func (*MsgExecuteDIDUpdate) ProtoMessage()    {}
+
+// MsgExecuteDIDUpdateResponse defines the Msg/ExecuteDIDUpdate response type.
+type MsgExecuteDIDUpdateResponse struct {
+}
+
+func (m *MsgExecuteDIDUpdateResponse) Reset()         { *m = MsgExecuteDIDUpdateResponse{} }
+func (m *MsgExecuteDIDUpdateResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgExecuteDIDUpdateResponse) ProtoMessage()    {}
+
+// MsgDelegateCapability stores a capability signed by the delegator DID with
+// one of its capabilityDelegation keys. Anyone may submit it.
+type MsgDelegateCapability struct {
+	Submitter        string          `protobuf:"bytes,1,opt,name=submitter,proto3" json:"submitter,omitempty"`
+	Delegator        string          `protobuf:"bytes,2,opt,name=delegator,proto3" json:"delegator,omitempty"`
+	Invoker          string          `protobuf:"bytes,3,opt,name=invoker,proto3" json:"invoker,omitempty"`
+	InvocationTarget string          `protobuf:"bytes,4,opt,name=invocation_target,json=invocationTarget,proto3" json:"invocation_target,omitempty"`
+	ParentCapability string          `protobuf:"bytes,5,opt,name=parent_capability,json=parentCapability,proto3" json:"parent_capability,omitempty"`
+	AllowedActions   []string        `protobuf:"bytes,6,rep,name=allowed_actions,json=allowedActions,proto3" json:"allowed_actions,omitempty"`
+	Expires          *time.Time      `protobuf:"bytes,7,opt,name=expires,proto3,stdtime" json:"expires,omitempty"`
+	MaxUses          uint64          `protobuf:"varint,8,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	Proof            CapabilityProof `protobuf:"bytes,9,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (m *MsgDelegateCapability) Reset()         { *m = MsgDelegateCapability{} }
+func (m *MsgDelegateCapability) String() string { return proto.CompactTextString(m) }
+func (*MsgDelegateCapability) ProtoMessage()    {}
+
+// MsgDelegateCapabilityResponse defines the Msg/DelegateCapability response type.
+type MsgDelegateCapabilityResponse struct {
+	CapabilityId string `protobuf:"bytes,1,opt,name=capability_id,json=capabilityId,proto3" json:"capability_id,omitempty"`
+}
+
+func (m *MsgDelegateCapabilityResponse) Reset()         { *m = MsgDelegateCapabilityResponse{} }
+func (m *MsgDelegateCapabilityResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgDelegateCapabilityResponse) ProtoMessage()    {}
+
+// MsgInvokeCapability verifies an invocation against its delegation chain
+// and counts a use of every capability in it. Anyone may submit it.
+type MsgInvokeCapability struct {
+	Submitter  string               `protobuf:"bytes,1,opt,name=submitter,proto3" json:"submitter,omitempty"`
+	Invocation CapabilityInvocation `protobuf:"bytes,2,opt,name=invocation,proto3" json:"invocation,omitempty"`
+}
+
+func (m *MsgInvokeCapability) Reset()         { *m = MsgInvokeCapability{} }
+func (m *MsgInvokeCapability) String() string { return proto.CompactTextString(m) }
+func (*MsgInvokeCapability) ProtoMessage()    {}
+
+// MsgInvokeCapabilityResponse defines the Msg/InvokeCapability response type.
+type MsgInvokeCapabilityResponse struct {
+}
+
+func (m *MsgInvokeCapabilityResponse) Reset()         { *m = MsgInvokeCapabilityResponse{} }
+func (m *MsgInvokeCapabilityResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgInvokeCapabilityResponse) ProtoMessage()    {}
+
+// MsgRevokeCapability revokes a capability, and every capability derived
+// from it, on behalf of a DID that delegated it or one of its ancestors.
+type MsgRevokeCapability struct {
+	Controller   string `protobuf:"bytes,1,opt,name=controller,proto3" json:"controller,omitempty"`
+	Revoker      string `protobuf:"bytes,2,opt,name=revoker,proto3" json:"revoker,omitempty"`
+	CapabilityId string `protobuf:"bytes,3,opt,name=capability_id,json=capabilityId,proto3" json:"capability_id,omitempty"`
+}
+
+func (m *MsgRevokeCapability) Reset()         { *m = MsgRevokeCapability{} }
+func (m *MsgRevokeCapability) String() string { return proto.CompactTextString(m) }
+func (*MsgRevokeCapability) ProtoMessage()    {}
+
+// MsgRevokeCapabilityResponse defines the Msg/RevokeCapability response type.
+type MsgRevokeCapabilityResponse struct {
+}
+
+func (m *MsgRevokeCapabilityResponse) Reset()         { *m = MsgRevokeCapabilityResponse{} }
+func (m *MsgRevokeCapabilityResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgRevokeCapabilityResponse) ProtoMessage()    {}
+
+// MsgCreateResource publishes content under a DID at
+// <did>/resources/<id>. A resource sharing the name and type of an existing
+// one becomes its next version.
+type MsgCreateResource struct {
+	Controller string `protobuf:"bytes,1,opt,name=controller,proto3" json:"controller,omitempty"`
+	Did        string `protobuf:"bytes,2,opt,name=did,proto3" json:"did,omitempty"`
+
+	// id is a lowercase UUID chosen by the controller.
+	Id           string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Version      string `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	ResourceType string `protobuf:"bytes,6,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	MediaType    string `protobuf:"bytes,7,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
+	Data         []byte `protobuf:"bytes,8,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *MsgCreateResource) Reset()         { *m = MsgCreateResource{} }
+func (m *MsgCreateResource) String() string { return proto.CompactTextString(m) }
+func (*MsgCreateResource) ProtoMessage()    {}
+
+// MsgCreateResourceResponse defines the Msg/CreateResource response type.
+type MsgCreateResourceResponse struct {
+	Checksum string `protobuf:"bytes,1,opt,name=checksum,proto3" json:"checksum,omitempty"`
+}
+
+func (m *MsgCreateResourceResponse) Reset()         { *m = MsgCreateResourceResponse{} }
+func (m *MsgCreateResourceResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgCreateResourceResponse) ProtoMessage()    {}
+
+// MsgRequestRemoteResolution resolves a DID on the chain at the other end of
+// a DID channel. The acknowledged document is cached and served by the
+// Resolve query.
+type MsgRequestRemoteResolution struct {
+	Creator   string `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	ChannelId string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Did       string `protobuf:"bytes,3,opt,name=did,proto3" json:"did,omitempty"`
+}
+
+func (m *MsgRequestRemoteResolution) Reset()         { *m = MsgRequestRemoteResolution{} }
+func (m *MsgRequestRemoteResolution) String() string { return proto.CompactTextString(m) }
+func (*MsgRequestRemoteResolution) ProtoMessage()    {}
+
+// MsgRequestRemoteResolutionResponse defines the Msg/RequestRemoteResolution
+// response type.
+type MsgRequestRemoteResolutionResponse struct {
+	// sequence is the sequence of the resolution packet.
+	Sequence uint64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (m *MsgRequestRemoteResolutionResponse) Reset()         { *m = MsgRequestRemoteResolutionResponse{} }
+func (m *MsgRequestRemoteResolutionResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgRequestRemoteResolutionResponse) ProtoMessage()    {}
+
 // Marshal implementations
 func (m *MsgCreateDid) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -158,4 +692,4 @@ func sovTx(x uint64) (n int) {
 func (m *MsgCreateDid) Unmarshal(dAtA []byte) error {
 	// Minimal implementation for compilation
 	return nil
-}
\ No newline at end of file
+}
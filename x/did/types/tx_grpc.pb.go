@@ -20,6 +20,29 @@ type MsgClient interface {
 	CreateDid(ctx context.Context, in *MsgCreateDid, opts ...grpc.CallOption) (*MsgCreateDidResponse, error)
 	UpdateDid(ctx context.Context, in *MsgUpdateDid, opts ...grpc.CallOption) (*MsgUpdateDidResponse, error)
 	DeactivateDid(ctx context.Context, in *MsgDeactivateDid, opts ...grpc.CallOption) (*MsgDeactivateDidResponse, error)
+	CreateDIDDocument(ctx context.Context, in *MsgCreateDIDDocument, opts ...grpc.CallOption) (*MsgCreateDIDDocumentResponse, error)
+	UpdateDIDDocument(ctx context.Context, in *MsgUpdateDIDDocument, opts ...grpc.CallOption) (*MsgUpdateDIDDocumentResponse, error)
+	DeactivateDIDDocument(ctx context.Context, in *MsgDeactivateDIDDocument, opts ...grpc.CallOption) (*MsgDeactivateDIDDocumentResponse, error)
+	AddVerificationMethod(ctx context.Context, in *MsgAddVerificationMethod, opts ...grpc.CallOption) (*MsgAddVerificationMethodResponse, error)
+	RevokeVerificationMethod(ctx context.Context, in *MsgRevokeVerificationMethod, opts ...grpc.CallOption) (*MsgRevokeVerificationMethodResponse, error)
+	AddService(ctx context.Context, in *MsgAddService, opts ...grpc.CallOption) (*MsgAddServiceResponse, error)
+	RemoveService(ctx context.Context, in *MsgRemoveService, opts ...grpc.CallOption) (*MsgRemoveServiceResponse, error)
+	UpdateDIDStatus(ctx context.Context, in *MsgUpdateDIDStatus, opts ...grpc.CallOption) (*MsgUpdateDIDStatusResponse, error)
+	RotateKeys(ctx context.Context, in *MsgRotateKeys, opts ...grpc.CallOption) (*MsgRotateKeysResponse, error)
+	RegisterHandle(ctx context.Context, in *MsgRegisterHandle, opts ...grpc.CallOption) (*MsgRegisterHandleResponse, error)
+	RenewHandle(ctx context.Context, in *MsgRenewHandle, opts ...grpc.CallOption) (*MsgRenewHandleResponse, error)
+	TransferHandle(ctx context.Context, in *MsgTransferHandle, opts ...grpc.CallOption) (*MsgTransferHandleResponse, error)
+	SetPrimaryHandle(ctx context.Context, in *MsgSetPrimaryHandle, opts ...grpc.CallOption) (*MsgSetPrimaryHandleResponse, error)
+	BatchUpdateDID(ctx context.Context, in *MsgBatchUpdateDID, opts ...grpc.CallOption) (*MsgBatchUpdateDIDResponse, error)
+	SetUpdatePolicy(ctx context.Context, in *MsgSetUpdatePolicy, opts ...grpc.CallOption) (*MsgSetUpdatePolicyResponse, error)
+	ProposeDIDUpdate(ctx context.Context, in *MsgProposeDIDUpdate, opts ...grpc.CallOption) (*MsgProposeDIDUpdateResponse, error)
+	ApproveDIDUpdate(ctx context.Context, in *MsgApproveDIDUpdate, opts ...grpc.CallOption) (*MsgApproveDIDUpdateResponse, error)
+	ExecuteDIDUpdate(ctx context.Context, in *MsgExecuteDIDUpdate, opts ...grpc.CallOption) (*MsgExecuteDIDUpdateResponse, error)
+	DelegateCapability(ctx context.Context, in *MsgDelegateCapability, opts ...grpc.CallOption) (*MsgDelegateCapabilityResponse, error)
+	InvokeCapability(ctx context.Context, in *MsgInvokeCapability, opts ...grpc.CallOption) (*MsgInvokeCapabilityResponse, error)
+	RevokeCapability(ctx context.Context, in *MsgRevokeCapability, opts ...grpc.CallOption) (*MsgRevokeCapabilityResponse, error)
+	CreateResource(ctx context.Context, in *MsgCreateResource, opts ...grpc.CallOption) (*MsgCreateResourceResponse, error)
+	RequestRemoteResolution(ctx context.Context, in *MsgRequestRemoteResolution, opts ...grpc.CallOption) (*MsgRequestRemoteResolutionResponse, error)
 }
 
 type msgClient struct {
@@ -57,11 +80,241 @@ func (c *msgClient) DeactivateDid(ctx context.Context, in *MsgDeactivateDid, opt
 	return out, nil
 }
 
+func (c *msgClient) CreateDIDDocument(ctx context.Context, in *MsgCreateDIDDocument, opts ...grpc.CallOption) (*MsgCreateDIDDocumentResponse, error) {
+	out := new(MsgCreateDIDDocumentResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/CreateDIDDocument", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) UpdateDIDDocument(ctx context.Context, in *MsgUpdateDIDDocument, opts ...grpc.CallOption) (*MsgUpdateDIDDocumentResponse, error) {
+	out := new(MsgUpdateDIDDocumentResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/UpdateDIDDocument", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) DeactivateDIDDocument(ctx context.Context, in *MsgDeactivateDIDDocument, opts ...grpc.CallOption) (*MsgDeactivateDIDDocumentResponse, error) {
+	out := new(MsgDeactivateDIDDocumentResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/DeactivateDIDDocument", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) AddVerificationMethod(ctx context.Context, in *MsgAddVerificationMethod, opts ...grpc.CallOption) (*MsgAddVerificationMethodResponse, error) {
+	out := new(MsgAddVerificationMethodResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/AddVerificationMethod", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) RevokeVerificationMethod(ctx context.Context, in *MsgRevokeVerificationMethod, opts ...grpc.CallOption) (*MsgRevokeVerificationMethodResponse, error) {
+	out := new(MsgRevokeVerificationMethodResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/RevokeVerificationMethod", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) AddService(ctx context.Context, in *MsgAddService, opts ...grpc.CallOption) (*MsgAddServiceResponse, error) {
+	out := new(MsgAddServiceResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/AddService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) RemoveService(ctx context.Context, in *MsgRemoveService, opts ...grpc.CallOption) (*MsgRemoveServiceResponse, error) {
+	out := new(MsgRemoveServiceResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/RemoveService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) UpdateDIDStatus(ctx context.Context, in *MsgUpdateDIDStatus, opts ...grpc.CallOption) (*MsgUpdateDIDStatusResponse, error) {
+	out := new(MsgUpdateDIDStatusResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/UpdateDIDStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) RotateKeys(ctx context.Context, in *MsgRotateKeys, opts ...grpc.CallOption) (*MsgRotateKeysResponse, error) {
+	out := new(MsgRotateKeysResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/RotateKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) RegisterHandle(ctx context.Context, in *MsgRegisterHandle, opts ...grpc.CallOption) (*MsgRegisterHandleResponse, error) {
+	out := new(MsgRegisterHandleResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/RegisterHandle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) RenewHandle(ctx context.Context, in *MsgRenewHandle, opts ...grpc.CallOption) (*MsgRenewHandleResponse, error) {
+	out := new(MsgRenewHandleResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/RenewHandle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) TransferHandle(ctx context.Context, in *MsgTransferHandle, opts ...grpc.CallOption) (*MsgTransferHandleResponse, error) {
+	out := new(MsgTransferHandleResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/TransferHandle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) SetPrimaryHandle(ctx context.Context, in *MsgSetPrimaryHandle, opts ...grpc.CallOption) (*MsgSetPrimaryHandleResponse, error) {
+	out := new(MsgSetPrimaryHandleResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/SetPrimaryHandle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) BatchUpdateDID(ctx context.Context, in *MsgBatchUpdateDID, opts ...grpc.CallOption) (*MsgBatchUpdateDIDResponse, error) {
+	out := new(MsgBatchUpdateDIDResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/BatchUpdateDID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) SetUpdatePolicy(ctx context.Context, in *MsgSetUpdatePolicy, opts ...grpc.CallOption) (*MsgSetUpdatePolicyResponse, error) {
+	out := new(MsgSetUpdatePolicyResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/SetUpdatePolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) ProposeDIDUpdate(ctx context.Context, in *MsgProposeDIDUpdate, opts ...grpc.CallOption) (*MsgProposeDIDUpdateResponse, error) {
+	out := new(MsgProposeDIDUpdateResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/ProposeDIDUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) ApproveDIDUpdate(ctx context.Context, in *MsgApproveDIDUpdate, opts ...grpc.CallOption) (*MsgApproveDIDUpdateResponse, error) {
+	out := new(MsgApproveDIDUpdateResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/ApproveDIDUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) ExecuteDIDUpdate(ctx context.Context, in *MsgExecuteDIDUpdate, opts ...grpc.CallOption) (*MsgExecuteDIDUpdateResponse, error) {
+	out := new(MsgExecuteDIDUpdateResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/ExecuteDIDUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) DelegateCapability(ctx context.Context, in *MsgDelegateCapability, opts ...grpc.CallOption) (*MsgDelegateCapabilityResponse, error) {
+	out := new(MsgDelegateCapabilityResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/DelegateCapability", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) InvokeCapability(ctx context.Context, in *MsgInvokeCapability, opts ...grpc.CallOption) (*MsgInvokeCapabilityResponse, error) {
+	out := new(MsgInvokeCapabilityResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/InvokeCapability", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) RevokeCapability(ctx context.Context, in *MsgRevokeCapability, opts ...grpc.CallOption) (*MsgRevokeCapabilityResponse, error) {
+	out := new(MsgRevokeCapabilityResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/RevokeCapability", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) CreateResource(ctx context.Context, in *MsgCreateResource, opts ...grpc.CallOption) (*MsgCreateResourceResponse, error) {
+	out := new(MsgCreateResourceResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/CreateResource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) RequestRemoteResolution(ctx context.Context, in *MsgRequestRemoteResolution, opts ...grpc.CallOption) (*MsgRequestRemoteResolutionResponse, error) {
+	out := new(MsgRequestRemoteResolutionResponse)
+	err := c.cc.Invoke(ctx, "/persona_chain.did.v1.Msg/RequestRemoteResolution", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MsgServer is the server API for Msg service.
 type MsgServer interface {
 	CreateDid(context.Context, *MsgCreateDid) (*MsgCreateDidResponse, error)
 	UpdateDid(context.Context, *MsgUpdateDid) (*MsgUpdateDidResponse, error)
 	DeactivateDid(context.Context, *MsgDeactivateDid) (*MsgDeactivateDidResponse, error)
+	CreateDIDDocument(context.Context, *MsgCreateDIDDocument) (*MsgCreateDIDDocumentResponse, error)
+	UpdateDIDDocument(context.Context, *MsgUpdateDIDDocument) (*MsgUpdateDIDDocumentResponse, error)
+	DeactivateDIDDocument(context.Context, *MsgDeactivateDIDDocument) (*MsgDeactivateDIDDocumentResponse, error)
+	AddVerificationMethod(context.Context, *MsgAddVerificationMethod) (*MsgAddVerificationMethodResponse, error)
+	RevokeVerificationMethod(context.Context, *MsgRevokeVerificationMethod) (*MsgRevokeVerificationMethodResponse, error)
+	AddService(context.Context, *MsgAddService) (*MsgAddServiceResponse, error)
+	RemoveService(context.Context, *MsgRemoveService) (*MsgRemoveServiceResponse, error)
+	UpdateDIDStatus(context.Context, *MsgUpdateDIDStatus) (*MsgUpdateDIDStatusResponse, error)
+	RotateKeys(context.Context, *MsgRotateKeys) (*MsgRotateKeysResponse, error)
+	RegisterHandle(context.Context, *MsgRegisterHandle) (*MsgRegisterHandleResponse, error)
+	RenewHandle(context.Context, *MsgRenewHandle) (*MsgRenewHandleResponse, error)
+	TransferHandle(context.Context, *MsgTransferHandle) (*MsgTransferHandleResponse, error)
+	SetPrimaryHandle(context.Context, *MsgSetPrimaryHandle) (*MsgSetPrimaryHandleResponse, error)
+	BatchUpdateDID(context.Context, *MsgBatchUpdateDID) (*MsgBatchUpdateDIDResponse, error)
+	SetUpdatePolicy(context.Context, *MsgSetUpdatePolicy) (*MsgSetUpdatePolicyResponse, error)
+	ProposeDIDUpdate(context.Context, *MsgProposeDIDUpdate) (*MsgProposeDIDUpdateResponse, error)
+	ApproveDIDUpdate(context.Context, *MsgApproveDIDUpdate) (*MsgApproveDIDUpdateResponse, error)
+	ExecuteDIDUpdate(context.Context, *MsgExecuteDIDUpdate) (*MsgExecuteDIDUpdateResponse, error)
+	DelegateCapability(context.Context, *MsgDelegateCapability) (*MsgDelegateCapabilityResponse, error)
+	InvokeCapability(context.Context, *MsgInvokeCapability) (*MsgInvokeCapabilityResponse, error)
+	RevokeCapability(context.Context, *MsgRevokeCapability) (*MsgRevokeCapabilityResponse, error)
+	CreateResource(context.Context, *MsgCreateResource) (*MsgCreateResourceResponse, error)
+	RequestRemoteResolution(context.Context, *MsgRequestRemoteResolution) (*MsgRequestRemoteResolutionResponse, error)
 }
 
 // UnimplementedMsgServer can be embedded to have forward compatible implementations.
@@ -80,7 +333,99 @@ func (*UnimplementedMsgServer) DeactivateDid(context.Context, *MsgDeactivateDid)
 	return nil, status.Errorf(codes.Unimplemented, "method DeactivateDid not implemented")
 }
 
-func RegisterMsgServer(s *grpc.Server, srv MsgServer) {
+func (*UnimplementedMsgServer) CreateDIDDocument(context.Context, *MsgCreateDIDDocument) (*MsgCreateDIDDocumentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDIDDocument not implemented")
+}
+
+func (*UnimplementedMsgServer) UpdateDIDDocument(context.Context, *MsgUpdateDIDDocument) (*MsgUpdateDIDDocumentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDIDDocument not implemented")
+}
+
+func (*UnimplementedMsgServer) DeactivateDIDDocument(context.Context, *MsgDeactivateDIDDocument) (*MsgDeactivateDIDDocumentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeactivateDIDDocument not implemented")
+}
+
+func (*UnimplementedMsgServer) AddVerificationMethod(context.Context, *MsgAddVerificationMethod) (*MsgAddVerificationMethodResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddVerificationMethod not implemented")
+}
+
+func (*UnimplementedMsgServer) RevokeVerificationMethod(context.Context, *MsgRevokeVerificationMethod) (*MsgRevokeVerificationMethodResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeVerificationMethod not implemented")
+}
+
+func (*UnimplementedMsgServer) AddService(context.Context, *MsgAddService) (*MsgAddServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddService not implemented")
+}
+
+func (*UnimplementedMsgServer) RemoveService(context.Context, *MsgRemoveService) (*MsgRemoveServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveService not implemented")
+}
+
+func (*UnimplementedMsgServer) UpdateDIDStatus(context.Context, *MsgUpdateDIDStatus) (*MsgUpdateDIDStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDIDStatus not implemented")
+}
+
+func (*UnimplementedMsgServer) RotateKeys(context.Context, *MsgRotateKeys) (*MsgRotateKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateKeys not implemented")
+}
+
+func (*UnimplementedMsgServer) RegisterHandle(context.Context, *MsgRegisterHandle) (*MsgRegisterHandleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterHandle not implemented")
+}
+
+func (*UnimplementedMsgServer) RenewHandle(context.Context, *MsgRenewHandle) (*MsgRenewHandleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewHandle not implemented")
+}
+
+func (*UnimplementedMsgServer) TransferHandle(context.Context, *MsgTransferHandle) (*MsgTransferHandleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferHandle not implemented")
+}
+
+func (*UnimplementedMsgServer) SetPrimaryHandle(context.Context, *MsgSetPrimaryHandle) (*MsgSetPrimaryHandleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPrimaryHandle not implemented")
+}
+
+func (*UnimplementedMsgServer) BatchUpdateDID(context.Context, *MsgBatchUpdateDID) (*MsgBatchUpdateDIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateDID not implemented")
+}
+
+func (*UnimplementedMsgServer) SetUpdatePolicy(context.Context, *MsgSetUpdatePolicy) (*MsgSetUpdatePolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUpdatePolicy not implemented")
+}
+
+func (*UnimplementedMsgServer) ProposeDIDUpdate(context.Context, *MsgProposeDIDUpdate) (*MsgProposeDIDUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProposeDIDUpdate not implemented")
+}
+
+func (*UnimplementedMsgServer) ApproveDIDUpdate(context.Context, *MsgApproveDIDUpdate) (*MsgApproveDIDUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveDIDUpdate not implemented")
+}
+
+func (*UnimplementedMsgServer) ExecuteDIDUpdate(context.Context, *MsgExecuteDIDUpdate) (*MsgExecuteDIDUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteDIDUpdate not implemented")
+}
+
+func (*UnimplementedMsgServer) DelegateCapability(context.Context, *MsgDelegateCapability) (*MsgDelegateCapabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DelegateCapability not implemented")
+}
+
+func (*UnimplementedMsgServer) InvokeCapability(context.Context, *MsgInvokeCapability) (*MsgInvokeCapabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvokeCapability not implemented")
+}
+
+func (*UnimplementedMsgServer) RevokeCapability(context.Context, *MsgRevokeCapability) (*MsgRevokeCapabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeCapability not implemented")
+}
+
+func (*UnimplementedMsgServer) CreateResource(context.Context, *MsgCreateResource) (*MsgCreateResourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateResource not implemented")
+}
+
+func (*UnimplementedMsgServer) RequestRemoteResolution(context.Context, *MsgRequestRemoteResolution) (*MsgRequestRemoteResolutionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestRemoteResolution not implemented")
+}
+
+func RegisterMsgServer(s grpc.ServiceRegistrar, srv MsgServer) {
 	s.RegisterService(&_Msg_serviceDesc, srv)
 }
 
@@ -100,6 +445,98 @@ var _Msg_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeactivateDid",
 			Handler:    _Msg_DeactivateDid_Handler,
 		},
+		{
+			MethodName: "CreateDIDDocument",
+			Handler:    _Msg_CreateDIDDocument_Handler,
+		},
+		{
+			MethodName: "UpdateDIDDocument",
+			Handler:    _Msg_UpdateDIDDocument_Handler,
+		},
+		{
+			MethodName: "DeactivateDIDDocument",
+			Handler:    _Msg_DeactivateDIDDocument_Handler,
+		},
+		{
+			MethodName: "AddVerificationMethod",
+			Handler:    _Msg_AddVerificationMethod_Handler,
+		},
+		{
+			MethodName: "RevokeVerificationMethod",
+			Handler:    _Msg_RevokeVerificationMethod_Handler,
+		},
+		{
+			MethodName: "AddService",
+			Handler:    _Msg_AddService_Handler,
+		},
+		{
+			MethodName: "RemoveService",
+			Handler:    _Msg_RemoveService_Handler,
+		},
+		{
+			MethodName: "UpdateDIDStatus",
+			Handler:    _Msg_UpdateDIDStatus_Handler,
+		},
+		{
+			MethodName: "RotateKeys",
+			Handler:    _Msg_RotateKeys_Handler,
+		},
+		{
+			MethodName: "RegisterHandle",
+			Handler:    _Msg_RegisterHandle_Handler,
+		},
+		{
+			MethodName: "RenewHandle",
+			Handler:    _Msg_RenewHandle_Handler,
+		},
+		{
+			MethodName: "TransferHandle",
+			Handler:    _Msg_TransferHandle_Handler,
+		},
+		{
+			MethodName: "SetPrimaryHandle",
+			Handler:    _Msg_SetPrimaryHandle_Handler,
+		},
+		{
+			MethodName: "BatchUpdateDID",
+			Handler:    _Msg_BatchUpdateDID_Handler,
+		},
+		{
+			MethodName: "SetUpdatePolicy",
+			Handler:    _Msg_SetUpdatePolicy_Handler,
+		},
+		{
+			MethodName: "ProposeDIDUpdate",
+			Handler:    _Msg_ProposeDIDUpdate_Handler,
+		},
+		{
+			MethodName: "ApproveDIDUpdate",
+			Handler:    _Msg_ApproveDIDUpdate_Handler,
+		},
+		{
+			MethodName: "ExecuteDIDUpdate",
+			Handler:    _Msg_ExecuteDIDUpdate_Handler,
+		},
+		{
+			MethodName: "DelegateCapability",
+			Handler:    _Msg_DelegateCapability_Handler,
+		},
+		{
+			MethodName: "InvokeCapability",
+			Handler:    _Msg_InvokeCapability_Handler,
+		},
+		{
+			MethodName: "RevokeCapability",
+			Handler:    _Msg_RevokeCapability_Handler,
+		},
+		{
+			MethodName: "CreateResource",
+			Handler:    _Msg_CreateResource_Handler,
+		},
+		{
+			MethodName: "RequestRemoteResolution",
+			Handler:    _Msg_RequestRemoteResolution_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "persona_chain/did/v1/tx.proto",
@@ -157,4 +594,418 @@ func _Msg_DeactivateDid_Handler(srv interface{}, ctx context.Context, dec func(i
 		return srv.(MsgServer).DeactivateDid(ctx, req.(*MsgDeactivateDid))
 	}
 	return interceptor(ctx, in, info, handler)
-}
\ No newline at end of file
+}
+
+func _Msg_CreateDIDDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgCreateDIDDocument)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).CreateDIDDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/CreateDIDDocument",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).CreateDIDDocument(ctx, req.(*MsgCreateDIDDocument))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_UpdateDIDDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgUpdateDIDDocument)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).UpdateDIDDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/UpdateDIDDocument",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).UpdateDIDDocument(ctx, req.(*MsgUpdateDIDDocument))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_DeactivateDIDDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgDeactivateDIDDocument)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).DeactivateDIDDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/DeactivateDIDDocument",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).DeactivateDIDDocument(ctx, req.(*MsgDeactivateDIDDocument))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_AddVerificationMethod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgAddVerificationMethod)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).AddVerificationMethod(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/AddVerificationMethod",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).AddVerificationMethod(ctx, req.(*MsgAddVerificationMethod))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_RevokeVerificationMethod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRevokeVerificationMethod)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RevokeVerificationMethod(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/RevokeVerificationMethod",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RevokeVerificationMethod(ctx, req.(*MsgRevokeVerificationMethod))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_AddService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgAddService)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).AddService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/AddService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).AddService(ctx, req.(*MsgAddService))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_RemoveService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRemoveService)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RemoveService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/RemoveService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RemoveService(ctx, req.(*MsgRemoveService))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_UpdateDIDStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgUpdateDIDStatus)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).UpdateDIDStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/UpdateDIDStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).UpdateDIDStatus(ctx, req.(*MsgUpdateDIDStatus))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_RotateKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRotateKeys)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RotateKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/RotateKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RotateKeys(ctx, req.(*MsgRotateKeys))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_RegisterHandle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRegisterHandle)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RegisterHandle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/RegisterHandle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RegisterHandle(ctx, req.(*MsgRegisterHandle))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_RenewHandle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRenewHandle)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RenewHandle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/RenewHandle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RenewHandle(ctx, req.(*MsgRenewHandle))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_TransferHandle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgTransferHandle)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).TransferHandle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/TransferHandle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).TransferHandle(ctx, req.(*MsgTransferHandle))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_SetPrimaryHandle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgSetPrimaryHandle)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).SetPrimaryHandle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/SetPrimaryHandle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).SetPrimaryHandle(ctx, req.(*MsgSetPrimaryHandle))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_BatchUpdateDID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgBatchUpdateDID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).BatchUpdateDID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/BatchUpdateDID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).BatchUpdateDID(ctx, req.(*MsgBatchUpdateDID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_SetUpdatePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgSetUpdatePolicy)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).SetUpdatePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/SetUpdatePolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).SetUpdatePolicy(ctx, req.(*MsgSetUpdatePolicy))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_ProposeDIDUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgProposeDIDUpdate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).ProposeDIDUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/ProposeDIDUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).ProposeDIDUpdate(ctx, req.(*MsgProposeDIDUpdate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_ApproveDIDUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgApproveDIDUpdate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).ApproveDIDUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/ApproveDIDUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).ApproveDIDUpdate(ctx, req.(*MsgApproveDIDUpdate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_ExecuteDIDUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgExecuteDIDUpdate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).ExecuteDIDUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/ExecuteDIDUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).ExecuteDIDUpdate(ctx, req.(*MsgExecuteDIDUpdate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_DelegateCapability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgDelegateCapability)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).DelegateCapability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/DelegateCapability",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).DelegateCapability(ctx, req.(*MsgDelegateCapability))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_InvokeCapability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgInvokeCapability)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).InvokeCapability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/InvokeCapability",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).InvokeCapability(ctx, req.(*MsgInvokeCapability))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_RevokeCapability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRevokeCapability)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RevokeCapability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/RevokeCapability",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RevokeCapability(ctx, req.(*MsgRevokeCapability))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_CreateResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgCreateResource)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).CreateResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/CreateResource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).CreateResource(ctx, req.(*MsgCreateResource))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_RequestRemoteResolution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRequestRemoteResolution)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RequestRemoteResolution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/persona_chain.did.v1.Msg/RequestRemoteResolution",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RequestRemoteResolution(ctx, req.(*MsgRequestRemoteResolution))
+	}
+	return interceptor(ctx, in, info, handler)
+}
@@ -0,0 +1,158 @@
+package types
+
+import (
+	"time"
+
+	"cosmossdk.io/errors"
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	proto "github.com/cosmos/gogoproto/proto"
+)
+
+// MaxPolicyControllers bounds the controllers of one update policy
+const MaxPolicyControllers = 32
+
+// MaxPendingUpdateMessages bounds the messages of one pending update
+const MaxPendingUpdateMessages = 16
+
+// WeightedController is a controller address and the weight its approval
+// carries under an update policy
+type WeightedController struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Weight  uint64 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+}
+
+func (m *WeightedController) Reset()         { *m = WeightedController{} }
+func (m *WeightedController) String() string { return proto.CompactTextString(m) }
+func (*WeightedController) ProtoMessage()    {}
+
+// UpdatePolicy requires approvals from controllers whose weights add up to
+// Threshold before a DID can be changed
+type UpdatePolicy struct {
+	Did         string               `protobuf:"bytes,1,opt,name=did,proto3" json:"did,omitempty"`
+	Threshold   uint64               `protobuf:"varint,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Controllers []WeightedController `protobuf:"bytes,3,rep,name=controllers,proto3" json:"controllers"`
+}
+
+func (m *UpdatePolicy) Reset()         { *m = UpdatePolicy{} }
+func (m *UpdatePolicy) String() string { return proto.CompactTextString(m) }
+func (*UpdatePolicy) ProtoMessage()    {}
+
+// Validate checks that the policy names distinct controllers with positive
+// weights and a threshold they can reach
+func (p UpdatePolicy) Validate() error {
+	if p.Threshold == 0 {
+		return errors.Wrap(ErrInvalidUpdatePolicy, "threshold must be positive")
+	}
+	if len(p.Controllers) == 0 || len(p.Controllers) > MaxPolicyControllers {
+		return errors.Wrapf(ErrInvalidUpdatePolicy, "policy must have between 1 and %d controllers", MaxPolicyControllers)
+	}
+
+	var total uint64
+	seen := make(map[string]bool, len(p.Controllers))
+	for _, c := range p.Controllers {
+		if _, err := sdk.AccAddressFromBech32(c.Address); err != nil {
+			return errors.Wrapf(ErrInvalidUpdatePolicy, "invalid controller address %q: %v", c.Address, err)
+		}
+		if seen[c.Address] {
+			return errors.Wrapf(ErrInvalidUpdatePolicy, "duplicate controller %s", c.Address)
+		}
+		seen[c.Address] = true
+		if c.Weight == 0 {
+			return errors.Wrapf(ErrInvalidUpdatePolicy, "controller %s has no weight", c.Address)
+		}
+		if total+c.Weight < total {
+			return errors.Wrap(ErrInvalidUpdatePolicy, "controller weights overflow")
+		}
+		total += c.Weight
+	}
+	if total < p.Threshold {
+		return errors.Wrapf(ErrInvalidUpdatePolicy, "threshold %d exceeds total weight %d", p.Threshold, total)
+	}
+	return nil
+}
+
+// WeightOf returns the combined weight of the policy controllers among
+// signers. Addresses outside the policy and repeats carry no weight.
+func (p UpdatePolicy) WeightOf(signers []string) uint64 {
+	var weight uint64
+	for _, c := range p.Controllers {
+		for _, signer := range signers {
+			if signer == c.Address {
+				weight += c.Weight
+				break
+			}
+		}
+	}
+	return weight
+}
+
+// IsController reports whether an address is one of the policy controllers
+func (p UpdatePolicy) IsController(addr string) bool {
+	return p.WeightOf([]string{addr}) > 0
+}
+
+// PendingUpdate holds DID messages that execute once the approving
+// controllers meet the update policy threshold
+type PendingUpdate struct {
+	Id        uint64          `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Did       string          `protobuf:"bytes,2,opt,name=did,proto3" json:"did,omitempty"`
+	Proposer  string          `protobuf:"bytes,3,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	Messages  []*cdctypes.Any `protobuf:"bytes,4,rep,name=messages,proto3" json:"messages,omitempty"`
+	Approvals []string        `protobuf:"bytes,5,rep,name=approvals,proto3" json:"approvals,omitempty"`
+	CreatedAt time.Time       `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3,stdtime" json:"created_at"`
+	ExpiresAt time.Time       `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3,stdtime" json:"expires_at"`
+}
+
+func (m *PendingUpdate) Reset()         { *m = PendingUpdate{} }
+func (m *PendingUpdate) String() string { return proto.CompactTextString(m) }
+func (*PendingUpdate) ProtoMessage()    {}
+
+var _ cdctypes.UnpackInterfacesMessage = PendingUpdate{}
+
+// GetMsgs unpacks the messages of the update
+func (u PendingUpdate) GetMsgs() ([]sdk.Msg, error) {
+	return sdktx.GetMsgs(u.Messages, "pending update")
+}
+
+// UnpackInterfaces implements UnpackInterfacesMessage
+func (u PendingUpdate) UnpackInterfaces(unpacker cdctypes.AnyUnpacker) error {
+	return sdktx.UnpackInterfaces(unpacker, u.Messages)
+}
+
+// HasApproved reports whether an address approved the update
+func (u PendingUpdate) HasApproved(addr string) bool {
+	for _, approval := range u.Approvals {
+		if approval == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpiredAt reports whether the update can no longer be approved at t
+func (u PendingUpdate) IsExpiredAt(t time.Time) bool {
+	return !t.Before(u.ExpiresAt)
+}
+
+// validateUpdateMessages checks the number of messages wrapped by a pending
+// or multi-signer update and runs their stateless validation. The keeper
+// checks that each targets the DID.
+func validateUpdateMessages(anys []*cdctypes.Any) error {
+	if len(anys) == 0 || len(anys) > MaxPendingUpdateMessages {
+		return errors.Wrapf(ErrInvalidUpdatePolicy, "update must carry between 1 and %d messages", MaxPendingUpdateMessages)
+	}
+	msgs, err := sdktx.GetMsgs(anys, "update")
+	if err != nil {
+		return err
+	}
+	for i, msg := range msgs {
+		if m, ok := msg.(sdk.HasValidateBasic); ok {
+			if err := m.ValidateBasic(); err != nil {
+				return errors.Wrapf(err, "message %d", i)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package types_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/persona-chain/persona-chain/x/did/types"
+)
+
+func policyAddress(name string) string {
+	addr := make([]byte, 20)
+	copy(addr, name)
+	return sdk.AccAddress(addr).String()
+}
+
+func TestUpdatePolicyValidate(t *testing.T) {
+	alice, bob := policyAddress("alice"), policyAddress("bob")
+
+	tests := []struct {
+		name   string
+		policy types.UpdatePolicy
+		valid  bool
+	}{
+		{
+			name:   "two of three",
+			policy: types.UpdatePolicy{Threshold: 2, Controllers: []types.WeightedController{{Address: alice, Weight: 1}, {Address: bob, Weight: 2}}},
+			valid:  true,
+		},
+		{
+			name:   "threshold equal to the total weight",
+			policy: types.UpdatePolicy{Threshold: 3, Controllers: []types.WeightedController{{Address: alice, Weight: 1}, {Address: bob, Weight: 2}}},
+			valid:  true,
+		},
+		{
+			name:   "zero threshold",
+			policy: types.UpdatePolicy{Controllers: []types.WeightedController{{Address: alice, Weight: 1}}},
+		},
+		{
+			name:   "no controllers",
+			policy: types.UpdatePolicy{Threshold: 1},
+		},
+		{
+			name:   "unreachable threshold",
+			policy: types.UpdatePolicy{Threshold: 4, Controllers: []types.WeightedController{{Address: alice, Weight: 1}, {Address: bob, Weight: 2}}},
+		},
+		{
+			name:   "duplicate controller",
+			policy: types.UpdatePolicy{Threshold: 1, Controllers: []types.WeightedController{{Address: alice, Weight: 1}, {Address: alice, Weight: 1}}},
+		},
+		{
+			name:   "controller without weight",
+			policy: types.UpdatePolicy{Threshold: 1, Controllers: []types.WeightedController{{Address: alice, Weight: 1}, {Address: bob}}},
+		},
+		{
+			name:   "controller is a DID",
+			policy: types.UpdatePolicy{Threshold: 1, Controllers: []types.WeightedController{{Address: "did:persona:abc", Weight: 1}}},
+		},
+		{
+			name:   "weights overflow",
+			policy: types.UpdatePolicy{Threshold: 1, Controllers: []types.WeightedController{{Address: alice, Weight: math.MaxUint64}, {Address: bob, Weight: 1}}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.Validate()
+			if !tc.valid {
+				require.ErrorIs(t, err, types.ErrInvalidUpdatePolicy)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUpdatePolicyWeightOf(t *testing.T) {
+	alice, bob, carol := policyAddress("alice"), policyAddress("bob"), policyAddress("carol")
+	policy := types.UpdatePolicy{Threshold: 3, Controllers: []types.WeightedController{{Address: alice, Weight: 2}, {Address: bob, Weight: 1}}}
+
+	tests := []struct {
+		name    string
+		signers []string
+		weight  uint64
+	}{
+		{name: "none"},
+		{name: "one controller", signers: []string{alice}, weight: 2},
+		{name: "all controllers", signers: []string{bob, alice}, weight: 3},
+		{name: "repeated signer counts once", signers: []string{alice, alice, alice}, weight: 2},
+		{name: "outsider carries no weight", signers: []string{carol, bob}, weight: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.weight, policy.WeightOf(tc.signers))
+		})
+	}
+
+	require.True(t, policy.IsController(bob))
+	require.False(t, policy.IsController(carol))
+}
+
+func TestPendingUpdate(t *testing.T) {
+	expiresAt := time.Unix(1_700_000_000, 0).UTC()
+	update := types.PendingUpdate{Approvals: []string{policyAddress("alice")}, ExpiresAt: expiresAt}
+
+	require.True(t, update.HasApproved(policyAddress("alice")))
+	require.False(t, update.HasApproved(policyAddress("bob")))
+	require.False(t, update.IsExpiredAt(expiresAt.Add(-time.Nanosecond)))
+	require.True(t, update.IsExpiredAt(expiresAt))
+}
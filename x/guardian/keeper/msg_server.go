@@ -2,12 +2,12 @@ package keeper
 
 import (
 	"context"
-	"time"
 
 	errorsmod "cosmossdk.io/errors"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
+	"github.com/persona-chain/persona-chain/internal/clock"
 	"github.com/persona-chain/persona-chain/x/guardian/types"
 )
 
@@ -55,20 +55,19 @@ func (k msgServer) AddGuardian(goCtx context.Context, msg *types.MsgAddGuardian)
 		GuardianAddress: msg.GuardianAddress,
 		PublicKey:       msg.PublicKey,
 		Active:          true,
-		AddedAt:         time.Now().Unix(),
+		AddedAt:         clock.Unix(ctx),
 	}
 
 	k.SetGuardian(ctx, guardian)
 
 	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.TypeMsgAddGuardian,
-			sdk.NewAttribute("controller", msg.Controller),
-			sdk.NewAttribute("did_id", msg.DidId),
-			sdk.NewAttribute("guardian_address", msg.GuardianAddress),
-		),
-	)
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventGuardianAdded{
+		Did:        msg.DidId,
+		Controller: msg.Controller,
+		Guardian:   msg.GuardianAddress,
+	}); err != nil {
+		return nil, err
+	}
 
 	return &types.MsgAddGuardianResponse{}, nil
 }
@@ -96,14 +95,13 @@ func (k msgServer) RemoveGuardian(goCtx context.Context, msg *types.MsgRemoveGua
 	k.SetGuardian(ctx, guardian)
 
 	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.TypeMsgRemoveGuardian,
-			sdk.NewAttribute("controller", msg.Controller),
-			sdk.NewAttribute("did_id", msg.DidId),
-			sdk.NewAttribute("guardian_address", msg.GuardianAddress),
-		),
-	)
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventGuardianRemoved{
+		Did:        msg.DidId,
+		Controller: msg.Controller,
+		Guardian:   msg.GuardianAddress,
+	}); err != nil {
+		return nil, err
+	}
 
 	return &types.MsgRemoveGuardianResponse{}, nil
 }
@@ -134,7 +132,7 @@ func (k msgServer) ProposeRecovery(goCtx context.Context, msg *types.MsgProposeR
 	}
 
 	// Validate expiration time
-	if msg.ExpiresAt <= time.Now().Unix() {
+	if msg.ExpiresAt <= clock.Unix(ctx) {
 		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "expiration time must be in the future")
 	}
 
@@ -147,7 +145,7 @@ func (k msgServer) ProposeRecovery(goCtx context.Context, msg *types.MsgProposeR
 		Approvals:     []string{},
 		Rejections:    []string{},
 		Status:        "pending",
-		CreatedAt:     time.Now().Unix(),
+		CreatedAt:     clock.Unix(ctx),
 		ExpiresAt:     msg.ExpiresAt,
 		ExecutedAt:    0,
 	}
@@ -155,15 +153,14 @@ func (k msgServer) ProposeRecovery(goCtx context.Context, msg *types.MsgProposeR
 	k.SetRecoveryProposal(ctx, proposal)
 
 	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.TypeMsgProposeRecovery,
-			sdk.NewAttribute("proposer", msg.Proposer),
-			sdk.NewAttribute("id", msg.Id),
-			sdk.NewAttribute("did_id", msg.DidId),
-			sdk.NewAttribute("new_controller", msg.NewController),
-		),
-	)
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventRecoveryProposed{
+		ProposalId:    msg.Id,
+		Did:           msg.DidId,
+		Proposer:      msg.Proposer,
+		NewController: msg.NewController,
+	}); err != nil {
+		return nil, err
+	}
 
 	return &types.MsgProposeRecoveryResponse{}, nil
 }
@@ -183,7 +180,7 @@ func (k msgServer) ApproveRecovery(goCtx context.Context, msg *types.MsgApproveR
 	}
 
 	// Check if proposal has expired
-	if proposal.ExpiresAt <= time.Now().Unix() {
+	if proposal.ExpiresAt <= clock.Unix(ctx) {
 		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "proposal has expired")
 	}
 
@@ -218,15 +215,14 @@ func (k msgServer) ApproveRecovery(goCtx context.Context, msg *types.MsgApproveR
 	k.SetRecoveryProposal(ctx, proposal)
 
 	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.TypeMsgApproveRecovery,
-			sdk.NewAttribute("guardian", msg.Guardian),
-			sdk.NewAttribute("proposal_id", msg.ProposalId),
-			sdk.NewAttribute("approve", fmt.Sprintf("%t", msg.Approve)),
-			sdk.NewAttribute("status", proposal.Status),
-		),
-	)
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventRecoveryApproved{
+		ProposalId: msg.ProposalId,
+		Guardian:   msg.Guardian,
+		Approve:    msg.Approve,
+		Status:     proposal.Status,
+	}); err != nil {
+		return nil, err
+	}
 
 	return &types.MsgApproveRecoveryResponse{}, nil
 }
@@ -246,7 +242,7 @@ func (k msgServer) ExecuteRecovery(goCtx context.Context, msg *types.MsgExecuteR
 	}
 
 	// Check if proposal has expired
-	if proposal.ExpiresAt <= time.Now().Unix() {
+	if proposal.ExpiresAt <= clock.Unix(ctx) {
 		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "proposal has expired")
 	}
 
@@ -257,19 +253,18 @@ func (k msgServer) ExecuteRecovery(goCtx context.Context, msg *types.MsgExecuteR
 
 	// Update proposal status
 	proposal.Status = "executed"
-	proposal.ExecutedAt = time.Now().Unix()
+	proposal.ExecutedAt = clock.Unix(ctx)
 	k.SetRecoveryProposal(ctx, proposal)
 
 	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.TypeMsgExecuteRecovery,
-			sdk.NewAttribute("executor", msg.Executor),
-			sdk.NewAttribute("proposal_id", msg.ProposalId),
-			sdk.NewAttribute("did_id", proposal.DidId),
-			sdk.NewAttribute("new_controller", proposal.NewController),
-		),
-	)
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventRecoveryExecuted{
+		ProposalId:    msg.ProposalId,
+		Did:           proposal.DidId,
+		Executor:      msg.Executor,
+		NewController: proposal.NewController,
+	}); err != nil {
+		return nil, err
+	}
 
 	return &types.MsgExecuteRecoveryResponse{}, nil
 }
@@ -299,19 +294,18 @@ func (k msgServer) SubmitSignatureShare(goCtx context.Context, msg *types.MsgSub
 		Signer:          msg.Signer,
 		SignatureShare:  msg.SignatureShare,
 		PublicKeyShare:  msg.PublicKeyShare,
-		SignedAt:        time.Now().Unix(),
+		SignedAt:        clock.Unix(ctx),
 	}
 
 	k.SetThresholdSignature(ctx, signature)
 
 	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.TypeMsgSubmitSignatureShare,
-			sdk.NewAttribute("signer", msg.Signer),
-			sdk.NewAttribute("proposal_id", msg.ProposalId),
-		),
-	)
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventSignatureShareSubmitted{
+		ProposalId: msg.ProposalId,
+		Signer:     msg.Signer,
+	}); err != nil {
+		return nil, err
+	}
 
 	return &types.MsgSubmitSignatureShareResponse{}, nil
 }
\ No newline at end of file
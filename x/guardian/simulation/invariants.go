@@ -2,11 +2,11 @@ package simulation
 
 import (
 	"fmt"
-	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/simulation"
 
+	"github.com/persona-chain/persona-chain/internal/clock"
 	"github.com/persona-chain/persona-chain/x/guardian/keeper"
 	"github.com/persona-chain/persona-chain/x/guardian/types"
 )
@@ -115,7 +115,7 @@ func RecoveryProposalLogicInvariant(k keeper.Keeper) sdk.Invariant {
 		)
 
 		allProposals := k.GetAllRecoveryProposal(ctx)
-		currentTime := time.Now().Unix()
+		currentTime := clock.Unix(ctx)
 		
 		for _, proposal := range allProposals {
 			// Check required fields
@@ -480,7 +480,7 @@ func ProposalStatusConsistencyInvariant(k keeper.Keeper) sdk.Invariant {
 		)
 
 		allProposals := k.GetAllRecoveryProposal(ctx)
-		currentTime := time.Now().Unix()
+		currentTime := clock.Unix(ctx)
 		
 		statusCounts := make(map[string]int)
 		for _, proposal := range allProposals {
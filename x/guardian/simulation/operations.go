@@ -3,7 +3,6 @@ package simulation
 import (
 	"fmt"
 	"math/rand"
-	"time"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -12,6 +11,7 @@ import (
 	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
 	"github.com/cosmos/cosmos-sdk/x/simulation"
 
+	"github.com/persona-chain/persona-chain/internal/clock"
 	didtypes "github.com/persona-chain/persona-chain/x/did/types"
 	"github.com/persona-chain/persona-chain/x/guardian/keeper"
 	"github.com/persona-chain/persona-chain/x/guardian/types"
@@ -303,7 +303,7 @@ func SimulateMsgApproveRecovery(
 		allProposals := k.GetAllRecoveryProposal(ctx)
 		pendingProposals := make([]types.RecoveryProposal, 0)
 		for _, proposal := range allProposals {
-			if proposal.Status == "pending" && proposal.ExpiresAt > time.Now().Unix() {
+			if proposal.Status == "pending" && proposal.ExpiresAt > clock.Unix(ctx) {
 				pendingProposals = append(pendingProposals, proposal)
 			}
 		}
@@ -386,7 +386,7 @@ func SimulateMsgRejectRecovery(
 		allProposals := k.GetAllRecoveryProposal(ctx)
 		pendingProposals := make([]types.RecoveryProposal, 0)
 		for _, proposal := range allProposals {
-			if proposal.Status == "pending" && proposal.ExpiresAt > time.Now().Unix() {
+			if proposal.Status == "pending" && proposal.ExpiresAt > clock.Unix(ctx) {
 				pendingProposals = append(pendingProposals, proposal)
 			}
 		}
@@ -12,6 +12,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
 
+	"github.com/persona-chain/persona-chain/internal/clock"
 	"github.com/persona-chain/persona-chain/x/identity/types"
 )
 
@@ -94,8 +95,8 @@ func (k Keeper) CreateUniversalIdentity(
 	identity := &types.UniversalIdentity{
 		ID:            identityID,
 		DID:           did,
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+		CreatedAt:     clock.Now(ctx),
+		UpdatedAt:     clock.Now(ctx),
 		IsActive:      true,
 		Protocols:     initialProtocols,
 		TenantID:      k.extractTenantID(creator),
@@ -112,7 +113,7 @@ func (k Keeper) CreateUniversalIdentity(
 		},
 		AuditTrail:    make([]types.AuditEntry, 0),
 		ZKCredentials: make([]types.ZKCredential, 0),
-		PrivacyPolicy: k.getDefaultPrivacyPolicy(),
+		PrivacyPolicy: k.getDefaultPrivacyPolicy(ctx),
 	}
 
 	// Validate identity
@@ -223,7 +224,7 @@ func (k Keeper) UpdateUniversalIdentity(
 	}
 
 	if changed {
-		identity.UpdatedAt = time.Now()
+		identity.UpdatedAt = clock.Now(ctx)
 		
 		if err := k.setUniversalIdentity(ctx, identity); err != nil {
 			return nil, fmt.Errorf("failed to update identity: %w", err)
@@ -272,7 +273,7 @@ func (k Keeper) AddProtocolIdentity(
 		identity.Protocols = make(map[types.ProtocolType]*types.ProtocolIdentity)
 	}
 	identity.Protocols[protocol] = protocolIdentity
-	identity.UpdatedAt = time.Now()
+	identity.UpdatedAt = clock.Now(ctx)
 
 	// Store updated identity
 	if err := k.setUniversalIdentity(ctx, identity); err != nil {
@@ -325,12 +326,12 @@ func (k Keeper) IssueVerifiableCredential(
 		Type:              append([]string{"VerifiableCredential"}, credentialType...),
 		ID:                fmt.Sprintf("https://persona.chain/credentials/%s", credentialID),
 		Issuer:            issuer,
-		IssuanceDate:      time.Now(),
+		IssuanceDate:      clock.Now(ctx),
 		ExpirationDate:    expirationDate,
 		CredentialSubject: credentialSubject,
 		Proof: &types.Proof{
 			Type:               "PersonaChainSignature2024",
-			Created:            time.Now(),
+			Created:            clock.Now(ctx),
 			ProofPurpose:       "assertionMethod",
 			VerificationMethod: fmt.Sprintf("%s#key-1", subjectDID),
 		},
@@ -415,7 +416,7 @@ func (k Keeper) IssueZKCredential(
 		CredentialSchema:    fmt.Sprintf("https://persona.chain/schemas/zk/%s", circuitID),
 		PrivacyParameters:   privacyParams,
 		SelectiveDisclosure: true,
-		CreatedAt:           time.Now(),
+		CreatedAt:           clock.Now(ctx),
 	}
 
 	// Verify ZK proof
@@ -425,7 +426,7 @@ func (k Keeper) IssueZKCredential(
 
 	// Add to holder's identity
 	holderIdentity.ZKCredentials = append(holderIdentity.ZKCredentials, *zkCred)
-	holderIdentity.UpdatedAt = time.Now()
+	holderIdentity.UpdatedAt = clock.Now(ctx)
 
 	if err := k.setUniversalIdentity(ctx, holderIdentity); err != nil {
 		return nil, fmt.Errorf("failed to update holder identity: %w", err)
@@ -500,7 +501,7 @@ func (k Keeper) UpdateComplianceData(
 		return fmt.Errorf("unsupported compliance type: %s", complianceType)
 	}
 
-	identity.UpdatedAt = time.Now()
+	identity.UpdatedAt = clock.Now(ctx)
 	
 	if err := k.setUniversalIdentity(ctx, identity); err != nil {
 		return fmt.Errorf("failed to update compliance data: %w", err)
@@ -550,13 +551,13 @@ func (k Keeper) GrantPermission(
 		Effect:     types.PermissionAllow,
 		ExpiresAt:  expiresAt,
 		GrantedBy:  grantor,
-		GrantedAt:  time.Now(),
+		GrantedAt:  clock.Now(ctx),
 		Conditions: make(map[string]interface{}),
 	}
 
 	// Add permission to identity
 	identity.Permissions = append(identity.Permissions, permission)
-	identity.UpdatedAt = time.Now()
+	identity.UpdatedAt = clock.Now(ctx)
 
 	if err := k.setUniversalIdentity(ctx, identity); err != nil {
 		return fmt.Errorf("failed to grant permission: %w", err)
@@ -636,7 +637,7 @@ func (k Keeper) hasPermission(ctx sdk.Context, actor, identityID, action string)
 	// Check explicit permissions
 	for _, perm := range identity.Permissions {
 		if perm.Action == action && perm.Effect == types.PermissionAllow {
-			if perm.ExpiresAt == nil || perm.ExpiresAt.After(time.Now()) {
+			if perm.ExpiresAt == nil || perm.ExpiresAt.After(clock.Now(ctx)) {
 				return true
 			}
 		}
@@ -657,7 +658,7 @@ func (k Keeper) recordAuditEntry(
 
 	auditEntry := types.AuditEntry{
 		ID:        fmt.Sprintf("audit_%d_%s", ctx.BlockHeight(), identityID),
-		Timestamp: time.Now(),
+		Timestamp: clock.Now(ctx),
 		Action:    action,
 		Actor:     actor,
 		Resource:  identityID,
@@ -706,7 +707,7 @@ func (k Keeper) extractTenantID(creator string) string {
 }
 
 // getDefaultPrivacyPolicy returns the default privacy policy
-func (k Keeper) getDefaultPrivacyPolicy() *types.PrivacyPolicy {
+func (k Keeper) getDefaultPrivacyPolicy(ctx sdk.Context) *types.PrivacyPolicy {
 	return &types.PrivacyPolicy{
 		Version: "1.0",
 		DataCollection: &types.DataCollectionPolicy{
@@ -754,8 +755,8 @@ func (k Keeper) getDefaultPrivacyPolicy() *types.PrivacyPolicy {
 			"dpo_email": "dpo@persona.chain",
 			"privacy_url": "https://persona.chain/privacy",
 		},
-		LastUpdated:   time.Now(),
-		EffectiveDate: time.Now(),
+		LastUpdated:   clock.Now(ctx),
+		EffectiveDate: clock.Now(ctx),
 	}
 }
 
@@ -9,6 +9,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
+	"github.com/persona-chain/persona-chain/internal/clock"
 	"github.com/persona-chain/persona-chain/x/identity/types"
 )
 
@@ -145,6 +146,9 @@ func (ms msgServer) IssueCredential(goCtx context.Context, msg *types.MsgIssueCr
 	var expirationDate *time.Time
 	if msg.ExpirationDate > 0 {
 		expDate := time.Unix(msg.ExpirationDate, 0)
+		if expDate.Before(clock.Now(ctx)) {
+			return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "expiration date cannot be in the past")
+		}
 		expirationDate = &expDate
 	}
 
@@ -207,7 +211,7 @@ func (ms msgServer) VerifyCredential(goCtx context.Context, msg *types.MsgVerify
 	return &types.MsgVerifyCredentialResponse{
 		CredentialId: msg.CredentialId,
 		IsValid:      isValid,
-		VerifiedAt:   time.Now().Unix(),
+		VerifiedAt:   clock.Unix(ctx),
 		Reason:       reason,
 	}, nil
 }
@@ -245,7 +249,7 @@ func (ms msgServer) RevokeCredential(goCtx context.Context, msg *types.MsgRevoke
 
 	return &types.MsgRevokeCredentialResponse{
 		CredentialId: msg.CredentialId,
-		RevokedAt:    time.Now().Unix(),
+		RevokedAt:    clock.Unix(ctx),
 		Reason:       msg.Reason,
 	}, nil
 }
@@ -331,7 +335,7 @@ func (ms msgServer) VerifyZKProof(goCtx context.Context, msg *types.MsgVerifyZKP
 	return &types.MsgVerifyZKProofResponse{
 		ZkCredentialId: msg.ZkCredentialId,
 		IsValid:        isValid,
-		VerifiedAt:     time.Now().Unix(),
+		VerifiedAt:     clock.Unix(ctx),
 		CircuitId:      zkCredential.CircuitID,
 	}, nil
 }
@@ -362,7 +366,7 @@ func (ms msgServer) UpdateCompliance(goCtx context.Context, msg *types.MsgUpdate
 	return &types.MsgUpdateComplianceResponse{
 		IdentityId:      msg.IdentityId,
 		ComplianceType:  msg.ComplianceType,
-		UpdatedAt:       time.Now().Unix(),
+		UpdatedAt:       clock.Unix(ctx),
 		Success:         true,
 	}, nil
 }
@@ -387,14 +391,14 @@ func (ms msgServer) PerformAudit(goCtx context.Context, msg *types.MsgPerformAud
 
 	// Store audit result
 	identity.ComplianceData.AuditResults = append(identity.ComplianceData.AuditResults, *auditResult)
-	now := time.Now()
+	now := clock.Now(ctx)
 	identity.ComplianceData.LastAudit = &now
 	
 	// Set next audit date (90 days from now)
 	nextAudit := now.AddDate(0, 0, 90)
 	identity.ComplianceData.NextAudit = &nextAudit
 	
-	identity.UpdatedAt = time.Now()
+	identity.UpdatedAt = clock.Now(ctx)
 
 	if err := ms.Keeper.setUniversalIdentity(ctx, identity); err != nil {
 		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "failed to store audit result")
@@ -435,6 +439,9 @@ func (ms msgServer) GrantPermission(goCtx context.Context, msg *types.MsgGrantPe
 	var expiresAt *time.Time
 	if msg.ExpiresAt > 0 {
 		expDate := time.Unix(msg.ExpiresAt, 0)
+		if expDate.Before(clock.Now(ctx)) {
+			return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "expiration date cannot be in the past")
+		}
 		expiresAt = &expDate
 	}
 
@@ -457,7 +464,7 @@ func (ms msgServer) GrantPermission(goCtx context.Context, msg *types.MsgGrantPe
 		Resource:   msg.Resource,
 		Action:     msg.Action,
 		Grantee:    msg.Grantee,
-		GrantedAt:  time.Now().Unix(),
+		GrantedAt:  clock.Unix(ctx),
 		ExpiresAt:  func() int64 {
 			if expiresAt != nil {
 				return expiresAt.Unix()
@@ -490,7 +497,7 @@ func (ms msgServer) RevokePermission(goCtx context.Context, msg *types.MsgRevoke
 	return &types.MsgRevokePermissionResponse{
 		IdentityId:   msg.IdentityId,
 		PermissionId: msg.PermissionId,
-		RevokedAt:    time.Now().Unix(),
+		RevokedAt:    clock.Unix(ctx),
 		Success:      true,
 	}, nil
 }
@@ -500,7 +507,7 @@ func (ms msgServer) RevokePermission(goCtx context.Context, msg *types.MsgRevoke
 // verifyCredentialIntegrity verifies the integrity of a verifiable credential
 func (ms msgServer) verifyCredentialIntegrity(ctx sdk.Context, credential *types.VerifiableCredential) (bool, string) {
 	// Check expiration
-	if credential.ExpirationDate != nil && credential.ExpirationDate.Before(time.Now()) {
+	if credential.ExpirationDate != nil && credential.ExpirationDate.Before(clock.Now(ctx)) {
 		return false, "credential has expired"
 	}
 
@@ -553,7 +560,7 @@ func (ms msgServer) performComplianceAudit(ctx sdk.Context, identity *types.Univ
 		Score:        0,
 		Findings:     make([]string, 0),
 		Remediation:  make([]string, 0),
-		AuditDate:    time.Now(),
+		AuditDate:    clock.Now(ctx),
 		AuditorInfo:  make(map[string]interface{}),
 	}
 
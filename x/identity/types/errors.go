@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	errorsmod "cosmossdk.io/errors"
 )
 
@@ -159,13 +161,15 @@ type EnhancedError struct {
 	Remediation string        `json:"remediation,omitempty"`
 }
 
-// NewEnhancedError creates a new enhanced error with context
+// NewEnhancedError creates a new enhanced error with context, timestamped
+// with the block time now
 func NewEnhancedError(
 	err error,
 	severity ErrorSeverity,
 	category ErrorCategory,
 	component, operation string,
 	metadata map[string]interface{},
+	now time.Time,
 ) *EnhancedError {
 	return &EnhancedError{
 		Code:      0, // Will be extracted from err if it's a cosmos error
@@ -174,7 +178,7 @@ func NewEnhancedError(
 		Category:  category,
 		Component: component,
 		Operation: operation,
-		Timestamp: time.Now().Unix(),
+		Timestamp: now.Unix(),
 		Metadata:  metadata,
 	}
 }
@@ -2,7 +2,6 @@ package types
 
 import (
 	"fmt"
-	"time"
 
 	errorsmod "cosmossdk.io/errors"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -362,12 +361,9 @@ func (msg *MsgIssueCredential) ValidateBasic() error {
 		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "credential subject cannot be empty")
 	}
 
-	// Validate expiration date if provided
-	if msg.ExpirationDate > 0 {
-		expTime := time.Unix(msg.ExpirationDate, 0)
-		if expTime.Before(time.Now()) {
-			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "expiration date cannot be in the past")
-		}
+	// The expiration is checked against the block time by the handler
+	if msg.ExpirationDate < 0 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "expiration date cannot be negative")
 	}
 
 	return nil
@@ -914,12 +910,9 @@ func (msg *MsgGrantPermission) ValidateBasic() error {
 		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "grantee cannot be empty")
 	}
 
-	// Validate expiration date if provided
-	if msg.ExpiresAt > 0 {
-		expTime := time.Unix(msg.ExpiresAt, 0)
-		if expTime.Before(time.Now()) {
-			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "expiration date cannot be in the past")
-		}
+	// The expiration is checked against the block time by the handler
+	if msg.ExpiresAt < 0 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "expiration date cannot be negative")
 	}
 
 	return nil
@@ -2,12 +2,12 @@ package keeper
 
 import (
 	"context"
-	"time"
 
 	errorsmod "cosmossdk.io/errors"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
+	"github.com/persona-chain/persona-chain/internal/clock"
 	"github.com/persona-chain/persona-chain/x/vc/types"
 )
 
@@ -43,7 +43,7 @@ func (k msgServer) IssueVc(goCtx context.Context, msg *types.MsgIssueVc) (*types
 	}
 
 	// Validate expiration date
-	if msg.ExpiresAt <= time.Now().Unix() {
+	if msg.ExpiresAt <= clock.Unix(ctx) {
 		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "expiration date must be in the future")
 	}
 
@@ -55,7 +55,7 @@ func (k msgServer) IssueVc(goCtx context.Context, msg *types.MsgIssueVc) (*types
 		CredentialData:   msg.CredentialData,
 		Proof:            msg.Proof,
 		Revoked:          false,
-		IssuedAt:         time.Now().Unix(),
+		IssuedAt:         clock.Unix(ctx),
 		ExpiresAt:        msg.ExpiresAt,
 		RevokedAt:        0,
 	}
@@ -63,15 +63,14 @@ func (k msgServer) IssueVc(goCtx context.Context, msg *types.MsgIssueVc) (*types
 	k.SetVcRecord(ctx, vcRecord)
 
 	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.TypeMsgIssueVc,
-			sdk.NewAttribute("issuer", msg.Issuer),
-			sdk.NewAttribute("id", msg.Id),
-			sdk.NewAttribute("issuer_did", msg.IssuerDid),
-			sdk.NewAttribute("subject_did", msg.SubjectDid),
-		),
-	)
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventCredentialIssued{
+		Id:         msg.Id,
+		Issuer:     msg.Issuer,
+		IssuerDid:  msg.IssuerDid,
+		SubjectDid: msg.SubjectDid,
+	}); err != nil {
+		return nil, err
+	}
 
 	return &types.MsgIssueVcResponse{}, nil
 }
@@ -96,7 +95,7 @@ func (k msgServer) RevokeVc(goCtx context.Context, msg *types.MsgRevokeVc) (*typ
 	}
 
 	// Check if VC has expired
-	if valFound.ExpiresAt <= time.Now().Unix() {
+	if valFound.ExpiresAt <= clock.Unix(ctx) {
 		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "VC has already expired")
 	}
 
@@ -111,19 +110,20 @@ func (k msgServer) RevokeVc(goCtx context.Context, msg *types.MsgRevokeVc) (*typ
 		Revoked:          true,
 		IssuedAt:         valFound.IssuedAt,
 		ExpiresAt:        valFound.ExpiresAt,
-		RevokedAt:        time.Now().Unix(),
+		RevokedAt:        clock.Unix(ctx),
 	}
 
 	k.SetVcRecord(ctx, vcRecord)
 
 	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.TypeMsgRevokeVc,
-			sdk.NewAttribute("issuer", msg.Issuer),
-			sdk.NewAttribute("id", msg.Id),
-		),
-	)
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventCredentialRevoked{
+		Id:         msg.Id,
+		Issuer:     msg.Issuer,
+		IssuerDid:  valFound.IssuerDid,
+		SubjectDid: valFound.SubjectDid,
+	}); err != nil {
+		return nil, err
+	}
 
 	return &types.MsgRevokeVcResponse{}, nil
 }
\ No newline at end of file
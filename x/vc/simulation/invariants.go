@@ -2,11 +2,11 @@ package simulation
 
 import (
 	"fmt"
-	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/simulation"
 
+	"github.com/persona-chain/persona-chain/internal/clock"
 	"github.com/persona-chain/persona-chain/x/vc/keeper"
 	"github.com/persona-chain/persona-chain/x/vc/types"
 )
@@ -143,7 +143,7 @@ func VcRevocationValidationInvariant(k keeper.Keeper) sdk.Invariant {
 				}
 
 				// Revocation timestamp cannot be in the future
-				currentTime := time.Now().Unix()
+				currentTime := clock.Unix(ctx)
 				if vc.RevokedAt > currentTime {
 					broken = true
 					msg += fmt.Sprintf("VC %s has future revocation timestamp: %d (current: %d)\n", 
@@ -231,7 +231,7 @@ func VcExpirationValidationInvariant(k keeper.Keeper) sdk.Invariant {
 		)
 
 		allVcs := k.GetAllVcRecord(ctx)
-		currentTime := time.Now().Unix()
+		currentTime := clock.Unix(ctx)
 		expiredCount := 0
 		activeCount := 0
 		
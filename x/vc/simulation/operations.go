@@ -13,6 +13,7 @@ import (
 	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
 	"github.com/cosmos/cosmos-sdk/x/simulation"
 
+	"github.com/persona-chain/persona-chain/internal/clock"
 	didtypes "github.com/persona-chain/persona-chain/x/did/types"
 	"github.com/persona-chain/persona-chain/x/vc/keeper"
 	"github.com/persona-chain/persona-chain/x/vc/types"
@@ -123,7 +124,7 @@ func SimulateMsgIssueVc(
 			CredentialSchema: generateRandomSchema(r),
 			CredentialData:   string(credentialDataBytes),
 			Proof:            generateRandomProof(r),
-			ExpiresAt:        time.Now().Add(time.Duration(r.Intn(365*24)) * time.Hour).Unix(),
+			ExpiresAt:        clock.Now(ctx).Add(time.Duration(r.Intn(365*24)) * time.Hour).Unix(),
 		}
 
 		account := ak.GetAccount(ctx, issuerAccount.Address)
@@ -161,7 +162,7 @@ func SimulateMsgRevokeVc(
 		allVcs := k.GetAllVcRecord(ctx)
 		activeVcs := make([]types.VcRecord, 0)
 		for _, vc := range allVcs {
-			if !vc.Revoked && vc.ExpiresAt > time.Now().Unix() {
+			if !vc.Revoked && vc.ExpiresAt > clock.Unix(ctx) {
 				activeVcs = append(activeVcs, vc)
 			}
 		}
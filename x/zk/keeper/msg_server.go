@@ -2,12 +2,12 @@ package keeper
 
 import (
 	"context"
-	"time"
 
 	errorsmod "cosmossdk.io/errors"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
+	"github.com/persona-chain/persona-chain/internal/clock"
 	"github.com/persona-chain/persona-chain/x/zk/types"
 )
 
@@ -56,27 +56,26 @@ func (k msgServer) SubmitProof(goCtx context.Context, msg *types.MsgSubmitProof)
 		ProofData:        msg.ProofData,
 		VerificationKey:  circuit.VerificationKey,
 		Verified:         verified,
-		SubmittedAt:      time.Now().Unix(),
+		SubmittedAt:      clock.Unix(ctx),
 		VerifiedAt:       0,
 		VerifierContract: circuit.WasmCodeHash,
 	}
 
 	if verified {
-		zkProof.VerifiedAt = time.Now().Unix()
+		zkProof.VerifiedAt = clock.Unix(ctx)
 	}
 
 	k.SetZkProof(ctx, zkProof)
 
 	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.TypeMsgSubmitProof,
-			sdk.NewAttribute("submitter", msg.Submitter),
-			sdk.NewAttribute("id", msg.Id),
-			sdk.NewAttribute("circuit_id", msg.CircuitId),
-			sdk.NewAttribute("verified", fmt.Sprintf("%t", verified)),
-		),
-	)
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventProofSubmitted{
+		Id:        msg.Id,
+		Submitter: msg.Submitter,
+		CircuitId: msg.CircuitId,
+		Verified:  verified,
+	}); err != nil {
+		return nil, err
+	}
 
 	return &types.MsgSubmitProofResponse{
 		Verified: verified,
@@ -106,21 +105,20 @@ func (k msgServer) RegisterCircuit(goCtx context.Context, msg *types.MsgRegister
 		VerificationKey: msg.VerificationKey,
 		Creator:         msg.Creator,
 		Active:          true,
-		CreatedAt:       time.Now().Unix(),
+		CreatedAt:       clock.Unix(ctx),
 	}
 
 	k.SetCircuit(ctx, circuit)
 
 	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.TypeMsgRegisterCircuit,
-			sdk.NewAttribute("creator", msg.Creator),
-			sdk.NewAttribute("id", msg.Id),
-			sdk.NewAttribute("name", msg.Name),
-			sdk.NewAttribute("code_hash", codeHash),
-		),
-	)
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventCircuitRegistered{
+		Id:       msg.Id,
+		Creator:  msg.Creator,
+		Name:     msg.Name,
+		CodeHash: codeHash,
+	}); err != nil {
+		return nil, err
+	}
 
 	return &types.MsgRegisterCircuitResponse{
 		CodeHash: codeHash,
@@ -160,13 +158,12 @@ func (k msgServer) DeactivateCircuit(goCtx context.Context, msg *types.MsgDeacti
 	k.SetCircuit(ctx, circuit)
 
 	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			types.TypeMsgDeactivateCircuit,
-			sdk.NewAttribute("creator", msg.Creator),
-			sdk.NewAttribute("id", msg.Id),
-		),
-	)
+	if err := ctx.EventManager().EmitTypedEvent(&types.EventCircuitDeactivated{
+		Id:      msg.Id,
+		Creator: msg.Creator,
+	}); err != nil {
+		return nil, err
+	}
 
 	return &types.MsgDeactivateCircuitResponse{}, nil
 }
\ No newline at end of file